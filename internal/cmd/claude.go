@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"github.com/mistergrinvalds/acorn/internal/components"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/components/claude"
 	"github.com/mistergrinvalds/acorn/internal/components/filesync"
@@ -19,6 +24,24 @@ import (
 var (
 	claudeDryRun  bool
 	claudeVerbose bool
+	claudeWatch   time.Duration
+
+	claudeAggregateUndoForce bool
+	claudeAggregateLink      bool
+	claudeStatsChart         bool
+	claudeStatsExportDir     string
+	claudeStatsExportForce   bool
+	claudeSettingsDiffOnSave bool
+	claudeProjectsPruneForce bool
+
+	claudeMcpType    string
+	claudeMcpCommand string
+	claudeMcpArgs    []string
+	claudeMcpEnv     []string
+	claudeMcpScope   string
+
+	claudeInstallVersion     string
+	claudeInstallCheckUpdate bool
 )
 
 // claudeCmd represents the claude command group
@@ -52,9 +75,14 @@ Shows:
   - Quick usage statistics
   - Agent and command counts
 
+With --watch, refreshes the summary on an interval (default 2s) and
+highlights changes since the last refresh. Press Ctrl+C to exit.
+
 Examples:
   acorn claude info
-  acorn claude info -o json`,
+  acorn claude info -o json
+  acorn claude info --watch
+  acorn claude info --watch=10s`,
 	RunE: runClaudeInfo,
 }
 
@@ -65,9 +93,15 @@ var claudeStatsCmd = &cobra.Command{
 	Long: `Display Claude Code usage statistics including session counts,
 message counts, and model usage breakdown.
 
+With --export-dir, a report bundle is written instead: summary.json,
+tokens-by-model.json, daily.csv (the full history), cost.csv and a
+manifest.json recording the export time and Claude version. An existing
+export is only overwritten with --force.
+
 Examples:
   acorn claude stats
-  acorn claude stats -o json`,
+  acorn claude stats -o json
+  acorn claude stats --export-dir ~/archive/claude/2026-01`,
 	RunE: runClaudeStats,
 }
 
@@ -90,13 +124,33 @@ var claudeStatsDailyCmd = &cobra.Command{
 	Short: "View daily token usage",
 	Long: `Display daily token usage for the last N days (default: 7).
 
+Use --chart to add a sparkline and a bar chart of daily totals, scaled
+to the terminal width, below the table.
+
 Examples:
-  acorn claude stats daily       # Last 7 days
-  acorn claude stats daily 14    # Last 14 days`,
+  acorn claude stats daily                  # Last 7 days
+  acorn claude stats daily 14               # Last 14 days
+  acorn claude stats daily 30 --chart       # Chart daily totals
+  acorn claude stats daily 30 -o csv       # One row per day per model`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runClaudeStatsDaily,
 }
 
+// claudeStatsCostCmd shows token usage and cost by model
+var claudeStatsCostCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "View cost by model",
+	Long: `Display token usage and recorded cost (USD) for each model.
+
+Use -o csv to export one row per model for spreadsheets.
+
+Examples:
+  acorn claude stats cost
+  acorn claude stats cost -o csv > claude-cost.csv
+  acorn claude stats cost -o json`,
+	RunE: runClaudeStatsCost,
+}
+
 // claudePermissionsCmd shows permissions
 var claudePermissionsCmd = &cobra.Command{
 	Use:   "permissions",
@@ -138,6 +192,28 @@ Examples:
 	RunE: runClaudePermissionsRemove,
 }
 
+// claudePermissionsCheckCmd tests a tool use against the rules
+var claudePermissionsCheckCmd = &cobra.Command{
+	Use:   "check <tool-use>",
+	Short: "Check whether a tool use would be allowed",
+	Long: `Evaluate a tool use against the permission rules in settings.local.json
+without running it, and explain which rule decides it.
+
+Deny rules take precedence over allow rules; a tool use matching neither
+would be asked about. Bash rules ending in :* match a command prefix,
+and each part of a compound command (&&, ||, ;, |) must be allowed on
+its own. Read/Edit/Write rules are path globs where ** spans directories,
+and WebFetch rules match domain:<host>.
+
+Examples:
+  acorn claude permissions check "Bash(rm -rf /)"
+  acorn claude permissions check "Bash(npm run test && git push)"
+  acorn claude permissions check "Read(./.env)"
+  acorn claude permissions check "WebFetch(https://docs.github.com/en)" -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClaudePermissionsCheck,
+}
+
 // claudeSettingsCmd shows settings
 var claudeSettingsCmd = &cobra.Command{
 	Use:   "settings [global|local|config]",
@@ -163,9 +239,14 @@ var claudeSettingsEditCmd = &cobra.Command{
 	Short: "Edit settings file with $EDITOR",
 	Long: `Open a settings file in your default editor.
 
+With --diff-on-save the file is snapshotted first. After the editor exits
+the changes are shown as a diff and the file is checked for valid JSON;
+if it no longer parses you are offered to revert to the snapshot.
+
 Examples:
   acorn claude settings edit           # Edit global settings
-  acorn claude settings edit local     # Edit local settings`,
+  acorn claude settings edit local     # Edit local settings
+  acorn claude settings edit --diff-on-save`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runClaudeSettingsEdit,
 }
@@ -178,10 +259,28 @@ var claudeProjectsCmd = &cobra.Command{
 
 Examples:
   acorn claude projects
-  acorn claude projects -o json`,
+  acorn claude projects -o json
+  acorn claude projects prune          # Find entries for deleted directories`,
 	RunE: runClaudeProjects,
 }
 
+// claudeProjectsPruneCmd removes project entries for deleted directories
+var claudeProjectsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove projects whose directories no longer exist",
+	Long: `Find trusted projects in ~/.claude.json whose directory no longer
+exists and remove their entries. Other settings in the file are kept.
+
+Without --force only the entries that would be removed are listed.
+
+Examples:
+  acorn claude projects prune
+  acorn claude projects prune --force
+  acorn claude projects prune -o json`,
+	Args: cobra.NoArgs,
+	RunE: runClaudeProjectsPrune,
+}
+
 // claudeMcpCmd shows MCP servers
 var claudeMcpCmd = &cobra.Command{
 	Use:   "mcp",
@@ -194,16 +293,46 @@ Examples:
 	RunE: runClaudeMcp,
 }
 
+// claudeMcpListCmd lists MCP servers with their scope
+var claudeMcpListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List MCP servers and where they are defined",
+	Long: `List MCP servers with the scope each one is defined at:
+
+  local    one project only, under that project in ~/.claude.json
+  project  .mcp.json in the current directory, shared with the repository
+  user     every project, at the top level of ~/.claude.json
+
+The same name can be defined at several scopes; local takes precedence
+over project, and project over user. Use --scope to show one scope.
+
+Examples:
+  acorn claude mcp list
+  acorn claude mcp list --scope user
+  acorn claude mcp list --scope project -o json`,
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    runClaudeMcp,
+}
+
 // claudeMcpAddCmd adds an MCP server
 var claudeMcpAddCmd = &cobra.Command{
-	Use:   "add <name> <url> [type]",
+	Use:   "add <name> [url] [type]",
 	Short: "Add MCP server to .mcp.json",
-	Long: `Add a new MCP server to the local .mcp.json configuration.
+	Long: `Add a new MCP server to the .mcp.json in the current directory, where
+it has project scope.
+
+HTTP and SSE servers are reached at a URL. Stdio servers are launched as a
+local command with --command, --args and --env; the type defaults to stdio
+when --command is given and to http otherwise.
 
 Examples:
   acorn claude mcp add myserver http://localhost:8080
-  acorn claude mcp add myserver http://localhost:8080 http`,
-	Args: cobra.RangeArgs(2, 3),
+  acorn claude mcp add myserver http://localhost:8080 sse
+  acorn claude mcp add fs --command npx \
+    --args "-y,@modelcontextprotocol/server-filesystem,/path"
+  acorn claude mcp add gh --command gh-mcp --env GITHUB_TOKEN=xxx --type stdio`,
+	Args: cobra.RangeArgs(1, 3),
 	RunE: runClaudeMcpAdd,
 }
 
@@ -219,6 +348,27 @@ Examples:
 	RunE: runClaudeCommands,
 }
 
+// claudeCommandsLintCmd validates custom command files
+var claudeCommandsLintCmd = &cobra.Command{
+	Use:   "lint [name]",
+	Short: "Validate custom command files",
+	Long: `Validate user and project command files.
+
+Checks that frontmatter parses and uses known fields (description,
+allowed-tools, argument-hint, model, disable-model-invocation), and that
+$ARGUMENTS and $1.. placeholders are used consistently.
+
+With no name, all commands are linted. Exits non-zero if any command has
+errors; warnings alone do not fail.
+
+Examples:
+  acorn claude commands lint
+  acorn claude commands lint review
+  acorn claude commands lint -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runClaudeCommandsLint,
+}
+
 // claudeAggregateCmd aggregates agents/commands
 var claudeAggregateCmd = &cobra.Command{
 	Use:   "aggregate [search-dir]",
@@ -227,11 +377,18 @@ var claudeAggregateCmd = &cobra.Command{
 agents, commands, and subagents to the dotfiles config.
 
 Handles file deduplication and renames conflicting files
-with repository prefixes.
+with repository prefixes. The files written are recorded so the
+run can be reverted with 'acorn claude aggregate undo'.
+
+With --link, files are symlinked to their source instead of copied, so
+edits made in the source repos show up in the config. Linked items break
+if a source repo is moved or deleted.
 
 Examples:
   acorn claude aggregate              # Scan ~/Repos
-  acorn claude aggregate ~/Projects   # Scan custom directory`,
+  acorn claude aggregate ~/Projects   # Scan custom directory
+  acorn claude aggregate --link       # Symlink instead of copying
+  acorn claude aggregate undo --force # Revert the last run`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runClaudeAggregate,
 }
@@ -249,6 +406,26 @@ Examples:
 	RunE: runClaudeAggregateList,
 }
 
+// claudeAggregateUndoCmd reverts the last aggregate run
+var claudeAggregateUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last aggregate run",
+	Long: `Remove the files added by the last 'acorn claude aggregate' run,
+including the repo-prefixed copies written for conflicting names.
+
+Each run records the files it wrote in
+$XDG_STATE_HOME/acorn/claude-aggregate-last.json. Files edited since the
+run are left in place and reported as modified. Requires --force; use
+--dry-run to list what would be removed.
+
+Examples:
+  acorn claude aggregate undo --dry-run
+  acorn claude aggregate undo --force
+  acorn claude aggregate undo --force -o json`,
+	Args: cobra.NoArgs,
+	RunE: runClaudeAggregateUndo,
+}
+
 // claudeClearCmd clears cache/stats
 var claudeClearCmd = &cobra.Command{
 	Use:   "clear [cache|stats]",
@@ -285,10 +462,17 @@ var claudeInstallCmd = &cobra.Command{
 
 Installs claude via npm (requires Node.js).
 
+With --version, installs that exact version (npm package
+@anthropic-ai/claude-code@<version>), replacing a different installed
+version. With --check-update, compares the installed version against the
+latest published one without installing anything.
+
 Examples:
-  acorn claude install           # Install Claude Code CLI
-  acorn claude install --dry-run # Show what would be installed
-  acorn claude install -v        # Verbose output`,
+  acorn claude install                  # Install Claude Code CLI
+  acorn claude install --version 1.0.30 # Install a pinned version
+  acorn claude install --check-update   # Report whether an update exists
+  acorn claude install --dry-run        # Show what would be installed
+  acorn claude install -v               # Verbose output`,
 	RunE: runClaudeInstall,
 }
 
@@ -349,19 +533,80 @@ func init() {
 	// Stats subcommands
 	claudeStatsCmd.AddCommand(claudeStatsTokensCmd)
 	claudeStatsCmd.AddCommand(claudeStatsDailyCmd)
+	claudeStatsCmd.AddCommand(claudeStatsCostCmd)
 
 	// Permissions subcommands
 	claudePermissionsCmd.AddCommand(claudePermissionsAddCmd)
 	claudePermissionsCmd.AddCommand(claudePermissionsRemoveCmd)
+	claudePermissionsCmd.AddCommand(claudePermissionsCheckCmd)
 
 	// Settings subcommands
 	claudeSettingsCmd.AddCommand(claudeSettingsEditCmd)
 
+	// Projects subcommands
+	claudeProjectsCmd.AddCommand(claudeProjectsPruneCmd)
+
 	// MCP subcommands
+	claudeMcpCmd.AddCommand(claudeMcpListCmd)
 	claudeMcpCmd.AddCommand(claudeMcpAddCmd)
 
 	// Aggregate subcommands
 	claudeAggregateCmd.AddCommand(claudeAggregateListCmd)
+	claudeAggregateCmd.AddCommand(claudeAggregateUndoCmd)
+
+	// Commands subcommands
+	claudeCommandsCmd.AddCommand(claudeCommandsLintCmd)
+
+	// MCP list flags
+	for _, c := range []*cobra.Command{claudeMcpCmd, claudeMcpListCmd} {
+		c.Flags().StringVar(&claudeMcpScope, "scope", claude.MCPScopeAll,
+			"Scope to list: "+strings.Join(claude.MCPScopes, ", "))
+	}
+
+	// MCP add flags
+	claudeMcpAddCmd.Flags().StringVar(&claudeMcpType, "type", "",
+		"Server type: stdio, http or sse")
+	claudeMcpAddCmd.Flags().StringVar(&claudeMcpCommand, "command", "",
+		"Command that launches a stdio server")
+	claudeMcpAddCmd.Flags().StringSliceVar(&claudeMcpArgs, "args", nil,
+		"Comma-separated arguments for the stdio command")
+	claudeMcpAddCmd.Flags().StringArrayVar(&claudeMcpEnv, "env", nil,
+		"Environment variable for the stdio command as KEY=VALUE (repeatable)")
+
+	// Aggregate flags
+	claudeAggregateCmd.Flags().BoolVar(&claudeAggregateLink, "link", false,
+		"Symlink files to their source repos instead of copying them")
+
+	// Aggregate undo flags
+	claudeAggregateUndoCmd.Flags().BoolVar(&claudeAggregateUndoForce, "force", false,
+		"Actually remove the aggregated files (required)")
+
+	// Projects prune flags
+	claudeProjectsPruneCmd.Flags().BoolVar(&claudeProjectsPruneForce, "force", false,
+		"Actually remove the stale entries (default: preview only)")
+
+	// Settings edit flags
+	claudeSettingsEditCmd.Flags().BoolVar(&claudeSettingsDiffOnSave, "diff-on-save", false,
+		"Show a diff after editing and offer to revert invalid JSON")
+
+	claudeStatsDailyCmd.Flags().BoolVar(&claudeStatsChart, "chart", false,
+		"Chart daily token totals below the table")
+	claudeStatsCmd.Flags().StringVar(&claudeStatsExportDir, "export-dir", "",
+		"Write a bundle of stats reports to this directory")
+	claudeStatsCmd.Flags().BoolVar(&claudeStatsExportForce, "force", false,
+		"Overwrite an existing export in --export-dir")
+
+	// Info flags
+	claudeInfoCmd.Flags().DurationVar(&claudeWatch, "watch", 0,
+		"Refresh the summary on an interval (e.g. --watch or --watch=5s)")
+	claudeInfoCmd.Flags().Lookup("watch").NoOptDefVal = "2s"
+
+	// Install flags
+	claudeInstallCmd.Flags().StringVar(&claudeInstallVersion, "version", "",
+		"Install a specific version (e.g. 1.0.30) instead of the latest")
+	claudeInstallCmd.Flags().BoolVar(&claudeInstallCheckUpdate, "check-update", false,
+		"Report whether a newer version is published, without installing")
+	claudeInstallCmd.MarkFlagsMutuallyExclusive("version", "check-update")
 
 	// Persistent flags (output format is inherited from root command)
 	claudeCmd.PersistentFlags().BoolVar(&claudeDryRun, "dry-run", false,
@@ -374,6 +619,14 @@ func init() {
 func runClaudeInfo(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+
+	if claudeWatch > 0 {
+		if ioHelper.IsStructured() {
+			return fmt.Errorf("--watch only supports table output")
+		}
+		return watchClaudeInfo(helper, claudeWatch)
+	}
+
 	info, err := helper.GetInfo()
 	if err != nil {
 		return err
@@ -383,7 +636,57 @@ func runClaudeInfo(cmd *cobra.Command, args []string) error {
 		return ioHelper.WriteOutput(info)
 	}
 
-	// Table format
+	printClaudeInfo(info)
+	return nil
+}
+
+// watchClaudeInfo reprints the info summary every interval until interrupted.
+func watchClaudeInfo(helper *claude.Helper, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := helper.NewWatcher()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastDelta claude.WatchDelta
+	for {
+		snap, changed, delta := watcher.Poll()
+		if changed {
+			lastDelta = delta
+		}
+
+		// Clear screen and move cursor home
+		fmt.Fprint(os.Stdout, "\033[H\033[2J")
+		printClaudeInfo(snap.Info)
+
+		fmt.Fprintf(os.Stdout, "\nTokens (in+out): %d%s\n", snap.TotalTokens, formatWatchDelta(lastDelta.Tokens, "tokens"))
+		if !lastDelta.IsZero() {
+			fmt.Fprintf(os.Stdout, "Since last change:%s%s\n",
+				formatWatchDelta(lastDelta.Sessions, "sessions"),
+				formatWatchDelta(lastDelta.Messages, "messages"))
+		}
+		fmt.Fprintf(os.Stdout, "\nUpdated %s, refreshing every %s (Ctrl+C to exit)\n",
+			snap.UpdatedAt.Format("15:04:05"), interval)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatWatchDelta formats a non-zero delta like " +3 messages".
+func formatWatchDelta(n int, label string) string {
+	if n == 0 {
+		return ""
+	}
+	return " " + output.Success(fmt.Sprintf("%+d %s", n, label))
+}
+
+// printClaudeInfo prints the info summary in table format.
+func printClaudeInfo(info *claude.Info) {
 	fmt.Fprintf(os.Stdout, "%s\n\n", output.Info("Claude Code Information"))
 
 	fmt.Fprintf(os.Stdout, "Version: %s\n\n", output.Success(info.Version))
@@ -405,8 +708,6 @@ func runClaudeInfo(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(os.Stdout, "Assets:\n")
 	fmt.Fprintf(os.Stdout, "  Agents:   %d\n", info.AgentCount)
 	fmt.Fprintf(os.Stdout, "  Commands: %d\n", info.CommandCount)
-
-	return nil
 }
 
 func printFileStatus(name string, exists bool) {
@@ -422,6 +723,13 @@ func printFileStatus(name string, exists bool) {
 func runClaudeStats(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+	if claudeStatsExportDir != "" {
+		return runClaudeStatsExport(ioHelper, helper)
+	}
+	if claudeStatsExportForce {
+		return fmt.Errorf("--force requires --export-dir")
+	}
+
 	summary, err := helper.GetStatsSummary()
 	if err != nil {
 		return err
@@ -456,6 +764,27 @@ func runClaudeStats(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runClaudeStatsExport writes the stats report bundle to --export-dir.
+func runClaudeStatsExport(ioHelper *ioutils.CommandIO, helper *claude.Helper) error {
+	export, err := helper.ExportStats(claudeStatsExportDir, claudeStatsExportForce)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(export)
+	}
+	if claudeDryRun {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Exported stats to %s\n", output.Success("✓"), export.Dir)
+	for _, name := range append(export.Files, claude.StatsManifestFile) {
+		fmt.Fprintf(os.Stdout, "  %s\n", name)
+	}
+	return nil
+}
+
 func runClaudeStatsTokens(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
@@ -500,12 +829,19 @@ func runClaudeStatsDaily(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if claudeStatsChart && cmd.Flags().Changed("output") && ioHelper.IsStructured() {
+		return fmt.Errorf("--chart cannot be combined with --output")
+	}
+
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
 	usage, err := helper.GetDailyUsage(days)
 	if err != nil {
 		return err
 	}
 
+	if ioHelper.Format() == ioutils.FormatCSV {
+		return ioHelper.WriteOutput(usage.Rows())
+	}
 	if ioHelper.IsStructured() {
 		return ioHelper.WriteOutput(usage)
 	}
@@ -521,6 +857,49 @@ func runClaudeStatsDaily(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	if claudeStatsChart && len(usage.Days) > 0 {
+		labels := make([]string, len(usage.Days))
+		totals := make([]int, len(usage.Days))
+		for i, day := range usage.Days {
+			labels[i], totals[i] = day.Date, day.Total
+		}
+		fmt.Fprintf(os.Stdout, "%s %s\n\n", output.Info("Trend:"), output.Sparkline(totals))
+		output.BarChart(os.Stdout, labels, totals, output.TerminalWidth())
+	}
+
+	return nil
+}
+
+func runClaudeStatsCost(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+	usage, err := helper.GetCostUsage()
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.Format() == ioutils.FormatCSV {
+		return ioHelper.WriteOutput(usage.Models)
+	}
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(usage)
+	}
+
+	// Table format
+	fmt.Fprintf(os.Stdout, "%s\n\n", output.Info("Cost by Model"))
+
+	for _, model := range usage.Models {
+		fmt.Fprintf(os.Stdout, "[%s]\n", output.Success(model.Model))
+		fmt.Fprintf(os.Stdout, "  Input:         %d\n", model.InputTokens)
+		fmt.Fprintf(os.Stdout, "  Output:        %d\n", model.OutputTokens)
+		fmt.Fprintf(os.Stdout, "  Cache Read:    %d\n", model.CacheReadInputTokens)
+		fmt.Fprintf(os.Stdout, "  Cache Create:  %d\n", model.CacheCreationInputTokens)
+		fmt.Fprintf(os.Stdout, "  Cost:          $%.2f\n", model.CostUSD)
+		fmt.Println()
+	}
+
+	fmt.Fprintf(os.Stdout, "Total Cost: $%.2f\n", usage.TotalUSD)
+
 	return nil
 }
 
@@ -599,6 +978,51 @@ func runClaudePermissionsRemove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runClaudePermissionsCheck(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+	check, err := helper.CheckPermission(args[0])
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(check)
+	}
+
+	printPermissionCheck(check, "")
+	for _, seg := range check.Segments {
+		printPermissionCheck(&seg, "  ")
+	}
+	return nil
+}
+
+// printPermissionCheck explains a permission decision.
+func printPermissionCheck(check *claude.PermissionCheck, indent string) {
+	switch check.Decision {
+	case claude.DecisionDeny:
+		fmt.Fprintf(os.Stdout, "%s%s Denied: %s\n", indent, output.Error("✗"), check.Invocation)
+	case claude.DecisionAllow:
+		fmt.Fprintf(os.Stdout, "%s%s Allowed: %s\n", indent, output.Success("✓"), check.Invocation)
+	default:
+		fmt.Fprintf(os.Stdout, "%s%s Ask: %s\n", indent, output.Warning("?"), check.Invocation)
+	}
+
+	switch {
+	case len(check.Segments) > 0 && check.Decision != claude.DecisionDeny:
+		fmt.Fprintf(os.Stdout, "%s  Each part of a compound command must be allowed:\n", indent)
+	case len(check.Segments) > 0:
+		fmt.Fprintf(os.Stdout, "%s  A part of the command matched deny rule %s:\n", indent, check.MatchedRule)
+	case check.MatchedRule != "":
+		fmt.Fprintf(os.Stdout, "%s  Matched %s rule: %s\n", indent, check.List, check.MatchedRule)
+	default:
+		fmt.Fprintf(os.Stdout, "%s  No rule matched; Claude would ask for permission\n", indent)
+	}
+	for _, rule := range check.Overridden {
+		fmt.Fprintf(os.Stdout, "%s  Also matched allow rule %s (deny takes precedence)\n", indent, rule)
+	}
+}
+
 func runClaudeSettings(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	typeArg := ""
@@ -643,7 +1067,51 @@ func runClaudeSettingsEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
-	return helper.EditSettings(st)
+	if !claudeSettingsDiffOnSave {
+		return helper.EditSettings(st)
+	}
+
+	result, err := helper.EditSettingsWithDiff(st)
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if !result.Changed {
+		fmt.Fprintf(os.Stdout, "%s No changes to %s\n", output.Info("ℹ"), result.Path)
+	} else {
+		fmt.Fprintf(os.Stdout, "%s\n%s\n", output.Info("Changes to "+result.Path), strings.Repeat("━", 40))
+		fmt.Fprint(os.Stdout, result.Diff)
+		fmt.Fprintln(os.Stdout)
+	}
+
+	if result.Valid {
+		fmt.Fprintf(os.Stdout, "%s Settings are valid JSON\n", output.Success("✓"))
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Settings are not valid JSON: %s\n", output.Error("✗"), result.ParseError)
+	if !result.Changed {
+		return fmt.Errorf("%s contains invalid JSON", result.Path)
+	}
+	ok, err := confirm("Revert to the version before editing?")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s contains invalid JSON", result.Path)
+	}
+	if err := helper.RevertEdit(result); err != nil {
+		return err
+	}
+	if result.Reverted {
+		fmt.Fprintf(os.Stdout, "%s Reverted %s\n", output.Success("✓"), result.Path)
+	}
+	return nil
 }
 
 func runClaudeProjects(cmd *cobra.Command, args []string) error {
@@ -674,10 +1142,50 @@ func runClaudeProjects(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runClaudeProjectsPrune(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+	result, err := helper.PruneProjects(claudeProjectsPruneForce)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if len(result.Removed) == 0 {
+		fmt.Fprintf(os.Stdout, "%s No stale projects found\n", output.Success("✓"))
+		return nil
+	}
+
+	symbol := output.Warning("!")
+	if result.Applied {
+		symbol = output.Success("✓")
+	}
+	for _, p := range result.Removed {
+		fmt.Fprintf(os.Stdout, "  %s %s ($%.2f)\n", symbol, p.Path, p.Cost)
+	}
+	fmt.Fprintln(os.Stdout)
+
+	if result.Applied {
+		fmt.Fprintf(os.Stdout, "%s Removed %d project(s) from %s ($%.2f of recorded cost dropped)\n",
+			output.Success("✓"), len(result.Removed), result.Config, result.TotalCost)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s %d project(s) point to missing directories ($%.2f of recorded cost)\n",
+		output.Info("ℹ"), len(result.Removed), result.TotalCost)
+	if !claudeDryRun {
+		fmt.Fprintln(os.Stdout, "Run with --force to remove them.")
+	}
+	return nil
+}
+
 func runClaudeMcp(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
-	mcpView, err := helper.GetMCPServers()
+	mcpView, err := helper.GetMCPServers(claudeMcpScope)
 	if err != nil {
 		return err
 	}
@@ -690,17 +1198,26 @@ func runClaudeMcp(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(os.Stdout, "%s\n\n", output.Info("MCP Servers"))
 
 	if len(mcpView.Servers) == 0 {
-		fmt.Println("No MCP servers configured.")
+		if mcpView.Scope == claude.MCPScopeAll {
+			fmt.Println("No MCP servers configured.")
+		} else {
+			fmt.Printf("No MCP servers configured at %s scope.\n", mcpView.Scope)
+		}
 	} else {
+		table := output.NewTable("NAME", "SCOPE", "PROJECT", "TYPE", "URL")
 		for _, server := range mcpView.Servers {
-			fmt.Fprintf(os.Stdout, "[%s] %s\n", output.Success(server.Name), server.Project)
-			fmt.Fprintf(os.Stdout, "  Type: %s\n", server.Type)
-			fmt.Fprintf(os.Stdout, "  URL:  %s\n\n", server.URL)
+			project := server.Project
+			if project == "" {
+				project = "-"
+			}
+			table.AddRow(server.Name, server.Scope, project, server.Type, server.URL)
 		}
+		table.Render(os.Stdout)
+		fmt.Fprintln(os.Stdout)
 	}
 
 	if mcpView.HasLocal {
-		fmt.Fprintf(os.Stdout, "Local MCP Config: %s\n", output.Success(mcpView.LocalFile))
+		fmt.Fprintf(os.Stdout, "Project MCP Config: %s\n", output.Success(mcpView.LocalFile))
 	}
 
 	return nil
@@ -708,14 +1225,35 @@ func runClaudeMcp(cmd *cobra.Command, args []string) error {
 
 func runClaudeMcpAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
-	url := args[1]
-	serverType := "http"
-	if len(args) > 2 {
-		serverType = args[2]
+	server := claude.MCPServer{
+		Command: claudeMcpCommand,
+		Args:    claudeMcpArgs,
+	}
+	if len(args) > 1 {
+		server.URL = args[1]
+	}
+
+	env, err := claude.ParseMCPEnv(claudeMcpEnv)
+	if err != nil {
+		return err
+	}
+	server.Env = env
+
+	switch {
+	case len(args) > 2 && claudeMcpType != "" && args[2] != claudeMcpType:
+		return fmt.Errorf("type given twice: %q and --type %q", args[2], claudeMcpType)
+	case len(args) > 2:
+		server.Type = args[2]
+	case claudeMcpType != "":
+		server.Type = claudeMcpType
+	case server.Command != "":
+		server.Type = claude.MCPTypeStdio
+	default:
+		server.Type = claude.MCPTypeHTTP
 	}
 
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
-	if err := helper.AddMCPServer(name, url, serverType); err != nil {
+	if err := helper.AddMCPServer(name, server); err != nil {
 		return err
 	}
 
@@ -760,6 +1298,58 @@ func runClaudeCommands(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runClaudeCommandsLint(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	results, err := helper.LintCommands(name)
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, r := range results {
+		if !r.Valid {
+			invalid++
+		}
+	}
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(results); err != nil {
+			return err
+		}
+	} else {
+		if len(results) == 0 {
+			fmt.Fprintln(os.Stdout, "No custom commands found.")
+			return nil
+		}
+
+		for _, r := range results {
+			status := output.Success("✓")
+			if !r.Valid {
+				status = output.Error("✗")
+			} else if len(r.Issues) > 0 {
+				status = output.Warning("!")
+			}
+			fmt.Fprintf(os.Stdout, "%s %s (%s)\n", status, r.Name, r.Source)
+			for _, issue := range r.Issues {
+				fmt.Fprintf(os.Stdout, "    %s: %s\n", issue.Level, issue.Message)
+			}
+		}
+		fmt.Fprintf(os.Stdout, "\n%d command(s) checked, %d with errors\n", len(results), invalid)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d command(s) have errors", invalid)
+	}
+	return nil
+}
+
 func runClaudeAggregate(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	searchDir := os.Getenv("HOME") + "/Repos"
@@ -768,7 +1358,7 @@ func runClaudeAggregate(cmd *cobra.Command, args []string) error {
 	}
 
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
-	result, err := helper.Aggregate(searchDir)
+	result, err := helper.Aggregate(searchDir, claude.AggregateOptions{Link: claudeAggregateLink})
 	if err != nil {
 		return err
 	}
@@ -783,11 +1373,11 @@ func runClaudeAggregate(cmd *cobra.Command, args []string) error {
 	for _, item := range result.Items {
 		switch item.Action {
 		case "added":
-			fmt.Fprintf(os.Stdout, "  Added: %s/%s (from %s)\n",
-				item.Type+"s", item.FileName, item.SourceRepo)
+			fmt.Fprintf(os.Stdout, "  %s: %s/%s (from %s)\n",
+				aggregateVerb(item), item.Type+"s", item.FileName, item.SourceRepo)
 		case "renamed":
-			fmt.Fprintf(os.Stdout, "  Added (renamed): %s/%s (from %s)\n",
-				item.Type+"s", item.FileName, item.SourceRepo)
+			fmt.Fprintf(os.Stdout, "  %s (renamed): %s/%s (from %s)\n",
+				aggregateVerb(item), item.Type+"s", item.FileName, item.SourceRepo)
 		case "skipped":
 			if claudeVerbose {
 				fmt.Fprintf(os.Stdout, "  Skipped (duplicate): %s/%s\n",
@@ -805,9 +1395,23 @@ func runClaudeAggregate(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(os.Stdout, "  Skipped (dups):     %d\n", result.Skipped)
 	fmt.Fprintf(os.Stdout, "  Renamed (conflicts):%d\n", result.Renamed)
 
+	if result.Linked && result.AgentsAdded+result.CommandsAdded+result.SubagentsAdded > 0 {
+		fmt.Println()
+		fmt.Fprintf(os.Stdout, "%s Linked items point into their source repos; moving or deleting a repo breaks its links\n",
+			output.Warning("!"))
+	}
+
 	return nil
 }
 
+// aggregateVerb describes how an aggregated item was written.
+func aggregateVerb(item claude.AggregateItem) string {
+	if item.Mode == claude.AggregateLinked {
+		return "Linked"
+	}
+	return "Added"
+}
+
 func runClaudeAggregateList(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
@@ -849,6 +1453,49 @@ func runClaudeAggregateList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runClaudeAggregateUndo(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := claude.NewHelper(claudeVerbose, claudeDryRun)
+	result, err := helper.UndoAggregate(claudeAggregateUndoForce)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	// Table format
+	fmt.Fprintf(os.Stdout, "%s\n\n", output.Info("Undo aggregate run from "+result.CreatedAt.Local().Format("2006-01-02 15:04")))
+
+	counts := map[string]int{}
+	for _, f := range result.Files {
+		counts[f.Status]++
+		switch f.Status {
+		case "removed":
+			fmt.Fprintf(os.Stdout, "  %s Removed: %s\n", output.Success("✓"), f.Path)
+		case "would_remove":
+			fmt.Fprintf(os.Stdout, "  [dry-run] would remove: %s\n", f.Path)
+		case "missing":
+			fmt.Fprintf(os.Stdout, "  %s Already gone: %s\n", output.Info("ℹ"), f.Path)
+		case "modified":
+			fmt.Fprintf(os.Stdout, "  %s Kept (modified since aggregate): %s\n", output.Warning("!"), f.Path)
+		}
+	}
+
+	fmt.Println()
+	if result.DryRun {
+		fmt.Fprintf(os.Stdout, "Would remove %d file(s). Run with --force to undo.\n", counts["would_remove"])
+	} else {
+		fmt.Fprintf(os.Stdout, "Removed %d file(s)\n", counts["removed"])
+	}
+	if counts["modified"] > 0 {
+		fmt.Fprintf(os.Stdout, "%s %d modified file(s) were kept\n", output.Warning("!"), counts["modified"])
+	}
+
+	return nil
+}
+
 func runClaudeClear(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	what := "cache"
@@ -893,11 +1540,13 @@ Stats & Usage:
   acorn claude stats         - View usage statistics
   acorn claude stats tokens  - View token usage by model
   acorn claude stats daily   - View daily token usage
+  acorn claude stats cost    - View cost by model (-o csv)
 
 Permissions:
   acorn claude permissions        - View all permissions
   acorn claude permissions add    - Add a permission rule
   acorn claude permissions remove - Remove a permission rule
+  acorn claude permissions check  - Test a tool use against the rules
 
 Settings:
   acorn claude settings [type]      - View settings [global|local|config]
@@ -906,6 +1555,7 @@ Settings:
 Projects & MCP:
   acorn claude projects      - List all projects
   acorn claude mcp           - List MCP servers
+  acorn claude mcp list      - List MCP servers by scope
   acorn claude mcp add       - Add MCP server
   acorn claude commands      - List custom commands
 
@@ -1049,10 +1699,21 @@ func runClaudeSyncStatus(cmd *cobra.Command, args []string) error {
 }
 
 func runClaudeInstall(cmd *cobra.Command, args []string) error {
-	inst := installer.NewInstaller(
+	opts := []installer.Option{
 		installer.WithDryRun(claudeDryRun),
 		installer.WithVerbose(claudeVerbose),
-	)
+	}
+	if claudeInstallVersion != "" {
+		if err := installer.ValidateVersion(claudeInstallVersion); err != nil {
+			return err
+		}
+		opts = append(opts, installer.WithToolVersion("claude", claudeInstallVersion))
+	}
+	inst := installer.NewInstaller(opts...)
+
+	if claudeInstallCheckUpdate {
+		return runClaudeCheckUpdate(cmd, inst)
+	}
 
 	// Show platform info
 	platform := inst.GetPlatform()
@@ -1098,12 +1759,23 @@ func runClaudeInstall(cmd *cobra.Command, args []string) error {
 	fmt.Fprintln(os.Stdout, "Tools:")
 	for _, t := range plan.Tools {
 		status := output.Warning("○")
+		name := t.Name
 		suffix := ""
-		if t.AlreadyInstalled {
+		if t.TargetVersion != "" {
+			name += "@" + t.TargetVersion
+		}
+		switch {
+		case t.AlreadyInstalled:
 			status = output.Success("✓")
 			suffix = " (installed)"
+		case t.Version != "":
+			current := installer.ExtractVersion(t.Version)
+			if current == "" {
+				current = t.Version
+			}
+			suffix = fmt.Sprintf(" (replaces %s)", current)
 		}
-		fmt.Fprintf(os.Stdout, "  %s %s - %s%s\n", status, t.Name, t.Description, suffix)
+		fmt.Fprintf(os.Stdout, "  %s %s - %s%s\n", status, name, t.Description, suffix)
 	}
 
 	if claudeDryRun {
@@ -1122,6 +1794,11 @@ func runClaudeInstall(cmd *cobra.Command, args []string) error {
 	if failed == 0 {
 		fmt.Fprintf(os.Stdout, "%s Installation complete (%d installed, %d skipped)\n",
 			output.Success("✓"), installed, skipped)
+		for _, t := range result.Tools {
+			if t.Name == "claude" && t.Version != "" {
+				fmt.Fprintf(os.Stdout, "  Installed version: %s\n", t.Version)
+			}
+		}
 	} else {
 		fmt.Fprintf(os.Stdout, "%s Installation failed (%d installed, %d skipped, %d failed)\n",
 			output.Error("✗"), installed, skipped, failed)
@@ -1130,6 +1807,30 @@ func runClaudeInstall(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runClaudeCheckUpdate reports whether a newer Claude Code is published.
+func runClaudeCheckUpdate(cmd *cobra.Command, inst *installer.Installer) error {
+	ioHelper := ioutils.IO(cmd)
+	check, err := inst.CheckUpdate(cmd.Context(), "claude", "claude")
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(check)
+	}
+
+	switch {
+	case check.Installed == "":
+		fmt.Fprintf(os.Stdout, "%s Claude Code is not installed (latest: %s)\n", output.Warning("○"), check.Latest)
+	case check.UpdateAvailable:
+		fmt.Fprintf(os.Stdout, "%s Update available: %s → %s\n", output.Warning("!"), check.Installed, check.Latest)
+		fmt.Fprintf(os.Stdout, "  Run: acorn claude install --version %s\n", check.Latest)
+	default:
+		fmt.Fprintf(os.Stdout, "%s Claude Code %s is up to date\n", output.Success("✓"), check.Installed)
+	}
+	return nil
+}
+
 func init() {
 	components.Register(&components.Registration{
 		Name: "claude",
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"github.com/mistergrinvalds/acorn/internal/components"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/components/cloudflare"
 	"github.com/mistergrinvalds/acorn/internal/utils/installer"
@@ -16,6 +21,28 @@ import (
 var (
 	cfDryRun  bool
 	cfVerbose bool
+	cfAccount string
+
+	cfD1Local         bool
+	cfD1Remote        bool
+	cfD1MigrationsDir string
+
+	cfPagesProject       string
+	cfPagesBranch        string
+	cfPagesCommitMessage string
+
+	cfSecretFromStdin    bool
+	cfSecretValueFromEnv string
+
+	cfWorkersDeleteForce  bool
+	cfResourceDeleteForce bool
+
+	cfLogsOnce     bool
+	cfLogsDuration time.Duration
+
+	cfR2LifecycleExpireDays int
+	cfR2LifecyclePrefix     string
+	cfR2LifecycleName       string
 )
 
 // cfCmd represents the cloudflare command group
@@ -28,12 +55,16 @@ Provides commands for managing CloudFlare resources using wrangler.
 
 Examples:
   acorn cf status              # Check wrangler status and auth
+  acorn cf accounts            # List accessible accounts
+  acorn cf --account <id> kv list  # Target a specific account
   acorn cf workers             # List Workers deployments
   acorn cf pages               # List Pages projects
+  acorn cf pages deploy dist   # Deploy a directory to Pages
   acorn cf r2 list             # List R2 buckets
   acorn cf kv list             # List KV namespaces
   acorn cf d1 list             # List D1 databases`,
-	Aliases: []string{"cloudflare"},
+	Aliases:           []string{"cloudflare"},
+	PersistentPreRunE: cfPersistentPreRun,
 }
 
 // cfStatusCmd shows CloudFlare CLI status
@@ -50,6 +81,20 @@ Examples:
 	RunE: runCfStatus,
 }
 
+// cfAccountsCmd lists accessible accounts
+var cfAccountsCmd = &cobra.Command{
+	Use:   "accounts",
+	Short: "List accessible CloudFlare accounts",
+	Long: `List the accounts the logged-in user can access, as reported by
+wrangler whoami. The active account (from --account or
+CLOUDFLARE_ACCOUNT_ID) is marked.
+
+Examples:
+  acorn cf accounts
+  acorn cf accounts -o json`,
+	RunE: runCfAccounts,
+}
+
 // cfWhoamiCmd shows current account
 var cfWhoamiCmd = &cobra.Command{
 	Use:   "whoami",
@@ -68,19 +113,72 @@ var cfWorkersCmd = &cobra.Command{
 	Long: `List all Workers deployments in your account.
 
 Examples:
-  acorn cf workers`,
+  acorn cf workers
+  acorn cf workers delete my-worker`,
 	RunE: runCfWorkers,
 }
 
+// cfWorkersDeleteCmd deletes a worker
+var cfWorkersDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a CloudFlare Worker",
+	Long: `Delete a Worker from your account with wrangler delete.
+
+Asks for confirmation unless --force is given. A worker that does not
+exist is reported by wrangler when the deletion is attempted.
+
+Examples:
+  acorn cf workers delete my-worker
+  acorn cf workers delete my-worker --force
+  acorn cf workers delete my-worker --dry-run`,
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runCfWorkersDelete,
+}
+
 // cfPagesCmd lists Pages projects
 var cfPagesCmd = &cobra.Command{
 	Use:   "pages",
+	Short: "List and deploy CloudFlare Pages projects",
+	Long: `List Pages projects in your account or deploy a site.
+
+Without a subcommand, lists projects.
+
+Examples:
+  acorn cf pages
+  acorn cf pages list -o json
+  acorn cf pages deploy dist --project-name my-site`,
+	RunE: runCfPages,
+}
+
+// cfPagesListCmd lists Pages projects
+var cfPagesListCmd = &cobra.Command{
+	Use:   "list",
 	Short: "List CloudFlare Pages projects",
 	Long: `List all Pages projects in your account.
 
 Examples:
-  acorn cf pages`,
-	RunE: runCfPages,
+  acorn cf pages list
+  acorn cf pages list -o json`,
+	Aliases: []string{"ls"},
+	RunE:    runCfPages,
+}
+
+// cfPagesDeployCmd deploys a directory to Pages
+var cfPagesDeployCmd = &cobra.Command{
+	Use:   "deploy <directory>",
+	Short: "Deploy a directory to CloudFlare Pages",
+	Long: `Deploy static build output to a CloudFlare Pages project.
+
+The directory must exist and contain build output (index.html,
+_worker.js or 404.html). Reports the deployment URL when done.
+
+Examples:
+  acorn cf pages deploy dist --project-name my-site
+  acorn cf pages deploy build --project-name my-site --branch preview
+  acorn cf pages deploy dist --project-name my-site --commit-message "Release 1.2"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCfPagesDeploy,
 }
 
 // cfLogsCmd tails worker logs
@@ -89,8 +187,15 @@ var cfLogsCmd = &cobra.Command{
 	Short: "Tail worker logs",
 	Long: `Tail logs for a CloudFlare Worker.
 
+By default the tail runs until interrupted. With --once or --duration
+it captures JSON events for a fixed window, then exits and reports
+how many events were captured.
+
 Examples:
-  acorn cf logs my-worker`,
+  acorn cf logs my-worker
+  acorn cf logs my-worker --once
+  acorn cf logs my-worker --duration 2m
+  acorn cf logs my-worker --once -o json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCfLogs,
 }
@@ -125,10 +230,14 @@ var cfSecretPutCmd = &cobra.Command{
 	Short: "Add a worker secret",
 	Long: `Add a secret to the current worker.
 
-You will be prompted to enter the secret value.
+By default you will be prompted to enter the secret value. For scripts and
+CI, read the value from stdin with --from-stdin or from an environment
+variable with --value-from-env. The value is never echoed.
 
 Examples:
-  acorn cf secret-put MY_API_KEY`,
+  acorn cf secret-put MY_API_KEY
+  echo "$TOKEN" | acorn cf secret-put MY_API_KEY --from-stdin
+  acorn cf secret-put MY_API_KEY --value-from-env TOKEN`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCfSecretPut,
 }
@@ -176,7 +285,9 @@ var cfR2Cmd = &cobra.Command{
 
 Examples:
   acorn cf r2 list
-  acorn cf r2 create my-bucket`,
+  acorn cf r2 create my-bucket
+  acorn cf r2 delete my-bucket
+  acorn cf r2 lifecycle get my-bucket`,
 }
 
 var cfR2ListCmd = &cobra.Command{
@@ -185,7 +296,8 @@ var cfR2ListCmd = &cobra.Command{
 	Long: `List all R2 buckets in your account.
 
 Examples:
-  acorn cf r2 list`,
+  acorn cf r2 list
+  acorn cf r2 list -o json`,
 	RunE: runCfR2List,
 }
 
@@ -200,6 +312,65 @@ Examples:
 	RunE: runCfR2Create,
 }
 
+var cfR2DeleteCmd = &cobra.Command{
+	Use:   "delete <bucket>",
+	Short: "Delete R2 bucket",
+	Long: `Delete an R2 bucket with wrangler r2 bucket delete.
+
+The bucket must appear in the bucket list, and wrangler refuses to delete
+a bucket that still holds objects. Asks for confirmation unless --force
+is given.
+
+Examples:
+  acorn cf r2 delete my-bucket
+  acorn cf r2 delete my-bucket --force
+  acorn cf r2 delete my-bucket --dry-run`,
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runCfR2Delete,
+}
+
+var cfR2LifecycleCmd = &cobra.Command{
+	Use:   "lifecycle",
+	Short: "Manage R2 bucket lifecycle rules",
+	Long: `View and set lifecycle rules that expire objects in an R2 bucket,
+keeping storage costs under control.
+
+Examples:
+  acorn cf r2 lifecycle get my-bucket
+  acorn cf r2 lifecycle set my-bucket --expire-days 30 --prefix logs/`,
+}
+
+var cfR2LifecycleGetCmd = &cobra.Command{
+	Use:   "get <bucket>",
+	Short: "Show a bucket's lifecycle rules",
+	Long: `Show the lifecycle rules of an R2 bucket.
+
+Examples:
+  acorn cf r2 lifecycle get my-bucket
+  acorn cf r2 lifecycle get my-bucket -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCfR2LifecycleGet,
+}
+
+var cfR2LifecycleSetCmd = &cobra.Command{
+	Use:   "set <bucket>",
+	Short: "Expire a bucket's objects after a number of days",
+	Long: `Add a lifecycle rule that deletes objects a number of days after they
+are uploaded. With --prefix the rule only applies to keys starting with
+it. A rule with the same name is replaced; the name defaults to one built
+from the prefix and days (e.g. expire-logs-30d).
+
+The resulting rule set is shown afterwards.
+
+Examples:
+  acorn cf r2 lifecycle set my-bucket --expire-days 30
+  acorn cf r2 lifecycle set my-bucket --expire-days 7 --prefix tmp/
+  acorn cf r2 lifecycle set my-bucket --expire-days 90 --name archive -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCfR2LifecycleSet,
+}
+
 // KV subcommands
 var cfKVCmd = &cobra.Command{
 	Use:   "kv",
@@ -208,7 +379,8 @@ var cfKVCmd = &cobra.Command{
 
 Examples:
   acorn cf kv list
-  acorn cf kv create my-namespace`,
+  acorn cf kv create my-namespace
+  acorn cf kv delete <namespace-id>`,
 }
 
 var cfKVListCmd = &cobra.Command{
@@ -216,8 +388,12 @@ var cfKVListCmd = &cobra.Command{
 	Short: "List KV namespaces",
 	Long: `List all KV namespaces in your account.
 
+The BINDING column is filled in from wrangler.toml or wrangler.json in the
+current directory.
+
 Examples:
-  acorn cf kv list`,
+  acorn cf kv list
+  acorn cf kv list -o json`,
 	RunE: runCfKVList,
 }
 
@@ -232,6 +408,25 @@ Examples:
 	RunE: runCfKVCreate,
 }
 
+var cfKVDeleteCmd = &cobra.Command{
+	Use:   "delete <namespace-id>",
+	Short: "Delete KV namespace",
+	Long: `Delete a KV namespace and all keys in it with wrangler kv namespace
+delete.
+
+The namespace is given by ID, or by title when no other namespace shares
+it, and must appear in the namespace list. Asks for confirmation unless
+--force is given.
+
+Examples:
+  acorn cf kv delete 0f2ac74b498b48028cb68387c421e279
+  acorn cf kv delete my-namespace --force
+  acorn cf kv delete my-namespace --dry-run`,
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runCfKVDelete,
+}
+
 // D1 subcommands
 var cfD1Cmd = &cobra.Command{
 	Use:   "d1",
@@ -240,7 +435,9 @@ var cfD1Cmd = &cobra.Command{
 
 Examples:
   acorn cf d1 list
-  acorn cf d1 create my-database`,
+  acorn cf d1 create my-database
+  acorn cf d1 delete my-database
+  acorn cf d1 migrations list my-database`,
 }
 
 var cfD1ListCmd = &cobra.Command{
@@ -249,7 +446,8 @@ var cfD1ListCmd = &cobra.Command{
 	Long: `List all D1 databases in your account.
 
 Examples:
-  acorn cf d1 list`,
+  acorn cf d1 list
+  acorn cf d1 list -o json`,
 	RunE: runCfD1List,
 }
 
@@ -264,6 +462,68 @@ Examples:
 	RunE: runCfD1Create,
 }
 
+var cfD1DeleteCmd = &cobra.Command{
+	Use:   "delete <database>",
+	Short: "Delete D1 database",
+	Long: `Delete a D1 database and all its data with wrangler d1 delete.
+
+The database is given by name or UUID and must appear in the database
+list. Asks for confirmation unless --force is given.
+
+Examples:
+  acorn cf d1 delete my-database
+  acorn cf d1 delete my-database --force
+  acorn cf d1 delete my-database --dry-run`,
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runCfD1Delete,
+}
+
+var cfD1MigrationsCmd = &cobra.Command{
+	Use:   "migrations",
+	Short: "D1 migration commands",
+	Long: `List and apply D1 schema migrations via wrangler.
+
+Migrations are read from the database's migrations_dir in wrangler.toml
+or wrangler.json (default: ./migrations), the same directory wrangler
+applies them from. wrangler has no flag to change it, so to use another
+directory set migrations_dir on the d1_databases entry; --migrations-dir
+only checks that it matches. Use --local or --remote to choose the target
+database.
+
+Examples:
+  acorn cf d1 migrations list my-database
+  acorn cf d1 migrations apply my-database --remote
+  acorn cf d1 migrations apply my-database --remote --dry-run`,
+}
+
+var cfD1MigrationsListCmd = &cobra.Command{
+	Use:   "list <database-name>",
+	Short: "List D1 migrations and their state",
+	Long: `List migrations in the migrations directory and whether each has
+been applied to the target database.
+
+Examples:
+  acorn cf d1 migrations list my-database
+  acorn cf d1 migrations list my-database --remote -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCfD1MigrationsList,
+}
+
+var cfD1MigrationsApplyCmd = &cobra.Command{
+	Use:   "apply <database-name>",
+	Short: "Apply pending D1 migrations",
+	Long: `Apply pending migrations to a D1 database.
+
+With --dry-run, shows the pending migrations without running them.
+
+Examples:
+  acorn cf d1 migrations apply my-database --local
+  acorn cf d1 migrations apply my-database --remote --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCfD1MigrationsApply,
+}
+
 // Init subcommands
 var cfInitCmd = &cobra.Command{
 	Use:   "init",
@@ -324,8 +584,12 @@ func init() {
 	cfCmd.AddCommand(cfInstallCmd)
 	cfCmd.AddCommand(cfStatusCmd)
 	cfCmd.AddCommand(cfWhoamiCmd)
+	cfCmd.AddCommand(cfAccountsCmd)
 	cfCmd.AddCommand(cfWorkersCmd)
+	cfWorkersCmd.AddCommand(cfWorkersDeleteCmd)
 	cfCmd.AddCommand(cfPagesCmd)
+	cfPagesCmd.AddCommand(cfPagesListCmd)
+	cfPagesCmd.AddCommand(cfPagesDeployCmd)
 	cfCmd.AddCommand(cfLogsCmd)
 	cfCmd.AddCommand(cfDeployCmd)
 	cfCmd.AddCommand(cfSecretsCmd)
@@ -338,16 +602,25 @@ func init() {
 	cfCmd.AddCommand(cfR2Cmd)
 	cfR2Cmd.AddCommand(cfR2ListCmd)
 	cfR2Cmd.AddCommand(cfR2CreateCmd)
+	cfR2Cmd.AddCommand(cfR2DeleteCmd)
+	cfR2Cmd.AddCommand(cfR2LifecycleCmd)
+	cfR2LifecycleCmd.AddCommand(cfR2LifecycleGetCmd)
+	cfR2LifecycleCmd.AddCommand(cfR2LifecycleSetCmd)
 
 	// KV subcommands
 	cfCmd.AddCommand(cfKVCmd)
 	cfKVCmd.AddCommand(cfKVListCmd)
 	cfKVCmd.AddCommand(cfKVCreateCmd)
+	cfKVCmd.AddCommand(cfKVDeleteCmd)
 
 	// D1 subcommands
 	cfCmd.AddCommand(cfD1Cmd)
 	cfD1Cmd.AddCommand(cfD1ListCmd)
 	cfD1Cmd.AddCommand(cfD1CreateCmd)
+	cfD1Cmd.AddCommand(cfD1DeleteCmd)
+	cfD1Cmd.AddCommand(cfD1MigrationsCmd)
+	cfD1MigrationsCmd.AddCommand(cfD1MigrationsListCmd)
+	cfD1MigrationsCmd.AddCommand(cfD1MigrationsApplyCmd)
 
 	// Init subcommands
 	cfCmd.AddCommand(cfInitCmd)
@@ -360,10 +633,122 @@ func init() {
 		"Show what would be done without executing")
 	cfCmd.PersistentFlags().BoolVarP(&cfVerbose, "verbose", "v", false,
 		"Show verbose output")
+	cfCmd.PersistentFlags().StringVar(&cfAccount, "account", "",
+		"Account ID (or name) to run wrangler against")
+
+	// Secret put flags
+	cfSecretPutCmd.Flags().BoolVar(&cfSecretFromStdin, "from-stdin", false,
+		"Read the secret value from stdin")
+	cfSecretPutCmd.Flags().StringVar(&cfSecretValueFromEnv, "value-from-env", "",
+		"Read the secret value from this environment variable")
+	cfSecretPutCmd.MarkFlagsMutuallyExclusive("from-stdin", "value-from-env")
+
+	// Workers delete flags
+	cfWorkersDeleteCmd.Flags().BoolVar(&cfWorkersDeleteForce, "force", false,
+		"Delete without asking for confirmation")
+
+	// R2, KV and D1 delete flags
+	for _, c := range []*cobra.Command{cfR2DeleteCmd, cfKVDeleteCmd, cfD1DeleteCmd} {
+		c.Flags().BoolVar(&cfResourceDeleteForce, "force", false,
+			"Delete without asking for confirmation")
+	}
+
+	// Logs flags
+	cfLogsCmd.Flags().BoolVar(&cfLogsOnce, "once", false,
+		fmt.Sprintf("Capture for one window (%s unless --duration is set), then exit", cloudflare.DefaultTailDuration))
+	cfLogsCmd.Flags().DurationVar(&cfLogsDuration, "duration", 0,
+		"Capture for this long, then exit (e.g. 30s, 2m)")
+
+	// R2 lifecycle flags
+	cfR2LifecycleSetCmd.Flags().IntVar(&cfR2LifecycleExpireDays, "expire-days", 0,
+		"Delete objects this many days after upload (required)")
+	cfR2LifecycleSetCmd.Flags().StringVar(&cfR2LifecyclePrefix, "prefix", "",
+		"Only apply the rule to keys with this prefix")
+	cfR2LifecycleSetCmd.Flags().StringVar(&cfR2LifecycleName, "name", "",
+		"Rule name (default: built from the prefix and days)")
+	cfR2LifecycleSetCmd.MarkFlagRequired("expire-days")
+
+	// Pages deploy flags
+	cfPagesDeployCmd.Flags().StringVar(&cfPagesProject, "project-name", "",
+		"Pages project to deploy to")
+	cfPagesDeployCmd.Flags().StringVar(&cfPagesBranch, "branch", "",
+		"Branch to deploy as (production or a preview branch)")
+	cfPagesDeployCmd.Flags().StringVar(&cfPagesCommitMessage, "commit-message", "",
+		"Commit message to attach to the deployment")
+
+	// D1 migration flags
+	cfD1MigrationsCmd.PersistentFlags().BoolVar(&cfD1Local, "local", false,
+		"Target the local D1 database")
+	cfD1MigrationsCmd.PersistentFlags().BoolVar(&cfD1Remote, "remote", false,
+		"Target the remote D1 database")
+	cfD1MigrationsCmd.PersistentFlags().StringVar(&cfD1MigrationsDir, "migrations-dir", "",
+		"Expected migrations directory; must match migrations_dir in the wrangler config")
 }
 
-func runCfStatus(cmd *cobra.Command, args []string) error {
+func newCfHelper() *cloudflare.Helper {
 	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper.SetAccount(cfAccount)
+	return helper
+}
+
+// cfPersistentPreRun validates --account against the accessible accounts
+// and resolves a name to its ID, after the root command's setup.
+func cfPersistentPreRun(cmd *cobra.Command, args []string) error {
+	if root := cmd.Root(); root.PersistentPreRunE != nil {
+		if err := root.PersistentPreRunE(cmd, args); err != nil {
+			return err
+		}
+	}
+
+	if cfAccount == "" || cmd == cfLoginCmd || cmd == cfLogoutCmd {
+		return nil
+	}
+
+	account, err := cloudflare.NewHelper(cfVerbose, cfDryRun).ResolveAccount(cfAccount)
+	if err != nil {
+		return err
+	}
+	cfAccount = account.ID
+	fmt.Fprintf(os.Stderr, "%s Using account %s (%s)\n", output.Info("ℹ"), account.Name, account.ID)
+	return nil
+}
+
+func runCfAccounts(cmd *cobra.Command, args []string) error {
+	helper := newCfHelper()
+	accounts, err := helper.ListAccounts()
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(accounts)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("CloudFlare Accounts"))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(accounts) == 0 {
+		fmt.Fprintln(os.Stdout, "No accounts found")
+		return nil
+	}
+
+	nameWidth := 0
+	for _, a := range accounts {
+		nameWidth = max(nameWidth, len(a.Name))
+	}
+	for _, a := range accounts {
+		marker := " "
+		if a.Active {
+			marker = output.Success("*")
+		}
+		fmt.Fprintf(os.Stdout, "%s %-*s  %s\n", marker, nameWidth, a.Name, a.ID)
+	}
+	return nil
+}
+
+func runCfStatus(cmd *cobra.Command, args []string) error {
+	helper := newCfHelper()
 	status, err := helper.GetStatus()
 	if err != nil {
 		return err
@@ -396,6 +781,9 @@ func runCfStatus(cmd *cobra.Command, args []string) error {
 		if status.AccountID != "" {
 			fmt.Fprintf(os.Stdout, "  ID: %s\n", status.AccountID)
 		}
+		if status.AccountOverride {
+			fmt.Fprintln(os.Stdout, "  (selected with --account)")
+		}
 	} else {
 		fmt.Fprintf(os.Stdout, "%s Not logged in\n", output.Warning("⚠"))
 		fmt.Fprintln(os.Stdout, "  Run: acorn cf login")
@@ -405,7 +793,7 @@ func runCfStatus(cmd *cobra.Command, args []string) error {
 }
 
 func runCfWhoami(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	info, err := helper.Whoami()
 	if err != nil {
 		return err
@@ -415,7 +803,7 @@ func runCfWhoami(cmd *cobra.Command, args []string) error {
 }
 
 func runCfWorkers(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 
 	fmt.Fprintf(os.Stdout, "%s\n", output.Info("CloudFlare Workers"))
 	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -430,32 +818,141 @@ func runCfWorkers(cmd *cobra.Command, args []string) error {
 }
 
 func runCfPages(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	projects, err := helper.ListPagesProjects()
+	if ioHelper.IsStructured() {
+		if err != nil {
+			return err
+		}
+		return ioHelper.WriteOutput(projects)
+	}
 
 	fmt.Fprintf(os.Stdout, "%s\n", output.Info("CloudFlare Pages Projects"))
 	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	pages, err := helper.ListPages()
-	if err != nil {
+	if err != nil || len(projects) == 0 {
 		fmt.Fprintln(os.Stdout, "No pages projects found or not authenticated")
 		return nil
 	}
-	fmt.Fprintln(os.Stdout, pages)
+
+	table := output.NewTable("NAME", "DOMAINS", "GIT", "MODIFIED")
+	for _, p := range projects {
+		table.AddRow(p.Name, strings.Join(p.Domains, ", "), p.GitProvider, p.LastModified)
+	}
+	table.Render(os.Stdout)
+	return nil
+}
+
+func runCfPagesDeploy(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	// Keep stdout clean for structured output
+	var stream io.Writer = os.Stdout
+	if ioHelper.IsStructured() {
+		stream = os.Stderr
+	}
+
+	deployment, err := helper.DeployPages(cloudflare.PagesDeployOptions{
+		Directory:     args[0],
+		ProjectName:   cfPagesProject,
+		Branch:        cfPagesBranch,
+		CommitMessage: cfPagesCommitMessage,
+	}, stream)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(deployment)
+	}
+
+	if cfDryRun {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stdout)
+	if deployment.URL != "" {
+		fmt.Fprintf(os.Stdout, "%s Deployed to %s\n", output.Success("✓"), deployment.URL)
+	} else {
+		fmt.Fprintf(os.Stdout, "%s Deployment complete (URL not reported)\n", output.Success("✓"))
+	}
+	return nil
+}
+
+func runCfWorkersDelete(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+	name := args[0]
+
+	if !cfDryRun && !cfWorkersDeleteForce {
+		if ioHelper.IsStructured() {
+			return fmt.Errorf("refusing to delete worker %s without --force in structured output mode", name)
+		}
+		ok, err := confirm(fmt.Sprintf("Delete worker %s? This cannot be undone.", name))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	result, err := helper.DeleteWorker(name)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+	if result.Deleted {
+		fmt.Fprintf(os.Stdout, "%s Deleted worker %s\n", output.Success("✓"), result.Name)
+	}
 	return nil
 }
 
 func runCfLogs(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
-	return helper.TailLogs(args[0])
+	helper := newCfHelper()
+	if !cfLogsOnce && !cmd.Flags().Changed("duration") {
+		return helper.TailLogs(args[0])
+	}
+	if cfLogsDuration < 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+
+	// Structured output replaces the event stream with the summary
+	ioHelper := ioutils.IO(cmd)
+	var events io.Writer = os.Stdout
+	if ioHelper.IsStructured() {
+		events = io.Discard
+	}
+
+	result, err := helper.TailLogsFor(args[0], cfLogsDuration, events)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+	if !result.DryRun {
+		fmt.Fprintf(os.Stdout, "%s Captured %d event(s) from %s in %s\n",
+			output.Success("✓"), result.Events, result.Worker, result.Duration)
+	}
+	return nil
 }
 
 func runCfDeploy(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	return helper.Deploy(args...)
 }
 
 func runCfSecrets(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	secrets, err := helper.ListSecrets()
 	if err != nil {
 		return err
@@ -465,12 +962,31 @@ func runCfSecrets(cmd *cobra.Command, args []string) error {
 }
 
 func runCfSecretPut(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
-	return helper.PutSecret(args[0])
+	helper := newCfHelper()
+	name := args[0]
+
+	switch {
+	case cfSecretFromStdin:
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read secret from stdin: %w", err)
+		}
+		// Drop the newline added by echo or a trailing line in a file
+		value := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+		return helper.PutSecretValue(name, value)
+	case cfSecretValueFromEnv != "":
+		value, ok := os.LookupEnv(cfSecretValueFromEnv)
+		if !ok {
+			return fmt.Errorf("environment variable %s is not set", cfSecretValueFromEnv)
+		}
+		return helper.PutSecretValue(name, value)
+	}
+
+	return helper.PutSecret(name)
 }
 
 func runCfOverview(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	overview, err := helper.GetOverview()
 	if err != nil {
 		return err
@@ -522,32 +1038,48 @@ func printResourceList(name string, items []string) {
 }
 
 func runCfLogin(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	return helper.Login()
 }
 
 func runCfLogout(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	return helper.Logout()
 }
 
 func runCfR2List(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	buckets, err := helper.ListR2Buckets()
+	if ioHelper.IsStructured() {
+		if err != nil {
+			return err
+		}
+		return ioHelper.WriteOutput(buckets)
+	}
 
 	fmt.Fprintf(os.Stdout, "%s\n", output.Info("CloudFlare R2 Buckets"))
 	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	buckets, err := helper.ListR2Buckets()
 	if err != nil {
-		fmt.Fprintln(os.Stdout, "No R2 buckets found or not authenticated")
+		return cfListError(err)
+	}
+	if len(buckets) == 0 {
+		fmt.Fprintln(os.Stdout, "No R2 buckets found")
 		return nil
 	}
-	fmt.Fprintln(os.Stdout, buckets)
+
+	table := output.NewTable("NAME", "CREATED")
+	for _, b := range buckets {
+		table.AddRow(b.Name, b.CreationDate)
+	}
+	table.Render(os.Stdout)
 	return nil
 }
 
 func runCfR2Create(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	if err := helper.CreateR2Bucket(args[0]); err != nil {
 		return err
 	}
@@ -555,23 +1087,124 @@ func runCfR2Create(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCfR2Delete(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	bucket, err := helper.FindR2Bucket(args[0])
+	if err != nil {
+		return err
+	}
+	if ok, err := confirmCfDelete(ioHelper, "R2 bucket "+bucket.Name); !ok || err != nil {
+		return err
+	}
+
+	result, err := helper.DeleteR2Bucket(bucket.Name)
+	if err != nil {
+		return err
+	}
+	return writeCfDeleteResult(ioHelper, result, "R2 bucket")
+}
+
+func runCfR2LifecycleGet(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	rules, err := helper.GetR2Lifecycle(args[0])
+	if err != nil {
+		return err
+	}
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(rules)
+	}
+
+	printR2LifecycleRules(args[0], rules)
+	return nil
+}
+
+func runCfR2LifecycleSet(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	rules, err := helper.SetR2Lifecycle(cloudflare.R2LifecycleOptions{
+		Bucket:     args[0],
+		Name:       cfR2LifecycleName,
+		Prefix:     cfR2LifecyclePrefix,
+		ExpireDays: cfR2LifecycleExpireDays,
+	})
+	if err != nil {
+		return err
+	}
+	if cfDryRun {
+		return nil
+	}
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(rules)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Lifecycle rule set on '%s'\n\n", output.Success("✓"), args[0])
+	printR2LifecycleRules(args[0], rules)
+	return nil
+}
+
+// printR2LifecycleRules renders a bucket's lifecycle rules as a table.
+func printR2LifecycleRules(bucket string, rules []cloudflare.R2LifecycleRule) {
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Lifecycle rules for "+bucket))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(rules) == 0 {
+		fmt.Fprintln(os.Stdout, "No lifecycle rules")
+		return
+	}
+
+	table := output.NewTable("NAME", "ENABLED", "PREFIX", "ACTION")
+	for _, r := range rules {
+		enabled := "no"
+		if r.Enabled {
+			enabled = "yes"
+		}
+		prefix := r.Prefix
+		if prefix == "" {
+			prefix = "(all)"
+		}
+		table.AddRow(r.Name, enabled, prefix, r.Action)
+	}
+	table.Render(os.Stdout)
+}
+
 func runCfKVList(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	namespaces, err := helper.ListKVNamespaces()
+	if ioHelper.IsStructured() {
+		if err != nil {
+			return err
+		}
+		return ioHelper.WriteOutput(namespaces)
+	}
 
 	fmt.Fprintf(os.Stdout, "%s\n", output.Info("CloudFlare KV Namespaces"))
 	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	namespaces, err := helper.ListKVNamespaces()
 	if err != nil {
-		fmt.Fprintln(os.Stdout, "No KV namespaces found or not authenticated")
+		return cfListError(err)
+	}
+	if len(namespaces) == 0 {
+		fmt.Fprintln(os.Stdout, "No KV namespaces found")
 		return nil
 	}
-	fmt.Fprintln(os.Stdout, namespaces)
+
+	table := output.NewTable("ID", "TITLE", "BINDING")
+	for _, ns := range namespaces {
+		table.AddRow(ns.ID, ns.Title, ns.Binding)
+	}
+	table.Render(os.Stdout)
 	return nil
 }
 
 func runCfKVCreate(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	if err := helper.CreateKVNamespace(args[0]); err != nil {
 		return err
 	}
@@ -579,23 +1212,68 @@ func runCfKVCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCfKVDelete(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	ns, err := helper.FindKVNamespace(args[0])
+	if err != nil {
+		return err
+	}
+	if ok, err := confirmCfDelete(ioHelper, fmt.Sprintf("KV namespace %s (%s) and all its keys", ns.Title, ns.ID)); !ok || err != nil {
+		return err
+	}
+
+	result, err := helper.DeleteKVNamespace(*ns)
+	if err != nil {
+		return err
+	}
+	return writeCfDeleteResult(ioHelper, result, "KV namespace")
+}
+
 func runCfD1List(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	databases, err := helper.ListD1Databases()
+	if ioHelper.IsStructured() {
+		if err != nil {
+			return err
+		}
+		return ioHelper.WriteOutput(databases)
+	}
 
 	fmt.Fprintf(os.Stdout, "%s\n", output.Info("CloudFlare D1 Databases"))
 	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	databases, err := helper.ListD1Databases()
 	if err != nil {
-		fmt.Fprintln(os.Stdout, "No D1 databases found or not authenticated")
+		return cfListError(err)
+	}
+	if len(databases) == 0 {
+		fmt.Fprintln(os.Stdout, "No D1 databases found")
 		return nil
 	}
-	fmt.Fprintln(os.Stdout, databases)
+
+	table := output.NewTable("UUID", "NAME", "CREATED", "TABLES")
+	for _, db := range databases {
+		table.AddRow(db.UUID, db.Name, db.CreatedAt, strconv.Itoa(db.NumTables))
+	}
+	table.Render(os.Stdout)
 	return nil
 }
 
+// cfListError reports a failed resource listing. A missing login gets the
+// same hint as the overview; other failures are returned.
+func cfListError(err error) error {
+	if errors.Is(err, cloudflare.ErrNotAuthenticated) {
+		fmt.Fprintf(os.Stdout, "%s Not authenticated. Run: acorn cf login\n", output.Warning("⚠"))
+		return nil
+	}
+	return err
+}
+
 func runCfD1Create(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	if err := helper.CreateD1Database(args[0]); err != nil {
 		return err
 	}
@@ -603,8 +1281,146 @@ func runCfD1Create(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func cfD1MigrationOptions(database string) cloudflare.D1MigrationOptions {
+	return cloudflare.D1MigrationOptions{
+		Database:      database,
+		MigrationsDir: cfD1MigrationsDir,
+		Local:         cfD1Local,
+		Remote:        cfD1Remote,
+	}
+}
+
+func runCfD1Delete(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := newCfHelper()
+
+	db, err := helper.FindD1Database(args[0])
+	if err != nil {
+		return err
+	}
+	if ok, err := confirmCfDelete(ioHelper, fmt.Sprintf("D1 database %s and all its data", db.Name)); !ok || err != nil {
+		return err
+	}
+
+	result, err := helper.DeleteD1Database(*db)
+	if err != nil {
+		return err
+	}
+	return writeCfDeleteResult(ioHelper, result, "D1 database")
+}
+
+// confirmCfDelete asks before deleting what, unless --force or --dry-run
+// is set. Structured output cannot prompt, so it requires --force.
+func confirmCfDelete(ioHelper *ioutils.CommandIO, what string) (bool, error) {
+	if cfDryRun || cfResourceDeleteForce {
+		return true, nil
+	}
+	if ioHelper.IsStructured() {
+		return false, fmt.Errorf("refusing to delete %s without --force in structured output mode", what)
+	}
+	ok, err := confirm(fmt.Sprintf("Delete %s? This cannot be undone.", what))
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Fprintln(os.Stdout, "Aborted.")
+	}
+	return ok, nil
+}
+
+// writeCfDeleteResult reports a resource deletion.
+func writeCfDeleteResult(ioHelper *ioutils.CommandIO, result *cloudflare.ResourceDeleteResult, kind string) error {
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+	if result.Deleted {
+		fmt.Fprintf(os.Stdout, "%s Deleted %s '%s'\n", output.Success("✓"), kind, result.Name)
+	}
+	return nil
+}
+
+func runCfD1MigrationsList(cmd *cobra.Command, args []string) error {
+	helper := newCfHelper()
+	migrations, err := helper.ListD1Migrations(cfD1MigrationOptions(args[0]))
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(map[string]interface{}{
+			"database":   args[0],
+			"migrations": migrations,
+		})
+	}
+
+	// Table format
+	if len(migrations) == 0 {
+		fmt.Fprintf(os.Stdout, "No migrations found in %s\n", cloudflare.D1MigrationsDir(".", args[0]))
+		return nil
+	}
+
+	pending := 0
+	table := output.NewTable("MIGRATION", "STATUS")
+	for _, m := range migrations {
+		status := "applied"
+		if !m.Applied {
+			status = "pending"
+			pending++
+		}
+		table.AddRow(m.Name, status)
+	}
+	table.Render(os.Stdout)
+	fmt.Fprintf(os.Stdout, "\nTotal: %d migrations, %d pending\n", len(migrations), pending)
+	return nil
+}
+
+func runCfD1MigrationsApply(cmd *cobra.Command, args []string) error {
+	helper := newCfHelper()
+	opts := cfD1MigrationOptions(args[0])
+
+	if cfDryRun {
+		migrations, err := helper.ListD1Migrations(opts)
+		if err != nil {
+			return err
+		}
+
+		var pending []cloudflare.D1Migration
+		for _, m := range migrations {
+			if !m.Applied {
+				pending = append(pending, m)
+			}
+		}
+
+		ioHelper := ioutils.IO(cmd)
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(map[string]interface{}{
+				"database": args[0],
+				"pending":  pending,
+				"dry_run":  true,
+			})
+		}
+
+		if len(pending) == 0 {
+			fmt.Fprintf(os.Stdout, "%s No pending migrations for '%s'\n", output.Success("✓"), args[0])
+			return nil
+		}
+		fmt.Fprintf(os.Stdout, "[dry-run] Would apply %d migration(s) to '%s':\n", len(pending), args[0])
+		for _, m := range pending {
+			fmt.Fprintf(os.Stdout, "  %s\n", m.Name)
+		}
+		return helper.ApplyD1Migrations(opts)
+	}
+
+	if err := helper.ApplyD1Migrations(opts); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "%s Migrations applied to '%s'\n", output.Success("✓"), args[0])
+	return nil
+}
+
 func runCfInitWorker(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	name := ""
 	if len(args) > 0 {
 		name = args[0]
@@ -613,7 +1429,7 @@ func runCfInitWorker(cmd *cobra.Command, args []string) error {
 }
 
 func runCfInitPages(cmd *cobra.Command, args []string) error {
-	helper := cloudflare.NewHelper(cfVerbose, cfDryRun)
+	helper := newCfHelper()
 	name := ""
 	if len(args) > 0 {
 		name = args[0]
@@ -1,20 +1,49 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
+	"github.com/mistergrinvalds/acorn/internal/components/shell"
 	"github.com/mistergrinvalds/acorn/internal/utils/component"
+	"github.com/mistergrinvalds/acorn/internal/utils/installer"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
+var (
+	componentListCategory     string
+	componentListPlatform     string
+	componentListRequiresTool string
+	componentListMissingTools bool
+	componentListInstalled    bool
+	componentListNotInstalled bool
+	componentShowGenerated    bool
+	componentShowAll          bool
+	componentInfoUsage        bool
+	componentInfoMarkdown     bool
+	componentDocsCategory     string
+	componentDocsFile         string
+	componentGraphFormat      string
+	componentCacheDryRun      bool
+	componentStatusSnapshot   string
+	componentStatusSince      string
+	componentStatusParallel   bool
+	componentStatusWorkers    int
+	componentStatusJUnit      string
+	componentValidateJUnit    string
+	componentValidateFixYAML  bool
+	componentValidateDryRun   bool
+)
+
 // componentCmd represents the component command group
 var componentCmd = &cobra.Command{
 	Use:   "component",
@@ -39,10 +68,23 @@ var componentListCmd = &cobra.Command{
 By default, displays components in a table format showing name, version,
 category, and description. Use --output to change format.
 
+Filters can be combined; only components matching all of them are listed:
+  --category        Components in a category
+  --platform        Components supporting an OS (darwin, linux)
+  --requires-tool   Components that require a tool
+  --missing-tools   Components with required tools not installed
+  --installed       Components whose shell integration is generated and linked
+  --not-installed   Components whose shell integration is not active
+
+Structured output includes an Installed flag for every component.
+
 Examples:
   acorn component list
   acorn component list --output json
-  acorn component list -o yaml`,
+  acorn component list -o yaml
+  acorn component list --category dev --platform linux
+  acorn component list --missing-tools -o json
+  acorn component list --installed`,
 	Aliases: []string{"ls"},
 	RunE:    runComponentList,
 }
@@ -60,10 +102,29 @@ Health checks include:
   - Configuration file existence
   - Platform compatibility
 
+With --snapshot, also records the results (status, required tool
+availability, issues and validation errors) to a file. With --since,
+compares the current health against an earlier snapshot and reports what
+changed instead: components added or removed, status changes, tools that
+appeared or disappeared, and new or resolved issues. Snapshots are YAML
+for .yaml/.yml files and JSON otherwise.
+
+With --parallel, components are checked concurrently by --workers workers.
+This mostly speeds up shell syntax checks; output order is unchanged.
+
+With --output-junit, a JUnit XML report is also written for CI, with one
+test case per component. Components with errors fail with their issues,
+and the command exits non-zero if any component failed.
+
 Examples:
   acorn component status           # Check all components
   acorn component status python    # Check specific component
-  acorn component status -o json   # JSON output`,
+  acorn component status -o json   # JSON output
+  acorn component status --parallel --workers 8
+  acorn component status --snapshot health.json
+  acorn component status --since health.json
+  acorn component status --since health.json --snapshot health.json -o json
+  acorn component status --output-junit component-health.xml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runComponentStatus,
 }
@@ -76,14 +137,26 @@ var componentValidateCmd = &cobra.Command{
 
 Performs strict validation including:
   - Required fields (name, version, description, category)
-  - Config file existence
+  - Config file sources exist inside the component directory
+  - No two config files share a target, within or across components
   - Platform and shell values
   - Shell script syntax
   - Config method values (symlink/copy)
 
+With --fix-yaml, each valid component.yaml is rewritten in canonical form:
+fields in a fixed order, two-space indentation, and an explicit method
+(default: symlink) on every config file. Comments on fields are kept.
+Use --dry-run to show the proposed changes without writing.
+
+With --output-junit, a JUnit XML report is also written for CI, with one
+test case per component failing with its validation errors.
+
 Examples:
   acorn component validate         # Validate all
-  acorn component validate python  # Validate specific component`,
+  acorn component validate python  # Validate specific component
+  acorn component validate --fix-yaml --dry-run  # Preview normalization
+  acorn component validate --fix-yaml            # Normalize all files
+  acorn component validate --output-junit component-validate.xml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runComponentValidate,
 }
@@ -97,19 +170,93 @@ var componentInfoCmd = &cobra.Command{
 Shows all metadata from component.yaml including dependencies, provided
 features, configuration files, and XDG directory usage.
 
+With --usage, reports where the component is wired in instead: whether
+it is registered for shell generation, whether its generated script exists
+and is symlinked, whether the shell.sh entrypoint sources it, and which
+components depend on it.
+
+With --markdown, renders the metadata as a Markdown section suitable for a
+README or docs site. Use 'acorn component docs' for a catalog of every
+component.
+
 Examples:
   acorn component info python
-  acorn component info git --output yaml`,
+  acorn component info git --output yaml
+  acorn component info fzf --usage
+  acorn component info tmux --markdown`,
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: completeComponentNames,
 	RunE:              runComponentInfo,
 }
 
+// componentDocsCmd generates a Markdown component catalog
+var componentDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate a Markdown catalog of components",
+	Long: `Render every discovered component as Markdown: an index table linking
+to one section per component with its description, version, platforms,
+requirements, provided aliases and functions, and configuration files.
+
+Examples:
+  acorn component docs
+  acorn component docs --category shell
+  acorn component docs --file docs/components.md`,
+	Args: cobra.NoArgs,
+	RunE: runComponentDocs,
+}
+
+// componentGraphCmd shows the dependency graph across all components
+var componentGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the dependency graph of all components",
+	Long: `Resolve every component's requires.components into a dependency graph
+across all discovered components.
+
+The default tree format lists each component nothing depends on,
+followed by what it requires, and then reports cycles, orphans (no
+dependencies and nothing depends on them) and requirements naming
+components that do not exist. --format dot emits Graphviz DOT with
+cycles drawn in red; -o json/yaml emits the adjacency list.
+
+Examples:
+  acorn component graph
+  acorn component graph --format dot | dot -Tsvg > components.svg
+  acorn component graph -o json`,
+	Args: cobra.NoArgs,
+	RunE: runComponentGraph,
+}
+
+// componentCacheCmd pre-downloads a component's tool packages
+var componentCacheCmd = &cobra.Command{
+	Use:   "cache <component>",
+	Short: "Pre-download a component's tools without installing them",
+	Long: `Download the packages for a component's install tools into the local
+package manager caches without installing them, so a later install is
+fast or works offline.
+
+Supported methods:
+  brew  - brew fetch
+  npm   - npm cache add
+  go    - go mod download
+
+Tools that are already installed, or whose method has no cache-only
+mode (apt, curl), are skipped.
+
+Examples:
+  acorn component cache node
+  acorn component cache kubernetes --dry-run
+  acorn component cache go -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeComponentNames,
+	RunE:              runComponentCache,
+}
+
 // componentShowCmd shows specific component resources
 var componentShowCmd = &cobra.Command{
 	Use:   "show <component> [resource]",
 	Short: "Show component resources",
-	Long: `Show specific resources for a component.
+	Long: `Show specific resources for a component, or with --all, one resource
+type across every component.
 
 Resources:
   aliases    - Show shell aliases
@@ -119,10 +266,25 @@ Resources:
   config     - Show raw config.yaml content
   all        - Show everything (default)
 
+With --generated, prints the exact shell script that shell generation
+would produce for the component, including header and section ordering.
+The script is rendered in memory; nothing is written unless --output-file
+is given.
+
+With --all, the resource (aliases, functions or env) is listed for every
+component with a component column. Names defined by more than one
+component are flagged as collisions, since whichever is sourced last
+silently shadows the others. Structured output has the values under
+"components" and the colliding names under "collisions".
+
 Examples:
   acorn component show docker
   acorn component show docker aliases
-  acorn component show git functions --output json`,
+  acorn component show git functions --output json
+  acorn component show git --generated
+  acorn component show git --generated --output-file /tmp/git.sh
+  acorn component show --all aliases
+  acorn component show --all functions -o json`,
 	Args:              cobra.RangeArgs(1, 2),
 	ValidArgsFunction: completeComponentShow,
 	RunE:              runComponentShow,
@@ -137,6 +299,72 @@ func init() {
 	componentCmd.AddCommand(componentValidateCmd)
 	componentCmd.AddCommand(componentInfoCmd)
 	componentCmd.AddCommand(componentShowCmd)
+	componentCmd.AddCommand(componentDocsCmd)
+	componentCmd.AddCommand(componentGraphCmd)
+	componentCmd.AddCommand(componentCacheCmd)
+
+	// List filter flags
+	componentListCmd.Flags().StringVar(&componentListCategory, "category", "",
+		"Only list components in this category")
+	componentListCmd.Flags().StringVar(&componentListPlatform, "platform", "",
+		"Only list components supporting this platform (darwin, linux)")
+	componentListCmd.Flags().StringVar(&componentListRequiresTool, "requires-tool", "",
+		"Only list components that require this tool")
+	componentListCmd.Flags().BoolVar(&componentListMissingTools, "missing-tools", false,
+		"Only list components with required tools that are not installed")
+	componentListCmd.Flags().BoolVar(&componentListInstalled, "installed", false,
+		"Only list components whose shell integration is generated and linked")
+	componentListCmd.Flags().BoolVar(&componentListNotInstalled, "not-installed", false,
+		"Only list components whose shell integration is not active")
+	componentListCmd.MarkFlagsMutuallyExclusive("installed", "not-installed")
+
+	// Status flags
+	componentStatusCmd.Flags().StringVar(&componentStatusSnapshot, "snapshot", "",
+		"Write a health snapshot to this file")
+	componentStatusCmd.Flags().StringVar(&componentStatusSince, "since", "",
+		"Report changes since the health snapshot in this file")
+	componentStatusCmd.Flags().BoolVar(&componentStatusParallel, "parallel", false,
+		"Run health checks concurrently")
+	componentStatusCmd.Flags().IntVar(&componentStatusWorkers, "workers", 4,
+		"Number of concurrent health checks (with --parallel)")
+	componentStatusCmd.Flags().StringVar(&componentStatusJUnit, "output-junit", "",
+		"Also write a JUnit XML report to this file and fail if any component has errors")
+
+	// Validate flags
+	componentValidateCmd.Flags().BoolVar(&componentValidateFixYAML, "fix-yaml", false,
+		"Rewrite valid component.yaml files in canonical form")
+	componentValidateCmd.Flags().BoolVar(&componentValidateDryRun, "dry-run", false,
+		"With --fix-yaml, show the changes without writing")
+	componentValidateCmd.Flags().StringVar(&componentValidateJUnit, "output-junit", "",
+		"Also write a JUnit XML report to this file")
+
+	// Info flags
+	componentInfoCmd.Flags().BoolVar(&componentInfoUsage, "usage", false,
+		"Show where the component is referenced and whether it is in use")
+	componentInfoCmd.Flags().BoolVar(&componentInfoMarkdown, "markdown", false,
+		"Render the component as a Markdown section")
+	componentInfoCmd.MarkFlagsMutuallyExclusive("usage", "markdown")
+
+	// Docs flags
+	componentDocsCmd.Flags().StringVar(&componentDocsCategory, "category", "",
+		"Only include components in this category")
+	componentDocsCmd.Flags().StringVar(&componentDocsFile, "file", "",
+		"Write the catalog to this file instead of stdout")
+
+	// Show flags
+	componentShowCmd.Flags().BoolVar(&componentShowGenerated, "generated", false,
+		"Show the generated shell script instead of config resources")
+	componentShowCmd.Flags().BoolVar(&componentShowAll, "all", false,
+		"Show one resource type (aliases, functions, env) across all components")
+	componentShowCmd.MarkFlagsMutuallyExclusive("all", "generated")
+
+	// Graph flags
+	componentGraphCmd.Flags().StringVar(&componentGraphFormat, "format", "tree",
+		"Graph format (tree, dot)")
+
+	// Cache flags
+	componentCacheCmd.Flags().BoolVar(&componentCacheDryRun, "dry-run", false,
+		"Show what would be downloaded without downloading")
 
 	// Output format is inherited from root command
 }
@@ -181,30 +409,76 @@ func runComponentList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to discover components: %w", err)
 	}
 
-	if len(components) == 0 {
-		fmt.Fprintln(os.Stderr, "No components found")
-		return nil
+	filter := component.Filter{
+		Category:     componentListCategory,
+		Platform:     componentListPlatform,
+		RequiresTool: componentListRequiresTool,
+		MissingTools: componentListMissingTools,
+		Installed:    componentListInstalled,
+		NotInstalled: componentListNotInstalled,
+		ToolExists:   commandExists,
+		IsInstalled:  componentInstalledChecker(),
 	}
+	components = filter.Apply(components)
 
 	if ioHelper.IsStructured() {
-		return ioHelper.WriteOutput(components)
+		entries := make([]componentListEntry, len(components))
+		for i, comp := range components {
+			entries[i] = componentListEntry{Component: comp, Installed: filter.IsInstalled(comp.Name)}
+		}
+		return ioHelper.WriteOutput(entries)
+	}
+
+	if len(components) == 0 {
+		if filter.IsEmpty() {
+			fmt.Fprintln(os.Stderr, "No components found")
+		} else {
+			fmt.Fprintln(os.Stderr, "No components match the given filters")
+		}
+		return nil
 	}
 
 	// Table format
-	table := output.NewTable("NAME", "VERSION", "CATEGORY", "DESCRIPTION")
-	for _, comp := range components {
-		desc := comp.Description
-		if len(desc) > 50 {
-			desc = desc[:47] + "..."
+	if componentListMissingTools {
+		table := output.NewTable("NAME", "CATEGORY", "MISSING TOOLS")
+		for _, comp := range components {
+			table.AddRow(comp.Name, comp.Category, strings.Join(comp.MissingTools(commandExists), ", "))
+		}
+		table.Render(os.Stdout)
+	} else {
+		table := output.NewTable("NAME", "VERSION", "CATEGORY", "DESCRIPTION")
+		for _, comp := range components {
+			desc := comp.Description
+			if len(desc) > 50 {
+				desc = desc[:47] + "..."
+			}
+			table.AddRow(comp.Name, comp.Version, comp.Category, desc)
 		}
-		table.AddRow(comp.Name, comp.Version, comp.Category, desc)
+		table.Render(os.Stdout)
 	}
-	table.Render(os.Stdout)
 
 	fmt.Fprintf(os.Stdout, "\nTotal: %d components\n", len(components))
 	return nil
 }
 
+// componentListEntry is a listed component with its shell integration state.
+type componentListEntry struct {
+	*component.Component `yaml:",inline"`
+	Installed            bool `json:"Installed" yaml:"installed"`
+}
+
+// componentInstalledChecker returns a lookup reporting whether a component's
+// shell integration is generated and symlinked into the acorn dir.
+func componentInstalledChecker() func(name string) bool {
+	manager := shell.NewManager(shell.NewConfig(false, true))
+	shell.RegisterAllComponents(manager)
+
+	return func(name string) bool {
+		usage := manager.GetComponentUsage(name)
+		return usage.GeneratedExists && usage.Symlinked
+	}
+}
+
 // runComponentStatus executes the status command
 func runComponentStatus(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
@@ -232,17 +506,48 @@ func runComponentStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Perform health checks
-	var results []*component.HealthCheck
-	for _, comp := range components {
-		hc := component.CheckHealth(comp)
-		results = append(results, hc)
+	workers := 1
+	if componentStatusParallel {
+		workers = componentStatusWorkers
 	}
+	results := component.CheckHealthAll(components, workers)
 
-	if ioHelper.IsStructured() {
-		return ioHelper.WriteOutput(results)
+	if componentStatusJUnit != "" {
+		if err := component.WriteJUnit(componentStatusJUnit, component.HealthJUnit(results)); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case componentStatusSince != "" || componentStatusSnapshot != "":
+		err = runComponentStatusSnapshot(ioHelper, results, args)
+	case ioHelper.IsStructured():
+		err = ioHelper.WriteOutput(results)
+	default:
+		printComponentStatus(results)
+	}
+	if err != nil || componentStatusJUnit == "" {
+		return err
 	}
 
-	// Table format with color-coded status
+	failed := 0
+	for _, hc := range results {
+		if hc.Status == component.StatusError {
+			failed++
+		}
+	}
+	if !ioHelper.IsStructured() {
+		fmt.Fprintf(os.Stdout, "JUnit report written to %s\n", componentStatusJUnit)
+	}
+	if failed > 0 {
+		return &exitCodeError{code: 1, msg: fmt.Sprintf("%d of %d components failed health checks", failed, len(results))}
+	}
+	return nil
+}
+
+// printComponentStatus prints health check results as a color-coded list
+// followed by a summary.
+func printComponentStatus(results []*component.HealthCheck) {
 	healthy := 0
 	warnings := 0
 	errors := 0
@@ -289,7 +594,94 @@ func runComponentStatus(cmd *cobra.Command, args []string) error {
 	if errors > 0 {
 		fmt.Fprintf(os.Stdout, "  %s: %d\n", output.Error("Errors"), errors)
 	}
+}
 
+// runComponentStatusSnapshot writes a health snapshot and/or reports the
+// changes since an earlier one.
+func runComponentStatusSnapshot(ioHelper *ioutils.CommandIO, results []*component.HealthCheck, args []string) error {
+	snap := component.NewSnapshot(results)
+
+	var diff *component.HealthDiff
+	if componentStatusSince != "" {
+		old, err := component.LoadSnapshot(componentStatusSince)
+		if err != nil {
+			return err
+		}
+		// When checking one component, ignore the rest of the old snapshot
+		if len(args) == 1 {
+			var kept []component.ComponentSnapshot
+			for _, c := range old.Components {
+				if c.Name == args[0] {
+					kept = append(kept, c)
+				}
+			}
+			old.Components = kept
+		}
+		diff = component.DiffSnapshots(old, snap)
+	}
+
+	if componentStatusSnapshot != "" {
+		if err := component.WriteSnapshot(componentStatusSnapshot, snap); err != nil {
+			return err
+		}
+	}
+
+	if diff == nil {
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(snap)
+		}
+		fmt.Fprintf(os.Stdout, "%s Wrote snapshot of %d components to %s\n",
+			output.Success("✓"), len(snap.Components), componentStatusSnapshot)
+		return nil
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(diff)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Component health since "+diff.Since.Local().Format("2006-01-02 15:04")))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if !diff.HasChanges() {
+		fmt.Fprintf(os.Stdout, "%s No changes\n", output.Success("✓"))
+	}
+	for _, name := range diff.Added {
+		fmt.Fprintf(os.Stdout, "%s %s (added)\n", output.Success("+"), name)
+	}
+	for _, name := range diff.Removed {
+		fmt.Fprintf(os.Stdout, "%s %s (removed)\n", output.Error("-"), name)
+	}
+	for _, c := range diff.Changed {
+		symbol := output.Warning("~")
+		if c.OldStatus != c.NewStatus {
+			if c.NewStatus == component.StatusHealthy {
+				symbol = output.Success("✓")
+			} else if c.OldStatus == component.StatusHealthy || c.NewStatus == component.StatusError {
+				symbol = output.Error("✗")
+			}
+			fmt.Fprintf(os.Stdout, "%s %s: %s → %s\n", symbol, c.Name, c.OldStatus, c.NewStatus)
+		} else {
+			fmt.Fprintf(os.Stdout, "%s %s: %s\n", symbol, c.Name, c.NewStatus)
+		}
+		for _, tool := range c.ToolsAppeared {
+			fmt.Fprintf(os.Stdout, "  %s tool installed: %s\n", output.Success("+"), tool)
+		}
+		for _, tool := range c.ToolsDisappeared {
+			fmt.Fprintf(os.Stdout, "  %s tool missing: %s\n", output.Error("-"), tool)
+		}
+		for _, issue := range c.NewIssues {
+			fmt.Fprintf(os.Stdout, "  %s %s\n", output.Error("✗"), issue)
+		}
+		for _, issue := range c.ResolvedIssues {
+			fmt.Fprintf(os.Stdout, "  %s resolved: %s\n", output.Success("✓"), issue)
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "\nSummary: %d added, %d removed, %d changed\n",
+		len(diff.Added), len(diff.Removed), len(diff.Changed))
+	if componentStatusSnapshot != "" {
+		fmt.Fprintf(os.Stdout, "Snapshot written to %s\n", componentStatusSnapshot)
+	}
 	return nil
 }
 
@@ -303,29 +695,66 @@ func runComponentValidate(cmd *cobra.Command, args []string) error {
 
 	disco := component.NewDiscovery(dotfilesRoot)
 
-	var components []*component.Component
+	// Target collisions are checked against every component, even when
+	// validating just one
+	all, err := disco.DiscoverAll()
+	if err != nil {
+		return err
+	}
+
+	var results []*component.ValidationResult
 	if len(args) == 1 {
 		comp, err := disco.FindByName(args[0])
 		if err != nil {
 			return err
 		}
-		components = []*component.Component{comp}
+		vr := component.Validate(comp)
+		component.CheckTargetCollisions(vr, all)
+		component.CheckDependencyCycles(vr, all)
+		results = []*component.ValidationResult{vr}
 	} else {
-		components, err = disco.DiscoverAll()
-		if err != nil {
-			return err
+		results = component.ValidateAll(all)
+	}
+
+	var formatted []*component.FormatResult
+	if componentValidateFixYAML {
+		for _, vr := range results {
+			if !vr.Valid {
+				continue
+			}
+			fr, err := component.FormatFile(vr.Component, componentValidateDryRun)
+			if err != nil {
+				return err
+			}
+			formatted = append(formatted, fr)
 		}
 	}
 
-	// Validate each component
-	var results []*component.ValidationResult
-	for _, comp := range components {
-		vr := component.Validate(comp)
-		results = append(results, vr)
+	if componentValidateJUnit != "" {
+		if err := component.WriteJUnit(componentValidateJUnit, component.ValidationJUnit(results)); err != nil {
+			return err
+		}
 	}
 
 	if ioHelper.IsStructured() {
-		return ioHelper.WriteOutput(results)
+		var err error
+		if componentValidateFixYAML {
+			err = ioHelper.WriteOutput(map[string]interface{}{
+				"validation": results,
+				"formatted":  formatted,
+			})
+		} else {
+			err = ioHelper.WriteOutput(results)
+		}
+		if err != nil || componentValidateJUnit == "" {
+			return err
+		}
+		for _, vr := range results {
+			if !vr.Valid {
+				return &exitCodeError{code: 1, msg: "validation failed"}
+			}
+		}
+		return nil
 	}
 
 	// Table format
@@ -350,7 +779,14 @@ func runComponentValidate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if componentValidateFixYAML {
+		printFormatResults(formatted)
+	}
+
 	fmt.Fprintln(os.Stdout)
+	if componentValidateJUnit != "" {
+		fmt.Fprintf(os.Stdout, "JUnit report written to %s\n", componentValidateJUnit)
+	}
 	if invalidCount == 0 {
 		fmt.Fprintln(os.Stdout, output.Success("All components are valid"))
 		return nil
@@ -363,6 +799,40 @@ func runComponentValidate(cmd *cobra.Command, args []string) error {
 	return fmt.Errorf("validation failed")
 }
 
+// printFormatResults prints the outcome of --fix-yaml for each component.
+func printFormatResults(results []*component.FormatResult) {
+	fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(os.Stdout, output.Info("YAML formatting"))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	changed := 0
+	for _, fr := range results {
+		switch {
+		case !fr.Changed:
+			continue
+		case fr.Written:
+			fmt.Fprintf(os.Stdout, "%s %s: rewrote %s\n", output.Success("✓"), fr.Component, fr.Path)
+		default:
+			fmt.Fprintf(os.Stdout, "[dry-run] would rewrite %s\n", fr.Path)
+			for _, line := range strings.Split(strings.TrimSuffix(fr.Diff, "\n"), "\n") {
+				switch {
+				case strings.HasPrefix(line, "+"):
+					fmt.Fprintf(os.Stdout, "  %s\n", output.Success(line))
+				case strings.HasPrefix(line, "-"):
+					fmt.Fprintf(os.Stdout, "  %s\n", output.Error(line))
+				default:
+					fmt.Fprintf(os.Stdout, "  %s\n", line)
+				}
+			}
+		}
+		changed++
+	}
+
+	if changed == 0 {
+		fmt.Fprintf(os.Stdout, "%s All %d component.yaml files are already canonical\n", output.Success("✓"), len(results))
+	}
+}
+
 // runComponentInfo executes the info command
 func runComponentInfo(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
@@ -377,6 +847,15 @@ func runComponentInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if componentInfoUsage {
+		return showComponentUsage(disco, comp, ioHelper)
+	}
+
+	if componentInfoMarkdown {
+		component.WriteMarkdown(os.Stdout, comp, 2)
+		return nil
+	}
+
 	if ioHelper.IsStructured() {
 		return ioHelper.WriteOutput(comp)
 	}
@@ -446,6 +925,210 @@ func runComponentInfo(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runComponentDocs(cmd *cobra.Command, args []string) error {
+	dotfilesRoot, err := getDotfilesRoot()
+	if err != nil {
+		return err
+	}
+
+	disco := component.NewDiscovery(dotfilesRoot)
+	var comps []*component.Component
+	if componentDocsCategory != "" {
+		comps, err = disco.FindByCategory(componentDocsCategory)
+	} else {
+		comps, err = disco.DiscoverAll()
+	}
+	if err != nil {
+		return err
+	}
+	if len(comps) == 0 {
+		return fmt.Errorf("no components found")
+	}
+
+	var buf bytes.Buffer
+	component.WriteCatalogMarkdown(&buf, comps)
+
+	if componentDocsFile == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(componentDocsFile, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", componentDocsFile, err)
+	}
+	fmt.Fprintf(os.Stdout, "%s Wrote catalog of %d component(s) to %s\n",
+		output.Success("✓"), len(comps), componentDocsFile)
+	return nil
+}
+
+func runComponentGraph(cmd *cobra.Command, args []string) error {
+	if componentGraphFormat != "tree" && componentGraphFormat != "dot" {
+		return fmt.Errorf("invalid --format %q (valid: tree, dot)", componentGraphFormat)
+	}
+	if componentGraphFormat == "dot" && cmd.Flags().Changed("output") {
+		return fmt.Errorf("--format dot cannot be combined with --output")
+	}
+
+	dotfilesRoot, err := getDotfilesRoot()
+	if err != nil {
+		return err
+	}
+	comps, err := component.NewDiscovery(dotfilesRoot).DiscoverAll()
+	if err != nil {
+		return err
+	}
+	graph := component.BuildGraph(comps)
+
+	// DOT is meant to be piped, so it wins over the non-TTY JSON default
+	if componentGraphFormat == "dot" {
+		graph.WriteDOT(os.Stdout)
+		return nil
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(graph)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Component Dependency Graph"))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	graph.WriteTree(os.Stdout)
+	fmt.Fprintln(os.Stdout)
+
+	for _, cycle := range graph.Cycles {
+		fmt.Fprintf(os.Stdout, "%s Cycle between: %s\n", output.Error("✗"), strings.Join(cycle, ", "))
+	}
+	for _, node := range graph.Nodes {
+		for _, dep := range node.Missing {
+			fmt.Fprintf(os.Stdout, "%s %s requires unknown component %s\n", output.Warning("!"), node.Name, dep)
+		}
+	}
+	if len(graph.Orphans) > 0 {
+		fmt.Fprintf(os.Stdout, "Orphans: %s\n", strings.Join(graph.Orphans, ", "))
+	}
+	fmt.Fprintf(os.Stdout, "%d component(s), %d cycle(s), %d orphan(s)\n",
+		len(graph.Nodes), len(graph.Cycles), len(graph.Orphans))
+	return nil
+}
+
+func runComponentCache(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+
+	// Keep package manager output off stdout when it carries structured data
+	opts := []installer.Option{installer.WithDryRun(componentCacheDryRun)}
+	if ioHelper.IsStructured() {
+		opts = append(opts, installer.WithOutput(os.Stderr, os.Stderr))
+	}
+	result, err := installer.NewInstaller(opts...).Cache(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	title := "Component Cache"
+	if result.DryRun {
+		title += " (dry run)"
+	}
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info(title+": "+result.Component))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, t := range result.Tools {
+		switch {
+		case t.Cached:
+			fmt.Fprintf(os.Stdout, "  %s %s (%s) %s\n", output.Success("✓"), t.Name, t.Method, output.FormatBytes(t.Size))
+			if t.Path != "" {
+				fmt.Fprintf(os.Stdout, "      %s\n", t.Path)
+			}
+		case t.Skipped && t.SkipReason == "dry run":
+			fmt.Fprintf(os.Stdout, "  %s %s (%s) would be downloaded\n", output.Warning("○"), t.Name, t.Method)
+		case t.Skipped:
+			fmt.Fprintf(os.Stdout, "  %s %s (%s)\n", output.Info("-"), t.Name, t.SkipReason)
+		default:
+			fmt.Fprintf(os.Stdout, "  %s %s: %s\n", output.Error("✗"), t.Name, t.Error)
+		}
+	}
+
+	cached, skipped, failed, size := result.Summary()
+	fmt.Fprintln(os.Stdout)
+	fmt.Fprintf(os.Stdout, "Cached: %d (%s), Skipped: %d, Failed: %d\n",
+		cached, output.FormatBytes(size), skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d tool(s) failed to cache", failed)
+	}
+	return nil
+}
+
+// ComponentUsage reports where a component is wired in.
+type ComponentUsage struct {
+	Component  string                `json:"component" yaml:"component"`
+	Shell      *shell.ComponentUsage `json:"shell" yaml:"shell"`
+	RequiredBy []string              `json:"required_by" yaml:"required_by"`
+	InUse      bool                  `json:"in_use" yaml:"in_use"`
+}
+
+// showComponentUsage displays where a component is referenced
+func showComponentUsage(disco *component.Discovery, comp *component.Component, ioHelper *ioutils.CommandIO) error {
+	all, err := disco.DiscoverAll()
+	if err != nil {
+		return err
+	}
+
+	manager := shell.NewManager(shell.NewConfig(false, true))
+	shell.RegisterAllComponents(manager)
+
+	usage := &ComponentUsage{
+		Component:  comp.Name,
+		Shell:      manager.GetComponentUsage(comp.Name),
+		RequiredBy: component.Dependents(all, comp.Name),
+	}
+	if usage.RequiredBy == nil {
+		usage.RequiredBy = []string{}
+	}
+	usage.InUse = usage.Shell.SourcedByEntrypoint || usage.Shell.Symlinked || len(usage.RequiredBy) > 0
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(usage)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info(comp.Name+" usage"))
+	fmt.Fprintf(os.Stdout, "%s\n\n", strings.Repeat("=", len(comp.Name)+6))
+
+	printUsageCheck("Registered for shell generation", usage.Shell.Registered, "")
+	printUsageCheck("Generated script exists", usage.Shell.GeneratedExists, usage.Shell.GeneratedPath)
+	printUsageCheck("Symlinked into acorn dir", usage.Shell.Symlinked, usage.Shell.SymlinkPath)
+	printUsageCheck("Sourced by shell.sh entrypoint", usage.Shell.SourcedByEntrypoint, "")
+
+	fmt.Fprintln(os.Stdout)
+	if len(usage.RequiredBy) > 0 {
+		fmt.Fprintf(os.Stdout, "Required by: %s\n", strings.Join(usage.RequiredBy, ", "))
+	} else {
+		fmt.Fprintln(os.Stdout, "Required by: (none)")
+	}
+
+	fmt.Fprintln(os.Stdout)
+	if usage.InUse {
+		fmt.Fprintf(os.Stdout, "%s %s is in use\n", output.Warning("!"), comp.Name)
+	} else {
+		fmt.Fprintf(os.Stdout, "%s %s is not referenced and can be removed safely\n", output.Success("✓"), comp.Name)
+	}
+
+	return nil
+}
+
+// printUsageCheck prints a single yes/no usage line with an optional detail
+func printUsageCheck(label string, ok bool, detail string) {
+	mark := output.Error("✗")
+	if ok {
+		mark = output.Success("✓")
+	}
+	if detail != "" {
+		fmt.Fprintf(os.Stdout, "  %s %s (%s)\n", mark, label, detail)
+	} else {
+		fmt.Fprintf(os.Stdout, "  %s %s\n", mark, label)
+	}
+}
+
 // completeComponentNames provides completion for component names
 func completeComponentNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	dotfilesRoot, err := getDotfilesRoot()
@@ -571,6 +1254,20 @@ func runComponentShow(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	componentName := args[0]
 
+	if componentShowAll {
+		if len(args) != 1 {
+			return fmt.Errorf("--all takes a single resource type (aliases, functions, env)")
+		}
+		return showAllComponents(args[0], ioHelper)
+	}
+
+	if componentShowGenerated {
+		if len(args) == 2 {
+			return fmt.Errorf("--generated cannot be combined with a resource type")
+		}
+		return showGenerated(componentName, ioHelper)
+	}
+
 	resource := "all"
 	if len(args) == 2 {
 		resource = args[1]
@@ -599,6 +1296,115 @@ func runComponentShow(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// allComponentsResult is the structured output of component show --all.
+type allComponentsResult struct {
+	Components map[string]map[string]string `json:"components" yaml:"components"`
+	Collisions []component.NameCollision    `json:"collisions" yaml:"collisions"`
+}
+
+// showAllComponents displays one resource type for every sapling component
+// and flags names defined by more than one component.
+func showAllComponents(resource string, ioHelper *ioutils.CommandIO) error {
+	var pick func(*SaplingComponent) map[string]string
+	var nameHeader string
+	switch resource {
+	case "aliases":
+		pick = func(c *SaplingComponent) map[string]string { return c.Aliases }
+		nameHeader = "ALIAS"
+	case "functions":
+		pick = func(c *SaplingComponent) map[string]string { return c.ShellFunctions }
+		nameHeader = "FUNCTION"
+	case "env":
+		pick = func(c *SaplingComponent) map[string]string { return c.Env }
+		nameHeader = "VARIABLE"
+	default:
+		return fmt.Errorf("--all supports aliases, functions or env, not %s", resource)
+	}
+
+	names, err := listSaplingComponents()
+	if err != nil {
+		return err
+	}
+
+	byComponent := map[string]map[string]string{}
+	for _, name := range names {
+		comp, err := loadSaplingComponent(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", output.Warning("!"), err)
+			continue
+		}
+		if values := pick(comp); len(values) > 0 {
+			byComponent[name] = values
+		}
+	}
+	collisions := component.FindNameCollisions(byComponent)
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(allComponentsResult{
+			Components: byComponent,
+			Collisions: collisions,
+		})
+	}
+
+	definedBy := component.NameOwners(byComponent)
+	if len(definedBy) == 0 {
+		fmt.Fprintf(os.Stdout, "No %s defined\n", resource)
+		return nil
+	}
+
+	keys := make([]string, 0, len(definedBy))
+	for key := range definedBy {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	table := output.NewTable(nameHeader, "COMPONENT", "VALUE")
+	total := 0
+	for _, key := range keys {
+		owners := definedBy[key]
+		for _, owner := range owners {
+			value := strings.Split(strings.TrimSpace(byComponent[owner][key]), "\n")[0]
+			if len(value) > 60 {
+				value = value[:57] + "..."
+			}
+			label := key
+			if len(owners) > 1 {
+				label = key + " !"
+			}
+			table.AddRow(label, owner, value)
+			total++
+		}
+	}
+	table.Render(os.Stdout)
+	fmt.Fprintf(os.Stdout, "\nTotal: %d %s across %d components\n", total, resource, len(byComponent))
+
+	if len(collisions) > 0 {
+		fmt.Fprintf(os.Stdout, "\n%s\n", output.Warning(fmt.Sprintf("Collisions (%d):", len(collisions))))
+		for _, c := range collisions {
+			fmt.Fprintf(os.Stdout, "  %s %s: %s\n", output.Warning("!"), c.Name, strings.Join(c.Components, ", "))
+		}
+	}
+	return nil
+}
+
+// showGenerated displays the shell script generated for a component
+func showGenerated(componentName string, ioHelper *ioutils.CommandIO) error {
+	manager := shell.NewManager(shell.NewConfig(false, true))
+	shell.RegisterAllComponents(manager)
+
+	script, err := manager.RenderComponent(componentName)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(script)
+	}
+
+	_, err = fmt.Fprint(ioHelper.Writer(), script.Content)
+	return err
+}
+
 // showAliases displays component aliases
 func showAliases(comp *SaplingComponent, ioHelper *ioutils.CommandIO) error {
 	if ioHelper.IsStructured() {
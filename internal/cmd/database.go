@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/components/database"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
@@ -13,8 +16,19 @@ import (
 )
 
 var (
-	dbDryRun  bool
-	dbVerbose bool
+	dbDryRun       bool
+	dbVerbose      bool
+	dbBackupDest   string
+	dbRestoreYes   bool
+
+	dbStatusWait     bool
+	dbStatusTimeout  time.Duration
+	dbStatusInterval time.Duration
+	dbStatusHost     string
+	dbStatusPing     bool
+
+	dbMigrateDir string
+	dbMigrateTo  int64
 )
 
 // dbCmd represents the database command group
@@ -30,21 +44,34 @@ Examples:
   acorn db status                # Check all database statuses
   acorn db start postgres        # Start PostgreSQL
   acorn db stop redis            # Stop Redis
-  acorn db start-all             # Start common databases`,
+  acorn db start-all             # Start common databases
+  acorn db backup postgres mydb  # Dump a database to a file
+  acorn db migrate postgres mydb # Apply SQL migrations`,
 	Aliases: []string{"database"},
 }
 
 // dbStatusCmd shows database status
 var dbStatusCmd = &cobra.Command{
-	Use:   "status",
+	Use:   "status [engine[:port]...]",
 	Short: "Check database service status",
 	Long: `Check the status of all database services.
 
 Shows whether each database is installed and running.
 
+With engine arguments, checks whether each engine accepts connections on
+its port (postgres, mysql, mongodb, redis, neo4j; append :port to override
+the default). With --wait, polls until every engine is ready or --timeout
+elapses, exiting non-zero if any engine is not ready. Add --ping to also
+run a trivial engine-specific query (SELECT 1, PING) once the port opens.
+A ping rejected for bad credentials, or a missing client, fails at once
+rather than being retried until the timeout.
+
 Examples:
   acorn db status
-  acorn db status -o json`,
+  acorn db status -o json
+  acorn db status postgres
+  acorn db status postgres redis --wait --timeout 30s
+  acorn db status redis:6380 --wait --ping -o json`,
 	RunE: runDbStatus,
 }
 
@@ -130,6 +157,96 @@ Examples:
 	RunE: runDbList,
 }
 
+// dbBackupCmd dumps a local database
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup <engine> [db]",
+	Short: "Back up a local database",
+	Long: `Dump a local database using the engine's native dump tool.
+
+Supported engines:
+  postgres  pg_dump (default db: postgres)
+  mysql     mysqldump -u root (default: all databases)
+  mongodb   mongodump --archive (default: all databases)
+  redis     redis-cli --rdb
+
+The service must be running. Without --dest, a timestamped file is
+written to the current directory.
+
+Examples:
+  acorn db backup postgres mydb
+  acorn db backup mysql app --dest app.sql
+  acorn db backup redis -o json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDbBackup,
+}
+
+// dbRestoreCmd loads a backup into a local database
+var dbRestoreCmd = &cobra.Command{
+	Use:   "restore <engine> <file> [db]",
+	Short: "Restore a local database from a backup",
+	Long: `Load a backup created by 'acorn db backup' into a local database.
+
+Restoring overwrites existing data, so you will be asked to confirm
+unless --yes is given.
+
+Examples:
+  acorn db restore postgres mydb-backup.sql mydb
+  acorn db restore mongodb mongodb.archive --yes`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runDbRestore,
+}
+
+// dbMigrateCmd applies SQL migrations
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate <engine> [db]",
+	Short: "Apply SQL migrations to a local database",
+	Long: `Apply numbered .sql files from a migrations directory in order.
+
+Files are named <version>_<name>.sql (or .up.sql), with an optional
+<version>_<name>.down.sql to revert them, e.g.:
+
+  001_create_users.sql
+  001_create_users.down.sql
+  002_add_email.sql
+
+Applied versions are recorded in a schema_migrations table, created on
+the first run, so already-applied files are skipped. With --to, only
+migrations up to and including that version are applied. --dry-run lists
+the pending migrations without running them.
+
+Supported engines:
+  postgres  psql (default db: postgres)
+  mysql     mysql -u root
+  sqlite    sqlite3 (db is the database file)
+
+Examples:
+  acorn db migrate postgres mydb
+  acorn db migrate sqlite app.db --dir db/migrations
+  acorn db migrate mysql app --to 3
+  acorn db migrate postgres mydb --dry-run
+  acorn db migrate down postgres mydb`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDbMigrate,
+}
+
+// dbMigrateDownCmd reverts SQL migrations
+var dbMigrateDownCmd = &cobra.Command{
+	Use:   "down <engine> [db]",
+	Short: "Revert applied SQL migrations",
+	Long: `Revert applied migrations using their .down.sql files, newest first.
+
+Without --to, only the most recently applied migration is reverted. With
+--to, every applied migration above that version is reverted; --to 0
+reverts them all.
+
+Examples:
+  acorn db migrate down postgres mydb
+  acorn db migrate down sqlite app.db --to 2
+  acorn db migrate down mysql app --to 0 --dry-run`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDbMigrateDown,
+}
+
 func init() {
 
 	// Add subcommands
@@ -140,6 +257,10 @@ func init() {
 	dbCmd.AddCommand(dbStartAllCmd)
 	dbCmd.AddCommand(dbStopAllCmd)
 	dbCmd.AddCommand(dbListCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbRestoreCmd)
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbMigrateCmd.AddCommand(dbMigrateDownCmd)
 	dbCmd.AddCommand(configcmd.NewConfigRouter("database"))
 
 	// Persistent flags
@@ -147,9 +268,40 @@ func init() {
 		"Show what would be done without executing")
 	dbCmd.PersistentFlags().BoolVarP(&dbVerbose, "verbose", "v", false,
 		"Show verbose output")
+
+	// Status flags
+	dbStatusCmd.Flags().BoolVar(&dbStatusWait, "wait", false,
+		"Wait until the given engines accept connections")
+	dbStatusCmd.Flags().DurationVar(&dbStatusTimeout, "timeout", 30*time.Second,
+		"Maximum time to wait with --wait")
+	dbStatusCmd.Flags().DurationVar(&dbStatusInterval, "interval", time.Second,
+		"Delay between readiness checks with --wait")
+	dbStatusCmd.Flags().StringVar(&dbStatusHost, "host", "localhost",
+		"Host to check engine ports on")
+	dbStatusCmd.Flags().BoolVar(&dbStatusPing, "ping", false,
+		"Also run an engine-specific ping once the port is open")
+
+	// Backup/restore flags
+	dbBackupCmd.Flags().StringVar(&dbBackupDest, "dest", "",
+		"Backup file path (default: <engine>[-<db>]-<timestamp>.<ext>)")
+	dbRestoreCmd.Flags().BoolVarP(&dbRestoreYes, "yes", "y", false,
+		"Skip confirmation")
+
+	// Migrate flags
+	dbMigrateCmd.PersistentFlags().StringVar(&dbMigrateDir, "dir", "migrations",
+		"Directory containing the migration files")
+	dbMigrateCmd.PersistentFlags().Int64Var(&dbMigrateTo, "to", 0,
+		"Target version (up: apply up to it, down: revert above it)")
 }
 
 func runDbStatus(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return runDbStatusWait(cmd, args)
+	}
+	if dbStatusWait {
+		return fmt.Errorf("--wait requires at least one engine (e.g. acorn db status postgres --wait)")
+	}
+
 	ioHelper := ioutils.IO(cmd)
 	helper := database.NewHelper(dbVerbose, dbDryRun)
 	status := helper.GetAllStatus()
@@ -182,6 +334,58 @@ func runDbStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runDbStatusWait checks (or with --wait, waits for) engine readiness.
+func runDbStatusWait(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := database.NewHelper(dbVerbose, dbDryRun)
+
+	opts := database.WaitOptions{
+		Targets:  args,
+		Host:     dbStatusHost,
+		Interval: dbStatusInterval,
+		Ping:     dbStatusPing,
+	}
+	if dbStatusWait {
+		opts.Timeout = dbStatusTimeout
+	}
+
+	results, err := helper.WaitReady(opts)
+	if err != nil {
+		return err
+	}
+
+	var notReady []string
+	for _, r := range results {
+		if !r.Ready {
+			notReady = append(notReady, r.Engine)
+		}
+	}
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(map[string][]database.WaitResult{"engines": results}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Ready {
+				fmt.Fprintf(os.Stdout, "%s %-10s ready at %s (%d attempt(s), %dms)\n",
+					output.Success("●"), r.Engine, r.Address, r.Attempts, r.ElapsedMs)
+			} else {
+				fmt.Fprintf(os.Stdout, "%s %-10s not ready at %s: %s\n",
+					output.Error("○"), r.Engine, r.Address, r.Error)
+			}
+		}
+	}
+
+	if len(notReady) > 0 {
+		if dbStatusWait {
+			return fmt.Errorf("timed out after %s waiting for: %s", dbStatusTimeout, strings.Join(notReady, ", "))
+		}
+		return fmt.Errorf("not ready: %s", strings.Join(notReady, ", "))
+	}
+	return nil
+}
+
 func runDbStart(cmd *cobra.Command, args []string) error {
 	helper := database.NewHelper(dbVerbose, dbDryRun)
 
@@ -272,6 +476,147 @@ func runDbList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runDbBackup(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := database.NewHelper(dbVerbose, dbDryRun)
+
+	var db string
+	if len(args) > 1 {
+		db = args[1]
+	}
+
+	result, err := helper.Backup(args[0], db, dbBackupDest)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if !dbDryRun {
+		fmt.Fprintf(os.Stdout, "%s Backed up %s to %s (%s)\n", output.Success("✓"),
+			result.Engine, result.File, output.FormatBytes(result.Size))
+	}
+	return nil
+}
+
+func runDbRestore(cmd *cobra.Command, args []string) error {
+	helper := database.NewHelper(dbVerbose, dbDryRun)
+
+	var db string
+	if len(args) > 2 {
+		db = args[2]
+	}
+
+	if !dbRestoreYes && !dbDryRun {
+		target := args[0]
+		if db != "" {
+			target += "/" + db
+		}
+		fmt.Fprintf(os.Stdout, "%s Restoring %s will overwrite existing data in %s.\n",
+			output.Warning("!"), args[1], target)
+		fmt.Fprint(os.Stdout, "Continue? [y/N]: ")
+
+		reader := bufio.NewReader(os.Stdin)
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	if err := helper.Restore(args[0], args[1], db); err != nil {
+		return err
+	}
+
+	if !dbDryRun {
+		fmt.Fprintf(os.Stdout, "%s Restored %s from %s\n", output.Success("✓"), args[0], args[1])
+	}
+	return nil
+}
+
+func runDbMigrate(cmd *cobra.Command, args []string) error {
+	return migrateDb(cmd, args, false)
+}
+
+func runDbMigrateDown(cmd *cobra.Command, args []string) error {
+	return migrateDb(cmd, args, true)
+}
+
+// migrateDb applies or, with down, reverts migrations and reports each file.
+func migrateDb(cmd *cobra.Command, args []string, down bool) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := database.NewHelper(dbVerbose, dbDryRun)
+
+	opts := database.MigrateOptions{
+		Dir:  dbMigrateDir,
+		Down: down,
+	}
+	if len(args) > 1 {
+		opts.DB = args[1]
+	}
+	if cmd.Flags().Changed("to") {
+		opts.To = &dbMigrateTo
+	}
+
+	results, err := helper.Migrate(args[0], opts)
+	if results == nil {
+		results = []database.MigrationResult{}
+	}
+
+	if ioHelper.IsStructured() {
+		if werr := ioHelper.WriteOutput(map[string][]database.MigrationResult{"migrations": results}); werr != nil {
+			return werr
+		}
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		file := fmt.Sprintf("%-40s", r.File)
+		switch r.Status {
+		case database.MigrationApplied:
+			fmt.Fprintf(os.Stdout, "%s %s applied\n", output.Success("✓"), file)
+		case database.MigrationReverted:
+			fmt.Fprintf(os.Stdout, "%s %s reverted\n", output.Success("✓"), file)
+		case database.MigrationSkipped:
+			fmt.Fprintf(os.Stdout, "%s %s skipped (already applied)\n", output.Info("○"), file)
+		case database.MigrationPending:
+			action := "would apply"
+			if opts.Down {
+				action = "would revert"
+			}
+			fmt.Fprintf(os.Stdout, "%s %s %s\n", output.Warning("○"), file, action)
+		case database.MigrationFailed:
+			fmt.Fprintf(os.Stdout, "%s %s failed\n", output.Error("✗"), file)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case dbDryRun:
+		fmt.Fprintf(os.Stdout, "\n%d pending\n", counts[database.MigrationPending])
+	case opts.Down && len(results) == 0:
+		fmt.Fprintln(os.Stdout, "No migrations to revert")
+	case opts.Down:
+		fmt.Fprintf(os.Stdout, "\nReverted: %d\n", counts[database.MigrationReverted])
+	case counts[database.MigrationApplied] == 0:
+		fmt.Fprintln(os.Stdout, "\nDatabase is up to date")
+	default:
+		fmt.Fprintf(os.Stdout, "\nApplied: %d, Skipped: %d\n",
+			counts[database.MigrationApplied], counts[database.MigrationSkipped])
+	}
+	return nil
+}
+
 func init() {
 	components.Register(&components.Registration{
 		Name: "database",
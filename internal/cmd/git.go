@@ -4,6 +4,8 @@ import (
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"path"
+	"strings"
 
 	"github.com/mistergrinvalds/acorn/internal/components/git"
 	"github.com/mistergrinvalds/acorn/internal/utils/configcmd"
@@ -15,6 +17,17 @@ import (
 var (
 	gitVerbose bool
 	gitDryRun  bool
+
+	gitFindGrepDiff string
+	gitFindRegex    bool
+	gitFindAll      bool
+	gitFindSelect   bool
+	gitFindShow     bool
+
+	gitBlameRange  string
+	gitBlameAuthor bool
+
+	gitKeepPatterns []string
 )
 
 // gitCmd represents the git command group
@@ -30,6 +43,7 @@ Examples:
   acorn git info              # Show repo info
   acorn git contributors      # Show contributors
   acorn git find "bug fix"    # Find commits
+  acorn git blame main.go     # Blame a file
   acorn git clean-branches    # Clean merged branches`,
 }
 
@@ -62,28 +76,73 @@ Examples:
 
 // gitFindCmd finds commits
 var gitFindCmd = &cobra.Command{
-	Use:   "find <search>",
-	Short: "Find commits by message",
+	Use:   "find [search]",
+	Short: "Find commits by message or diff content",
 	Long: `Search for commits containing the given text in the message.
 
+With --grep-diff, searches commit diffs instead: finds the commits that
+added or removed code matching the pattern. By default this uses git's
+pickaxe (-S), matching commits that change how often the string occurs.
+With --regex, uses -G to match commits whose diff contains a line matching
+the regular expression.
+
+With --interactive, pick a commit from a numbered list. Cancelling the
+picker exits with status 1 and prints nothing on stdout.
+
 Examples:
   acorn git find "bug fix"
-  acorn git find "refactor"`,
-	Args: cobra.ExactArgs(1),
+  acorn git find "refactor"
+  acorn git find --grep-diff "legacyHandler"
+  acorn git find --grep-diff "func \w+Handler" --regex --all
+  acorn git find --grep-diff "API_KEY" -o json
+  acorn git find "bug fix" --interactive          # Pick one, print its hash
+  acorn git find "bug fix" -i --show              # Pick one, open git show
+  git checkout $(acorn git find "release" -i)`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runGitFind,
 }
 
+// gitBlameCmd blames a file
+var gitBlameCmd = &cobra.Command{
+	Use:   "blame <file>",
+	Short: "Show who last changed each line of a file",
+	Long: `Show the commit, author and date that last changed each line of a
+tracked file, parsed from git blame --line-porcelain.
+
+With --range, only the given lines are blamed. With --author, prints a
+summary of how many lines each author owns instead of the per-line view.
+
+Examples:
+  acorn git blame main.go
+  acorn git blame main.go --range 10:40
+  acorn git blame main.go --author
+  acorn git blame main.go -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGitBlame,
+}
+
 // gitCleanBranchesCmd cleans merged branches
 var gitCleanBranchesCmd = &cobra.Command{
 	Use:   "clean-branches",
 	Short: "Clean merged branches",
 	Long: `Remove local branches that have been merged.
 
-Skips main, master, and develop branches.
+Skips the current branch and main, master, and develop. More branches can
+be protected with --keep-pattern or the clean_branches.protected list in
+the git component config:
+
+  clean_branches:
+    protected:
+      - release/*
+      - develop
+
+Patterns are globs in which * does not match a slash. Merged branches
+skipped due to protection are reported.
 
 Examples:
   acorn git clean-branches
-  acorn git clean-branches --dry-run`,
+  acorn git clean-branches --dry-run
+  acorn git clean-branches --keep-pattern 'release/*' --keep-pattern staging`,
 	Aliases: []string{"cleanup"},
 	RunE:    runGitCleanBranches,
 }
@@ -105,10 +164,33 @@ func init() {
 	gitCmd.AddCommand(gitInfoCmd)
 	gitCmd.AddCommand(gitContributorsCmd)
 	gitCmd.AddCommand(gitFindCmd)
+	gitCmd.AddCommand(gitBlameCmd)
 	gitCmd.AddCommand(gitCleanBranchesCmd)
 	gitCmd.AddCommand(gitReposDirCmd)
 	gitCmd.AddCommand(configcmd.NewConfigRouter("git"))
 
+	// Find flags
+	gitFindCmd.Flags().StringVar(&gitFindGrepDiff, "grep-diff", "",
+		"Search commit diffs for added/removed content matching a pattern")
+	gitFindCmd.Flags().BoolVar(&gitFindRegex, "regex", false,
+		"Treat the --grep-diff pattern as a regex (git log -G instead of -S)")
+	gitFindCmd.Flags().BoolVar(&gitFindAll, "all", false,
+		"Search diffs on all branches, not just the current one")
+	gitFindCmd.Flags().BoolVarP(&gitFindSelect, "interactive", "i", false,
+		"Choose a commit from a numbered list and print its hash (no fzf needed)")
+	gitFindCmd.Flags().BoolVar(&gitFindShow, "show", false,
+		"With --interactive, open the chosen commit with git show")
+
+	// Blame flags
+	gitBlameCmd.Flags().StringVar(&gitBlameRange, "range", "",
+		"Only blame lines in <start>:<end> (either side may be omitted)")
+	gitBlameCmd.Flags().BoolVar(&gitBlameAuthor, "author", false,
+		"Summarize how many lines each author owns")
+
+	// Clean branches flags
+	gitCleanBranchesCmd.Flags().StringArrayVar(&gitKeepPatterns, "keep-pattern", nil,
+		"Never delete branches matching this glob (repeatable)")
+
 	// Persistent flags
 	gitCmd.PersistentFlags().BoolVarP(&gitVerbose, "verbose", "v", false,
 		"Show verbose output")
@@ -177,12 +259,40 @@ func runGitContributors(cmd *cobra.Command, args []string) error {
 }
 
 func runGitFind(cmd *cobra.Command, args []string) error {
+	if gitFindShow && !gitFindSelect {
+		return fmt.Errorf("--show requires --interactive")
+	}
+	if gitFindSelect && cmd.Flags().Changed("output") {
+		return fmt.Errorf("--interactive cannot be combined with --output")
+	}
+
+	if gitFindGrepDiff != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot combine a message search with --grep-diff")
+		}
+		return runGitFindDiff(cmd)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("a search term or --grep-diff pattern is required")
+	}
+	if gitFindRegex || gitFindAll {
+		return fmt.Errorf("--regex and --all require --grep-diff")
+	}
+
 	helper := git.NewHelper(gitVerbose)
 	commits, err := helper.FindCommits(args[0])
 	if err != nil {
 		return err
 	}
 
+	if gitFindSelect {
+		hashes := make([]string, len(commits))
+		for i, c := range commits {
+			hashes[i], _, _ = strings.Cut(c, " ")
+		}
+		return selectGitCommit(helper, hashes, commits)
+	}
+
 	ioHelper := ioutils.IO(cmd)
 	if ioHelper.IsStructured() {
 		return ioHelper.WriteOutput(map[string][]string{"commits": commits})
@@ -205,14 +315,172 @@ func runGitFind(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runGitFindDiff(cmd *cobra.Command) error {
+	helper := git.NewHelper(gitVerbose)
+	commits, err := helper.FindInDiffs(git.DiffSearchOptions{
+		Pattern: gitFindGrepDiff,
+		Regex:   gitFindRegex,
+		All:     gitFindAll,
+	})
+	if err != nil {
+		return err
+	}
+
+	if gitFindSelect {
+		hashes := make([]string, len(commits))
+		items := make([]string, len(commits))
+		for i, c := range commits {
+			hashes[i] = c.Hash
+			items[i] = fmt.Sprintf("%s %s %s  %s", c.Hash[:min(7, len(c.Hash))], shortDate(c.Date), c.Author, c.Subject)
+		}
+		return selectGitCommit(helper, hashes, items)
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(map[string][]git.DiffCommit{"commits": commits})
+	}
+
+	// Table format
+	if len(commits) == 0 {
+		fmt.Fprintf(os.Stdout, "No commits changed content matching: %s\n", gitFindGrepDiff)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Commits changing: "+gitFindGrepDiff))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	for _, c := range commits {
+		fmt.Fprintf(os.Stdout, "%s %s %s  %s\n", c.Hash[:min(7, len(c.Hash))], shortDate(c.Date), c.Author, c.Subject)
+		for _, f := range c.FilesTouched {
+			fmt.Fprintf(os.Stdout, "    %s\n", f)
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "\nFound: %d commits\n", len(commits))
+	return nil
+}
+
+// shortDate trims an ISO 8601 timestamp to its date.
+func shortDate(date string) string {
+	if len(date) >= 10 {
+		return date[:10]
+	}
+	return date
+}
+
+// selectGitCommit lets the user pick one of the found commits, then prints
+// its hash or, with --show, opens it with git show.
+func selectGitCommit(helper *git.Helper, hashes, items []string) error {
+	if len(items) == 0 {
+		return fmt.Errorf("no matching commits")
+	}
+
+	choice, err := selectItem("Select a commit", items)
+	if err != nil {
+		return err
+	}
+	if choice < 0 {
+		// Exit non-zero so $(acorn git find -i) callers see the cancel
+		return &exitCodeError{code: 1, msg: "Aborted."}
+	}
+
+	if gitFindShow {
+		return helper.ShowCommit(hashes[choice])
+	}
+	fmt.Fprintln(os.Stdout, hashes[choice])
+	return nil
+}
+
+func runGitBlame(cmd *cobra.Command, args []string) error {
+	opts := git.BlameOptions{File: args[0]}
+	if gitBlameRange != "" {
+		start, end, err := git.ParseLineRange(gitBlameRange)
+		if err != nil {
+			return err
+		}
+		opts.Start, opts.End = start, end
+	}
+
+	helper := git.NewHelper(gitVerbose)
+	lines, err := helper.Blame(opts)
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if gitBlameAuthor {
+		authors := git.SummarizeBlame(lines)
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(authors)
+		}
+
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info("Line ownership: "+args[0]))
+		fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+		for _, a := range authors {
+			fmt.Fprintf(os.Stdout, "%6d  %5.1f%%  %s\n", a.Lines, a.Percent, a.Author)
+		}
+
+		fmt.Fprintf(os.Stdout, "\nTotal: %d lines\n", len(lines))
+		return nil
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(lines)
+	}
+
+	// Table format
+	authorWidth := 0
+	for _, l := range lines {
+		authorWidth = max(authorWidth, len(l.Author))
+	}
+	for _, l := range lines {
+		date := l.Date
+		if len(date) >= 10 {
+			date = date[:10]
+		}
+		fmt.Fprintf(os.Stdout, "%s %-*s %s %5d) %s\n",
+			output.Info(l.Hash[:min(8, len(l.Hash))]), authorWidth, l.Author, date, l.Line, l.Content)
+	}
+	return nil
+}
+
 func runGitCleanBranches(cmd *cobra.Command, args []string) error {
+	for _, pattern := range gitKeepPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --keep-pattern %q: %w", pattern, err)
+		}
+	}
+
+	protected, err := git.LoadProtectedBranches()
+	if err != nil {
+		return err
+	}
+
 	helper := git.NewHelper(gitVerbose)
-	deleted, err := helper.CleanMergedBranches(gitDryRun)
+	result, err := helper.CleanMergedBranches(git.CleanBranchesOptions{
+		DryRun:       gitDryRun,
+		KeepPatterns: append(protected, gitKeepPatterns...),
+	})
 	if err != nil {
 		return err
 	}
 
-	if len(deleted) == 0 {
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if len(result.Protected) > 0 {
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info("Protected branches (skipped):"))
+		for _, p := range result.Protected {
+			fmt.Fprintf(os.Stdout, "  %s %s (matches %s)\n", output.Warning("○"), p.Name, p.Pattern)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+
+	if len(result.Deleted) == 0 {
 		fmt.Fprintln(os.Stdout, "No merged branches to clean")
 		return nil
 	}
@@ -223,11 +491,11 @@ func runGitCleanBranches(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stdout, "%s\n", output.Info("Deleted branches:"))
 	}
 
-	for _, b := range deleted {
+	for _, b := range result.Deleted {
 		fmt.Fprintf(os.Stdout, "  %s %s\n", output.Success("✓"), b)
 	}
 
-	fmt.Fprintf(os.Stdout, "\nTotal: %d branches\n", len(deleted))
+	fmt.Fprintf(os.Stdout, "\nTotal: %d branches\n", len(result.Deleted))
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mistergrinvalds/acorn/internal/components/golang"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
@@ -13,8 +14,28 @@ import (
 )
 
 var (
-	goDryRun  bool
-	goVerbose bool
+	goDryRun       bool
+	goVerbose      bool
+	goTestPackages []string
+	goTestFailFast bool
+	goTestRace     bool
+	goTestQuiet    bool
+
+	goCoverDiff string
+	goCoverMin  float64
+
+	goCobraModule  string
+	goCobraGit     bool
+	goCobraLicense string
+
+	goNewWorkspace bool
+	goNewModules   []string
+
+	goToolsCheck    bool
+	goToolsManifest string
+
+	goBuildCompress  bool
+	goBuildChecksums bool
 )
 
 // goCmd represents the go command group
@@ -44,9 +65,14 @@ var goNewCmd = &cobra.Command{
 Creates a directory with the module name, initializes go.mod,
 and creates a basic main.go file.
 
+With --workspace, creates a multi-module workspace instead: one module
+per --modules entry (named <module-name>/<module>) tied together by a
+go.work file.
+
 Examples:
   acorn go new myapp
-  acorn go new github.com/user/myapp`,
+  acorn go new github.com/user/myapp
+  acorn go new github.com/user/mono --workspace --modules api,worker`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGoNew,
 }
@@ -60,9 +86,16 @@ var goTestCmd = &cobra.Command{
 If no pattern is provided, runs all tests in the project.
 Use -run compatible patterns to filter specific tests.
 
+Results are parsed from 'go test -json' and shown as a per-package
+summary followed by the output of each failing test.
+
 Examples:
-  acorn go test                # Run all tests
-  acorn go test TestFoo        # Run tests matching "TestFoo"`,
+  acorn go test                          # Run all tests
+  acorn go test TestFoo                  # Run tests matching "TestFoo"
+  acorn go test --package ./internal/... # Scope to packages
+  acorn go test --race --failfast        # Pass through go test flags
+  acorn go test --quiet                  # Only print failures
+  acorn go test -o json                  # Structured summary`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runGoTest,
 }
@@ -76,8 +109,17 @@ var goCoverCmd = &cobra.Command{
 Generates coverage.out and coverage.html in the current directory,
 then displays a coverage summary.
 
+With --diff, reports patch coverage instead: the share of lines changed
+since the merge base with <base-ref> (including uncommitted changes and
+untracked files) that the tests executed. Lines without statements are not
+counted. Use --min to fail when patch coverage is below a percentage. Run
+from the module root.
+
 Examples:
-  acorn go cover`,
+  acorn go cover
+  acorn go cover --diff origin/main
+  acorn go cover --diff main --min 80
+  acorn go cover --diff main -o json`,
 	Aliases: []string{"coverage"},
 	RunE:    runGoCover,
 }
@@ -110,9 +152,16 @@ Builds for:
 
 Output goes to dist/ directory.
 
+With --compress each binary is packed into a release archive: .zip for
+windows, .tar.gz otherwise. Archives use fixed timestamps and owners, so
+rebuilding the same binaries gives byte-identical archives; set
+SOURCE_DATE_EPOCH to choose the timestamp. With --checksums a SHA256SUMS
+file covering the artifacts is written next to them.
+
 Examples:
-  acorn go build-all           # Build as "app"
-  acorn go build-all myapp     # Build as "myapp"`,
+  acorn go build-all                              # Build as "app"
+  acorn go build-all myapp                        # Build as "myapp"
+  acorn go build-all myapp --compress --checksums # Release assets`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runGoBuildAll,
 }
@@ -128,6 +177,30 @@ Examples:
 	RunE: runGoClean,
 }
 
+// goInstallToolsCmd installs pinned dev tools
+var goInstallToolsCmd = &cobra.Command{
+	Use:   "install-tools [dir]",
+	Short: "Install the project's pinned dev tools",
+	Long: `Install the development tools a project pins, such as golangci-lint,
+mockgen or goimports, and report the installed versions.
+
+Tools are read from .acorn-gotools, one package@version per line with
+# comments, or from a tools.go of blank imports whose versions come from
+go.mod. .acorn-gotools is preferred when both exist; use --manifest to
+choose a file. Every entry must be a module path pinned to a version.
+
+With --check, nothing is installed: each tool's binary is compared with
+its pinned version and the command fails if any is missing or differs.
+
+Examples:
+  acorn go install-tools
+  acorn go install-tools --check
+  acorn go install-tools --manifest tools/tools.go
+  acorn go install-tools --check -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGoInstallTools,
+}
+
 // goEnvCmd shows Go environment
 var goEnvCmd = &cobra.Command{
 	Use:   "env",
@@ -140,6 +213,44 @@ Examples:
 	RunE: runGoEnv,
 }
 
+// goWorkCmd is the parent for go.work workspace subcommands
+var goWorkCmd = &cobra.Command{
+	Use:   "work",
+	Short: "Manage go.work multi-module workspaces",
+	Long: `Manage a go.work file that ties multiple local modules together.
+
+Examples:
+  acorn go work init ./api ./worker
+  acorn go work sync`,
+}
+
+// goWorkInitCmd creates or extends a go.work
+var goWorkInitCmd = &cobra.Command{
+	Use:   "init <dirs...>",
+	Short: "Create a go.work using the given modules",
+	Long: `Create a go.work in the current directory and add each module
+directory to it. If a go.work already exists, the directories are added
+to it. Every directory must contain a go.mod.
+
+Examples:
+  acorn go work init ./api ./worker
+  acorn go work init ./tools -o json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runGoWorkInit,
+}
+
+// goWorkSyncCmd syncs workspace dependencies
+var goWorkSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the workspace build list back to its modules",
+	Long: `Run go work sync in the current workspace.
+
+Examples:
+  acorn go work sync`,
+	Args: cobra.NoArgs,
+	RunE: runGoWorkSync,
+}
+
 // goCobraCmd is the parent for Cobra-related subcommands
 var goCobraCmd = &cobra.Command{
 	Use:   "cobra",
@@ -157,11 +268,15 @@ var goCobraNewCmd = &cobra.Command{
 	Short: "Create a new Cobra CLI project",
 	Long: `Initialize a new Cobra CLI project.
 
-Installs cobra-cli if not present, then creates a new CLI project
-with the standard Cobra structure.
+Creates <app-name>/ with go.mod, main.go and cmd/root.go, then runs
+go mod tidy. The module path defaults to the app name; use --module
+to set a full path. Optionally initializes git with an initial commit
+and adds a LICENSE file.
 
 Examples:
-  acorn go cobra new mycli`,
+  acorn go cobra new mycli
+  acorn go cobra new mycli --module github.com/me/mycli
+  acorn go cobra new mycli --module github.com/me/mycli --git --license mit`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGoCobraNew,
 }
@@ -190,23 +305,82 @@ func init() {
 	goCmd.AddCommand(goBenchCmd)
 	goCmd.AddCommand(goBuildAllCmd)
 	goCmd.AddCommand(goCleanCmd)
+	goCmd.AddCommand(goInstallToolsCmd)
 	goCmd.AddCommand(goEnvCmd)
 	goCmd.AddCommand(goCobraCmd)
+	goCmd.AddCommand(goWorkCmd)
 	goCmd.AddCommand(configcmd.NewConfigRouter("go"))
 
 	// Cobra subcommands
 	goCobraCmd.AddCommand(goCobraNewCmd)
 	goCobraCmd.AddCommand(goCobraAddCmd)
 
+	// Work subcommands
+	goWorkCmd.AddCommand(goWorkInitCmd)
+	goWorkCmd.AddCommand(goWorkSyncCmd)
+
+	// New flags
+	goNewCmd.Flags().BoolVar(&goNewWorkspace, "workspace", false,
+		"Create a go.work workspace with multiple modules")
+	goNewCmd.Flags().StringSliceVar(&goNewModules, "modules", []string{"app"},
+		"Modules to create in the workspace (with --workspace)")
+
+	// Cobra new flags
+	goCobraNewCmd.Flags().StringVar(&goCobraModule, "module", "",
+		"Go module path (default: the app name)")
+	goCobraNewCmd.Flags().BoolVar(&goCobraGit, "git", false,
+		"Initialize a git repository with an initial commit")
+	goCobraNewCmd.Flags().StringVar(&goCobraLicense, "license", "none",
+		"LICENSE file to add (mit|apache2|none)")
+
+	// Build-all flags
+	goBuildAllCmd.Flags().BoolVar(&goBuildCompress, "compress", false,
+		"Archive each binary (zip for windows, tar.gz otherwise)")
+	goBuildAllCmd.Flags().BoolVar(&goBuildChecksums, "checksums", false,
+		"Write a SHA256SUMS file covering the produced artifacts")
+
 	// Persistent flags
 	goCmd.PersistentFlags().BoolVar(&goDryRun, "dry-run", false,
 		"Show what would be done without executing")
 	goCmd.PersistentFlags().BoolVarP(&goVerbose, "verbose", "v", false,
 		"Show verbose output")
+
+	// Test flags
+	goTestCmd.Flags().StringArrayVar(&goTestPackages, "package", nil,
+		"Package pattern to test (repeatable, default: ./...)")
+	goTestCmd.Flags().BoolVar(&goTestFailFast, "failfast", false,
+		"Stop after the first test failure")
+	goTestCmd.Flags().BoolVar(&goTestRace, "race", false,
+		"Enable the race detector")
+	goTestCmd.Flags().BoolVarP(&goTestQuiet, "quiet", "q", false,
+		"Only print failures")
+
+	// Cover flags
+	goCoverCmd.Flags().StringVar(&goCoverDiff, "diff", "",
+		"Report coverage of lines changed since this base ref")
+	goCoverCmd.Flags().Float64Var(&goCoverMin, "min", 0,
+		"Fail if changed-line coverage is below this percentage (with --diff)")
+
+	// Install-tools flags
+	goInstallToolsCmd.Flags().BoolVar(&goToolsCheck, "check", false,
+		"Verify installed tools match their pinned versions without installing")
+	goInstallToolsCmd.Flags().StringVar(&goToolsManifest, "manifest", "",
+		"Tools file to read (default: .acorn-gotools or tools.go in dir)")
 }
 
 func runGoNew(cmd *cobra.Command, args []string) error {
 	helper := golang.NewHelper(goVerbose, goDryRun)
+	if goNewWorkspace {
+		ws, err := helper.InitWorkspaceProject(args[0], goNewModules)
+		if err != nil {
+			return err
+		}
+		return printGoWorkspace(cmd, ws, "Go workspace initialized!")
+	}
+	if cmd.Flags().Changed("modules") {
+		return fmt.Errorf("--modules requires --workspace")
+	}
+
 	project, err := helper.InitProject(args[0])
 	if err != nil {
 		return err
@@ -222,21 +396,133 @@ func runGoNew(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runGoWorkInit(cmd *cobra.Command, args []string) error {
+	helper := golang.NewHelper(goVerbose, goDryRun)
+	ws, err := helper.InitWorkspace(".", args)
+	if err != nil {
+		return err
+	}
+	return printGoWorkspace(cmd, ws, "Workspace updated!")
+}
+
+func runGoWorkSync(cmd *cobra.Command, args []string) error {
+	helper := golang.NewHelper(goVerbose, goDryRun)
+	if err := helper.SyncWorkspace("."); err != nil {
+		return err
+	}
+	if !goDryRun {
+		fmt.Fprintf(os.Stdout, "%s Workspace synced\n", output.Success("✓"))
+	}
+	return nil
+}
+
+// printGoWorkspace reports a workspace and the modules it uses.
+func printGoWorkspace(cmd *cobra.Command, ws *golang.Workspace, title string) error {
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(ws)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s %s\n", output.Success("✓"), title)
+	fmt.Fprintf(os.Stdout, "  Path: %s\n", ws.Path)
+	if ws.GoVersion != "" {
+		fmt.Fprintf(os.Stdout, "  Go: %s\n", ws.GoVersion)
+	}
+	fmt.Fprintln(os.Stdout)
+
+	table := output.NewTable("DIR", "MODULE")
+	for _, m := range ws.Modules {
+		table.AddRow(m.Dir, m.Module)
+	}
+	table.Render(os.Stdout)
+	return nil
+}
+
 func runGoTest(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
 	helper := golang.NewHelper(goVerbose, goDryRun)
 
-	pattern := ""
+	opts := golang.TestOptions{
+		Packages: goTestPackages,
+		FailFast: goTestFailFast,
+		Race:     goTestRace,
+	}
 	if len(args) > 0 {
-		pattern = args[0]
+		opts.Pattern = args[0]
+	}
+
+	if !ioHelper.IsStructured() && !goTestQuiet {
+		fmt.Fprintln(os.Stdout, "Running tests...")
+	}
+
+	summary, err := helper.RunTestsSummary(opts)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(summary); err != nil {
+			return err
+		}
+	} else if !goDryRun {
+		printGoTestSummary(summary, goTestQuiet)
+	}
+
+	if !summary.Success {
+		return fmt.Errorf("%d test(s) failed", len(summary.Failures))
+	}
+	return nil
+}
+
+// printGoTestSummary renders a test summary as a table followed by failures.
+func printGoTestSummary(summary *golang.TestSummary, quiet bool) {
+	if !quiet {
+		fmt.Fprintln(os.Stdout)
+		table := output.NewTable("STATUS", "PACKAGE", "PASS", "FAIL", "SKIP", "TIME")
+		for _, pkg := range summary.Packages {
+			table.AddRow(pkg.Status, pkg.Package,
+				fmt.Sprintf("%d", pkg.Passed),
+				fmt.Sprintf("%d", pkg.Failed),
+				fmt.Sprintf("%d", pkg.Skipped),
+				fmt.Sprintf("%.2fs", pkg.Elapsed))
+		}
+		table.Render(os.Stdout)
+	}
+
+	for _, f := range summary.Failures {
+		name := f.Package
+		if f.Test != "" {
+			name = f.Package + " " + f.Test
+		}
+		fmt.Fprintf(os.Stdout, "\n%s %s\n", output.Error("✗"), name)
+		for _, line := range strings.Split(strings.TrimRight(f.Output, "\n"), "\n") {
+			fmt.Fprintf(os.Stdout, "    %s\n", line)
+		}
+	}
+
+	if quiet && summary.Success {
+		return
 	}
 
-	fmt.Fprintln(os.Stdout, "Running tests...")
-	return helper.RunTests(pattern)
+	fmt.Fprintln(os.Stdout)
+	if summary.Success {
+		fmt.Fprintf(os.Stdout, "%s %d passed, %d skipped\n", output.Success("✓"), summary.Passed, summary.Skipped)
+	} else {
+		fmt.Fprintf(os.Stdout, "%s %d passed, %d failed, %d skipped\n", output.Error("✗"),
+			summary.Passed, summary.Failed, summary.Skipped)
+	}
 }
 
 func runGoCover(cmd *cobra.Command, args []string) error {
 	helper := golang.NewHelper(goVerbose, goDryRun)
 
+	if goCoverDiff != "" {
+		return runGoCoverDiff(cmd, helper)
+	}
+	if cmd.Flags().Changed("min") {
+		return fmt.Errorf("--min requires --diff")
+	}
+
 	fmt.Fprintln(os.Stdout, "Running tests with coverage...")
 	if err := helper.RunTestsWithCoverage(); err != nil {
 		return err
@@ -246,6 +532,70 @@ func runGoCover(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runGoCoverDiff runs the tests and reports coverage of changed lines.
+func runGoCoverDiff(cmd *cobra.Command, helper *golang.Helper) error {
+	ioHelper := ioutils.IO(cmd)
+	if goCoverMin < 0 || goCoverMin > 100 {
+		return fmt.Errorf("--min must be between 0 and 100")
+	}
+
+	if !ioHelper.IsStructured() {
+		fmt.Fprintln(os.Stdout, "Running tests with coverage...")
+	}
+	if err := helper.RunCoverProfile("coverage.out"); err != nil {
+		return err
+	}
+	if goDryRun {
+		return nil
+	}
+
+	result, err := helper.DiffCoverage(goCoverDiff, "coverage.out")
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(result); err != nil {
+			return err
+		}
+	} else {
+		printDiffCoverage(result)
+	}
+
+	if cmd.Flags().Changed("min") && result.Percent < goCoverMin {
+		return fmt.Errorf("changed-line coverage %.1f%% is below the minimum %.1f%%", result.Percent, goCoverMin)
+	}
+	return nil
+}
+
+// printDiffCoverage renders per-file changed-line coverage and the total.
+func printDiffCoverage(result *golang.DiffCoverage) {
+	fmt.Fprintf(os.Stdout, "\n%s\n", output.Info("Coverage of changes since "+result.Base))
+	fmt.Fprintln(os.Stdout, strings.Repeat("━", 40))
+
+	if result.Changed == 0 {
+		fmt.Fprintf(os.Stdout, "%s No changed statements to cover\n", output.Success("✓"))
+		return
+	}
+
+	table := output.NewTable("FILE", "CHANGED", "COVERED", "PERCENT", "UNCOVERED LINES")
+	for _, f := range result.Files {
+		table.AddRow(f.File,
+			fmt.Sprintf("%d", f.Changed),
+			fmt.Sprintf("%d", f.Covered),
+			fmt.Sprintf("%.1f%%", f.Percent),
+			golang.FormatLineRanges(f.Uncovered))
+	}
+	table.Render(os.Stdout)
+
+	symbol := output.Success("✓")
+	if result.Covered < result.Changed {
+		symbol = output.Warning("!")
+	}
+	fmt.Fprintf(os.Stdout, "\n%s %d of %d changed lines covered (%.1f%%)\n",
+		symbol, result.Covered, result.Changed, result.Percent)
+}
+
 func runGoBench(cmd *cobra.Command, args []string) error {
 	helper := golang.NewHelper(goVerbose, goDryRun)
 
@@ -266,12 +616,34 @@ func runGoBuildAll(cmd *cobra.Command, args []string) error {
 		name = args[0]
 	}
 
-	fmt.Fprintf(os.Stdout, "Building %s for multiple platforms...\n\n", output.Info(name))
-	if err := helper.BuildAll(name); err != nil {
+	ioHelper := ioutils.IO(cmd)
+	if !ioHelper.IsStructured() {
+		fmt.Fprintf(os.Stdout, "Building %s for multiple platforms...\n\n", output.Info(name))
+	}
+	result, err := helper.BuildAll(name, golang.BuildAllOptions{
+		Compress:  goBuildCompress,
+		Checksums: goBuildChecksums,
+	})
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stdout, "\n%s Builds complete in dist/\n", output.Success("✓"))
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	fmt.Fprintf(os.Stdout, "\n%s Builds complete in %s/\n", output.Success("✓"), golang.DistDir)
+	if len(result.Artifacts) > 0 && (goBuildCompress || goBuildChecksums) {
+		fmt.Fprintln(os.Stdout)
+		table := output.NewTable("ARTIFACT", "PLATFORM", "SIZE")
+		for _, a := range result.Artifacts {
+			table.AddRow(a.Name, a.OS+"/"+a.Arch, output.FormatBytes(a.Size))
+		}
+		table.Render(os.Stdout)
+	}
+	if result.Checksums != "" {
+		fmt.Fprintf(os.Stdout, "\n%s Checksums written to %s\n", output.Success("✓"), result.Checksums)
+	}
 	return nil
 }
 
@@ -287,6 +659,81 @@ func runGoClean(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runGoInstallTools(cmd *cobra.Command, args []string) error {
+	helper := golang.NewHelper(goVerbose, goDryRun)
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	source := goToolsManifest
+	if source == "" {
+		var err error
+		if source, err = golang.FindToolsSource(dir); err != nil {
+			return err
+		}
+	}
+	tools, err := golang.LoadDevTools(source)
+	if err != nil {
+		return err
+	}
+
+	var report *golang.ToolsReport
+	if goToolsCheck {
+		report, err = helper.CheckDevTools(source, tools)
+	} else {
+		report, err = helper.InstallDevTools(dir, source, tools)
+	}
+	if err != nil {
+		return err
+	}
+	if goDryRun && !goToolsCheck {
+		return nil
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(report); err != nil {
+			return err
+		}
+	} else {
+		printToolsReport(report)
+	}
+
+	if !report.OK {
+		if goToolsCheck {
+			return fmt.Errorf("dev tools are not installed at their pinned versions")
+		}
+		return fmt.Errorf("some dev tools failed to install")
+	}
+	return nil
+}
+
+// printToolsReport renders each tool's pinned and installed version.
+func printToolsReport(report *golang.ToolsReport) {
+	fmt.Fprintf(os.Stdout, "\n%s\n", output.Info("Dev tools from "+report.Source))
+	fmt.Fprintln(os.Stdout, strings.Repeat("━", 40))
+
+	table := output.NewTable("TOOL", "PINNED", "INSTALLED", "STATUS", "PACKAGE")
+	for _, t := range report.Tools {
+		installed := t.Installed
+		if installed == "" {
+			installed = "-"
+		}
+		table.AddRow(t.Binary, t.Version, installed, t.Status, t.Package)
+	}
+	table.Render(os.Stdout)
+
+	for _, t := range report.Tools {
+		if t.Error != "" {
+			fmt.Fprintf(os.Stdout, "%s %s: %s\n", output.Error("✗"), t.Package, t.Error)
+		}
+	}
+	if report.OK {
+		fmt.Fprintf(os.Stdout, "\n%s %d tool(s) at their pinned versions\n", output.Success("✓"), len(report.Tools))
+	}
+}
+
 func runGoEnv(cmd *cobra.Command, args []string) error {
 	helper := golang.NewHelper(goVerbose, goDryRun)
 
@@ -320,18 +767,41 @@ func runGoEnv(cmd *cobra.Command, args []string) error {
 }
 
 func runGoCobraNew(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
 	helper := golang.NewHelper(goVerbose, goDryRun)
 
-	project, err := helper.InitCobraProject(args[0])
+	project, err := helper.InitCobraProject(golang.CobraOptions{
+		Name:    args[0],
+		Module:  goCobraModule,
+		Git:     goCobraGit,
+		License: goCobraLicense,
+	})
 	if err != nil {
 		return err
 	}
 
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(project)
+	}
+
+	if goDryRun {
+		return nil
+	}
+
 	fmt.Fprintf(os.Stdout, "%s Cobra CLI project initialized!\n", output.Success("✓"))
-	fmt.Fprintf(os.Stdout, "  Path: %s\n", project.Path)
+	fmt.Fprintf(os.Stdout, "  Path:   %s\n", project.Path)
+	fmt.Fprintf(os.Stdout, "  Module: %s\n", project.Module)
+	fmt.Fprintf(os.Stdout, "\nCreated:\n")
+	for _, f := range project.Files {
+		fmt.Fprintf(os.Stdout, "  %s\n", f)
+	}
+	if project.Git {
+		fmt.Fprintf(os.Stdout, "\n%s Git repository initialized with an initial commit\n", output.Success("✓"))
+	}
 	fmt.Fprintf(os.Stdout, "\nNext steps:\n")
 	fmt.Fprintf(os.Stdout, "  cd %s\n", project.Name)
 	fmt.Fprintf(os.Stdout, "  go run . --help\n")
+	fmt.Fprintf(os.Stdout, "  acorn go cobra add <command>\n")
 
 	return nil
 }
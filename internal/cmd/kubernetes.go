@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/components/kubernetes"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
@@ -13,8 +18,35 @@ import (
 )
 
 var (
-	k8sVerbose bool
-	k8sDryRun  bool
+	k8sVerbose   bool
+	k8sDryRun    bool
+	k8sNamespace string
+	k8sPFRetry   bool
+
+	k8sAllNamespaces bool
+	k8sSelector      string
+
+	k8sContainer   string
+	k8sInteractive bool
+
+	k8sNamespaceLabels       []string
+	k8sNamespaceForce        bool
+	k8sNamespaceAllowCurrent bool
+
+	k8sRolloutRevision int
+	k8sRolloutCompare  int
+
+	k8sPodsSort    string
+	k8sPodsColumns []string
+
+	k8sAllKinds []string
+
+	k8sDiffRaw bool
+
+	k8sRestartAll     bool
+	k8sRestartWait    bool
+	k8sRestartTimeout time.Duration
+	k8sRestartForce   bool
 )
 
 // k8sCmd represents the kubernetes command group
@@ -31,7 +63,12 @@ Examples:
   acorn k8s namespace     # List/switch namespaces
   acorn k8s pods          # List pods
   acorn k8s all           # Show all resources
-  acorn k8s clean         # Clean evicted pods`,
+  acorn k8s clean         # Clean evicted pods
+  acorn k8s port-forward  # Forward local ports
+  acorn k8s exec          # Run a command in a pod
+  acorn k8s rollout       # Inspect deployment rollouts
+  acorn k8s restart       # Rolling restart of deployments
+  acorn k8s diff          # Preview what applying a manifest would change`,
 	Aliases: []string{"kube", "kubernetes"},
 }
 
@@ -39,7 +76,12 @@ Examples:
 var k8sInfoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show current context info",
-	Long: `Display current Kubernetes context, namespace, and server.
+	Long: `Display current Kubernetes context, namespace, server and user, plus
+details from the live cluster: its Kubernetes version, node count and the
+user the API server authenticates you as.
+
+If the cluster cannot be reached, the kubeconfig details are still shown
+and the live details are marked unavailable.
 
 Examples:
   acorn k8s info
@@ -72,13 +114,50 @@ var k8sNamespaceCmd = &cobra.Command{
 Without arguments, lists all namespaces.
 With a namespace name, switches to that namespace.
 
+Use the create and delete subcommands to manage namespaces.
+
 Examples:
   acorn k8s namespace            # List namespaces
-  acorn k8s namespace kube-system  # Switch to kube-system`,
+  acorn k8s namespace kube-system  # Switch to kube-system
+  acorn k8s namespace create scratch --label team=platform
+  acorn k8s namespace delete scratch --force`,
 	Aliases: []string{"ns"},
 	RunE:    runK8sNamespace,
 }
 
+// k8sNamespaceCreateCmd creates a namespace
+var k8sNamespaceCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a namespace",
+	Long: `Create a namespace, optionally with labels.
+
+Fails if the namespace already exists.
+
+Examples:
+  acorn k8s namespace create scratch
+  acorn k8s namespace create scratch --label team=platform --label env=test`,
+	Args: cobra.ExactArgs(1),
+	RunE: runK8sNamespaceCreate,
+}
+
+// k8sNamespaceDeleteCmd deletes a namespace
+var k8sNamespaceDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a namespace and everything in it",
+	Long: `Delete a namespace along with all resources in it.
+
+Lists the resources that will be removed and asks for confirmation;
+--force skips the prompt. Deleting the namespace of the current context
+is refused unless --allow-current is given.
+
+Examples:
+  acorn k8s namespace delete scratch
+  acorn k8s namespace delete scratch --force
+  acorn k8s namespace delete scratch --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runK8sNamespaceDelete,
+}
+
 // k8sPodsCmd lists pods
 var k8sPodsCmd = &cobra.Command{
 	Use:   "pods [filter]",
@@ -87,9 +166,18 @@ var k8sPodsCmd = &cobra.Command{
 
 With a filter argument, only shows pods matching the filter.
 
+Use --sort to order by name, status, restarts (highest first) or age
+(newest first), and --columns to choose which fields are shown:
+name, ready, status, restarts, age (compact, e.g. 3d), created (the
+creation timestamp). With -o json, --columns also limits the fields
+written. Without --columns, structured output keeps age as the creation
+timestamp and adds the compact age as age_human.
+
 Examples:
   acorn k8s pods
-  acorn k8s pods nginx`,
+  acorn k8s pods nginx
+  acorn k8s pods --sort restarts
+  acorn k8s pods --sort age --columns name,status,age`,
 	RunE: runK8sPods,
 }
 
@@ -99,11 +187,16 @@ var k8sAllCmd = &cobra.Command{
 	Short: "Show all resources in namespace",
 	Long: `Display pods, services, and deployments in a namespace.
 
-Uses current namespace if not specified.
+Uses current namespace if not specified. With --all-namespaces, gathers
+them across every namespace. The kinds are fetched in parallel and can be
+narrowed with --kinds (pods, services, deployments, or po, svc, deploy).
 
 Examples:
   acorn k8s all
-  acorn k8s all kube-system`,
+  acorn k8s all kube-system
+  acorn k8s all -A --kinds pods,svc
+  acorn k8s all -o json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runK8sAll,
 }
 
@@ -119,6 +212,147 @@ Examples:
 	RunE: runK8sClean,
 }
 
+// k8sPortForwardCmd forwards local ports to a cluster target
+var k8sPortForwardCmd = &cobra.Command{
+	Use:   "port-forward <target> <ports...>",
+	Short: "Forward local ports to a pod, service, or deployment",
+	Long: `Forward one or more local ports to a pod, service, or deployment.
+
+Targets:
+  <pod> or pod/<name>     Forward to a pod
+  svc/<name>              Forward to a service
+  deploy/<name>           Forward to a running pod of a deployment
+
+With --retry, the forward is re-established whenever it drops (for example
+when the pod is replaced during a rollout), giving up after 5 attempts in
+a row that fail to connect. Press Ctrl+C to stop.
+
+Examples:
+  acorn k8s port-forward my-pod 8080:80
+  acorn k8s port-forward svc/api 8080:80 -n staging
+  acorn k8s port-forward deploy/web 3000 9229 --retry`,
+	Aliases: []string{"pf"},
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runK8sPortForward,
+}
+
+// k8sGetCmd gets any resource kind
+var k8sGetCmd = &cobra.Command{
+	Use:   "get <resource> [name]",
+	Short: "Get resources of any kind",
+	Long: `Get resources of any kind, including custom resources.
+
+Renders a generic table of name, namespace, status and age. With -o json
+or -o yaml the kubectl output is passed through unchanged.
+
+Examples:
+  acorn k8s get configmaps
+  acorn k8s get ingresses -n staging
+  acorn k8s get jobs -A -l app=worker
+  acorn k8s get certificates.cert-manager.io my-cert -o yaml`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runK8sGet,
+}
+
+// k8sExecCmd runs a command in a pod
+var k8sExecCmd = &cobra.Command{
+	Use:   "exec <pod> [-- command...]",
+	Short: "Run a command or shell in a pod",
+	Long: `Run a command in a pod, or open an interactive shell.
+
+With a command and no --interactive, the command runs without a TTY and its
+stdout and stderr are captured, so it can be used from scripts. With -o json
+the result is reported as {stdout, stderr, exit_code}.
+
+Without a command, or with --interactive, a TTY is attached (kubectl exec -it)
+and /bin/sh is started if no command is given.
+
+Examples:
+  acorn k8s exec my-pod -- cat /etc/hostname
+  acorn k8s exec my-pod -c sidecar -o json -- env
+  acorn k8s exec my-pod                  # Interactive shell
+  acorn k8s exec my-pod -i -- bash`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runK8sExec,
+}
+
+// k8sRolloutCmd is the parent for rollout subcommands
+var k8sRolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Inspect deployment rollouts",
+	Long: `Inspect deployment rollouts.
+
+Examples:
+  acorn k8s rollout history my-app`,
+}
+
+// k8sRolloutHistoryCmd lists the revisions of a deployment
+var k8sRolloutHistoryCmd = &cobra.Command{
+	Use:   "history <deployment>",
+	Short: "Show the revision history of a deployment",
+	Long: `Show the recorded revisions of a deployment with their change-cause
+and creation time, so you can pick a revision to roll back to.
+
+With --revision the pod template of that revision is shown. Adding
+--compare shows a diff of the pod template between the two revisions.
+
+Examples:
+  acorn k8s rollout history my-app
+  acorn k8s rollout history my-app -n staging -o json
+  acorn k8s rollout history my-app --revision 3
+  acorn k8s rollout history my-app --revision 3 --compare 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runK8sRolloutHistory,
+}
+
+// k8sRestartCmd performs rolling restarts of deployments
+var k8sRestartCmd = &cobra.Command{
+	Use:   "restart [deployment...]",
+	Short: "Rolling restart of deployments",
+	Long: `Trigger a rolling restart of deployments, for example after changing a
+ConfigMap or Secret they read at startup.
+
+Name the deployments to restart, or use --all for every deployment in the
+namespace, or --selector for those matching a label selector. Restarting
+more than the named deployments asks for confirmation, showing the context
+and namespace; --force skips the prompt. With --wait each rollout must
+finish (within --timeout) before the next deployment is restarted.
+
+Each deployment's outcome is reported, and the command fails if any
+restart or rollout failed. --dry-run lists what would be restarted.
+
+Examples:
+  acorn k8s restart api
+  acorn k8s restart --all -n staging
+  acorn k8s restart -l app=web --wait
+  acorn k8s restart --all --dry-run`,
+	RunE: runK8sRestart,
+}
+
+// k8sDiffCmd compares manifests with the live cluster
+var k8sDiffCmd = &cobra.Command{
+	Use:   "diff [manifest...]",
+	Short: "Preview what applying manifests would change",
+	Long: `Compare manifests with the live cluster using kubectl diff and summarize
+which resources would be created, changed or deleted, with the fields that
+change on each.
+
+Manifests are given as arguments (files, directories or URLs, passed to
+kubectl as-is), or read from the input: a file given with -f or piped on
+stdin. Differences are not an error: the command only fails when kubectl
+itself does. Use --raw to print kubectl's unified diff instead of the
+summary.
+
+Examples:
+  acorn k8s diff -f deploy.yaml
+  kustomize build overlays/prod | acorn k8s diff
+  acorn k8s diff manifests/ -n staging
+  acorn k8s diff https://example.com/app.yaml
+  acorn k8s diff -f deploy.yaml -o json
+  acorn k8s diff -f deploy.yaml --raw`,
+	RunE: runK8sDiff,
+}
+
 func init() {
 
 	// Add subcommands
@@ -128,13 +362,95 @@ func init() {
 	k8sCmd.AddCommand(k8sPodsCmd)
 	k8sCmd.AddCommand(k8sAllCmd)
 	k8sCmd.AddCommand(k8sCleanCmd)
+	k8sCmd.AddCommand(k8sPortForwardCmd)
+	k8sCmd.AddCommand(k8sGetCmd)
+	k8sCmd.AddCommand(k8sExecCmd)
+	k8sCmd.AddCommand(k8sRolloutCmd)
+	k8sCmd.AddCommand(k8sRestartCmd)
+	k8sCmd.AddCommand(k8sDiffCmd)
 	k8sCmd.AddCommand(configcmd.NewConfigRouter("kubernetes"))
 
+	// Namespace subcommands
+	k8sNamespaceCmd.AddCommand(k8sNamespaceCreateCmd)
+	k8sNamespaceCmd.AddCommand(k8sNamespaceDeleteCmd)
+
+	// Rollout subcommands
+	k8sRolloutCmd.AddCommand(k8sRolloutHistoryCmd)
+
 	// Persistent flags (output format is inherited from root command)
 	k8sCmd.PersistentFlags().BoolVarP(&k8sVerbose, "verbose", "v", false,
 		"Show verbose output")
 	k8sCmd.PersistentFlags().BoolVar(&k8sDryRun, "dry-run", false,
 		"Show what would be done without executing")
+
+	// Namespace flags
+	k8sNamespaceCreateCmd.Flags().StringArrayVarP(&k8sNamespaceLabels, "label", "l", nil,
+		"Label to set as key=value (repeatable)")
+	k8sNamespaceDeleteCmd.Flags().BoolVar(&k8sNamespaceForce, "force", false,
+		"Delete without asking for confirmation")
+	k8sNamespaceDeleteCmd.Flags().BoolVar(&k8sNamespaceAllowCurrent, "allow-current", false,
+		"Allow deleting the current context's namespace")
+
+	// Port-forward flags
+	k8sPortForwardCmd.Flags().StringVarP(&k8sNamespace, "namespace", "n", "",
+		"Namespace of the target (default: current namespace)")
+	k8sPortForwardCmd.Flags().BoolVar(&k8sPFRetry, "retry", false,
+		"Reconnect automatically when the forward drops")
+
+	// Get flags
+	k8sAllCmd.Flags().BoolVarP(&k8sAllNamespaces, "all-namespaces", "A", false,
+		"Gather resources across all namespaces")
+	k8sAllCmd.Flags().StringSliceVar(&k8sAllKinds, "kinds", nil,
+		"Comma-separated kinds to include: "+strings.Join(kubernetes.ResourceKinds, ", "))
+
+	k8sGetCmd.Flags().StringVarP(&k8sNamespace, "namespace", "n", "",
+		"Namespace to query (default: current namespace)")
+	k8sGetCmd.Flags().BoolVarP(&k8sAllNamespaces, "all-namespaces", "A", false,
+		"Query across all namespaces")
+	k8sGetCmd.Flags().StringVarP(&k8sSelector, "selector", "l", "",
+		"Label selector to filter on (e.g. app=web)")
+
+	// Exec flags
+	k8sExecCmd.Flags().StringVarP(&k8sNamespace, "namespace", "n", "",
+		"Namespace of the pod (default: current namespace)")
+	k8sExecCmd.Flags().StringVarP(&k8sContainer, "container", "c", "",
+		"Container to run in (default: the pod's default container)")
+	k8sExecCmd.Flags().BoolVarP(&k8sInteractive, "interactive", "i", false,
+		"Attach stdin and a TTY even when a command is given")
+
+	// Pods flags
+	k8sPodsCmd.Flags().StringVar(&k8sPodsSort, "sort", "",
+		"Sort by name, status, restarts or age")
+	k8sPodsCmd.Flags().StringSliceVar(&k8sPodsColumns, "columns", kubernetes.DefaultPodColumns,
+		"Columns to show (name, ready, status, restarts, age, created)")
+
+	// Rollout history flags
+	k8sRolloutHistoryCmd.Flags().StringVarP(&k8sNamespace, "namespace", "n", "",
+		"Namespace of the deployment (default: current namespace)")
+	k8sRolloutHistoryCmd.Flags().IntVar(&k8sRolloutRevision, "revision", 0,
+		"Show the pod template of this revision")
+	k8sRolloutHistoryCmd.Flags().IntVar(&k8sRolloutCompare, "compare", 0,
+		"Diff the --revision pod template against this revision")
+
+	// Restart flags
+	k8sRestartCmd.Flags().StringVarP(&k8sNamespace, "namespace", "n", "",
+		"Namespace of the deployments (default: current namespace)")
+	k8sRestartCmd.Flags().BoolVar(&k8sRestartAll, "all", false,
+		"Restart every deployment in the namespace")
+	k8sRestartCmd.Flags().StringVarP(&k8sSelector, "selector", "l", "",
+		"Restart deployments matching this label selector (e.g. app=web)")
+	k8sRestartCmd.Flags().BoolVar(&k8sRestartWait, "wait", false,
+		"Wait for each rollout to complete before restarting the next")
+	k8sRestartCmd.Flags().DurationVar(&k8sRestartTimeout, "timeout", kubernetes.DefaultRestartTimeout,
+		"How long --wait waits for each rollout")
+	k8sRestartCmd.Flags().BoolVar(&k8sRestartForce, "force", false,
+		"Restart without asking for confirmation")
+
+	// Diff flags (manifests also come from the input opened by the inherited -f)
+	k8sDiffCmd.Flags().StringVarP(&k8sNamespace, "namespace", "n", "",
+		"Namespace for manifests that do not set one (default: current namespace)")
+	k8sDiffCmd.Flags().BoolVar(&k8sDiffRaw, "raw", false,
+		"Print kubectl's unified diff instead of a summary")
 }
 
 func runK8sInfo(cmd *cobra.Command, args []string) error {
@@ -145,7 +461,7 @@ func runK8sInfo(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("kubectl is not installed")
 	}
 
-	info, err := helper.GetContextInfo()
+	info, err := helper.GetClusterInfo()
 	if err != nil {
 		return err
 	}
@@ -158,6 +474,25 @@ func runK8sInfo(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(os.Stdout, "Context:   %s\n", info.Context)
 	fmt.Fprintf(os.Stdout, "Namespace: %s\n", info.Namespace)
 	fmt.Fprintf(os.Stdout, "Server:    %s\n", info.Server)
+	if info.User != "" {
+		fmt.Fprintf(os.Stdout, "User:      %s\n", info.User)
+	}
+
+	fmt.Fprintln(os.Stdout)
+	if !info.Reachable {
+		fmt.Fprintf(os.Stdout, "%s Live details unavailable: %s\n", output.Warning("!"), info.Error)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "Version:   %s\n", info.ServerVersion)
+	if info.Nodes != nil {
+		fmt.Fprintf(os.Stdout, "Nodes:     %d\n", *info.Nodes)
+	} else {
+		fmt.Fprintln(os.Stdout, "Nodes:     unavailable")
+	}
+	if info.AuthenticatedAs != "" {
+		fmt.Fprintf(os.Stdout, "Auth as:   %s\n", info.AuthenticatedAs)
+	}
 
 	return nil
 }
@@ -228,6 +563,105 @@ func runK8sNamespace(cmd *cobra.Command, args []string) error {
 	return helper.UseNamespace(args[0])
 }
 
+func runK8sNamespaceCreate(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	labels, err := kubernetes.ParseLabels(k8sNamespaceLabels)
+	if err != nil {
+		return err
+	}
+
+	result, err := helper.CreateNamespace(args[0], labels)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if !result.DryRun {
+		fmt.Fprintf(os.Stdout, "%s Created namespace %s\n", output.Success("✓"), result.Name)
+		for _, label := range k8sNamespaceLabels {
+			fmt.Fprintf(os.Stdout, "  label: %s\n", label)
+		}
+	}
+
+	return nil
+}
+
+func runK8sNamespaceDelete(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+	name := args[0]
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	exists, err := helper.NamespaceExists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("namespace %q not found", name)
+	}
+
+	if info, err := helper.GetContextInfo(); err == nil && info.Namespace == name && !k8sNamespaceAllowCurrent {
+		return fmt.Errorf("namespace %q is the current context's namespace; use --allow-current to delete it", name)
+	}
+
+	if !k8sNamespaceForce && !k8sDryRun {
+		if ioHelper.IsStructured() {
+			return fmt.Errorf("use --force to delete namespace %s", name)
+		}
+
+		resources, err := helper.NamespaceResources(name)
+		if err != nil {
+			fmt.Fprintf(os.Stdout, "%s Could not list resources in %s: %v\n", output.Warning("!"), name, err)
+		} else if len(resources) > 0 {
+			fmt.Fprintf(os.Stdout, "%s Deleting %s will also remove %d resources:\n", output.Warning("!"), name, len(resources))
+			for _, r := range resources {
+				fmt.Fprintf(os.Stdout, "  %s/%s\n", strings.ToLower(r.Kind), r.Name)
+			}
+			fmt.Fprintln(os.Stdout)
+		}
+
+		ok, err := confirm(fmt.Sprintf("Delete namespace %s?", name))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	result, err := helper.DeleteNamespace(name)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if !result.DryRun {
+		fmt.Fprintf(os.Stdout, "%s Deleted namespace %s (%d resources removed)\n",
+			output.Success("✓"), result.Name, len(result.Resources))
+	} else if len(result.Resources) > 0 {
+		fmt.Fprintf(os.Stdout, "%s %d resources would be removed with the namespace\n",
+			output.Warning("!"), len(result.Resources))
+	}
+
+	return nil
+}
+
 func runK8sPods(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
@@ -241,13 +675,34 @@ func runK8sPods(cmd *cobra.Command, args []string) error {
 		filter = args[0]
 	}
 
+	if err := kubernetes.ValidatePodColumns(k8sPodsColumns); err != nil {
+		return err
+	}
+
 	pods, err := helper.GetPods(filter)
 	if err != nil {
 		return err
 	}
 
+	if k8sPodsSort != "" {
+		if err := kubernetes.SortPods(pods, k8sPodsSort); err != nil {
+			return err
+		}
+	}
+
 	if ioHelper.IsStructured() {
-		return ioHelper.WriteOutput(map[string]interface{}{"pods": pods})
+		if !cmd.Flags().Changed("columns") {
+			return ioHelper.WriteOutput(map[string]interface{}{"pods": pods})
+		}
+		rows := make([]map[string]string, len(pods))
+		for i, pod := range pods {
+			values := kubernetes.PodRow(pod, k8sPodsColumns)
+			rows[i] = make(map[string]string, len(values))
+			for j, col := range k8sPodsColumns {
+				rows[i][strings.ToLower(col)] = values[j]
+			}
+		}
+		return ioHelper.WriteOutput(map[string]interface{}{"pods": rows})
 	}
 
 	// Table format
@@ -256,14 +711,283 @@ func runK8sPods(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Fprintf(os.Stdout, "%-50s %-10s %-12s %-10s\n", "NAME", "READY", "STATUS", "RESTARTS")
+	headers := make([]string, len(k8sPodsColumns))
+	for i, col := range k8sPodsColumns {
+		headers[i] = strings.ToUpper(col)
+	}
+	table := output.NewTable(headers...)
 	for _, pod := range pods {
-		fmt.Fprintf(os.Stdout, "%-50s %-10s %-12s %-10s\n", pod.Name, pod.Ready, pod.Status, pod.Restarts)
+		table.AddRow(kubernetes.PodRow(pod, k8sPodsColumns)...)
 	}
+	table.Render(os.Stdout)
 
 	return nil
 }
 
+func runK8sRolloutHistory(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	deployment := args[0]
+	if k8sRolloutCompare > 0 && k8sRolloutRevision == 0 {
+		return fmt.Errorf("--compare requires --revision")
+	}
+
+	if k8sRolloutRevision > 0 {
+		var detail *kubernetes.RevisionDetail
+		var err error
+		if k8sRolloutCompare > 0 {
+			detail, err = helper.CompareRevisions(deployment, k8sNamespace, k8sRolloutRevision, k8sRolloutCompare)
+		} else {
+			detail, err = helper.RevisionTemplate(deployment, k8sNamespace, k8sRolloutRevision)
+		}
+		if err != nil {
+			return err
+		}
+
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(detail)
+		}
+
+		if k8sRolloutCompare == 0 {
+			fmt.Fprint(os.Stdout, detail.Template)
+			return nil
+		}
+		if detail.Identical {
+			fmt.Fprintf(os.Stdout, "%s Revisions %d and %d have identical pod templates\n",
+				output.Info("ℹ"), detail.CompareTo, detail.Revision)
+			return nil
+		}
+		fmt.Fprint(os.Stdout, detail.Diff)
+		return nil
+	}
+
+	revisions, err := helper.RolloutHistory(deployment, k8sNamespace)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(map[string]interface{}{"revisions": revisions})
+	}
+
+	if len(revisions) == 0 {
+		fmt.Fprintf(os.Stdout, "No revisions recorded for %s\n", deployment)
+		return nil
+	}
+
+	table := output.NewTable("REVISION", "CREATED", "REPLICASET", "CHANGE-CAUSE")
+	for _, r := range revisions {
+		cause := r.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		table.AddRow(fmt.Sprintf("%d", r.Revision), formatK8sTimestamp(r.Created), r.ReplicaSet, cause)
+	}
+	table.Render(os.Stdout)
+
+	return nil
+}
+
+func runK8sRestart(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	bulk := k8sRestartAll || k8sSelector != ""
+	switch {
+	case len(args) > 0 && bulk:
+		return fmt.Errorf("name deployments or use --all/--selector, not both")
+	case len(args) == 0 && !bulk:
+		return fmt.Errorf("specify deployments to restart, or use --all or --selector")
+	}
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	deployments, err := helper.SelectDeployments(k8sNamespace, args, k8sSelector)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(&kubernetes.RestartReport{Namespace: k8sNamespace, Results: []kubernetes.RestartResult{}})
+		}
+		fmt.Fprintln(os.Stdout, "No deployments to restart")
+		return nil
+	}
+
+	if bulk && !k8sRestartForce && !k8sDryRun {
+		if ioHelper.IsStructured() {
+			return fmt.Errorf("use --force to restart %d deployments", len(deployments))
+		}
+
+		kubeContext, namespace := "unknown", k8sNamespace
+		if info, err := helper.GetContextInfo(); err == nil {
+			kubeContext = info.Context
+			if namespace == "" {
+				namespace = info.Namespace
+			}
+		}
+		fmt.Fprintf(os.Stdout, "%s Restarting %d deployments in namespace %s (context %s):\n",
+			output.Warning("!"), len(deployments), namespace, kubeContext)
+		for _, d := range deployments {
+			fmt.Fprintf(os.Stdout, "  %s\n", d)
+		}
+		fmt.Fprintln(os.Stdout)
+
+		ok, err := confirm("Restart these deployments?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	report := helper.RestartDeployments(deployments, kubernetes.RestartOptions{
+		Namespace: k8sNamespace,
+		Wait:      k8sRestartWait,
+		Timeout:   k8sRestartTimeout,
+	})
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(report); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range report.Results {
+			switch r.Status {
+			case kubernetes.RestartWould:
+				fmt.Fprintf(os.Stdout, "[dry-run] would restart deployment/%s\n", r.Deployment)
+			case kubernetes.RestartRolledOut:
+				fmt.Fprintf(os.Stdout, "%s %s restarted and rolled out\n", output.Success("✓"), r.Deployment)
+			case kubernetes.RestartDone:
+				fmt.Fprintf(os.Stdout, "%s %s restarted\n", output.Success("✓"), r.Deployment)
+			default:
+				fmt.Fprintf(os.Stdout, "%s %s: %s\n", output.Error("✗"), r.Deployment, r.Error)
+			}
+		}
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d deployments failed to restart", report.Failed, len(report.Results))
+	}
+	return nil
+}
+
+// formatK8sTimestamp renders an RFC 3339 timestamp in local time.
+func formatK8sTimestamp(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func runK8sGet(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	opts := kubernetes.GetOptions{
+		Resource:      args[0],
+		Namespace:     k8sNamespace,
+		AllNamespaces: k8sAllNamespaces,
+		Selector:      k8sSelector,
+	}
+	if len(args) > 1 {
+		opts.Name = args[1]
+	}
+
+	// Pass kubectl's own JSON/YAML through unchanged
+	switch ioHelper.Format() {
+	case ioutils.FormatJSON, ioutils.FormatYAML:
+		out, err := helper.GetRaw(opts, ioHelper.Format().String())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(ioHelper.Writer(), out)
+		return err
+	}
+
+	resources, err := helper.GetResources(opts)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(map[string]interface{}{"resources": resources})
+	}
+
+	// Table format
+	if len(resources) == 0 {
+		fmt.Fprintf(os.Stdout, "No %s found\n", opts.Resource)
+		return nil
+	}
+
+	var table *output.Table
+	if k8sAllNamespaces {
+		table = output.NewTable("NAMESPACE", "NAME", "STATUS", "AGE")
+		for _, r := range resources {
+			table.AddRow(r.Namespace, r.Name, r.Status, r.AgeHuman)
+		}
+	} else {
+		table = output.NewTable("NAME", "STATUS", "AGE")
+		for _, r := range resources {
+			table.AddRow(r.Name, r.Status, r.AgeHuman)
+		}
+	}
+	table.Render(os.Stdout)
+
+	return nil
+}
+
+func runK8sExec(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	opts := kubernetes.ExecOptions{
+		Pod:         args[0],
+		Namespace:   k8sNamespace,
+		Container:   k8sContainer,
+		Command:     args[1:],
+		Interactive: k8sInteractive,
+	}
+
+	if opts.Interactive || len(opts.Command) == 0 {
+		return helper.ExecInPod(opts)
+	}
+
+	result, err := helper.ExecCapture(opts)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	fmt.Fprint(os.Stdout, result.Stdout)
+	fmt.Fprint(os.Stderr, result.Stderr)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("command exited with code %d", result.ExitCode)
+	}
+	return nil
+}
+
 func runK8sAll(cmd *cobra.Command, args []string) error {
 	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
 
@@ -271,12 +995,81 @@ func runK8sAll(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("kubectl is not installed")
 	}
 
-	namespace := ""
+	opts := kubernetes.AllOptions{
+		AllNamespaces: k8sAllNamespaces,
+		Kinds:         k8sAllKinds,
+	}
 	if len(args) > 0 {
-		namespace = args[0]
+		if k8sAllNamespaces {
+			return fmt.Errorf("cannot combine a namespace with --all-namespaces")
+		}
+		opts.Namespace = args[0]
+	}
+
+	result, err := helper.GetAll(opts)
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	// Grouped table format
+	scope := "namespace " + result.Namespace
+	if result.AllNamespaces {
+		scope = "all namespaces"
+	}
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Resources in "+scope))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	withNamespace := func(headers ...string) []string {
+		if result.AllNamespaces {
+			return append([]string{"NAMESPACE"}, headers...)
+		}
+		return headers
+	}
+	row := func(namespace string, cols ...string) []string {
+		if result.AllNamespaces {
+			return append([]string{namespace}, cols...)
+		}
+		return cols
 	}
 
-	return helper.GetAllResources(namespace)
+	for _, kind := range result.Kinds {
+		var table *output.Table
+		count := 0
+		switch kind {
+		case "pods":
+			table = output.NewTable(withNamespace("NAME", "READY", "STATUS", "RESTARTS", "AGE")...)
+			for _, p := range result.Pods {
+				table.AddRow(row(p.Namespace, p.Name, p.Ready, p.Status, p.Restarts, p.AgeHuman)...)
+			}
+			count = len(result.Pods)
+		case "services":
+			table = output.NewTable(withNamespace("NAME", "TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORTS", "AGE")...)
+			for _, svc := range result.Services {
+				table.AddRow(row(svc.Namespace, svc.Name, svc.Type, svc.ClusterIP, svc.ExternalIP, svc.Ports, svc.AgeHuman)...)
+			}
+			count = len(result.Services)
+		case "deployments":
+			table = output.NewTable(withNamespace("NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE")...)
+			for _, d := range result.Deployments {
+				table.AddRow(row(d.Namespace, d.Name, d.Ready, d.UpToDate, d.Available, d.AgeHuman)...)
+			}
+			count = len(result.Deployments)
+		}
+
+		fmt.Fprintf(os.Stdout, "\n%s (%d)\n", output.Info(strings.ToUpper(kind[:1])+kind[1:]), count)
+		if count == 0 {
+			fmt.Fprintf(os.Stdout, "  No %s found\n", kind)
+			continue
+		}
+		table.Render(os.Stdout)
+	}
+
+	return nil
 }
 
 func runK8sClean(cmd *cobra.Command, args []string) error {
@@ -302,9 +1095,107 @@ func runK8sClean(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runK8sPortForward(cmd *cobra.Command, args []string) error {
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ports := strings.Join(args[1:], " ")
+	opts := kubernetes.PortForwardOptions{
+		Target:    args[0],
+		Namespace: k8sNamespace,
+		Ports:     ports,
+		Retry:     k8sPFRetry,
+		OnConnect: func(resolved string, connection int) {
+			if connection == 1 {
+				fmt.Fprintf(os.Stdout, "%s Forwarding %s -> %s\n", output.Success("✓"), ports, resolved)
+			} else {
+				fmt.Fprintf(os.Stdout, "%s Reconnected %s -> %s (connection %d)\n", output.Info("↻"), ports, resolved, connection)
+			}
+		},
+		OnDisconnect: func(err error) {
+			fmt.Fprintf(os.Stdout, "%s Forward dropped: %v; retrying in %s\n",
+				output.Warning("!"), err, kubernetes.PortForwardRetryDelay)
+		},
+	}
+
+	return helper.PortForwardTarget(ctx, opts)
+}
+
 func init() {
 	components.Register(&components.Registration{
 		Name: "kubernetes",
 		RegisterCmd: func() *cobra.Command { return k8sCmd },
 	})
 }
+
+func runK8sDiff(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := kubernetes.NewHelper(k8sVerbose, k8sDryRun)
+
+	if !helper.IsKubectlInstalled() {
+		return fmt.Errorf("kubectl is not installed")
+	}
+
+	opts := kubernetes.DiffOptions{Files: args, Namespace: k8sNamespace}
+	// Root has already opened -f, so read it through the input rather than
+	// by path. Piped stdin is only read when no arguments are given.
+	if file, _ := cmd.Flags().GetString("input-file"); file != "" || (len(args) == 0 && ioHelper.HasInput()) {
+		manifest, err := ioHelper.ReadRaw()
+		if err != nil {
+			return fmt.Errorf("failed to read manifest (pass directories and URLs as arguments): %w", err)
+		}
+		opts.Manifest = manifest
+	}
+	if len(opts.Files) == 0 && len(opts.Manifest) == 0 {
+		return fmt.Errorf("no manifests given (use -f <manifest> or pass them as arguments)")
+	}
+
+	if k8sDiffRaw {
+		diff, changed, err := helper.RawDiff(opts)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			fmt.Fprintf(os.Stdout, "%s No differences with the cluster\n", output.Success("✓"))
+			return nil
+		}
+		fmt.Fprint(os.Stdout, diff)
+		return nil
+	}
+
+	diffs, err := helper.Diff(opts)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(diffs)
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintf(os.Stdout, "%s No differences with the cluster\n", output.Success("✓"))
+		return nil
+	}
+
+	table := output.NewTable("ACTION", "KIND", "NAMESPACE", "NAME", "CHANGED FIELDS")
+	counts := map[string]int{}
+	for _, d := range diffs {
+		counts[d.Action]++
+		namespace := d.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		table.AddRow(d.Action, d.Kind, namespace, d.Name, strings.Join(d.ChangedFields, ", "))
+	}
+	table.Render(os.Stdout)
+
+	fmt.Fprintf(os.Stdout, "\n%d to create, %d to change, %d to delete\n",
+		counts[kubernetes.DiffCreated], counts[kubernetes.DiffChanged], counts[kubernetes.DiffDeleted])
+	return nil
+}
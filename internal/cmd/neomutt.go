@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/components/neomutt"
+	"github.com/mistergrinvalds/acorn/internal/utils/component"
 	"github.com/mistergrinvalds/acorn/internal/utils/configcmd"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
@@ -16,6 +18,12 @@ import (
 var (
 	neomuttDryRun  bool
 	neomuttVerbose bool
+
+	neomuttCacheAccount     string
+	neomuttCacheHeadersOnly bool
+	neomuttCacheBodiesOnly  bool
+
+	neomuttDoctorTimeout time.Duration
 )
 
 // neomuttCmd represents the neomutt command group
@@ -29,6 +37,7 @@ Provides commands for status, accounts, tokens, and cache management.
 
 Examples:
   acorn mail neomutt status        # Show NeoMutt status
+  acorn mail neomutt doctor        # Check the whole email setup
   acorn mail neomutt accounts      # List email accounts
   acorn mail neomutt tokens        # Show OAuth2 token status
   acorn mail neomutt cache info    # Show cache info
@@ -48,6 +57,24 @@ Examples:
 	RunE: runNeomuttStatus,
 }
 
+// neomuttDoctorCmd runs all health checks
+var neomuttDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the email setup works",
+	Long: `Run every NeoMutt health check in one pass.
+
+Checks the installation and main config, that each account config parses,
+OAuth2 token files and their expiry, the mutt_oauth2.py script, IMAP
+connectivity per account, and the cache directories. Each check reports
+pass, warn or fail with guidance. Exits non-zero if any check fails.
+
+Examples:
+  acorn mail neomutt doctor
+  acorn mail neomutt doctor --timeout 10s
+  acorn mail neomutt doctor -o json`,
+	RunE: runNeomuttDoctor,
+}
+
 // neomuttInstallCmd installs NeoMutt
 var neomuttInstallCmd = &cobra.Command{
 	Use:   "install",
@@ -100,7 +127,8 @@ Shows account names, types (gmail, microsoft, imap), and OAuth2 status.
 
 Examples:
   acorn mail neomutt accounts
-  acorn mail neomutt accounts -o json`,
+  acorn mail neomutt accounts -o json
+  acorn mail neomutt account edit gmail-ross`,
 	Aliases: []string{"account", "ls"},
 	RunE:    runNeomuttAccounts,
 }
@@ -177,10 +205,17 @@ Examples:
 var neomuttCacheCleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Clean cache",
-	Long: `Remove all cached headers and messages.
+	Long: `Remove cached headers and messages.
+
+By default both caches are cleared for all accounts. Use --account to clear
+only one account's entries, and --headers-only or --bodies-only to clear a
+single cache type. The space reclaimed is reported per path.
 
 Examples:
-  acorn mail neomutt cache clean`,
+  acorn mail neomutt cache clean
+  acorn mail neomutt cache clean --account gmail-personal
+  acorn mail neomutt cache clean --account me@example.com --headers-only
+  acorn mail neomutt cache clean --bodies-only --dry-run`,
 	RunE: runNeomuttCacheClean,
 }
 
@@ -234,10 +269,29 @@ Examples:
 	RunE:    runNeomuttAddMicrosoft,
 }
 
+// neomuttAccountEditCmd edits an account config
+var neomuttAccountEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit an account config with $EDITOR",
+	Long: `Open an account's .muttrc in your default editor.
+
+The account may be given by name or email address. After the editor
+exits, the config is re-read to check that its email address and OAuth2
+token file can still be found. If either went missing you can reopen the
+editor to fix it, instead of finding out when NeoMutt connects.
+
+Examples:
+  acorn mail neomutt account edit gmail-ross
+  acorn mail neomutt account edit ross.bercot@gmail.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNeomuttAccountEdit,
+}
+
 func init() {
 
 	// Add subcommands
 	neomuttCmd.AddCommand(neomuttStatusCmd)
+	neomuttCmd.AddCommand(neomuttDoctorCmd)
 	neomuttCmd.AddCommand(neomuttInstallCmd)
 	neomuttCmd.AddCommand(neomuttLaunchCmd)
 	neomuttCmd.AddCommand(neomuttInitCmd)
@@ -254,6 +308,19 @@ func init() {
 	neomuttCacheCmd.AddCommand(neomuttCacheInfoCmd)
 	neomuttCacheCmd.AddCommand(neomuttCacheCleanCmd)
 
+	// Doctor flags
+	neomuttDoctorCmd.Flags().DurationVar(&neomuttDoctorTimeout, "timeout", neomutt.DefaultIMAPTimeout,
+		"How long to wait for each IMAP server")
+
+	// Cache clean flags
+	neomuttCacheCleanCmd.Flags().StringVar(&neomuttCacheAccount, "account", "",
+		"Only clear cached data for this account (name or email)")
+	neomuttCacheCleanCmd.Flags().BoolVar(&neomuttCacheHeadersOnly, "headers-only", false,
+		"Only clear the header cache")
+	neomuttCacheCleanCmd.Flags().BoolVar(&neomuttCacheBodiesOnly, "bodies-only", false,
+		"Only clear the message body cache")
+	neomuttCacheCleanCmd.MarkFlagsMutuallyExclusive("headers-only", "bodies-only")
+
 	// Generate command
 	neomuttCmd.AddCommand(neomuttGenerateCmd)
 	neomuttCmd.AddCommand(configcmd.NewConfigRouter("neomutt"))
@@ -262,6 +329,7 @@ func init() {
 	neomuttAccountsCmd.AddCommand(neomuttAccountAddCmd)
 	neomuttAccountAddCmd.AddCommand(neomuttAccountAddGmailCmd)
 	neomuttAccountAddCmd.AddCommand(neomuttAccountAddMicrosoftCmd)
+	neomuttAccountsCmd.AddCommand(neomuttAccountEditCmd)
 
 	// Persistent flags
 	neomuttCmd.PersistentFlags().BoolVar(&neomuttDryRun, "dry-run", false,
@@ -309,6 +377,46 @@ func runNeomuttStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runNeomuttDoctor(cmd *cobra.Command, args []string) error {
+	helper := newNeomuttHelper()
+	report := helper.Doctor(neomuttDoctorTimeout)
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info("NeoMutt Doctor"))
+		fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+		for _, c := range report.Checks {
+			symbol := output.Success("✓")
+			switch c.Status {
+			case component.CheckWarn:
+				symbol = output.Warning("!")
+			case component.CheckFail:
+				symbol = output.Error("✗")
+			}
+			name := c.Name
+			if c.Account != "" {
+				name = c.Account + " " + c.Name
+			}
+			fmt.Fprintf(os.Stdout, "%s %s: %s\n", symbol, name, c.Message)
+			if c.Hint != "" && c.Status != component.CheckPass {
+				fmt.Fprintf(os.Stdout, "    %s\n", c.Hint)
+			}
+		}
+
+		fmt.Fprintf(os.Stdout, "\n%d passed, %d warnings, %d failed\n", report.Passed, report.Warnings, report.Failed)
+	}
+
+	if report.Failed > 0 {
+		return &exitCodeError{code: 1}
+	}
+	return nil
+}
+
 func runNeomuttInstall(cmd *cobra.Command, args []string) error {
 	helper := newNeomuttHelper()
 	return helper.Install()
@@ -365,6 +473,50 @@ func runNeomuttAccounts(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runNeomuttAccountEdit(cmd *cobra.Command, args []string) error {
+	helper := newNeomuttHelper()
+	result, err := helper.EditAccount(args[0])
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	for !result.OK() {
+		for _, problem := range result.Problems {
+			fmt.Fprintf(os.Stdout, "%s %s\n", output.Warning("!"), problem)
+		}
+		reopen, err := confirm("Reopen the editor to fix it?")
+		if err != nil {
+			return err
+		}
+		if !reopen {
+			fmt.Fprintf(os.Stdout, "%s Saved %s with problems; NeoMutt may fail to connect\n",
+				output.Warning("!"), result.Path)
+			return nil
+		}
+		if err := helper.ReopenAccountEdit(result); err != nil {
+			return err
+		}
+	}
+
+	if !result.Changed {
+		fmt.Fprintf(os.Stdout, "%s No changes to %s\n", output.Info("ℹ"), result.Path)
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "%s Saved %s\n", output.Success("✓"), result.Path)
+	if result.Email != "" {
+		fmt.Fprintf(os.Stdout, "  Email: %s\n", result.Email)
+	}
+	if result.TokenFile != "" {
+		fmt.Fprintf(os.Stdout, "  Token file: %s\n", result.TokenFile)
+	}
+	return nil
+}
+
 func runNeomuttTokensStatus(cmd *cobra.Command, args []string) error {
 	helper := newNeomuttHelper()
 	tokens, err := helper.GetTokenStatus()
@@ -439,10 +591,41 @@ func runNeomuttCacheInfo(cmd *cobra.Command, args []string) error {
 
 func runNeomuttCacheClean(cmd *cobra.Command, args []string) error {
 	helper := newNeomuttHelper()
-	if err := helper.CleanCache(); err != nil {
+	result, err := helper.CleanCache(neomutt.CacheCleanOptions{
+		Account:     neomuttCacheAccount,
+		HeadersOnly: neomuttCacheHeadersOnly,
+		BodiesOnly:  neomuttCacheBodiesOnly,
+	})
+	if err != nil {
 		return err
 	}
-	fmt.Fprintf(os.Stdout, "%s Cache cleaned\n", output.Success("✓"))
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if len(result.Paths) == 0 {
+		if result.Account != "" {
+			fmt.Fprintf(os.Stdout, "%s No cached data found for %s\n", output.Info("ℹ"), result.Account)
+		} else {
+			fmt.Fprintf(os.Stdout, "%s Cache is already empty\n", output.Info("ℹ"))
+		}
+		return nil
+	}
+
+	verb := "Cleared"
+	if result.DryRun {
+		verb = "Would clear"
+	}
+	for _, p := range result.Paths {
+		fmt.Fprintf(os.Stdout, "  %s %s %s (%s)\n", output.Success("✓"), verb, p.Path, output.FormatBytes(p.Bytes))
+	}
+	if result.DryRun {
+		fmt.Fprintf(os.Stdout, "\n%s Would reclaim %s\n", output.Info("ℹ"), output.FormatBytes(result.TotalBytes))
+	} else {
+		fmt.Fprintf(os.Stdout, "\n%s Cache cleaned, reclaimed %s\n", output.Success("✓"), output.FormatBytes(result.TotalBytes))
+	}
 	return nil
 }
 
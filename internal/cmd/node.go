@@ -16,6 +16,10 @@ var (
 	nodeVerbose bool
 	nodeDryRun  bool
 	nodeForce   bool
+
+	nodeCleanOlderThan string
+	nodeCleanExcept    []string
+	nodeCleanWorkers   int
 )
 
 // nodeCmd represents the node command group
@@ -96,11 +100,19 @@ var nodeCleanAllCmd = &cobra.Command{
 	Short: "Remove all node_modules in directory tree",
 	Long: `Find and remove all node_modules directories.
 
-Requires --force flag to actually delete.
+Lists the directories found with their sizes and asks for confirmation
+before deleting; --force skips the prompt. Directories are removed
+concurrently and the total reclaimed space is reported.
+
+Filters:
+  --older-than   Only directories not modified within this age (30d, 2w, 12h)
+  --except       Keep projects whose path or name matches a glob (repeatable)
 
 Examples:
-  acorn node cleanall --force
-  acorn node cleanall ~/projects --force`,
+  acorn node cleanall --dry-run
+  acorn node cleanall ~/projects --force
+  acorn node cleanall ~/projects --older-than 30d --except 'acorn*' --force
+  acorn node cleanall ~/projects --force -o json`,
 	RunE: runNodeCleanAll,
 }
 
@@ -243,7 +255,13 @@ func init() {
 
 	// Clean all flags
 	nodeCleanAllCmd.Flags().BoolVar(&nodeForce, "force", false,
-		"Actually remove the directories (required)")
+		"Remove without asking for confirmation")
+	nodeCleanAllCmd.Flags().StringVar(&nodeCleanOlderThan, "older-than", "",
+		"Only remove node_modules not modified within this age (e.g. 30d, 2w, 12h)")
+	nodeCleanAllCmd.Flags().StringSliceVar(&nodeCleanExcept, "except", nil,
+		"Keep projects whose path or directory name matches this glob (repeatable)")
+	nodeCleanAllCmd.Flags().IntVar(&nodeCleanWorkers, "workers", 4,
+		"Number of directories to remove concurrently")
 
 	// Cache flags
 	nodeCacheCmd.Flags().BoolVar(&nodeCacheClean, "clean", false,
@@ -368,39 +386,87 @@ func runNodeFind(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeCleanAll(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
 	helper := node.NewHelper(nodeVerbose, nodeDryRun)
 
-	root := "."
+	opts := node.CleanOptions{
+		Root:    ".",
+		Except:  nodeCleanExcept,
+		Workers: nodeCleanWorkers,
+	}
 	if len(args) > 0 {
-		root = args[0]
+		opts.Root = args[0]
+	}
+	if nodeCleanOlderThan != "" {
+		age, err := node.ParseAge(nodeCleanOlderThan)
+		if err != nil {
+			return err
+		}
+		opts.OlderThan = age
 	}
 
-	// First show what we found
-	modules, err := helper.FindNodeModules(root)
+	result, err := helper.FindCleanTargets(opts)
 	if err != nil {
 		return err
 	}
 
-	if len(modules) == 0 {
-		fmt.Fprintln(os.Stdout, "No node_modules directories found")
-		return nil
+	if !ioHelper.IsStructured() {
+		if len(result.Entries) == 0 {
+			fmt.Fprintln(os.Stdout, "No node_modules directories found")
+			return nil
+		}
+
+		var total int64
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info("Found node_modules:"))
+		for _, e := range result.Entries {
+			fmt.Fprintf(os.Stdout, "  %10s  %s\n", e.Size, e.Path)
+			total += e.Bytes
+		}
+		for _, path := range result.Excluded {
+			fmt.Fprintf(os.Stdout, "  %10s  %s\n", "(kept)", path)
+		}
+		fmt.Fprintf(os.Stdout, "\n%d directories, %s\n\n", len(result.Entries), output.FormatBytes(total))
 	}
 
-	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Found node_modules:"))
-	for _, m := range modules {
-		fmt.Fprintf(os.Stdout, "  %s\t%s\n", m.Size, m.Path)
+	if !nodeForce && !nodeDryRun && len(result.Entries) > 0 {
+		if ioHelper.IsStructured() {
+			return fmt.Errorf("use --force to remove %d node_modules directories", len(result.Entries))
+		}
+		ok, err := confirm(fmt.Sprintf("Remove %d node_modules directories?", len(result.Entries)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
 	}
-	fmt.Fprintln(os.Stdout)
 
-	count, err := helper.CleanAllNodeModules(root, nodeForce)
-	if err != nil {
-		return err
+	helper.RemoveCleanTargets(result, nodeCleanWorkers)
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if !nodeDryRun {
+		for _, e := range result.Entries {
+			if e.Status == "failed" {
+				fmt.Fprintf(os.Stdout, "%s %s: %s\n", output.Error("✗"), e.Path, e.Error)
+			} else {
+				fmt.Fprintf(os.Stdout, "%s %s (%s)\n", output.Success("✓"), e.Path, e.Size)
+			}
+		}
+		fmt.Fprintln(os.Stdout)
 	}
 
 	if nodeDryRun {
-		fmt.Fprintf(os.Stdout, "Would remove %d directories\n", count)
+		fmt.Fprintf(os.Stdout, "[dry-run] would remove %d directories, reclaiming %s\n", result.Removed, result.Reclaimed)
 	} else {
-		fmt.Fprintf(os.Stdout, "%s Removed %d node_modules directories\n", output.Success("✓"), count)
+		fmt.Fprintf(os.Stdout, "%s Removed %d node_modules directories, reclaimed %s\n",
+			output.Success("✓"), result.Removed, result.Reclaimed)
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("failed to remove %d directories", result.Failed)
 	}
 
 	return nil
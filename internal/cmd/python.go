@@ -152,7 +152,11 @@ Examples:
 var pythonEnvCmd = &cobra.Command{
 	Use:   "env",
 	Short: "Show Python environment information",
-	Long: `Display Python environment variables and configuration.
+	Long: `Display a snapshot of the Python environment: the python3 on PATH
+and its version, installed package count, the active virtualenv, the
+enclosing project (pyproject.toml / uv.lock) and the UV cache size.
+
+Warns when python3 does not come from the active virtualenv.
 
 Examples:
   acorn python env
@@ -383,7 +387,8 @@ func runPythonEnv(cmd *cobra.Command, args []string) error {
 
 	fmt.Fprintf(os.Stdout, "%s\n\n", output.Info("Python Environment"))
 	if info.Version != "" {
-		fmt.Fprintf(os.Stdout, "Python:        %s\n", info.Version)
+		fmt.Fprintf(os.Stdout, "Python:        %s (%s)\n", info.Version, info.PythonPath)
+		fmt.Fprintf(os.Stdout, "Packages:      %d installed\n", info.Packages)
 	} else {
 		fmt.Fprintf(os.Stdout, "Python:        %s\n", output.Warning("not found"))
 	}
@@ -395,12 +400,31 @@ func runPythonEnv(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Fprintf(os.Stdout, "UV:            %s\n", output.Warning("not installed"))
 	}
-	if info.VirtualEnv != "" {
+	if info.UVCacheDir != "" {
+		fmt.Fprintf(os.Stdout, "UV Cache:      %s (%s)\n", info.UVCacheDir, output.FormatBytes(info.UVCacheSize))
+	}
+	if info.VenvActive {
 		fmt.Fprintf(os.Stdout, "Virtual Env:   %s\n", output.Success(info.VirtualEnv))
 	} else {
 		fmt.Fprintf(os.Stdout, "Virtual Env:   none active\n")
 	}
 	fmt.Fprintf(os.Stdout, "Envs Location: %s\n", info.EnvsLocation)
+	if p := info.Project; p != nil {
+		kind := "pyproject.toml"
+		if p.UVManaged {
+			kind = "uv-managed"
+		}
+		fmt.Fprintf(os.Stdout, "Project:       %s (%s)\n", p.Dir, kind)
+	} else {
+		fmt.Fprintf(os.Stdout, "Project:       none\n")
+	}
+
+	if len(info.Warnings) > 0 {
+		fmt.Fprintln(os.Stdout)
+		for _, w := range info.Warnings {
+			fmt.Fprintf(os.Stdout, "%s %s\n", output.Warning("!"), w)
+		}
+	}
 
 	return nil
 }
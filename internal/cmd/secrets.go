@@ -3,16 +3,20 @@ package cmd
 import (
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/mistergrinvalds/acorn/internal/components/secrets"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
-	secretsVerbose bool
+	secretsVerbose    bool
+	secretsRotateDays int
 )
 
 // secretsCmd represents the secrets command group
@@ -28,7 +32,9 @@ Examples:
   acorn secrets status           # Check secrets file status
   acorn secrets list             # List configured secret keys
   acorn secrets check            # Check all credentials
-  acorn secrets check aws        # Check specific credential`,
+  acorn secrets check aws        # Check specific credential
+  acorn secrets set GITHUB_TOKEN # Set a secret (prompts for value)
+  acorn secrets rotate-check     # Report how old each secret is`,
 }
 
 // secretsStatusCmd shows secrets file status
@@ -131,6 +137,60 @@ Examples:
 	RunE: runSecretsPath,
 }
 
+// secretsSetCmd sets a secret value
+var secretsSetCmd = &cobra.Command{
+	Use:   "set <key> [value]",
+	Short: "Set a secret in the secrets file",
+	Long: `Add or update a key in the secrets file.
+
+Existing keys are updated in place, preserving comments and ordering.
+The file is written with secure permissions (0600) and the time the key
+was set is recorded in a sidecar metadata file (.env.meta.json), which
+'acorn secrets rotate-check' uses to report how old each key is.
+
+If the value is omitted, it is read from stdin when piped, or prompted
+for without echo when running interactively.
+
+Examples:
+  acorn secrets set GITHUB_TOKEN              # Prompt for value
+  echo "$TOKEN" | acorn secrets set GITHUB_TOKEN
+  acorn secrets set AWS_REGION us-east-1`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSecretsSet,
+}
+
+// secretsUnsetCmd removes a secret
+var secretsUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a secret from the secrets file",
+	Long: `Remove a key from the secrets file and its metadata.
+
+Examples:
+  acorn secrets unset GITHUB_TOKEN`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSecretsUnset,
+}
+
+// secretsRotateCheckCmd reports secret ages
+var secretsRotateCheckCmd = &cobra.Command{
+	Use:   "rotate-check",
+	Short: "Report how long ago each secret was set",
+	Long: `Report the age of every key in the secrets file.
+
+Ages come from the set times that 'acorn secrets set' records in the
+sidecar metadata file (.env.meta.json). Keys older than --days are
+flagged as due for rotation; keys added by editing the file directly
+have no recorded time and are shown as unknown.
+
+Exits with code 1 when any key is stale.
+
+Examples:
+  acorn secrets rotate-check
+  acorn secrets rotate-check --days 30
+  acorn secrets rotate-check -o json`,
+	RunE: runSecretsRotateCheck,
+}
+
 func init() {
 
 	// Add subcommands
@@ -141,10 +201,16 @@ func init() {
 	secretsCmd.AddCommand(secretsValidateCmd)
 	secretsCmd.AddCommand(secretsInitCmd)
 	secretsCmd.AddCommand(secretsPathCmd)
+	secretsCmd.AddCommand(secretsSetCmd)
+	secretsCmd.AddCommand(secretsUnsetCmd)
+	secretsCmd.AddCommand(secretsRotateCheckCmd)
 
 	// Persistent flags
 	secretsCmd.PersistentFlags().BoolVarP(&secretsVerbose, "verbose", "v", false,
 		"Show verbose output")
+
+	secretsRotateCheckCmd.Flags().IntVar(&secretsRotateDays, "days", secrets.DefaultRotateDays,
+		"Flag secrets last set more than this many days ago")
 }
 
 func runSecretsStatus(cmd *cobra.Command, args []string) error {
@@ -344,6 +410,127 @@ func runSecretsPath(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSecretsSet(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if !secrets.ValidKeyName(key) {
+		return fmt.Errorf("invalid key name: %q (must match [A-Za-z_][A-Za-z0-9_]*)", key)
+	}
+
+	var value string
+	if len(args) > 1 {
+		value = args[1]
+	} else {
+		v, err := readSecretValue(key)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+
+	helper := secrets.NewHelper(secretsVerbose)
+	result, err := helper.SetSecret(key, value)
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	verb := "Added"
+	if result.Action == "updated" {
+		verb = "Updated"
+	}
+	fmt.Fprintf(os.Stdout, "%s %s %s\n", output.Success("✓"), verb, key)
+	if secretsVerbose {
+		fmt.Fprintf(os.Stdout, "Path: %s\n", result.FilePath)
+	}
+	return nil
+}
+
+func runSecretsUnset(cmd *cobra.Command, args []string) error {
+	helper := secrets.NewHelper(secretsVerbose)
+	result, err := helper.UnsetSecret(args[0])
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if result.Action == "not_found" {
+		fmt.Fprintf(os.Stdout, "%s %s not found in secrets file\n", output.Warning("⚠"), args[0])
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "%s Removed %s\n", output.Success("✓"), args[0])
+	return nil
+}
+
+func runSecretsRotateCheck(cmd *cobra.Command, args []string) error {
+	helper := secrets.NewHelper(secretsVerbose)
+	report, err := helper.RotateCheck(secretsRotateDays)
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info("Secret Rotation"))
+		fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+		if len(report.Keys) == 0 {
+			fmt.Fprintln(os.Stdout, "No secrets configured")
+			return nil
+		}
+
+		for _, k := range report.Keys {
+			switch k.Status {
+			case "stale":
+				fmt.Fprintf(os.Stdout, "%s %s: %d days old\n", output.Warning("⚠"), k.Key, k.AgeDays)
+			case "unknown":
+				fmt.Fprintf(os.Stdout, "%s %s: age unknown\n", output.Info("?"), k.Key)
+			default:
+				fmt.Fprintf(os.Stdout, "%s %s: %d days old\n", output.Success("✓"), k.Key, k.AgeDays)
+			}
+		}
+
+		fmt.Fprintln(os.Stdout)
+		fmt.Fprintf(os.Stdout, "Stale (> %d days): %d, Unknown: %d\n", report.MaxAgeDays, report.Stale, report.Unknown)
+	}
+
+	if report.Stale > 0 {
+		return &exitCodeError{code: 1}
+	}
+	return nil
+}
+
+// readSecretValue reads a secret from stdin, prompting without echo on a TTY.
+func readSecretValue(key string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read value from stdin: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Value for %s: ", key)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read value: %w", err)
+	}
+	return string(data), nil
+}
+
 func joinEnvVars(vars []string) string {
 	if len(vars) == 0 {
 		return ""
@@ -6,13 +6,18 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/mistergrinvalds/acorn/internal/components"
 	"github.com/mistergrinvalds/acorn/internal/components/filesync"
 	"github.com/mistergrinvalds/acorn/internal/components/shell"
 	"github.com/mistergrinvalds/acorn/internal/utils/config"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
+	"github.com/mistergrinvalds/acorn/internal/utils/tools"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -21,6 +26,9 @@ var (
 	setupSkipBuild   bool
 	setupSaplingRepo string
 	setupSaplingPath string
+	setupProfile     string
+
+	setupInitProfileForce bool
 )
 
 // setupCmd represents the setup command
@@ -37,28 +45,67 @@ This command stages everything needed for a working acorn environment:
   3. Inject acorn into shell configuration
   4. Create symlinks for generated config files
   5. Sync component configurations (e.g., claude)
+  6. Install tools listed in the profile
 
 This is the recommended way to set up acorn on a new machine or after
 pulling changes from the dotfiles repository.
 
+With --profile, setup runs non-interactively from a YAML machine profile
+that declares the sapling source, whether to build, which components to
+set up and which tools to install. Command-line flags take precedence
+over the profile. Use 'acorn setup init-profile' to write a starter
+profile from this machine.
+
 Examples:
   acorn setup                                    # Full setup (interactive)
   acorn setup --sapling-repo git@github.com:user/sapling.git  # Clone sapling repo
   acorn setup --sapling-path ~/my-sapling        # Use existing sapling directory
   acorn setup --dry-run                          # Preview what would be done
   acorn setup --skip-build                       # Skip go build step
+  acorn setup --profile ~/.sapling/profiles/server.yaml  # Provision from a profile
   acorn setup -v                                 # Verbose output`,
 	RunE: runSetup,
 }
 
+// setupInitProfileCmd writes a starter setup profile
+var setupInitProfileCmd = &cobra.Command{
+	Use:   "init-profile <file>",
+	Short: "Write a setup profile from this machine",
+	Long: `Write a starter setup profile describing this machine.
+
+The profile lists the components that have a configuration in .sapling
+and records the sapling repository's origin remote. Edit it to suit the
+target machine, then run 'acorn setup --profile <file>' there.
+
+Profile format:
+  name: laptop
+  description: Personal laptop
+  sapling:
+    repo: git@github.com:user/sapling.git   # or path: ~/my-sapling
+  build: true
+  components: [core, git, tmux]
+  tools: [jq, fzf]
+
+Examples:
+  acorn setup init-profile laptop.yaml
+  acorn setup init-profile ~/.sapling/profiles/server.yaml --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSetupInitProfile,
+}
+
 func init() {
 	rootCmd.AddCommand(setupCmd)
+	setupCmd.AddCommand(setupInitProfileCmd)
 
 	setupCmd.Flags().BoolVar(&setupDryRun, "dry-run", false, "Show what would be done without executing")
 	setupCmd.Flags().BoolVarP(&setupVerbose, "verbose", "v", false, "Show verbose output")
 	setupCmd.Flags().BoolVar(&setupSkipBuild, "skip-build", false, "Skip the go build step")
 	setupCmd.Flags().StringVar(&setupSaplingRepo, "sapling-repo", "", "Git repository URL to clone .sapling from")
 	setupCmd.Flags().StringVar(&setupSaplingPath, "sapling-path", "", "Path to existing .sapling directory to link")
+	setupCmd.Flags().StringVar(&setupProfile, "profile", "", "Machine profile (YAML) to set up from")
+
+	// Init profile flags
+	setupInitProfileCmd.Flags().BoolVar(&setupInitProfileForce, "force", false, "Overwrite an existing file")
 }
 
 func runSetup(cmd *cobra.Command, args []string) error {
@@ -67,18 +114,43 @@ func runSetup(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get dotfiles root: %w", err)
 	}
 
+	var profile *config.SetupProfile
+	if setupProfile != "" {
+		profile, err = config.LoadSetupProfile(setupProfile)
+		if err != nil {
+			return err
+		}
+		if err := validateProfileTools(profile.Tools); err != nil {
+			return err
+		}
+		applySetupProfile(profile)
+	}
+
 	if setupDryRun {
 		fmt.Fprintf(os.Stdout, "%s Setup Preview (dry-run)\n", output.Info("ℹ"))
 	} else {
 		fmt.Fprintf(os.Stdout, "%s Acorn Environment Setup\n", output.Info("ℹ"))
 	}
-	fmt.Fprintf(os.Stdout, "  Dotfiles: %s\n\n", dotfilesRoot)
+	fmt.Fprintf(os.Stdout, "  Dotfiles: %s\n", dotfilesRoot)
+	if profile != nil {
+		fmt.Fprintf(os.Stdout, "  Profile:  %s\n", setupProfile)
+	}
+	fmt.Fprintln(os.Stdout)
 
 	// Step 0: Setup .sapling repository
 	if err := setupSapling(dotfilesRoot); err != nil {
 		return err
 	}
 
+	// Components can only be checked once .sapling is in place
+	var selected []string
+	if profile != nil {
+		if err := validateProfileComponents(profile.Components); err != nil {
+			return err
+		}
+		selected = profile.Components
+	}
+
 	// Step 1: Build acorn
 	if !setupSkipBuild {
 		if err := setupBuild(dotfilesRoot); err != nil {
@@ -89,7 +161,7 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 2: Generate shell scripts
-	if err := setupShellGenerate(); err != nil {
+	if err := setupShellGenerate(selected); err != nil {
 		return err
 	}
 
@@ -104,10 +176,17 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 5: Sync component configurations
-	if err := setupComponentSync(dotfilesRoot); err != nil {
+	if err := setupComponentSync(dotfilesRoot, selected); err != nil {
 		return err
 	}
 
+	// Step 6: Install profile tools
+	if profile != nil && len(profile.Tools) > 0 {
+		if err := setupInstallTools(profile.Tools); err != nil {
+			return err
+		}
+	}
+
 	// Summary
 	fmt.Fprintln(os.Stdout)
 	if setupDryRun {
@@ -169,10 +248,55 @@ func setupSapling(dotfilesRoot string) error {
 		return linkSaplingPath(saplingDir, setupSaplingPath, homeSaplingLink)
 	}
 
+	// A profile is meant to run unattended, so never prompt
+	if setupProfile != "" {
+		return fmt.Errorf("profile %s does not declare a sapling source (sapling.repo or sapling.path)", setupProfile)
+	}
+
 	// Interactive mode - ask user what to do
 	return setupSaplingInteractive(saplingDir, homeSaplingLink)
 }
 
+// confirm asks a yes/no question on stdin and returns true only for y/yes.
+func confirm(prompt string) (bool, error) {
+	fmt.Fprintf(os.Stdout, "%s [y/N]: ", prompt)
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// selectItem shows items as a numbered list and reads a choice from stdin.
+// The list and prompt go to stderr so stdout stays free for the result.
+// It returns -1 when the answer is blank.
+func selectItem(prompt string, items []string) (int, error) {
+	for i, item := range items {
+		fmt.Fprintf(os.Stderr, "%3d) %s\n", i+1, item)
+	}
+	fmt.Fprintf(os.Stderr, "\n%s [1-%d, blank to cancel]: ", prompt, len(items))
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil && answer == "" {
+		return -1, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return -1, nil
+	}
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(items) {
+		return -1, fmt.Errorf("invalid selection %q (expected 1-%d)", answer, len(items))
+	}
+	return n - 1, nil
+}
+
 // setupSaplingInteractive prompts the user for sapling setup options
 func setupSaplingInteractive(saplingDir, homeSaplingLink string) error {
 	reader := bufio.NewReader(os.Stdin)
@@ -213,15 +337,10 @@ func setupSaplingInteractive(saplingDir, homeSaplingLink string) error {
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
 		}
-		existingPath = strings.TrimSpace(existingPath)
+		existingPath = expandHome(strings.TrimSpace(existingPath))
 		if existingPath == "" {
 			return fmt.Errorf("path cannot be empty")
 		}
-		// Expand ~ if present
-		if strings.HasPrefix(existingPath, "~/") {
-			home, _ := os.UserHomeDir()
-			existingPath = filepath.Join(home, existingPath[2:])
-		}
 		return linkSaplingPath(saplingDir, existingPath, homeSaplingLink)
 
 	case "3":
@@ -457,8 +576,9 @@ func setupBuild(dotfilesRoot string) error {
 	return nil
 }
 
-// setupShellGenerate generates shell integration scripts
-func setupShellGenerate() error {
+// setupShellGenerate generates shell integration scripts, for all
+// components or only the given ones
+func setupShellGenerate(components []string) error {
 	fmt.Fprintf(os.Stdout, "Step 2: Generating shell scripts\n")
 
 	// Check for valid sapling repo
@@ -472,9 +592,19 @@ func setupShellGenerate() error {
 
 	cfg := shell.NewConfig(setupVerbose, setupDryRun)
 	manager := shell.NewManager(cfg)
-	shell.RegisterAllComponents(manager)
-
-	result, err := manager.GenerateAll()
+	var result *shell.GenerateResult
+	var err error
+	if len(components) > 0 {
+		// The profile names the components explicitly
+		shell.RegisterComponents(manager, components)
+		if err := applyDependencyOrder(manager); err != nil {
+			return err
+		}
+		result, err = manager.GenerateAll()
+	} else {
+		shell.RegisterAllComponents(manager)
+		result, err = generateAllEnabled(manager, "", nil, nil)
+	}
 	if err != nil {
 		return fmt.Errorf("shell generate failed: %w", err)
 	}
@@ -516,6 +646,12 @@ func setupShellInject() error {
 	switch result.Action {
 	case "injected":
 		fmt.Fprintf(os.Stdout, "  %s Injected into %s\n", output.Success("✓"), result.RCFile)
+	case "updated":
+		fmt.Fprintf(os.Stdout, "  %s Updated stale injection in %s\n", output.Success("✓"), result.RCFile)
+	case "would_update":
+		if setupDryRun {
+			fmt.Fprintf(os.Stdout, "  %s Would update stale injection in %s\n", output.Info("○"), result.RCFile)
+		}
 	case "already_injected":
 		fmt.Fprintf(os.Stdout, "  %s Already injected in %s\n", output.Info("○"), result.RCFile)
 	default:
@@ -579,12 +715,18 @@ func setupSyncLink() error {
 	return nil
 }
 
-// setupComponentSync syncs component-specific configurations
-func setupComponentSync(dotfilesRoot string) error {
+// setupComponentSync syncs component-specific configurations, limited to
+// selected when it is non-empty
+func setupComponentSync(dotfilesRoot string, selected []string) error {
 	fmt.Fprintf(os.Stdout, "Step 5: Syncing component configurations\n")
 
 	// Get list of components with sync_files
 	components := []string{"claude", "git", "karabiner", "python", "r", "ssh", "tmux", "vscode", "wget"} // Add more as needed
+	if len(selected) > 0 {
+		components = slices.DeleteFunc(components, func(c string) bool {
+			return !slices.Contains(selected, c)
+		})
+	}
 
 	syncedCount := 0
 	for _, component := range components {
@@ -636,6 +778,149 @@ func setupComponentSync(dotfilesRoot string) error {
 	return nil
 }
 
+// setupInstallTools installs the profile's tools that are missing
+func setupInstallTools(names []string) error {
+	fmt.Fprintf(os.Stdout, "\nStep 6: Installing tools\n")
+
+	checker := tools.NewChecker()
+	updater := tools.NewUpdater(setupDryRun, setupVerbose)
+	failed := 0
+	for _, name := range names {
+		if status := checker.CheckTool(name); status.Installed {
+			fmt.Fprintf(os.Stdout, "  %s %s already installed\n", output.Info("○"), name)
+			continue
+		}
+
+		if setupDryRun {
+			def, _ := tools.FindTool(name)
+			fmt.Fprintf(os.Stdout, "  %s Would install %s: %s\n", output.Info("○"), name, def.InstallHint)
+			continue
+		}
+
+		if err := updater.InstallTool(name); err != nil {
+			fmt.Fprintf(os.Stdout, "  %s %s: %v\n", output.Error("✗"), name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "  %s Installed %s\n", output.Success("✓"), name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tools failed to install", failed, len(names))
+	}
+	return nil
+}
+
+// applySetupProfile fills setup options the command line left unset from
+// the profile.
+func applySetupProfile(profile *config.SetupProfile) {
+	if setupSaplingRepo == "" && setupSaplingPath == "" {
+		setupSaplingRepo = profile.Sapling.Repo
+		setupSaplingPath = expandHome(profile.Sapling.Path)
+	}
+	if !profile.ShouldBuild() {
+		setupSkipBuild = true
+	}
+}
+
+// validateProfileTools checks that every profile tool is in the registry,
+// since only registry tools have an install method.
+func validateProfileTools(names []string) error {
+	var unknown []string
+	for _, name := range names {
+		if _, found := tools.FindTool(name); !found {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("profile lists unknown tools: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// validateProfileComponents checks that every profile component is either
+// a registered acorn component or has a configuration in .sapling.
+func validateProfileComponents(names []string) error {
+	known := make(map[string]bool)
+	for _, name := range components.Names() {
+		known[name] = true
+	}
+	if configs, err := config.ListComponentConfigs(); err == nil {
+		for _, name := range configs {
+			known[name] = true
+		}
+	}
+
+	var unknown []string
+	for _, name := range names {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("profile lists unknown components: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// expandHome expands a leading ~/ to the user's home directory
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+func runSetupInitProfile(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	if _, err := os.Stat(path); err == nil && !setupInitProfileForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+
+	hostname, _ := os.Hostname()
+	build := true
+	profile := config.SetupProfile{
+		Name:       hostname,
+		Build:      &build,
+		Components: []string{},
+		Tools:      []string{},
+	}
+
+	// Enabled components are those in the shell order with a sapling config
+	for _, name := range shell.GetComponentOrder() {
+		if config.HasComponentConfig(name) {
+			profile.Components = append(profile.Components, name)
+		}
+	}
+
+	if root, err := config.SaplingRoot(); err == nil {
+		if out, err := exec.Command("git", "-C", root, "remote", "get-url", "origin").Output(); err == nil {
+			profile.Sapling.Repo = strings.TrimSpace(string(out))
+		}
+	}
+
+	data, err := yaml.Marshal(&profile)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+	header := "# acorn setup profile\n" +
+		"# Apply with: acorn setup --profile " + path + "\n" +
+		"# tools lists registry tools to install when missing (see: acorn tools list)\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(header), data...), 0o644); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Wrote %s (%d components)\n", output.Success("✓"), path, len(profile.Components))
+	if profile.Sapling.Repo == "" {
+		fmt.Fprintf(os.Stdout, "%s No sapling origin remote found; set sapling.repo or sapling.path\n", output.Warning("!"))
+	}
+	return nil
+}
+
 // getDotfilesRootOrDefault returns dotfiles root or a default
 func getDotfilesRootOrDefault() string {
 	root, err := getDotfilesRoot()
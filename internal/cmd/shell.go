@@ -4,8 +4,11 @@ import (
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/mistergrinvalds/acorn/internal/components/shell"
+	"github.com/mistergrinvalds/acorn/internal/utils/component"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
 	"github.com/spf13/cobra"
@@ -14,6 +17,27 @@ import (
 var (
 	shellDryRun  bool
 	shellVerbose bool
+	shellPrint   bool
+	shellProfile string
+	shellTarget  string
+
+	shellShowContent bool
+	shellContentOf   string
+	shellBackup      bool
+	shellKeepBackups int
+	shellOnlyChanged bool
+	shellEnabledFrom string
+	shellLintCompl   bool
+	shellOnly        []string
+	shellExclude     []string
+
+	shellDiffExitCode bool
+
+	shellEjectAll   bool
+	shellEjectForce bool
+
+	shellFunctionsGrep    string
+	shellFunctionsAliases bool
 )
 
 // shellCmd represents the shell command group
@@ -32,7 +56,12 @@ Examples:
   acorn shell generate    # Generate shell scripts
   acorn shell inject      # Add source line to shell rc
   acorn shell install     # Generate + inject (full setup)
-  acorn shell eject       # Remove from shell rc`,
+  acorn shell eject       # Remove from shell rc
+  acorn shell doctor      # Check integration and completions
+
+The target shell is detected from $SHELL; use --shell to generate or
+inject for another one (for example zsh while running bash, or in CI).`,
+	PersistentPreRunE: shellPersistentPreRun,
 }
 
 // shellStatusCmd shows shell integration status
@@ -62,18 +91,71 @@ var shellGenerateCmd = &cobra.Command{
 If no component is specified, generates all components plus the entrypoint.
 If specific components are specified, only generates those.
 
+Use --only and --exclude (comma-separated) to pick components by name
+instead, e.g. to skip tools not used on this machine. They narrow the
+enabled set described below: --exclude is applied after --only, unknown
+names are an error, and the entrypoint is regenerated without the
+filtered-out components, which are reported as skipped.
+
 Creates files in $XDG_CONFIG_HOME/acorn/:
   - shell.sh: Main entrypoint (sources all component scripts)
   - go.sh: Go development aliases and functions
   - vscode.sh: VS Code aliases and functions
   - tools.sh: Tool management functions
 
+For fish, .fish scripts and a shell.fish entrypoint are generated instead.
+Environment, PATH, aliases and wrappers are translated to fish syntax;
+shell functions are only included when the component config provides a
+fish_functions variant, and the rest are listed in a comment.
+
+Use --backup to copy the current scripts to a timestamped directory under
+$XDG_STATE_HOME/acorn/shell-backups/ before they are overwritten, so a bad
+regeneration can be undone with 'acorn shell restore'.
+
+Use --only-changed to leave scripts whose content is unchanged since the
+last generation untouched (compared by hash against .hashes.json in the
+generated directory), so their mtimes do not change.
+
+When generating everything, only the components listed in an enabled
+file are generated and sourced by the entrypoint; the rest stay registered
+but are skipped. The file lists one component per line (# starts a
+comment) and defaults to ~/.config/acorn/enabled.txt. Without the file,
+all components are generated. Keep the bootstrap components (bootstrap,
+xdg, theme, core) listed, as the others rely on them.
+
+Use --lint-completions to run 'acorn completion <shell>' and parse the
+result with '<shell> -n' before the entrypoint relies on it. If the check
+fails, completions are left out of the entrypoint. Either way, a failing
+completion command cannot break a new shell, as the entrypoint ignores
+its errors.
+
+Use --shell to target bash, zsh or fish regardless of $SHELL. It selects
+the script syntax, the completions the entrypoint loads and the rc file
+used by inject.
+
+Use --profile to generate leaner scripts for servers or CI:
+  full          Environment, aliases, functions and completions (default)
+  minimal       Environment and functions only
+  aliases-only  Aliases only
+
 Examples:
   acorn shell generate              # Generate all
   acorn shell generate go           # Generate only go.sh
   acorn shell generate go vscode    # Generate go.sh and vscode.sh
+  acorn shell generate --only go,python,git
+  acorn shell generate --exclude kubernetes,huggingface
+  acorn shell generate --profile aliases-only  # Aliases only
+  acorn shell generate --shell zsh  # Target zsh whatever $SHELL is
+  acorn shell generate --shell fish # Generate .fish scripts
   acorn shell generate -o json      # Output as JSON (includes file content)
-  acorn shell generate --dry-run    # Show what would be done`,
+  acorn shell generate --dry-run    # Show what would be done
+  acorn shell generate --dry-run --show-content              # Preview all scripts
+  acorn shell generate --dry-run --show-content --component go  # Preview go.sh
+  acorn shell generate --backup     # Snapshot current scripts first
+  acorn shell generate --backup --keep-backups 10
+  acorn shell generate --only-changed  # Only rewrite changed scripts
+  acorn shell generate --components-from ~/.config/acorn/enabled.txt
+  acorn shell generate --lint-completions`,
 	Aliases: []string{"gen"},
 	RunE:    runShellGenerate,
 }
@@ -85,13 +167,22 @@ var shellInjectCmd = &cobra.Command{
 	Long: `Add the acorn shell integration source line to your shell rc file.
 
 Modifies ~/.bashrc or ~/.zshrc (based on detected shell) to source
-the acorn shell.sh entrypoint.
+the acorn shell.sh entrypoint. For fish, ~/.config/fish/config.fish
+sources shell.fish instead.
+
+The injection is idempotent - running multiple times is safe. An
+existing injection block that no longer matches the current configuration
+(e.g. a stale ACORN_CONFIG_DIR after moving your dotfiles) is rewritten in
+place, leaving the rest of the rc file untouched.
 
-The injection is idempotent - running multiple times is safe.
+With --print, the injection block is written to stdout and no file is
+modified, so it can be added to an rc file managed by other tooling.
 
 Examples:
   acorn shell inject
-  acorn shell inject --dry-run`,
+  acorn shell inject --dry-run
+  acorn shell inject --print >> ~/.config/zsh/acorn.zsh
+  acorn shell inject --shell fish`,
 	RunE: runShellInject,
 }
 
@@ -103,9 +194,15 @@ var shellEjectCmd = &cobra.Command{
 
 This removes only the acorn-specific lines, leaving other configuration intact.
 
+With --all, also removes the symlinks in $XDG_CONFIG_HOME/acorn/ that point
+into the generated shell directory. Add --force to delete the generated
+scripts themselves; without it they are kept in the repo.
+
 Examples:
   acorn shell eject
-  acorn shell eject --dry-run`,
+  acorn shell eject --dry-run
+  acorn shell eject --all
+  acorn shell eject --all --force --dry-run`,
 	RunE: runShellEject,
 }
 
@@ -119,6 +216,9 @@ This command:
   1. Generates all shell scripts
   2. Injects the source line into your shell rc
 
+Like generate, only the components in ~/.config/acorn/enabled.txt are
+generated and sourced when that file exists.
+
 Equivalent to running: acorn shell generate && acorn shell inject
 
 Examples:
@@ -145,6 +245,25 @@ Examples:
 	RunE:    runShellUninstall,
 }
 
+// shellRestoreCmd restores generated scripts from a backup
+var shellRestoreCmd = &cobra.Command{
+	Use:   "restore [backup]",
+	Short: "Restore generated scripts from a backup",
+	Long: `Restore the generated shell scripts saved by 'acorn shell generate --backup'.
+
+Without an argument, lists the available backups. Pass a backup name, or
+"latest" for the most recent one, to copy its scripts back into the
+generated shell directory.
+
+Examples:
+  acorn shell restore                   # List backups
+  acorn shell restore latest
+  acorn shell restore 20250101-120000
+  acorn shell restore latest --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runShellRestore,
+}
+
 // shellListCmd lists available components
 var shellListCmd = &cobra.Command{
 	Use:   "list",
@@ -161,6 +280,63 @@ Examples:
 	RunE:    runShellList,
 }
 
+// shellDoctorCmd checks the shell integration
+var shellDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the shell integration for problems",
+	Long: `Check that the shell entrypoint is generated and sourced from your rc
+file, and that 'acorn completion <shell>' produces a script your shell can
+parse, since the entrypoint evaluates it in every new shell.
+
+Examples:
+  acorn shell doctor
+  acorn shell doctor -o json`,
+	RunE: runShellDoctor,
+}
+
+// shellDiffCmd compares generated scripts with the installed ones
+var shellDiffCmd = &cobra.Command{
+	Use:   "diff [component...]",
+	Short: "Show how installed scripts differ from freshly generated ones",
+	Long: `Render each component's script in memory and compare it with the file
+installed in $XDG_CONFIG_HOME/acorn/ (following the symlink), printing a
+unified diff for every stale script and a summary. Nothing is written.
+
+Without component arguments, every enabled component and the entrypoint
+are compared, so the result tells you whether 'acorn shell generate &&
+acorn sync link' would change anything. Use --profile to compare against
+scripts generated with another profile.
+
+With --exit-code, exits 1 when any script is stale. Structured output
+uses the same snake_case keys as the other commands (in_sync, diff_lines).
+
+Examples:
+  acorn shell diff
+  acorn shell diff go git
+  acorn shell diff --exit-code
+  acorn shell diff -o json`,
+	RunE: runShellDiff,
+}
+
+// shellListFunctionsCmd lists the shell functions defined by components
+var shellListFunctionsCmd = &cobra.Command{
+	Use:   "list-functions",
+	Short: "List shell functions from all components",
+	Long: `List the shell functions every component defines, grouped by
+component, with the description from the comment above each function
+(or its first line when there is none).
+
+Functions starting with an underscore are private and not listed.
+
+Examples:
+  acorn shell list-functions
+  acorn shell list-functions --grep venv
+  acorn shell list-functions --include-aliases --grep git
+  acorn shell list-functions -o json`,
+	Aliases: []string{"functions"},
+	RunE:    runShellListFunctions,
+}
+
 func init() {
 
 	// Add subcommands
@@ -171,21 +347,93 @@ func init() {
 	shellCmd.AddCommand(shellInstallCmd)
 	shellCmd.AddCommand(shellUninstallCmd)
 	shellCmd.AddCommand(shellListCmd)
+	shellCmd.AddCommand(shellListFunctionsCmd)
+	shellCmd.AddCommand(shellRestoreCmd)
+	shellCmd.AddCommand(shellDoctorCmd)
+	shellCmd.AddCommand(shellDiffCmd)
 
 	// Persistent flags
 	shellCmd.PersistentFlags().BoolVar(&shellDryRun, "dry-run", false,
 		"Show what would be done without executing")
 	shellCmd.PersistentFlags().BoolVarP(&shellVerbose, "verbose", "v", false,
 		"Show verbose output")
+	shellCmd.PersistentFlags().StringVar(&shellTarget, "shell", "",
+		"Target shell: "+strings.Join(shell.Shells, ", ")+" (default: detected from $SHELL)")
+
+	// Generate flags
+	shellGenerateCmd.Flags().StringVar(&shellProfile, "profile", shell.ProfileFull,
+		"Sections to generate: full, minimal (env + functions), aliases-only")
+	shellGenerateCmd.Flags().BoolVar(&shellShowContent, "show-content", false,
+		"With --dry-run, print the generated scripts instead of writing them")
+	shellGenerateCmd.Flags().StringVar(&shellContentOf, "component", "",
+		"With --show-content, print only this component (or \"entrypoint\")")
+	shellGenerateCmd.Flags().BoolVar(&shellBackup, "backup", false,
+		"Back up the current generated scripts before overwriting them")
+	shellGenerateCmd.Flags().IntVar(&shellKeepBackups, "keep-backups", shell.DefaultKeepBackups,
+		"With --backup, number of backups to keep (0 keeps all)")
+	shellGenerateCmd.Flags().BoolVar(&shellOnlyChanged, "only-changed", false,
+		"Skip rewriting scripts whose content is unchanged")
+	shellGenerateCmd.Flags().StringVar(&shellEnabledFrom, "components-from", "",
+		"File listing the components to generate (default: ~/.config/acorn/enabled.txt)")
+	shellGenerateCmd.Flags().BoolVar(&shellLintCompl, "lint-completions", false,
+		"Check that acorn completion output parses before the entrypoint loads it")
+	shellGenerateCmd.Flags().StringSliceVar(&shellOnly, "only", nil,
+		"Generate only these components (comma-separated)")
+	shellGenerateCmd.Flags().StringSliceVar(&shellExclude, "exclude", nil,
+		"Skip these components (comma-separated, applied after --only)")
+
+	// Diff flags
+	shellDiffCmd.Flags().StringVar(&shellProfile, "profile", shell.ProfileFull,
+		"Profile to render the scripts with: full, minimal, aliases-only")
+	shellDiffCmd.Flags().BoolVar(&shellDiffExitCode, "exit-code", false,
+		"Exit 1 when any script is stale")
+
+	// Inject flags
+	shellInjectCmd.Flags().BoolVar(&shellPrint, "print", false,
+		"Print the injection block instead of modifying the rc file")
+
+	// Eject flags
+	shellEjectCmd.Flags().BoolVar(&shellEjectAll, "all", false,
+		"Also remove symlinks to generated shell scripts")
+	shellEjectCmd.Flags().BoolVar(&shellEjectForce, "force", false,
+		"With --all, also delete the generated shell scripts")
+
+	// List functions flags
+	shellListFunctionsCmd.Flags().StringVar(&shellFunctionsGrep, "grep", "",
+		"Only show entries whose name, component or description contains this text")
+	shellListFunctionsCmd.Flags().BoolVar(&shellFunctionsAliases, "include-aliases", false,
+		"Include aliases")
 }
 
 func getShellManager() *shell.Manager {
 	config := shell.NewConfig(shellVerbose, shellDryRun)
+	if shellTarget != "" {
+		config.Shell = shellTarget
+	}
+	config.Profile = shellProfile
+	config.Backup = shellBackup
+	config.KeepBackups = shellKeepBackups
+	config.OnlyChanged = shellOnlyChanged
+	config.LintCompletions = shellLintCompl
 	manager := shell.NewManager(config)
 	shell.RegisterAllComponents(manager)
 	return manager
 }
 
+// shellPersistentPreRun validates --shell after the root command's setup.
+func shellPersistentPreRun(cmd *cobra.Command, args []string) error {
+	if root := cmd.Root(); root.PersistentPreRunE != nil {
+		if err := root.PersistentPreRunE(cmd, args); err != nil {
+			return err
+		}
+	}
+
+	if shellTarget == "" {
+		return nil
+	}
+	return shell.ValidateShell(shellTarget)
+}
+
 func runShellStatus(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	manager := getShellManager()
@@ -236,6 +484,29 @@ func runShellStatus(cmd *cobra.Command, args []string) error {
 }
 
 func runShellGenerate(cmd *cobra.Command, args []string) error {
+	if err := shell.ValidateProfile(shellProfile); err != nil {
+		return err
+	}
+	if shellShowContent && !shellDryRun {
+		return fmt.Errorf("--show-content requires --dry-run")
+	}
+	if shellContentOf != "" && !shellShowContent {
+		return fmt.Errorf("--component requires --show-content")
+	}
+	if cmd.Flags().Changed("keep-backups") && !shellBackup {
+		return fmt.Errorf("--keep-backups requires --backup")
+	}
+	if shellKeepBackups < 0 {
+		return fmt.Errorf("--keep-backups must not be negative")
+	}
+	filtered := len(shellOnly) > 0 || len(shellExclude) > 0
+	if filtered && len(args) > 0 {
+		return fmt.Errorf("--only and --exclude cannot be combined with component arguments")
+	}
+	if shellLintCompl && len(args) > 0 {
+		return fmt.Errorf("--lint-completions checks the entrypoint, which is only generated without component arguments")
+	}
+
 	ioHelper := ioutils.IO(cmd)
 	manager := getShellManager()
 
@@ -243,8 +514,8 @@ func runShellGenerate(cmd *cobra.Command, args []string) error {
 	var err error
 
 	if len(args) == 0 {
-		// Generate all components + entrypoint
-		result, err = manager.GenerateAll()
+		// Generate all enabled components + entrypoint
+		result, err = generateAllEnabled(manager, shellEnabledFrom, shellOnly, shellExclude)
 	} else {
 		// Generate specific components only
 		result, err = manager.GenerateComponents(args...)
@@ -259,11 +530,15 @@ func runShellGenerate(cmd *cobra.Command, args []string) error {
 		return ioHelper.WriteOutput(result)
 	}
 
+	if shellShowContent {
+		return printGeneratedContent(result, shellContentOf)
+	}
+
 	// Table format - human readable output
 	if shellDryRun {
-		fmt.Fprintln(os.Stdout, "[dry-run] Would generate shell scripts:")
+		fmt.Fprintf(os.Stdout, "[dry-run] Would generate shell scripts (profile: %s):\n", result.Profile)
 	} else {
-		fmt.Fprintln(os.Stdout, "Generated shell scripts:")
+		fmt.Fprintf(os.Stdout, "Generated shell scripts (profile: %s):\n", result.Profile)
 	}
 	fmt.Fprintln(os.Stdout)
 
@@ -272,6 +547,12 @@ func runShellGenerate(cmd *cobra.Command, args []string) error {
 		if shellDryRun {
 			status = output.Warning("○")
 		}
+		if script.Unchanged {
+			if shellVerbose {
+				fmt.Fprintf(os.Stdout, "  %s %s (unchanged)\n", output.Info("-"), script.GeneratedPath)
+			}
+			continue
+		}
 		fmt.Fprintf(os.Stdout, "  %s %s\n", status, script.GeneratedPath)
 		fmt.Fprintf(os.Stdout, "    → symlink to: %s\n", script.SymlinkPath)
 		if shellVerbose {
@@ -279,7 +560,7 @@ func runShellGenerate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if result.Entrypoint != nil {
+	if result.Entrypoint != nil && !result.Entrypoint.Unchanged {
 		status := output.Success("✓")
 		if shellDryRun {
 			status = output.Warning("○")
@@ -306,9 +587,32 @@ func runShellGenerate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(result.Disabled) > 0 {
+		fmt.Fprintf(os.Stdout, "\n%s Skipped %d disabled component(s)", output.Info("-"), len(result.Disabled))
+		if shellVerbose {
+			fmt.Fprintf(os.Stdout, ": %s", strings.Join(result.Disabled, ", "))
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+
+	if result.Backup != nil {
+		printShellBackup(result.Backup)
+	}
+
+	if result.Completions != nil {
+		printCompletionCheck(result.Completions)
+	}
+
 	fmt.Fprintln(os.Stdout)
+	regenerated, unchanged := result.ScriptCounts()
 	if shellDryRun {
+		if shellOnlyChanged {
+			fmt.Fprintf(os.Stdout, "Would regenerate %d script(s), skip %d unchanged\n", regenerated, unchanged)
+		}
 		fmt.Fprintf(os.Stdout, "Use without --dry-run to write files.\n")
+	} else if shellOnlyChanged {
+		fmt.Fprintf(os.Stdout, "%s Regenerated %d script(s), skipped %d unchanged\n",
+			output.Success("✓"), regenerated, unchanged)
 	} else {
 		totalFiles := len(result.Scripts) + len(result.ConfigFiles)
 		fmt.Fprintf(os.Stdout, "%s Generated %d file(s)\n", output.Success("✓"), totalFiles)
@@ -317,10 +621,141 @@ func runShellGenerate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// generateAllEnabled regenerates the enabled components and the
+// entrypoint, as selected by prepareEntrypoint.
+func generateAllEnabled(manager *shell.Manager, enabledFrom string, only, exclude []string) (*shell.GenerateResult, error) {
+	if err := prepareEntrypoint(manager, enabledFrom, only, exclude); err != nil {
+		return nil, err
+	}
+	return manager.GenerateAll()
+}
+
+// prepareEntrypoint selects what GenerateAll writes and the entrypoint
+// sources: the components in the enabled file, narrowed by only and
+// exclude, in dependency order. Every command that regenerates the
+// entrypoint goes through here, so a disabled component never comes back.
+func prepareEntrypoint(manager *shell.Manager, enabledFrom string, only, exclude []string) error {
+	if err := applyEnabledComponents(manager, enabledFrom); err != nil {
+		return err
+	}
+	if len(only) > 0 || len(exclude) > 0 {
+		if err := manager.FilterEnabled(only, exclude); err != nil {
+			return err
+		}
+	}
+	return applyDependencyOrder(manager)
+}
+
+// applyEnabledComponents limits generation to the components listed in
+// enabledFrom (or the default enabled file, if present), warning about
+// names that are not registered components.
+func applyEnabledComponents(manager *shell.Manager, enabledFrom string) error {
+	path := expandHome(enabledFrom)
+	if path == "" {
+		path = filepath.Join(shell.NewConfig(false, false).AcornDir, shell.EnabledFile)
+	}
+
+	names, err := shell.LoadEnabledComponents(path)
+	if err != nil {
+		return err
+	}
+	if names == nil {
+		if enabledFrom != "" {
+			fmt.Fprintf(os.Stderr, "%s %s not found, generating all components\n", output.Warning("!"), path)
+		}
+		return nil
+	}
+
+	for _, name := range manager.SetEnabled(names) {
+		fmt.Fprintf(os.Stderr, "%s Unknown component %q in %s\n", output.Warning("!"), name, path)
+	}
+	return nil
+}
+
+// applyDependencyOrder makes the entrypoint source each component after the
+// components its component.yaml requires, keeping the shell order
+// otherwise. It fails when the requirements form a cycle, as no order
+// would be correct. Without component metadata the shell order is used
+// as is.
+func applyDependencyOrder(manager *shell.Manager) error {
+	dotfilesRoot, err := getDotfilesRoot()
+	if err != nil {
+		return nil
+	}
+	comps, err := component.NewDiscovery(dotfilesRoot).DiscoverAll()
+	if err != nil {
+		return nil
+	}
+
+	byName := make(map[string]*component.Component, len(comps))
+	for _, c := range comps {
+		byName[c.Name] = c
+	}
+	ordered := make([]*component.Component, 0, len(byName))
+	for _, name := range shell.GetComponentOrder() {
+		c, ok := byName[name]
+		if !ok {
+			c = &component.Component{Name: name}
+		}
+		ordered = append(ordered, c)
+	}
+
+	order, err := component.SortByDependencies(ordered)
+	if err != nil {
+		return fmt.Errorf("refusing to generate the shell entrypoint: %w (see: acorn component validate)", err)
+	}
+	manager.SetOrder(order)
+	return nil
+}
+
+// printGeneratedContent prints the content of each generated script, or only
+// the one for component ("entrypoint" selects shell.sh or shell.fish).
+func printGeneratedContent(result *shell.GenerateResult, component string) error {
+	scripts := append([]*shell.GeneratedScript{}, result.Scripts...)
+	if result.Entrypoint != nil {
+		scripts = append(scripts, result.Entrypoint)
+	}
+
+	printed := 0
+	for _, script := range scripts {
+		if component != "" {
+			isEntrypoint := script == result.Entrypoint
+			if (isEntrypoint && component != "entrypoint") || (!isEntrypoint && script.Component != component) {
+				continue
+			}
+		}
+
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info(fmt.Sprintf("[dry-run] %s", script.GeneratedPath)))
+		fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Fprint(os.Stdout, script.Content)
+		if !strings.HasSuffix(script.Content, "\n") {
+			fmt.Fprintln(os.Stdout)
+		}
+		fmt.Fprintln(os.Stdout)
+		printed++
+	}
+
+	if printed == 0 {
+		return fmt.Errorf("component %q was not generated", component)
+	}
+	return nil
+}
+
 func runShellInject(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	manager := getShellManager()
 
+	if shellPrint {
+		result := manager.PrintInjection()
+		// Only emit structured output when asked for explicitly, so that
+		// --print can be redirected straight into a file
+		if ioHelper.IsStructured() && cmd.Flags().Changed("output") {
+			return ioHelper.WriteOutput(result)
+		}
+		fmt.Fprintln(os.Stdout, result.InjectionBlock)
+		return nil
+	}
+
 	result, err := manager.Inject()
 	if err != nil {
 		return err
@@ -335,6 +770,14 @@ func runShellInject(cmd *cobra.Command, args []string) error {
 	switch result.Action {
 	case "already_injected":
 		fmt.Fprintf(os.Stdout, "%s Already injected in %s\n", output.Info("ℹ"), result.RCFile)
+	case "would_update":
+		fmt.Fprintf(os.Stdout, "[dry-run] Would update stale injection in: %s\n", result.RCFile)
+		if shellVerbose {
+			fmt.Fprintf(os.Stdout, "Injection block:\n%s\n", result.InjectionBlock)
+		}
+	case "updated":
+		fmt.Fprintf(os.Stdout, "%s Updated stale injection in %s\n", output.Success("✓"), result.RCFile)
+		fmt.Fprintf(os.Stdout, "Restart your shell or run: source %s\n", result.RCFile)
 	case "would_inject":
 		fmt.Fprintf(os.Stdout, "[dry-run] Would inject into: %s\n", result.RCFile)
 		if shellVerbose {
@@ -348,17 +791,38 @@ func runShellInject(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// EjectResult combines the eject result with removed files for eject --all.
+type EjectResult struct {
+	Eject   *shell.InjectResult `json:"eject" yaml:"eject"`
+	Removed []shell.RemovedFile `json:"removed" yaml:"removed"`
+}
+
 func runShellEject(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	manager := getShellManager()
 
+	if shellEjectForce && !shellEjectAll {
+		return fmt.Errorf("--force requires --all")
+	}
+
 	result, err := manager.Eject()
 	if err != nil {
 		return err
 	}
 
+	var removed []shell.RemovedFile
+	if shellEjectAll {
+		removed, err = manager.Cleanup(shellEjectForce)
+		if err != nil {
+			return err
+		}
+	}
+
 	// JSON/YAML output
 	if ioHelper.IsStructured() {
+		if shellEjectAll {
+			return ioHelper.WriteOutput(&EjectResult{Eject: result, Removed: removed})
+		}
 		return ioHelper.WriteOutput(result)
 	}
 
@@ -372,6 +836,24 @@ func runShellEject(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stdout, "%s Removed acorn from %s\n", output.Success("✓"), result.RCFile)
 	}
 
+	if !shellEjectAll {
+		return nil
+	}
+
+	for _, f := range removed {
+		if f.Removed {
+			fmt.Fprintf(os.Stdout, "%s Removed %s: %s\n", output.Success("✓"), f.Kind, f.Path)
+		} else {
+			fmt.Fprintf(os.Stdout, "[dry-run] Would remove %s: %s\n", f.Kind, f.Path)
+		}
+	}
+	if len(removed) == 0 {
+		fmt.Fprintf(os.Stdout, "%s No generated shell files to remove\n", output.Info("ℹ"))
+	}
+	if !shellEjectForce {
+		fmt.Fprintln(os.Stdout, "\nNote: Generated scripts were kept. Use --all --force to delete them.")
+	}
+
 	return nil
 }
 
@@ -386,7 +868,7 @@ func runShellInstall(cmd *cobra.Command, args []string) error {
 	manager := getShellManager()
 
 	// Generate all
-	genResult, err := manager.GenerateAll()
+	genResult, err := generateAllEnabled(manager, "", nil, nil)
 	if err != nil {
 		return err
 	}
@@ -452,11 +934,15 @@ func runShellInstall(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stdout, "%s Already injected in %s\n", output.Info("ℹ"), injectResult.RCFile)
 	case "would_inject":
 		fmt.Fprintf(os.Stdout, "[dry-run] Would inject into: %s\n", injectResult.RCFile)
+	case "would_update":
+		fmt.Fprintf(os.Stdout, "[dry-run] Would update stale injection in: %s\n", injectResult.RCFile)
 	case "injected":
 		fmt.Fprintf(os.Stdout, "%s Injected into %s\n", output.Success("✓"), injectResult.RCFile)
+	case "updated":
+		fmt.Fprintf(os.Stdout, "%s Updated stale injection in %s\n", output.Success("✓"), injectResult.RCFile)
 	}
 
-	if !shellDryRun && injectResult.Action == "injected" {
+	if !shellDryRun && (injectResult.Action == "injected" || injectResult.Action == "updated") {
 		fmt.Fprintf(os.Stdout, "\nRestart your shell or run: source %s\n", injectResult.RCFile)
 	}
 
@@ -536,9 +1022,244 @@ func runShellList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runShellListFunctions(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	manager := getShellManager()
+
+	fns := []shell.ShellFunction{}
+	for _, fn := range manager.ListFunctions(shellFunctionsAliases) {
+		if shellFunctionsGrep == "" || fn.Matches(shellFunctionsGrep) {
+			fns = append(fns, fn)
+		}
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(fns)
+	}
+
+	if len(fns) == 0 {
+		if shellFunctionsGrep != "" {
+			fmt.Fprintf(os.Stdout, "No functions matching %q\n", shellFunctionsGrep)
+		} else {
+			fmt.Fprintln(os.Stdout, "No functions defined")
+		}
+		return nil
+	}
+
+	components := 0
+	for i := 0; i < len(fns); {
+		component := fns[i].Component
+		table := output.NewTable("NAME", "DESCRIPTION")
+		if shellFunctionsAliases {
+			table = output.NewTable("NAME", "KIND", "DESCRIPTION")
+		}
+		for ; i < len(fns) && fns[i].Component == component; i++ {
+			desc := fns[i].Description
+			if len(desc) > 70 {
+				desc = desc[:67] + "..."
+			}
+			if shellFunctionsAliases {
+				table.AddRow(fns[i].Name, fns[i].Kind, desc)
+			} else {
+				table.AddRow(fns[i].Name, desc)
+			}
+		}
+
+		if components > 0 {
+			fmt.Fprintln(os.Stdout)
+		}
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info(component))
+		table.Render(os.Stdout)
+		components++
+	}
+
+	fmt.Fprintf(os.Stdout, "\nTotal: %d across %d components\n", len(fns), components)
+	return nil
+}
+
 func init() {
 	components.Register(&components.Registration{
 		Name: "shell",
 		RegisterCmd: func() *cobra.Command { return shellCmd },
 	})
 }
+
+// printShellBackup reports the backup taken before generation.
+func printShellBackup(backup *shell.BackupResult) {
+	fmt.Fprintln(os.Stdout)
+	if backup.Dir == "" {
+		fmt.Fprintln(os.Stdout, "No existing scripts to back up.")
+		return
+	}
+
+	verb := "Backed up"
+	if shellDryRun {
+		verb = "[dry-run] Would back up"
+	}
+	fmt.Fprintf(os.Stdout, "%s %d script(s) to %s\n", verb, len(backup.Files), backup.Dir)
+	for _, dir := range backup.Pruned {
+		if shellDryRun {
+			fmt.Fprintf(os.Stdout, "  [dry-run] Would prune %s\n", dir)
+		} else {
+			fmt.Fprintf(os.Stdout, "  Pruned %s\n", dir)
+		}
+	}
+}
+
+func runShellRestore(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+
+	if len(args) == 0 {
+		backups, err := shell.ListBackups()
+		if err != nil {
+			return err
+		}
+		if backups == nil {
+			backups = []string{}
+		}
+
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(map[string]interface{}{
+				"dir":     shell.BackupRoot(),
+				"backups": backups,
+			})
+		}
+
+		if len(backups) == 0 {
+			fmt.Fprintf(os.Stdout, "No backups in %s\n", shell.BackupRoot())
+			fmt.Fprintln(os.Stdout, "Create one with: acorn shell generate --backup")
+			return nil
+		}
+		fmt.Fprintf(os.Stdout, "%s\n\n", output.Info("Shell Script Backups"))
+		for i := len(backups) - 1; i >= 0; i-- {
+			fmt.Fprintf(os.Stdout, "  %s\n", backups[i])
+		}
+		fmt.Fprintf(os.Stdout, "\nLocation: %s\n", shell.BackupRoot())
+		return nil
+	}
+
+	result, err := getShellManager().RestoreBackup(args[0])
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	if result.DryRun {
+		fmt.Fprintf(os.Stdout, "[dry-run] Would restore %d script(s) from %s:\n", len(result.Files), result.Backup)
+	} else {
+		fmt.Fprintf(os.Stdout, "Restored %d script(s) from %s:\n", len(result.Files), result.Backup)
+	}
+	for _, f := range result.Files {
+		status := output.Success("✓")
+		if result.DryRun {
+			status = output.Warning("○")
+		}
+		fmt.Fprintf(os.Stdout, "  %s %s\n", status, f)
+	}
+	fmt.Fprintf(os.Stdout, "\nTarget: %s\n", result.Target)
+
+	return nil
+}
+
+// printCompletionCheck reports whether completions validated.
+func printCompletionCheck(c *shell.CompletionCheck) {
+	fmt.Fprintln(os.Stdout)
+	switch {
+	case !c.Valid:
+		fmt.Fprintf(os.Stdout, "%s Completions failed validation: %s\n", output.Error("✗"), c.Error)
+		fmt.Fprintf(os.Stdout, "    Completions were left out of the entrypoint\n")
+	case !c.SyntaxChecked:
+		fmt.Fprintf(os.Stdout, "%s Completions generated, but %s is not installed to parse them\n", output.Warning("!"), c.Shell)
+	default:
+		fmt.Fprintf(os.Stdout, "%s Completions validated (%s -n)\n", output.Success("✓"), c.Shell)
+	}
+}
+
+func runShellDoctor(cmd *cobra.Command, args []string) error {
+	manager := getShellManager()
+	report := manager.Doctor()
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stdout, "%s\n", output.Info("Shell Doctor"))
+		fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+		for _, c := range report.Checks {
+			symbol := output.Success("✓")
+			switch c.Status {
+			case component.CheckWarn:
+				symbol = output.Warning("!")
+			case component.CheckFail:
+				symbol = output.Error("✗")
+			}
+			fmt.Fprintf(os.Stdout, "%s %s: %s\n", symbol, c.Name, c.Message)
+			if c.Hint != "" && c.Status != component.CheckPass {
+				fmt.Fprintf(os.Stdout, "    %s\n", c.Hint)
+			}
+		}
+
+		fmt.Fprintf(os.Stdout, "\n%d passed, %d warnings, %d failed\n", report.Passed, report.Warnings, report.Failed)
+	}
+
+	if report.Failed > 0 {
+		return &exitCodeError{code: 1}
+	}
+	return nil
+}
+
+func runShellDiff(cmd *cobra.Command, args []string) error {
+	if err := shell.ValidateProfile(shellProfile); err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	manager := getShellManager()
+	if len(args) == 0 {
+		if err := prepareEntrypoint(manager, shellEnabledFrom, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	report, err := manager.Diff(args...)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(report); err != nil {
+			return err
+		}
+	} else {
+		for _, d := range report.Scripts {
+			switch {
+			case d.InSync:
+				fmt.Fprintf(os.Stdout, "%s %s\n", output.Success("✓"), d.Component)
+			case d.Missing:
+				fmt.Fprintf(os.Stdout, "%s %s: not installed at %s\n", output.Warning("!"), d.Component, d.Installed)
+			default:
+				fmt.Fprintf(os.Stdout, "%s %s: %d line(s) differ\n", output.Warning("!"), d.Component, d.DiffLines)
+				fmt.Fprintln(os.Stdout, strings.TrimRight(d.Diff, "\n"))
+			}
+		}
+
+		fmt.Fprintln(os.Stdout)
+		if report.Stale == 0 {
+			fmt.Fprintln(os.Stdout, output.Success(report.Summary()))
+		} else {
+			fmt.Fprintln(os.Stdout, output.Warning(report.Summary()))
+			fmt.Fprintln(os.Stdout, "Run: acorn shell generate && acorn sync link")
+		}
+	}
+
+	if shellDiffExitCode && report.Stale > 0 {
+		return &exitCodeError{code: 1}
+	}
+	return nil
+}
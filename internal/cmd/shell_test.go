@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestShellInstallHonorsEnabled checks that install, like generate, leaves
+// components missing from enabled.txt out of the entrypoint.
+func TestShellInstallHonorsEnabled(t *testing.T) {
+	root := t.TempDir()
+	configHome := filepath.Join(root, "config")
+	saplingDir := filepath.Join(root, "dotfiles", ".sapling")
+	for _, dir := range []string{filepath.Join(configHome, "acorn"), filepath.Join(saplingDir, "config")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("SAPLING_DIR", saplingDir)
+	t.Setenv("DOTFILES_ROOT", filepath.Join(root, "dotfiles"))
+
+	enabled := "# only these\ngit\ntmux\n"
+	if err := os.WriteFile(filepath.Join(configHome, "acorn", "enabled.txt"), []byte(enabled), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	oldTarget := shellTarget
+	shellTarget = "bash"
+	t.Cleanup(func() { shellTarget = oldTarget })
+
+	if err := runShellInstall(shellInstallCmd, nil); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(saplingDir, "generated", "shell", "shell.sh"))
+	if err != nil {
+		t.Fatalf("entrypoint not written: %v", err)
+	}
+	entrypoint := string(data)
+	for _, name := range []string{"git", "tmux"} {
+		if !strings.Contains(entrypoint, "/"+name+".sh") {
+			t.Errorf("entrypoint should source %s.sh:\n%s", name, entrypoint)
+		}
+	}
+	if strings.Contains(entrypoint, "/kubernetes.sh") {
+		t.Errorf("entrypoint sources a disabled component:\n%s", entrypoint)
+	}
+}
@@ -1,20 +1,33 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/utils/configfile"
+	"github.com/mistergrinvalds/acorn/internal/utils/gitrepo"
+	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
 	"github.com/mistergrinvalds/acorn/internal/components/shell"
 	"github.com/spf13/cobra"
 )
 
 var (
-	syncQuiet bool
+	syncQuiet       bool
+	syncAuditExport string
+	syncSyncFirst   bool
+	syncDriftFetch  bool
+	syncExitCode    bool
+	syncPorcelain   bool
+	syncStatusFetch bool
+
+	syncStrategy        string
+	syncAbortOnConflict bool
 )
 
 // syncCmd represents the sync command group
@@ -54,7 +67,26 @@ Shows:
   - Current branch
   - Commits ahead/behind remote
   - Modified and untracked files
-  - Symlink status for config files`,
+  - Symlink status for config files
+
+With -o json or yaml the same report as 'acorn sync audit' is written.
+
+--porcelain prints a single line of key=value pairs for prompts and
+scripts. Its keys and their order are stable across versions, and values
+are shell-quoted when needed, so the line can be eval'd or split with cut:
+
+  branch=main ahead=2 behind=0 dirty=3 links_ok=12 links_broken=1
+
+branch is HEAD when detached, dirty counts entries in git status, and
+links_broken counts config symlinks that are missing or do not point
+into the generated directory. Use --fetch=false to skip contacting the
+remote, which keeps prompts fast.
+
+Examples:
+  acorn sync status
+  acorn sync status -o json
+  acorn sync status --porcelain --fetch=false
+  eval "$(acorn sync status --porcelain --fetch=false)"`,
 	Aliases: []string{"st"},
 	RunE:    runSyncStatus,
 }
@@ -65,7 +97,20 @@ var syncPullCmd = &cobra.Command{
 	Short: "Pull latest changes from remote",
 	Long: `Pull the latest changes from the remote repository.
 
-Uses git pull --rebase to maintain a clean history.`,
+--strategy chooses how remote changes are integrated:
+  rebase   - replay local commits on top of the remote (default)
+  merge    - create a merge commit when histories have diverged
+  ff-only  - only fast-forward; fail if local commits would need integrating
+
+If the pull stops on a conflict, the interrupted rebase or merge is
+detected and the commands to finish or back out are printed. With
+--abort-on-conflict it is backed out automatically instead, leaving the
+repository as it was before the pull.
+
+Examples:
+  acorn sync pull
+  acorn sync pull --strategy ff-only
+  acorn sync pull --strategy merge --abort-on-conflict`,
 	RunE: runSyncPull,
 }
 
@@ -77,9 +122,14 @@ var syncPushCmd = &cobra.Command{
 
 If no message is provided, a default message with timestamp is used.
 
+By default, remote changes are pulled first (git pull --rebase --autostash)
+so the push is not rejected. A conflicting pull is aborted and your commit
+is kept locally. Use --sync-first=false to push without pulling.
+
 Examples:
   acorn sync push
-  acorn sync push "Update tmux config"`,
+  acorn sync push "Update tmux config"
+  acorn sync push --sync-first=false`,
 	RunE: runSyncPush,
 }
 
@@ -90,7 +140,23 @@ var syncDriftCmd = &cobra.Command{
 	Long: `Quickly check if local and remote are in sync.
 
 Shows commits ahead/behind without detailed file changes.
-Use --quiet for use in shell startup scripts.`,
+Use --quiet for use in shell startup scripts.
+
+With --exit-code the exit status reports the drift, so scripts and prompt
+segments can branch on it:
+  0  in sync
+  1  error (e.g. not a git repository)
+  2  ahead of remote only
+  3  behind remote only
+  4  both ahead and behind (diverged)
+
+Use --fetch=false to compare against the last fetched state without
+contacting the remote.
+
+Examples:
+  acorn sync drift
+  acorn sync drift --quiet
+  acorn sync drift --quiet --exit-code --fetch=false`,
 	RunE: runSyncDrift,
 }
 
@@ -104,7 +170,16 @@ Shows detailed information about:
   - Repository status
   - All modified files
   - Untracked files
-  - Symlink health`,
+  - Symlink health
+
+With --export, the full audit is written to a versioned JSON document
+(YAML for .yaml/.yml files) for comparing machines or attaching to bug
+reports. The same document is printed with -o json.
+
+Examples:
+  acorn sync audit
+  acorn sync audit --export audit-$(hostname).json
+  acorn sync audit -o json`,
 	RunE: runSyncAudit,
 }
 
@@ -145,7 +220,13 @@ var syncUpdateCmd = &cobra.Command{
 Equivalent to running:
   acorn sync pull
   acorn shell generate
-  source $ACORN_CONFIG_DIR/shell.sh (in your current shell)`,
+  source $ACORN_CONFIG_DIR/shell.sh (in your current shell)
+
+--strategy and --abort-on-conflict apply to the pull as for 'acorn sync pull'.
+
+Examples:
+  acorn sync update
+  acorn sync update --strategy ff-only`,
 	RunE: runSyncUpdate,
 }
 
@@ -163,7 +244,17 @@ func init() {
 	syncCmd.AddCommand(syncUpdateCmd)
 
 	// Flags
+	syncStatusCmd.Flags().BoolVar(&syncPorcelain, "porcelain", false, "Print a stable key=value line for scripts and prompts")
+	syncStatusCmd.Flags().BoolVar(&syncStatusFetch, "fetch", true, "Fetch from the remote before comparing")
 	syncDriftCmd.Flags().BoolVarP(&syncQuiet, "quiet", "q", false, "Minimal output (for shell startup)")
+	syncDriftCmd.Flags().BoolVar(&syncDriftFetch, "fetch", true, "Fetch from the remote before comparing")
+	syncDriftCmd.Flags().BoolVar(&syncExitCode, "exit-code", false, "Exit 2 when ahead, 3 when behind, 4 when both")
+	syncAuditCmd.Flags().StringVar(&syncAuditExport, "export", "", "Write the audit report to a file")
+	syncPushCmd.Flags().BoolVar(&syncSyncFirst, "sync-first", true, "Pull remote changes (rebase with autostash) before pushing")
+	for _, c := range []*cobra.Command{syncPullCmd, syncUpdateCmd} {
+		c.Flags().StringVar(&syncStrategy, "strategy", gitrepo.StrategyRebase, "How to integrate remote changes (rebase|merge|ff-only)")
+		c.Flags().BoolVar(&syncAbortOnConflict, "abort-on-conflict", false, "Back out a pull that stops on conflicts")
+	}
 }
 
 // getSyncRoot returns the .sapling repository root for sync operations
@@ -203,12 +294,25 @@ func syncGitCmd(args ...string) *exec.Cmd {
 
 // runSyncStatus shows repository status
 func runSyncStatus(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
 	root := getSyncRoot()
 
 	if !isSyncGitRepo(root) {
 		return fmt.Errorf("not a git repository: %s", root)
 	}
 
+	if syncPorcelain || ioHelper.IsStructured() {
+		audit, err := collectSyncAudit(root, syncStatusFetch)
+		if err != nil {
+			return err
+		}
+		if syncPorcelain {
+			fmt.Fprintln(os.Stdout, formatSyncPorcelain(audit))
+			return nil
+		}
+		return ioHelper.WriteOutput(audit)
+	}
+
 	fmt.Fprintf(os.Stdout, "%s Dotfiles Status\n", output.Info("ℹ"))
 	fmt.Fprintf(os.Stdout, "  Repository: %s\n", root)
 
@@ -219,7 +323,12 @@ func runSyncStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Commits ahead/behind
-	ahead, behind := getCommitCounts()
+	var ahead, behind int
+	if syncStatusFetch {
+		ahead, behind = getCommitCounts()
+	} else {
+		ahead, behind = countCommits()
+	}
 	if ahead > 0 || behind > 0 {
 		fmt.Fprintf(os.Stdout, "  Remote:     %d ahead, %d behind\n", ahead, behind)
 	} else {
@@ -244,7 +353,11 @@ func getCommitCounts() (ahead, behind int) {
 	// Fetch first (silently)
 	syncGitCmd("fetch", "-q").Run()
 
-	// Get counts
+	return countCommits()
+}
+
+// countCommits returns commits ahead and behind the last fetched upstream
+func countCommits() (ahead, behind int) {
 	out, err := syncGitCmd("rev-list", "--left-right", "--count", "@{u}...HEAD").Output()
 	if err != nil {
 		return 0, 0
@@ -258,6 +371,11 @@ func getCommitCounts() (ahead, behind int) {
 	return
 }
 
+// syncRepo returns the dotfiles repository
+func syncRepo() gitrepo.Repo {
+	return gitrepo.Repo{Dir: getSyncRoot()}
+}
+
 // runSyncPull pulls latest changes
 func runSyncPull(cmd *cobra.Command, args []string) error {
 	root := getSyncRoot()
@@ -266,19 +384,63 @@ func runSyncPull(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not a git repository: %s", root)
 	}
 
-	fmt.Fprintf(os.Stdout, "%s Pulling latest changes...\n", output.Info("→"))
+	repo := gitrepo.Repo{Dir: root}
+	if _, err := gitrepo.PullArgs(syncStrategy); err != nil {
+		return err
+	}
+	if op := repo.InterruptedOp(); op != "" {
+		return fmt.Errorf("a %s is already in progress in %s; finish it with git %s --continue or back out with git %s --abort",
+			op, root, op, op)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Pulling latest changes (strategy: %s)...\n", output.Info("→"), syncStrategy)
 
-	pullCmd := syncGitCmd("pull", "--rebase")
-	pullCmd.Stdout = os.Stdout
-	pullCmd.Stderr = os.Stderr
-	if err := pullCmd.Run(); err != nil {
-		return fmt.Errorf("git pull failed: %w", err)
+	before := repo.Head()
+	if err := repo.Pull(syncStrategy, os.Stdout, os.Stderr); err != nil {
+		return syncPullFailed(repo, err)
 	}
 
-	fmt.Fprintf(os.Stdout, "%s Pull complete\n", output.Success("✓"))
+	pulled := repo.RevCount(before + "..HEAD")
+	if pulled == 0 {
+		fmt.Fprintf(os.Stdout, "%s Already up to date (%s)\n", output.Success("✓"), syncStrategy)
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "%s Pull complete (%s): %d new commit(s)\n", output.Success("✓"), syncStrategy, pulled)
 	return nil
 }
 
+// syncPullFailed explains a failed pull. A pull stopped on conflicts is
+// backed out with --abort-on-conflict, otherwise the way to finish or
+// abort it is printed. Other failures are returned as they are.
+func syncPullFailed(repo gitrepo.Repo, pullErr error) error {
+	root := repo.Dir
+	op := repo.InterruptedOp()
+	if op == "" {
+		return pullErr
+	}
+
+	if syncAbortOnConflict {
+		if err := repo.Command(op, "--abort").Run(); err != nil {
+			return fmt.Errorf("pull stopped on conflicts and git %s --abort failed: %w", op, err)
+		}
+		fmt.Fprintf(os.Stdout, "%s Pull had conflicts; the %s was aborted\n", output.Error("✗"), op)
+		return fmt.Errorf("remote changes conflict with local commits; repository left as before the pull")
+	}
+
+	fmt.Fprintf(os.Stdout, "\n%s Pull stopped on conflicts; the %s is in progress\n", output.Error("✗"), op)
+	fmt.Fprintln(os.Stdout, "  To finish:")
+	fmt.Fprintf(os.Stdout, "    1. Fix the conflicted files (git -C %s status)\n", root)
+	fmt.Fprintf(os.Stdout, "    2. git -C %s add <files>\n", root)
+	if op == "rebase" {
+		fmt.Fprintf(os.Stdout, "    3. git -C %s rebase --continue\n", root)
+	} else {
+		fmt.Fprintf(os.Stdout, "    3. git -C %s commit --no-edit\n", root)
+	}
+	fmt.Fprintln(os.Stdout, "  To back out:")
+	fmt.Fprintf(os.Stdout, "    git -C %s %s --abort\n", root, op)
+	return fmt.Errorf("git pull stopped on conflicts (%s in progress): %w", op, pullErr)
+}
+
 // runSyncPush commits and pushes changes
 func runSyncPush(cmd *cobra.Command, args []string) error {
 	root := getSyncRoot()
@@ -287,33 +449,41 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not a git repository: %s", root)
 	}
 
-	// Check for changes
+	// Check for changes; commits left by an earlier rejected push still go out
 	statusOut, _ := syncGitCmd("status", "--porcelain").Output()
-	if len(statusOut) == 0 {
+	if len(statusOut) == 0 && syncRepo().RevCount("@{u}..HEAD") == 0 {
 		fmt.Fprintf(os.Stdout, "%s No changes to commit\n", output.Info("ℹ"))
 		return nil
 	}
 
-	// Determine commit message
-	message := "Update dotfiles"
-	if len(args) > 0 {
-		message = args[0]
-	}
+	if len(statusOut) > 0 {
+		// Determine commit message
+		message := "Update dotfiles"
+		if len(args) > 0 {
+			message = args[0]
+		}
+
+		fmt.Fprintf(os.Stdout, "%s Committing changes...\n", output.Info("→"))
 
-	fmt.Fprintf(os.Stdout, "%s Committing changes...\n", output.Info("→"))
+		// Add all changes
+		addCmd := syncGitCmd("add", "-A")
+		if err := addCmd.Run(); err != nil {
+			return fmt.Errorf("git add failed: %w", err)
+		}
 
-	// Add all changes
-	addCmd := syncGitCmd("add", "-A")
-	if err := addCmd.Run(); err != nil {
-		return fmt.Errorf("git add failed: %w", err)
+		// Commit
+		commitCmd := syncGitCmd("commit", "-m", message)
+		commitCmd.Stdout = os.Stdout
+		commitCmd.Stderr = os.Stderr
+		if err := commitCmd.Run(); err != nil {
+			return fmt.Errorf("git commit failed: %w", err)
+		}
 	}
 
-	// Commit
-	commitCmd := syncGitCmd("commit", "-m", message)
-	commitCmd.Stdout = os.Stdout
-	commitCmd.Stderr = os.Stderr
-	if err := commitCmd.Run(); err != nil {
-		return fmt.Errorf("git commit failed: %w", err)
+	if syncSyncFirst {
+		if err := syncPullBeforePush(root); err != nil {
+			return err
+		}
 	}
 
 	fmt.Fprintf(os.Stdout, "%s Pushing to remote...\n", output.Info("→"))
@@ -330,18 +500,50 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// syncPullBeforePush pulls remote commits (rebase with autostash) so the
+// following push is not rejected. A conflicting rebase is aborted.
+func syncPullBeforePush(root string) error {
+	behind, err := gitrepo.Repo{Dir: root}.PullBeforePush(os.Stdout, os.Stderr)
+	if errors.Is(err, gitrepo.ErrConflict) {
+		fmt.Fprintf(os.Stdout, "%s Pull had conflicts and was aborted\n", output.Error("✗"))
+		return fmt.Errorf("your commit is kept locally: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+	if behind == 0 {
+		fmt.Fprintf(os.Stdout, "%s Remote had no new changes\n", output.Info("ℹ"))
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Pulled %d remote commit(s), rebased cleanly\n", output.Success("✓"), behind)
+	return nil
+}
+
 // runSyncDrift checks for drift
 func runSyncDrift(cmd *cobra.Command, args []string) error {
 	root := getSyncRoot()
 
 	if !isSyncGitRepo(root) {
+		if syncExitCode {
+			msg := fmt.Sprintf("not a git repository: %s", root)
+			if syncQuiet {
+				msg = ""
+			}
+			return &exitCodeError{code: 1, msg: msg}
+		}
 		if !syncQuiet {
 			fmt.Fprintf(os.Stderr, "not a git repository: %s\n", root)
 		}
 		return nil // Don't error for quiet mode
 	}
 
-	ahead, behind := getCommitCounts()
+	var ahead, behind int
+	if syncDriftFetch {
+		ahead, behind = getCommitCounts()
+	} else {
+		ahead, behind = countCommits()
+	}
 
 	if syncQuiet {
 		// Minimal output for shell startup
@@ -361,94 +563,274 @@ func runSyncDrift(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if syncExitCode {
+		if code := driftExitCode(ahead, behind); code != 0 {
+			return &exitCodeError{code: code}
+		}
+	}
 	return nil
 }
 
+// driftExitCode maps ahead/behind counts to the --exit-code status.
+func driftExitCode(ahead, behind int) int {
+	switch {
+	case ahead > 0 && behind > 0:
+		return 4
+	case behind > 0:
+		return 3
+	case ahead > 0:
+		return 2
+	}
+	return 0
+}
+
+// syncAuditSchemaVersion is bumped whenever the exported audit format changes
+// incompatibly.
+const syncAuditSchemaVersion = 1
+
+// SyncAudit is the exported state of a dotfiles repository.
+type SyncAudit struct {
+	SchemaVersion int             `json:"schema_version" yaml:"schema_version"`
+	GeneratedAt   string          `json:"generated_at" yaml:"generated_at"`
+	Hostname      string          `json:"hostname" yaml:"hostname"`
+	Repository    SyncAuditRepo   `json:"repository" yaml:"repository"`
+	Remote        SyncAuditRemote `json:"remote" yaml:"remote"`
+	Changes       []SyncChange    `json:"changes" yaml:"changes"`
+	Symlinks      SymlinkReport   `json:"symlinks" yaml:"symlinks"`
+}
+
+// SyncAuditRepo identifies the audited repository.
+type SyncAuditRepo struct {
+	Path   string `json:"path" yaml:"path"`
+	Branch string `json:"branch" yaml:"branch"`
+}
+
+// SyncAuditRemote is the repository's position relative to its upstream.
+type SyncAuditRemote struct {
+	Ahead  int  `json:"ahead" yaml:"ahead"`
+	Behind int  `json:"behind" yaml:"behind"`
+	InSync bool `json:"in_sync" yaml:"in_sync"`
+}
+
+// SyncChange is a single entry from git status --porcelain.
+type SyncChange struct {
+	Status string `json:"status" yaml:"status"`
+	Path   string `json:"path" yaml:"path"`
+}
+
+// SymlinkReport describes the health of config symlinks.
+type SymlinkReport struct {
+	GeneratedDir    string          `json:"generated_dir" yaml:"generated_dir"`
+	GeneratedExists bool            `json:"generated_exists" yaml:"generated_exists"`
+	Links           []SymlinkStatus `json:"links" yaml:"links"`
+}
+
+// SymlinkStatus is the state of one XDG config symlink.
+type SymlinkStatus struct {
+	Target string `json:"target" yaml:"target"`
+	Source string `json:"source" yaml:"source"`
+	Status string `json:"status" yaml:"status"` // linked, not_linked, wrong_target, regular_file
+	Actual string `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
 // runSyncAudit performs full audit
 func runSyncAudit(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
 	root := getSyncRoot()
 
 	if !isSyncGitRepo(root) {
 		return fmt.Errorf("not a git repository: %s", root)
 	}
 
+	audit, err := collectSyncAudit(root, true)
+	if err != nil {
+		return err
+	}
+
+	if syncAuditExport != "" {
+		if err := exportSyncAudit(audit, syncAuditExport); err != nil {
+			return err
+		}
+		if !ioHelper.IsStructured() {
+			fmt.Fprintf(os.Stdout, "%s Audit exported to %s\n", output.Success("✓"), syncAuditExport)
+			return nil
+		}
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(audit)
+	}
+
+	printSyncAudit(audit)
+	return nil
+}
+
+// collectSyncAudit gathers repository, remote, change and symlink state,
+// fetching from the remote first when fetch is set.
+func collectSyncAudit(root string, fetch bool) (*SyncAudit, error) {
+	hostname, _ := os.Hostname()
+	audit := &SyncAudit{
+		SchemaVersion: syncAuditSchemaVersion,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Hostname:      hostname,
+		Repository:    SyncAuditRepo{Path: root},
+		Changes:       []SyncChange{},
+	}
+
+	if out, err := syncGitCmd("branch", "--show-current").Output(); err == nil {
+		audit.Repository.Branch = strings.TrimSpace(string(out))
+	}
+
+	var ahead, behind int
+	if fetch {
+		ahead, behind = getCommitCounts()
+	} else {
+		ahead, behind = countCommits()
+	}
+	audit.Remote = SyncAuditRemote{Ahead: ahead, Behind: behind, InSync: ahead == 0 && behind == 0}
+
+	statusOut, _ := syncGitCmd("status", "--porcelain").Output()
+	for _, line := range strings.Split(string(statusOut), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		audit.Changes = append(audit.Changes, SyncChange{
+			Status: strings.TrimSpace(line[:2]),
+			Path:   line[3:],
+		})
+	}
+
+	symlinks, err := checkSymlinks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check symlinks: %w", err)
+	}
+	audit.Symlinks = *symlinks
+
+	return audit, nil
+}
+
+// formatSyncPorcelain renders the --porcelain status line. The keys and
+// their order are part of the output's stability guarantee: only append.
+func formatSyncPorcelain(audit *SyncAudit) string {
+	branch := audit.Repository.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+
+	linksOK := 0
+	for _, link := range audit.Symlinks.Links {
+		if link.Status == "linked" {
+			linksOK++
+		}
+	}
+
+	fields := []string{
+		"branch=" + shellQuote(branch),
+		fmt.Sprintf("ahead=%d", audit.Remote.Ahead),
+		fmt.Sprintf("behind=%d", audit.Remote.Behind),
+		fmt.Sprintf("dirty=%d", len(audit.Changes)),
+		fmt.Sprintf("links_ok=%d", linksOK),
+		fmt.Sprintf("links_broken=%d", len(audit.Symlinks.Links)-linksOK),
+	}
+	return strings.Join(fields, " ")
+}
+
+// shellQuote single-quotes s unless it only holds characters that are safe
+// unquoted in a POSIX shell.
+func shellQuote(s string) string {
+	safe := s != ""
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("._/@+-", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// exportSyncAudit writes the audit to path as YAML for .yaml/.yml files and
+// JSON otherwise.
+func exportSyncAudit(audit *SyncAudit, path string) error {
+	if err := ioutils.WriteFile(path, audit); err != nil {
+		return fmt.Errorf("failed to write audit: %w", err)
+	}
+	return nil
+}
+
+// printSyncAudit renders the human-readable audit report.
+func printSyncAudit(audit *SyncAudit) {
 	fmt.Fprintf(os.Stdout, "%s Dotfiles Audit\n", output.Info("ℹ"))
 	fmt.Fprintf(os.Stdout, "═══════════════════════════════════════════════════\n")
 
 	// Repository info
 	fmt.Fprintln(os.Stdout)
 	fmt.Fprintln(os.Stdout, "Repository:")
-	fmt.Fprintf(os.Stdout, "  Path:   %s\n", root)
-
-	out, err := syncGitCmd("branch", "--show-current").Output()
-	if err == nil {
-		fmt.Fprintf(os.Stdout, "  Branch: %s\n", strings.TrimSpace(string(out)))
+	fmt.Fprintf(os.Stdout, "  Path:   %s\n", audit.Repository.Path)
+	if audit.Repository.Branch != "" {
+		fmt.Fprintf(os.Stdout, "  Branch: %s\n", audit.Repository.Branch)
 	}
 
 	// Remote status
-	ahead, behind := getCommitCounts()
 	fmt.Fprintln(os.Stdout)
 	fmt.Fprintln(os.Stdout, "Remote Status:")
-	if ahead == 0 && behind == 0 {
+	if audit.Remote.InSync {
 		fmt.Fprintf(os.Stdout, "  %s In sync with remote\n", output.Success("✓"))
 	} else {
-		if ahead > 0 {
-			fmt.Fprintf(os.Stdout, "  %s %d commit(s) to push\n", output.Warning("→"), ahead)
+		if audit.Remote.Ahead > 0 {
+			fmt.Fprintf(os.Stdout, "  %s %d commit(s) to push\n", output.Warning("→"), audit.Remote.Ahead)
 		}
-		if behind > 0 {
-			fmt.Fprintf(os.Stdout, "  %s %d commit(s) to pull\n", output.Warning("←"), behind)
+		if audit.Remote.Behind > 0 {
+			fmt.Fprintf(os.Stdout, "  %s %d commit(s) to pull\n", output.Warning("←"), audit.Remote.Behind)
 		}
 	}
 
 	// Changed files
-	statusOut, _ := syncGitCmd("status", "--porcelain").Output()
-	if len(statusOut) > 0 {
-		fmt.Fprintln(os.Stdout)
+	fmt.Fprintln(os.Stdout)
+	if len(audit.Changes) > 0 {
 		fmt.Fprintln(os.Stdout, "Changes:")
-		lines := strings.Split(string(statusOut), "\n")
-		for _, line := range lines {
-			if line != "" {
-				fmt.Fprintf(os.Stdout, "  %s\n", line)
-			}
+		for _, c := range audit.Changes {
+			fmt.Fprintf(os.Stdout, "  %2s %s\n", c.Status, c.Path)
 		}
 	} else {
-		fmt.Fprintln(os.Stdout)
 		fmt.Fprintf(os.Stdout, "  %s Working tree clean\n", output.Success("✓"))
 	}
 
 	// Symlink status
 	fmt.Fprintln(os.Stdout)
 	fmt.Fprintln(os.Stdout, "Config Symlinks:")
-	if err := checkSymlinks(); err != nil {
-		fmt.Fprintf(os.Stdout, "  %s Error checking symlinks: %v\n", output.Error("✗"), err)
-	}
-
-	return nil
+	printSymlinks(&audit.Symlinks)
 }
 
 // checkSymlinks verifies symlink status
-func checkSymlinks() error {
+func checkSymlinks() (*SymlinkReport, error) {
 	generatedDir := getGeneratedDir()
+	report := &SymlinkReport{GeneratedDir: generatedDir, Links: []SymlinkStatus{}}
 
 	// Check if generated directory exists
 	if _, err := os.Stat(generatedDir); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stdout, "  %s Generated directory not found: %s\n", output.Warning("!"), generatedDir)
-		fmt.Fprintf(os.Stdout, "    Run 'acorn shell generate' to create config files\n")
-		return nil
+		return report, nil
+	}
+	report.GeneratedExists = true
+
+	// Determine XDG config root
+	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
+	if xdgConfig == "" {
+		home, _ := os.UserHomeDir()
+		xdgConfig = filepath.Join(home, ".config")
 	}
 
 	// Walk through generated directory
-	found := false
 	err := filepath.Walk(generatedDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip errors
 		}
-		if info.IsDir() {
+		if info.IsDir() || info.Name() == shell.HashesFile {
 			return nil
 		}
 
-		found = true
-
 		// Get relative path from generated dir
 		relPath, _ := filepath.Rel(generatedDir, path)
 		parts := strings.Split(relPath, string(filepath.Separator))
@@ -459,13 +841,6 @@ func checkSymlinks() error {
 		component := parts[0]
 		filename := parts[len(parts)-1]
 
-		// Determine XDG target
-		xdgConfig := os.Getenv("XDG_CONFIG_HOME")
-		if xdgConfig == "" {
-			home, _ := os.UserHomeDir()
-			xdgConfig = filepath.Join(home, ".config")
-		}
-
 		// Special case: shell scripts go to acorn/ directory, not shell/
 		targetComponent := component
 		if component == "shell" {
@@ -474,28 +849,54 @@ func checkSymlinks() error {
 		target := filepath.Join(xdgConfig, targetComponent, filename)
 
 		// Check symlink
+		link := SymlinkStatus{Target: target, Source: path}
 		linkInfo, err := os.Lstat(target)
 		if os.IsNotExist(err) {
-			fmt.Fprintf(os.Stdout, "  %s %s → not linked\n", output.Warning("○"), target)
+			link.Status = "not_linked"
 		} else if linkInfo.Mode()&os.ModeSymlink != 0 {
 			linkDest, _ := os.Readlink(target)
 			if linkDest == path {
-				fmt.Fprintf(os.Stdout, "  %s %s → %s\n", output.Success("✓"), target, path)
+				link.Status = "linked"
 			} else {
-				fmt.Fprintf(os.Stdout, "  %s %s → %s (wrong target)\n", output.Warning("!"), target, linkDest)
+				link.Status = "wrong_target"
+				link.Actual = linkDest
 			}
 		} else {
-			fmt.Fprintf(os.Stdout, "  %s %s (regular file, not symlink)\n", output.Warning("!"), target)
+			link.Status = "regular_file"
 		}
+		report.Links = append(report.Links, link)
 
 		return nil
 	})
 
-	if !found {
+	return report, err
+}
+
+// printSymlinks renders a symlink report.
+func printSymlinks(report *SymlinkReport) {
+	if !report.GeneratedExists {
+		fmt.Fprintf(os.Stdout, "  %s Generated directory not found: %s\n", output.Warning("!"), report.GeneratedDir)
+		fmt.Fprintf(os.Stdout, "    Run 'acorn shell generate' to create config files\n")
+		return
+	}
+
+	if len(report.Links) == 0 {
 		fmt.Fprintf(os.Stdout, "  %s No generated config files found\n", output.Info("ℹ"))
+		return
 	}
 
-	return err
+	for _, link := range report.Links {
+		switch link.Status {
+		case "linked":
+			fmt.Fprintf(os.Stdout, "  %s %s → %s\n", output.Success("✓"), link.Target, link.Source)
+		case "not_linked":
+			fmt.Fprintf(os.Stdout, "  %s %s → not linked\n", output.Warning("○"), link.Target)
+		case "wrong_target":
+			fmt.Fprintf(os.Stdout, "  %s %s → %s (wrong target)\n", output.Warning("!"), link.Target, link.Actual)
+		default:
+			fmt.Fprintf(os.Stdout, "  %s %s (regular file, not symlink)\n", output.Warning("!"), link.Target)
+		}
+	}
 }
 
 // runSyncLink creates symlinks
@@ -515,7 +916,7 @@ func runSyncLink(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return nil
 		}
-		if info.IsDir() {
+		if info.IsDir() || info.Name() == shell.HashesFile {
 			return nil
 		}
 
@@ -601,7 +1002,7 @@ func runSyncUnlink(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return nil
 		}
-		if info.IsDir() {
+		if info.IsDir() || info.Name() == shell.HashesFile {
 			return nil
 		}
 
@@ -688,8 +1089,7 @@ func runSyncUpdate(cmd *cobra.Command, args []string) error {
 	config := shell.NewConfig(false, false)
 	manager := shell.NewManager(config)
 	shell.RegisterAllComponents(manager)
-
-	result, err := manager.GenerateAll()
+	result, err := generateAllEnabled(manager, "", nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to generate shell config: %w", err)
 	}
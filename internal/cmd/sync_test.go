@@ -0,0 +1,56 @@
+package cmd
+
+import "testing"
+
+// TestFormatSyncPorcelain pins the exact --porcelain lines. Scripts and
+// prompts parse this output, so a change here breaks them: keys may only
+// be appended.
+func TestFormatSyncPorcelain(t *testing.T) {
+	links := func(statuses ...string) SymlinkReport {
+		var r SymlinkReport
+		for _, s := range statuses {
+			r.Links = append(r.Links, SymlinkStatus{Status: s})
+		}
+		return r
+	}
+
+	tests := []struct {
+		name  string
+		audit SyncAudit
+		want  string
+	}{
+		{
+			name:  "clean",
+			audit: SyncAudit{Repository: SyncAuditRepo{Branch: "main"}},
+			want:  "branch=main ahead=0 behind=0 dirty=0 links_ok=0 links_broken=0",
+		},
+		{
+			name: "ahead, behind and dirty",
+			audit: SyncAudit{
+				Repository: SyncAuditRepo{Branch: "feature/x-1"},
+				Remote:     SyncAuditRemote{Ahead: 2, Behind: 1},
+				Changes:    []SyncChange{{"M", "a"}, {"??", "b"}, {"D", "c"}},
+				Symlinks:   links("linked", "linked", "not_linked", "wrong_target", "regular_file"),
+			},
+			want: "branch=feature/x-1 ahead=2 behind=1 dirty=3 links_ok=2 links_broken=3",
+		},
+		{
+			name:  "detached",
+			audit: SyncAudit{Symlinks: links("linked")},
+			want:  "branch=HEAD ahead=0 behind=0 dirty=0 links_ok=1 links_broken=0",
+		},
+		{
+			name:  "quoted branch",
+			audit: SyncAudit{Repository: SyncAuditRepo{Branch: "it's $x"}},
+			want:  `branch='it'\''s $x' ahead=0 behind=0 dirty=0 links_ok=0 links_broken=0`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSyncPorcelain(&tt.audit); got != tt.want {
+				t.Errorf("formatSyncPorcelain() =\n  %s\nwant\n  %s", got, tt.want)
+			}
+		})
+	}
+}
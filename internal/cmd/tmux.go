@@ -4,6 +4,8 @@ import (
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	tmuxpkg "github.com/mistergrinvalds/acorn/internal/components/tmux"
 	"github.com/mistergrinvalds/acorn/internal/utils/configcmd"
@@ -14,10 +16,29 @@ import (
 )
 
 var (
-	tmuxDryRun  bool
-	tmuxVerbose bool
+	tmuxDryRun   bool
+	tmuxVerbose  bool
+	tmuxSmugRepo string
+	tmuxSmugYes  bool
+
+	tmuxSessionFile string
+	tmuxSessionName string
+
+	tmuxKillAll   bool
+	tmuxKillForce bool
+	tmuxKillYes   bool
+
+	tmuxWithPlugins bool
+
+	tmuxSmugFromSession    string
+	tmuxSmugSyncFirst      bool
+	tmuxSmugValidateOnSave bool
 )
 
+// currentSessionFlag is the --from-session value meaning "the current
+// session". tmux does not allow '.' in session names.
+const currentSessionFlag = "."
+
 // tmuxCmd represents the tmux command group
 var tmuxCmd = &cobra.Command{
 	Use:   "tmux",
@@ -67,6 +88,64 @@ Examples:
 	RunE:    runTmuxSessionList,
 }
 
+// tmuxSessionSaveCmd snapshots a live session
+var tmuxSessionSaveCmd = &cobra.Command{
+	Use:   "save [name]",
+	Short: "Save a live session to a JSON snapshot",
+	Long: `Capture a session's windows, pane layouts, working directories and
+running commands into a JSON file for later restore.
+
+Defaults to the current session when run inside tmux. Snapshots are written
+to $XDG_DATA_HOME/acorn/tmux/sessions/<name>.json unless --file is given.
+
+Examples:
+  acorn tmux session save
+  acorn tmux session save work
+  acorn tmux session save work --file ~/work-session.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTmuxSessionSave,
+}
+
+// tmuxSessionRestoreCmd recreates a saved session
+var tmuxSessionRestoreCmd = &cobra.Command{
+	Use:   "restore <file|name>",
+	Short: "Restore a session from a JSON snapshot",
+	Long: `Recreate a saved session detached, including windows, pane layouts,
+working directories and running commands.
+
+The argument is a snapshot file path, or the name of a snapshot saved in
+the default directory. Panes whose directory no longer exists start in
+your home directory.
+
+Examples:
+  acorn tmux session restore work
+  acorn tmux session restore ~/work-session.json
+  acorn tmux session restore work --name work-2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTmuxSessionRestore,
+}
+
+// tmuxSessionKillCmd kills sessions by pattern
+var tmuxSessionKillCmd = &cobra.Command{
+	Use:   "kill [pattern]",
+	Short: "Kill sessions matching a pattern",
+	Long: `Kill the tmux sessions whose names match a pattern, or every session
+with --all.
+
+A pattern containing *, ? or [ is a glob matched against the whole session
+name; any other pattern matches as a substring. Killing more than one
+session asks for confirmation unless --yes is given. The session you are
+attached to is left alone unless --force is given.
+
+Examples:
+  acorn tmux session kill scratch
+  acorn tmux session kill 'test-*'
+  acorn tmux session kill 'test-*' --dry-run
+  acorn tmux session kill --all --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTmuxSessionKill,
+}
+
 // tmuxTPMCmd is the parent for TPM subcommands
 var tmuxTPMCmd = &cobra.Command{
 	Use:   "tpm",
@@ -156,16 +235,41 @@ Examples:
 
 // tmuxSmugNewCmd creates a new smug session
 var tmuxSmugNewCmd = &cobra.Command{
-	Use:   "new <name>",
+	Use:   "new <name> [session]",
 	Short: "Create a new smug session config",
 	Long: `Create a new smug session configuration from template.
 
+With --from-session, the config is generated from a live tmux session
+instead: its windows, layouts, working directories and running commands
+are captured so an ad-hoc layout can be started again with smug. Without
+a session name, the current session is used.
+
 Examples:
-  acorn tmux smug new myproject`,
-	Args: cobra.ExactArgs(1),
+  acorn tmux smug new myproject
+  acorn tmux smug new myproject --from-session
+  acorn tmux smug new myproject --from-session work`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runTmuxSmugNew,
 }
 
+// tmuxSmugEditCmd edits a smug session config
+var tmuxSmugEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit a smug session config with $EDITOR",
+	Long: `Open a smug session config in your default editor.
+
+The config is snapshotted first and validated after the editor exits, so
+a broken config is caught now instead of at smug start. If it is invalid
+you can reopen the editor to fix it, or revert to the snapshot.
+Use --validate-on-save=false to only open the editor.
+
+Examples:
+  acorn tmux smug edit myproject
+  acorn tmux smug edit myproject --validate-on-save=false`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTmuxSmugEdit,
+}
+
 // tmuxSmugInstallCmd installs smug
 var tmuxSmugInstallCmd = &cobra.Command{
 	Use:   "install",
@@ -198,8 +302,15 @@ var tmuxSmugRepoInitCmd = &cobra.Command{
 
 This enables cross-machine session portability.
 
+Use --repo to clone your own sessions repository instead of the default.
+The URL is saved and used by later repo-init runs; pull, push, and sync
+use the repository's origin remote. If the repo already exists with a
+different origin, you will be asked whether to re-point it.
+
 Examples:
-  acorn tmux smug repo-init`,
+  acorn tmux smug repo-init
+  acorn tmux smug repo-init --repo git@github.com:me/smug-sessions.git
+  acorn tmux smug repo-init --repo https://github.com/me/sessions.git --yes`,
 	RunE: runTmuxSmugRepoInit,
 }
 
@@ -232,9 +343,15 @@ var tmuxSmugPushCmd = &cobra.Command{
 	Short: "Commit and push session changes",
 	Long: `Commit local changes and push to the remote repository.
 
+By default, changes pushed from another machine are pulled first
+(git pull --rebase --autostash) so the push is not rejected. If the pull
+conflicts it is aborted and your commit is kept locally.
+Use --sync-first=false to push without pulling.
+
 Examples:
   acorn tmux smug push
-  acorn tmux smug push "Add new project session"`,
+  acorn tmux smug push "Add new project session"
+  acorn tmux smug push --sync-first=false`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runTmuxSmugPush,
 }
@@ -259,10 +376,14 @@ var tmuxInstallCmd = &cobra.Command{
 Installs tmux, smug (session manager), and fzf (fuzzy finder).
 Uses brew on macOS and apt on Linux.
 
+With --with-plugins, TPM is installed (if missing) and the plugins declared
+in tmux.conf are installed non-interactively, replacing prefix + I.
+
 Examples:
-  acorn tmux install           # Install all tmux tools
-  acorn tmux install --dry-run # Show what would be installed
-  acorn tmux install -v        # Verbose output`,
+  acorn tmux install                # Install all tmux tools
+  acorn tmux install --with-plugins # Also install TPM and tmux.conf plugins
+  acorn tmux install --dry-run      # Show what would be installed
+  acorn tmux install -v             # Verbose output`,
 	RunE: runTmuxInstall,
 }
 
@@ -280,6 +401,9 @@ func init() {
 
 	// Session subcommands
 	tmuxSessionCmd.AddCommand(tmuxSessionListCmd)
+	tmuxSessionCmd.AddCommand(tmuxSessionSaveCmd)
+	tmuxSessionCmd.AddCommand(tmuxSessionRestoreCmd)
+	tmuxSessionCmd.AddCommand(tmuxSessionKillCmd)
 
 	// TPM subcommands
 	tmuxTPMCmd.AddCommand(tmuxTPMInstallCmd)
@@ -290,6 +414,7 @@ func init() {
 	// Smug subcommands
 	tmuxSmugCmd.AddCommand(tmuxSmugListCmd)
 	tmuxSmugCmd.AddCommand(tmuxSmugNewCmd)
+	tmuxSmugCmd.AddCommand(tmuxSmugEditCmd)
 	tmuxSmugCmd.AddCommand(tmuxSmugInstallCmd)
 	tmuxSmugCmd.AddCommand(tmuxSmugLinkCmd)
 	tmuxSmugCmd.AddCommand(tmuxSmugRepoInitCmd)
@@ -303,6 +428,43 @@ func init() {
 		"Show what would be done without executing")
 	tmuxCmd.PersistentFlags().BoolVarP(&tmuxVerbose, "verbose", "v", false,
 		"Show verbose output")
+
+	// Smug repo-init flags
+	tmuxSmugRepoInitCmd.Flags().StringVar(&tmuxSmugRepo, "repo", "",
+		"Smug sessions repository URL (saved for future use)")
+	tmuxSmugRepoInitCmd.Flags().BoolVarP(&tmuxSmugYes, "yes", "y", false,
+		"Re-point an existing repo's remote without asking")
+
+	// Session save/restore flags
+	tmuxSessionSaveCmd.Flags().StringVar(&tmuxSessionFile, "file", "",
+		"Snapshot file to write (default: $XDG_DATA_HOME/acorn/tmux/sessions/<name>.json)")
+	tmuxSessionRestoreCmd.Flags().StringVar(&tmuxSessionName, "name", "",
+		"Session name to restore as (default: the saved name)")
+
+	// Session kill flags
+	tmuxSessionKillCmd.Flags().BoolVar(&tmuxKillAll, "all", false,
+		"Kill every session")
+	tmuxSessionKillCmd.Flags().BoolVar(&tmuxKillForce, "force", false,
+		"Also kill the session you are attached to")
+	tmuxSessionKillCmd.Flags().BoolVarP(&tmuxKillYes, "yes", "y", false,
+		"Kill multiple sessions without asking")
+
+	// Smug new flags
+	tmuxSmugNewCmd.Flags().StringVar(&tmuxSmugFromSession, "from-session", "",
+		"Generate the config from a live tmux session (default: current session)")
+	tmuxSmugNewCmd.Flags().Lookup("from-session").NoOptDefVal = currentSessionFlag
+
+	// Smug edit flags
+	tmuxSmugEditCmd.Flags().BoolVar(&tmuxSmugValidateOnSave, "validate-on-save", true,
+		"Validate the config after editing and offer to fix or revert it")
+
+	// Smug push flags
+	tmuxSmugPushCmd.Flags().BoolVar(&tmuxSmugSyncFirst, "sync-first", true,
+		"Pull remote changes (rebase with autostash) before pushing")
+
+	// Install flags
+	tmuxInstallCmd.Flags().BoolVar(&tmuxWithPlugins, "with-plugins", false,
+		"Also install TPM and the plugins declared in tmux.conf")
 }
 
 func runTmuxInfo(cmd *cobra.Command, args []string) error {
@@ -374,6 +536,167 @@ func runTmuxSessionList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTmuxSessionKill(cmd *cobra.Command, args []string) error {
+	if tmuxKillAll == (len(args) > 0) {
+		return fmt.Errorf("specify a pattern or --all")
+	}
+	pattern := ""
+	if len(args) > 0 {
+		pattern = args[0]
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
+	if !helper.HasTmux() {
+		return fmt.Errorf("tmux is not installed")
+	}
+
+	sessions, err := helper.ListSessions()
+	if err != nil {
+		return err
+	}
+	current, _ := helper.CurrentSession()
+
+	result, err := tmuxpkg.SelectSessionsToKill(sessions, pattern, tmuxKillAll, current, tmuxKillForce)
+	if err != nil {
+		return err
+	}
+	result.DryRun = tmuxDryRun
+
+	if !ioHelper.IsStructured() {
+		for _, name := range result.Skipped {
+			fmt.Fprintf(os.Stdout, "%s Skipping %s (current session, use --force to kill it)\n", output.Warning("!"), name)
+		}
+		if len(result.Matched) == 0 {
+			fmt.Fprintln(os.Stdout, "No matching sessions")
+			return nil
+		}
+	}
+
+	if tmuxDryRun {
+		if ioHelper.IsStructured() {
+			return ioHelper.WriteOutput(result)
+		}
+		fmt.Fprintf(os.Stdout, "[dry-run] Would kill %d session(s):\n", len(result.Matched))
+		for _, name := range result.Matched {
+			fmt.Fprintf(os.Stdout, "  %s\n", name)
+		}
+		return nil
+	}
+
+	if len(result.Matched) > 1 && !tmuxKillYes {
+		if ioHelper.IsStructured() {
+			return fmt.Errorf("use --yes to kill %d sessions with structured output", len(result.Matched))
+		}
+		fmt.Fprintf(os.Stdout, "Sessions to kill: %s\n", strings.Join(result.Matched, ", "))
+		ok, err := confirm(fmt.Sprintf("Kill %d sessions?", len(result.Matched)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stdout, "Aborted.")
+			return nil
+		}
+	}
+
+	for _, name := range result.Matched {
+		if err := helper.KillSession(name); err != nil {
+			if !ioHelper.IsStructured() {
+				fmt.Fprintf(os.Stdout, "%s %s: %v\n", output.Error("✗"), name, err)
+			}
+			continue
+		}
+		result.Killed = append(result.Killed, name)
+		if !ioHelper.IsStructured() {
+			fmt.Fprintf(os.Stdout, "%s Killed %s\n", output.Success("✓"), name)
+		}
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+	if len(result.Killed) < len(result.Matched) {
+		return fmt.Errorf("killed %d of %d sessions", len(result.Killed), len(result.Matched))
+	}
+	return nil
+}
+
+func runTmuxSessionSave(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	} else {
+		current, err := helper.CurrentSession()
+		if err != nil {
+			return err
+		}
+		name = current
+	}
+
+	snapshot, err := helper.CaptureSession(name)
+	if err != nil {
+		return err
+	}
+
+	path := tmuxSessionFile
+	if path == "" {
+		path = filepath.Join(tmuxpkg.GetSessionSnapshotDir(), name+".json")
+	}
+	if err := helper.SaveSessionSnapshot(snapshot, path); err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(map[string]interface{}{"file": path, "session": snapshot})
+	}
+
+	panes := 0
+	for _, w := range snapshot.Windows {
+		panes += len(w.Panes)
+	}
+	if !tmuxDryRun {
+		fmt.Fprintf(os.Stdout, "%s Saved session '%s' (%d windows, %d panes)\n", output.Success("✓"), name, len(snapshot.Windows), panes)
+		fmt.Fprintf(os.Stdout, "  File: %s\n", path)
+	}
+	return nil
+}
+
+func runTmuxSessionRestore(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
+
+	path := args[0]
+	if _, err := os.Stat(path); os.IsNotExist(err) && !strings.ContainsRune(path, os.PathSeparator) {
+		path = filepath.Join(tmuxpkg.GetSessionSnapshotDir(), strings.TrimSuffix(path, ".json")+".json")
+	}
+
+	snapshot, err := tmuxpkg.LoadSessionSnapshot(path)
+	if err != nil {
+		return err
+	}
+
+	result, err := helper.RestoreSession(snapshot, tmuxSessionName)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stdout, "%s %s\n", output.Warning("!"), w)
+	}
+	if !tmuxDryRun {
+		fmt.Fprintf(os.Stdout, "%s Restored session '%s' (%d windows, %d panes)\n", output.Success("✓"), result.Session, result.Windows, result.Panes)
+		fmt.Fprintf(os.Stdout, "  Attach with: tmux attach -t %s\n", result.Session)
+	}
+	return nil
+}
+
 func runTmuxTPMInstall(cmd *cobra.Command, args []string) error {
 	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
 
@@ -471,7 +794,30 @@ func runTmuxSmugList(cmd *cobra.Command, args []string) error {
 func runTmuxSmugNew(cmd *cobra.Command, args []string) error {
 	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
 
-	configFile, err := helper.CreateSmugSession(args[0])
+	var configFile string
+	var err error
+	if cmd.Flags().Changed("from-session") {
+		// --from-session takes an optional value, so "--from-session work"
+		// leaves the session name as a second argument
+		session := tmuxSmugFromSession
+		if len(args) == 2 {
+			if session != currentSessionFlag {
+				return fmt.Errorf("session given twice: %s and %s", session, args[1])
+			}
+			session = args[1]
+		}
+		if session == currentSessionFlag {
+			if session, err = helper.CurrentSession(); err != nil {
+				return err
+			}
+		}
+		configFile, err = helper.CreateSmugSessionFromTmux(args[0], session)
+	} else {
+		if len(args) == 2 {
+			return fmt.Errorf("a session argument requires --from-session")
+		}
+		configFile, err = helper.CreateSmugSession(args[0])
+	}
 	if err != nil {
 		return err
 	}
@@ -483,6 +829,64 @@ func runTmuxSmugNew(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runTmuxSmugEdit(cmd *cobra.Command, args []string) error {
+	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
+	if !tmuxSmugValidateOnSave {
+		return helper.EditSmugConfig(args[0])
+	}
+
+	result, err := helper.EditSmugConfigValidated(args[0])
+	if err != nil {
+		return err
+	}
+
+	ioHelper := ioutils.IO(cmd)
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(result)
+	}
+
+	for !result.Valid {
+		fmt.Fprintf(os.Stdout, "%s %s\n", output.Error("✗"), result.Error)
+		reopen, err := confirm("Reopen the editor to fix it?")
+		if err != nil {
+			return err
+		}
+		if !reopen {
+			break
+		}
+		if err := helper.ReopenSmugEdit(result); err != nil {
+			return err
+		}
+	}
+
+	if !result.Valid {
+		if !result.Changed {
+			return fmt.Errorf("%s is not a valid smug config", result.Path)
+		}
+		revert, err := confirm("Revert to the version before editing?")
+		if err != nil {
+			return err
+		}
+		if !revert {
+			return fmt.Errorf("%s is not a valid smug config", result.Path)
+		}
+		if err := helper.RevertSmugEdit(result); err != nil {
+			return err
+		}
+		if result.Reverted {
+			fmt.Fprintf(os.Stdout, "%s Reverted %s\n", output.Success("✓"), result.Path)
+		}
+		return nil
+	}
+
+	if !result.Changed {
+		fmt.Fprintf(os.Stdout, "%s No changes to %s\n", output.Info("ℹ"), result.Path)
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "%s Saved valid smug config %s\n", output.Success("✓"), result.Path)
+	return nil
+}
+
 func runTmuxSmugInstall(cmd *cobra.Command, args []string) error {
 	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
 
@@ -513,10 +917,45 @@ func runTmuxSmugLink(cmd *cobra.Command, args []string) error {
 func runTmuxSmugRepoInit(cmd *cobra.Command, args []string) error {
 	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
 
-	if err := helper.SmugRepoInit(); err != nil {
+	if tmuxSmugRepo != "" {
+		if err := tmuxpkg.ValidateRepoURL(tmuxSmugRepo); err != nil {
+			return err
+		}
+
+		// Existing repo pointing elsewhere: re-point or abort
+		if remote, err := helper.GetSmugRepoRemote(); err == nil && remote != tmuxSmugRepo {
+			fmt.Fprintf(os.Stdout, "%s Smug repo at %s uses a different remote:\n",
+				output.Warning("!"), tmuxpkg.GetSmugRepoDir())
+			fmt.Fprintf(os.Stdout, "  current: %s\n", remote)
+			fmt.Fprintf(os.Stdout, "  new:     %s\n", tmuxSmugRepo)
+
+			ok := tmuxSmugYes || tmuxDryRun
+			if !ok {
+				if ok, err = confirm("Re-point origin to the new URL?"); err != nil {
+					return err
+				}
+			}
+			if !ok {
+				fmt.Fprintln(os.Stdout, "Aborted.")
+				return nil
+			}
+
+			if err := helper.SetSmugRepoRemote(tmuxSmugRepo); err != nil {
+				return fmt.Errorf("failed to update remote: %w", err)
+			}
+		}
+	}
+
+	if err := helper.SmugRepoInit(tmuxSmugRepo); err != nil {
 		return err
 	}
 
+	if tmuxSmugRepo != "" {
+		if err := helper.SaveSmugRepo(tmuxSmugRepo); err != nil {
+			return fmt.Errorf("failed to save repo URL: %w", err)
+		}
+	}
+
 	fmt.Fprintf(os.Stdout, "\n%s Smug repo initialized!\n", output.Success("✓"))
 	fmt.Fprintf(os.Stdout, "Location: %s\n", tmuxpkg.GetSmugRepoDir())
 	fmt.Fprintln(os.Stdout, "\nCommands:")
@@ -584,10 +1023,24 @@ func runTmuxSmugPush(cmd *cobra.Command, args []string) error {
 		message = args[0]
 	}
 
-	if err := helper.SmugRepoPush(message); err != nil {
+	result, err := helper.SmugRepoPush(message, tmuxSmugSyncFirst)
+	if result != nil && result.Pulled {
+		if result.Clean {
+			fmt.Fprintf(os.Stdout, "%s Pulled %d remote commit(s), rebased cleanly\n", output.Success("✓"), result.Commits)
+		} else {
+			fmt.Fprintf(os.Stdout, "%s Pulled %d remote commit(s) with conflicts\n", output.Error("✗"), result.Commits)
+		}
+	}
+	if err != nil {
 		return err
 	}
 
+	if tmuxDryRun {
+		return nil
+	}
+	if tmuxSmugSyncFirst && !result.Pulled {
+		fmt.Fprintf(os.Stdout, "%s Remote had no new changes\n", output.Info("ℹ"))
+	}
 	fmt.Fprintf(os.Stdout, "%s Sessions pushed!\n", output.Success("✓"))
 	return nil
 }
@@ -631,7 +1084,7 @@ func runTmuxInstall(cmd *cobra.Command, args []string) error {
 	pending := plan.PendingTools()
 	if len(pending) == 0 {
 		fmt.Fprintf(os.Stdout, "%s All tools already installed\n", output.Success("✓"))
-		return nil
+		return runTmuxInstallPlugins()
 	}
 
 	// Show prerequisites
@@ -662,6 +1115,9 @@ func runTmuxInstall(cmd *cobra.Command, args []string) error {
 	}
 
 	if tmuxDryRun {
+		if err := runTmuxInstallPlugins(); err != nil {
+			return err
+		}
 		fmt.Fprintln(os.Stdout, "\nRun without --dry-run to install.")
 		return nil
 	}
@@ -680,8 +1136,62 @@ func runTmuxInstall(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Fprintf(os.Stdout, "%s Installation failed (%d installed, %d skipped, %d failed)\n",
 			output.Error("✗"), installed, skipped, failed)
+		return nil
+	}
+
+	return runTmuxInstallPlugins()
+}
+
+// runTmuxInstallPlugins installs TPM and the tmux.conf plugins when
+// --with-plugins is set.
+func runTmuxInstallPlugins() error {
+	if !tmuxWithPlugins {
+		return nil
 	}
 
+	helper := tmuxpkg.NewHelper(tmuxVerbose, tmuxDryRun)
+	fmt.Fprintln(os.Stdout)
+
+	if helper.IsTPMInstalled() {
+		fmt.Fprintf(os.Stdout, "%s TPM already installed\n", output.Success("✓"))
+	} else {
+		if err := helper.InstallTPM(); err != nil {
+			return err
+		}
+		if !tmuxDryRun {
+			fmt.Fprintf(os.Stdout, "%s TPM installed\n", output.Success("✓"))
+		}
+	}
+
+	result, err := helper.InstallDeclaredPlugins()
+	if result != nil && len(result.Declared) == 0 {
+		fmt.Fprintf(os.Stdout, "%s No plugins declared in %s\n", output.Info("ℹ"), result.ConfigFile)
+		return nil
+	}
+	failed := 0
+	if result != nil {
+		for _, p := range result.Plugins {
+			switch p.Status {
+			case "installed":
+				fmt.Fprintf(os.Stdout, "  %s %s\n", output.Success("✓"), p.Plugin)
+			case "already_installed":
+				fmt.Fprintf(os.Stdout, "  %s %s (already installed)\n", output.Success("✓"), p.Plugin)
+			default:
+				fmt.Fprintf(os.Stdout, "  %s %s (download failed)\n", output.Error("✗"), p.Plugin)
+				failed++
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d plugin(s) failed to install", failed)
+	}
+
+	if !tmuxDryRun {
+		fmt.Fprintf(os.Stdout, "%s Plugins installed (%d declared)\n", output.Success("✓"), len(result.Declared))
+	}
 	return nil
 }
 
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
+	"time"
 
+	"github.com/mistergrinvalds/acorn/internal/utils/component"
 	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
 	"github.com/mistergrinvalds/acorn/internal/utils/output"
 	"github.com/mistergrinvalds/acorn/internal/utils/tools"
@@ -14,6 +18,11 @@ import (
 var (
 	toolsDryRun  bool
 	toolsVerbose bool
+
+	toolsExportMarkdown string
+	toolsGroupBy        string
+	toolsPrereqsFor     []string
+	toolsFailOnMissing  []string
 )
 
 // toolsCmd represents the tools command group
@@ -43,10 +52,19 @@ var toolsStatusCmd = &cobra.Command{
 Shows each tool grouped by category (System, Languages, Cloud, Development)
 with installation status and version information.
 
+With --fail-on-missing, the full status is still printed, and the command
+then exits 1 if any of the listed tools is not installed, naming the
+missing ones. Tools outside the tracked registry may be listed too.
+
 Examples:
   acorn tools status
+  acorn tools status --fail-on-missing go,git,docker
   acorn tools status -o json
-  acorn tools status -o yaml`,
+  acorn tools status -o yaml
+  acorn tools status --group-by category -o json  # Map of category to tools
+  acorn tools status --group-by none              # One flat list
+  acorn tools status --export-markdown -          # Markdown to stdout
+  acorn tools status --export-markdown tools.md   # Markdown to a file`,
 	RunE: runToolsStatus,
 }
 
@@ -74,9 +92,14 @@ var toolsCheckCmd = &cobra.Command{
 
 If no tools are specified, checks all known tools.
 
+With --prereqs-for, checks the tools the given components declare in
+requires.tools instead, and exits non-zero if any are missing.
+
 Examples:
   acorn tools check git go node
-  acorn tools check kubectl helm terraform`,
+  acorn tools check kubectl helm terraform
+  acorn tools check --prereqs-for tmux
+  acorn tools check --prereqs-for tmux,kubernetes -o json`,
 	RunE:              runToolsCheck,
 	ValidArgsFunction: completeToolNames,
 }
@@ -166,6 +189,19 @@ func init() {
 	toolsCmd.AddCommand(toolsInstallCmd)
 	toolsCmd.AddCommand(toolsUpgradeBashCmd)
 
+	// Status flags
+	toolsStatusCmd.Flags().StringVar(&toolsExportMarkdown, "export-markdown", "",
+		"Write the inventory as a Markdown table to a file (- for stdout)")
+	toolsStatusCmd.Flags().StringVar(&toolsGroupBy, "group-by", "",
+		"Group tools by: "+strings.Join(tools.GroupByModes, ", "))
+	toolsStatusCmd.Flags().StringSliceVar(&toolsFailOnMissing, "fail-on-missing", nil,
+		"Exit 1 if any of these tools is not installed (comma-separated)")
+
+	// Check flags
+	toolsCheckCmd.Flags().StringSliceVar(&toolsPrereqsFor, "prereqs-for", nil,
+		"Check the tools required by these components (repeatable)")
+	toolsCheckCmd.RegisterFlagCompletionFunc("prereqs-for", completeComponentNames)
+
 	// Flags for update/install commands
 	toolsUpdateCmd.Flags().BoolVar(&toolsDryRun, "dry-run", false, "Show what would be done without executing")
 	toolsUpdateCmd.Flags().BoolVarP(&toolsVerbose, "verbose", "v", false, "Show verbose output")
@@ -175,26 +211,55 @@ func init() {
 
 func runToolsStatus(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
+
+	switch toolsGroupBy {
+	case "", tools.GroupByCategory, tools.GroupByNone:
+	default:
+		return fmt.Errorf("invalid --group-by %q (valid: %s)", toolsGroupBy, strings.Join(tools.GroupByModes, ", "))
+	}
+
 	checker := tools.NewChecker()
 	result := checker.CheckAll()
 
+	missing := checker.MissingRequired(result, toolsFailOnMissing)
+
+	if toolsExportMarkdown != "" {
+		if err := exportToolsMarkdown(result, toolsExportMarkdown); err != nil {
+			return err
+		}
+		return requiredToolsError(missing)
+	}
+
 	if ioHelper.IsStructured() {
-		return ioHelper.WriteOutput(result)
+		var err error
+		switch toolsGroupBy {
+		case tools.GroupByCategory:
+			err = ioHelper.WriteOutput(result.Grouped())
+		case tools.GroupByNone:
+			err = ioHelper.WriteOutput(result.Flat())
+		default:
+			err = ioHelper.WriteOutput(result)
+		}
+		if err != nil {
+			return err
+		}
+		return requiredToolsError(missing)
 	}
 
-	// Table format with colored status
-	for _, cat := range result.Categories {
-		fmt.Fprintf(os.Stdout, "\n%s\n", output.Info(cat.Name))
-		fmt.Fprintln(os.Stdout, strings.Repeat("-", len(cat.Name)))
+	if toolsGroupBy == tools.GroupByNone {
+		fmt.Fprintln(os.Stdout)
+		for _, tool := range result.Flat().Tools {
+			printToolStatusLine(tool, fmt.Sprintf("%-12s ", tool.Category))
+		}
+	} else {
+		// Table format with colored status
+		for _, cat := range result.Categories {
+			fmt.Fprintf(os.Stdout, "\n%s\n", output.Info(cat.Name))
+			fmt.Fprintln(os.Stdout, strings.Repeat("-", len(cat.Name)))
 
-		for _, tool := range cat.Tools {
-			status := output.Success("✓")
-			version := tool.Version
-			if !tool.Installed {
-				status = output.Error("✗")
-				version = "not installed"
+			for _, tool := range cat.Tools {
+				printToolStatusLine(tool, "")
 			}
-			fmt.Fprintf(os.Stdout, "  %s %-15s %s\n", status, tool.Name, version)
 		}
 	}
 
@@ -205,6 +270,75 @@ func runToolsStatus(cmd *cobra.Command, args []string) error {
 		output.Success(fmt.Sprintf("%d", result.Summary.Installed)),
 		output.Error(fmt.Sprintf("%d", result.Summary.Missing)))
 
+	if len(toolsFailOnMissing) == 0 {
+		return nil
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stdout, "%s Missing required tools: %s\n", output.Error("✗"), strings.Join(missing, ", "))
+		return &exitCodeError{code: 1}
+	}
+	fmt.Fprintf(os.Stdout, "%s All required tools installed: %s\n", output.Success("✓"), strings.Join(toolsFailOnMissing, ", "))
+	return nil
+}
+
+// requiredToolsError reports missing --fail-on-missing tools on stderr,
+// keeping structured and exported output on stdout intact.
+func requiredToolsError(missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	return &exitCodeError{code: 1, msg: "missing required tools: " + strings.Join(missing, ", ")}
+}
+
+// printToolStatusLine prints one tool with a colored status mark, after an
+// optional prefix column.
+func printToolStatusLine(tool tools.ToolStatus, prefix string) {
+	status := output.Success("✓")
+	version := tool.Version
+	if !tool.Installed {
+		status = output.Error("✗")
+		version = "not installed"
+	}
+	fmt.Fprintf(os.Stdout, "  %s %s%-15s %s\n", status, prefix, tool.Name, version)
+}
+
+// exportToolsMarkdown writes the tool inventory as Markdown to path, or to
+// stdout when path is "-".
+func exportToolsMarkdown(result *tools.StatusResult, path string) error {
+	var buf bytes.Buffer
+	hostname, _ := os.Hostname()
+
+	fmt.Fprintln(&buf, "## Tool Inventory")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "_Generated on %s (%s/%s, host `%s`)_\n",
+		time.Now().UTC().Format(time.RFC3339), runtime.GOOS, runtime.GOARCH, hostname)
+	fmt.Fprintln(&buf)
+
+	table := output.NewTable("Category", "Tool", "Installed", "Version")
+	for _, cat := range result.Categories {
+		for _, tool := range cat.Tools {
+			installed := "✓"
+			if !tool.Installed {
+				installed = "✗"
+			}
+			table.AddRow(cat.Name, tool.Name, installed, tool.Version)
+		}
+	}
+	table.RenderMarkdown(&buf)
+
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "**Total:** %d tools (%d installed, %d missing)\n",
+		result.Summary.Total, result.Summary.Installed, result.Summary.Missing)
+
+	if path == "-" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	fmt.Fprintf(os.Stdout, "%s Tool inventory written to %s\n", output.Success("✓"), path)
 	return nil
 }
 
@@ -231,6 +365,13 @@ func runToolsCheck(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	checker := tools.NewChecker()
 
+	if len(toolsPrereqsFor) > 0 {
+		if len(args) > 0 {
+			return fmt.Errorf("tool names cannot be combined with --prereqs-for")
+		}
+		return runToolsCheckPrereqs(ioHelper, checker)
+	}
+
 	var results []tools.ToolStatus
 	if len(args) == 0 {
 		// Check all tools
@@ -267,6 +408,68 @@ func runToolsCheck(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runToolsCheckPrereqs checks the tools required by the --prereqs-for
+// components, exiting non-zero when any are missing.
+func runToolsCheckPrereqs(ioHelper *ioutils.CommandIO, checker *tools.Checker) error {
+	dotfilesRoot, err := getDotfilesRoot()
+	if err != nil {
+		return err
+	}
+	disco := component.NewDiscovery(dotfilesRoot)
+
+	results := []tools.PrereqStatus{}
+	for _, name := range toolsPrereqsFor {
+		comp, err := disco.FindByName(name)
+		if err != nil {
+			return err
+		}
+		results = append(results, checker.CheckPrereqs(comp.Name, comp.Requires.Tools)...)
+	}
+
+	missing := 0
+	for _, r := range results {
+		if !r.Installed {
+			missing++
+		}
+	}
+
+	if ioHelper.IsStructured() {
+		if err := ioHelper.WriteOutput(results); err != nil {
+			return err
+		}
+	} else {
+		for _, name := range toolsPrereqsFor {
+			fmt.Fprintf(os.Stdout, "%s\n", output.Info(name))
+			found := false
+			for _, r := range results {
+				if r.Component != name {
+					continue
+				}
+				found = true
+				if r.Installed {
+					fmt.Fprintf(os.Stdout, "  %s %s: %s\n", output.Success("✓"), r.Tool, r.Path)
+				} else {
+					fmt.Fprintf(os.Stdout, "  %s %s: not installed\n", output.Error("✗"), r.Tool)
+				}
+			}
+			if !found {
+				fmt.Fprintln(os.Stdout, "  (no required tools)")
+			}
+		}
+		fmt.Fprintln(os.Stdout)
+		if missing == 0 {
+			fmt.Fprintf(os.Stdout, "%s All %d required tool(s) installed\n", output.Success("✓"), len(results))
+		} else {
+			fmt.Fprintf(os.Stdout, "%s %d of %d required tool(s) missing\n", output.Error("✗"), missing, len(results))
+		}
+	}
+
+	if missing > 0 {
+		return &exitCodeError{code: 1}
+	}
+	return nil
+}
+
 func runToolsMissing(cmd *cobra.Command, args []string) error {
 	ioHelper := ioutils.IO(cmd)
 	checker := tools.NewChecker()
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"github.com/mistergrinvalds/acorn/internal/components"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mistergrinvalds/acorn/internal/components/vscode"
 	"github.com/mistergrinvalds/acorn/internal/utils/configcmd"
@@ -15,6 +17,11 @@ import (
 var (
 	vscodeDryRun  bool
 	vscodeVerbose bool
+
+	vscodeEssentialsLangs []string
+	vscodeEssentialsList  bool
+
+	vscodeNoBackup bool
 )
 
 // vscodeCmd represents the vscode command group
@@ -28,7 +35,8 @@ Examples:
   acorn vscode workspace myproject  # Open workspace
   acorn vscode project new myapp go # Create Go project
   acorn vscode ext list             # List extensions
-  acorn vscode config sync          # Sync config from dotfiles`,
+  acorn vscode config sync          # Sync config from dotfiles
+  acorn vscode config backups       # List config backups`,
 	Aliases: []string{"code", "vs"},
 }
 
@@ -147,8 +155,15 @@ Includes:
   - Kubernetes, Docker tools
   - Catppuccin theme
 
+With --lang, installs a base set plus curated bundles for each language
+instead (e.g. Go: golang.go; Python: Python, Pylance, debugpy, Ruff).
+Use --list to show the bundles without installing.
+
 Examples:
-  acorn vscode ext essentials`,
+  acorn vscode ext essentials
+  acorn vscode ext essentials --lang go,python
+  acorn vscode ext essentials --lang rust --dry-run
+  acorn vscode ext essentials --list`,
 	RunE: runVscodeExtEssentials,
 }
 
@@ -158,14 +173,60 @@ var vscodeConfigSyncCmd = &cobra.Command{
 	Short: "Sync configuration from dotfiles",
 	Long: `Sync VS Code settings and keybindings from dotfiles.
 
-Backs up existing files before syncing.
+The live settings, keybindings and snippets are backed up first (see
+'acorn vscode config backups'); --no-backup skips this.
 
 Examples:
   acorn vscode config sync
-  acorn vscode config sync --dry-run`,
+  acorn vscode config sync --dry-run
+  acorn vscode config sync --no-backup`,
 	RunE: runVscodeConfigSync,
 }
 
+// vscodeConfigBackupCmd backs up the live config
+var vscodeConfigBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the live VS Code config",
+	Long: `Copy the live settings.json, keybindings.json and snippets directory
+into a timestamped backup.
+
+Backups are stored in ~/.local/share/vscode/backups/
+
+Examples:
+  acorn vscode config backup
+  acorn vscode config backup -o json`,
+	RunE: runVscodeConfigBackup,
+}
+
+// vscodeConfigBackupsCmd lists backups
+var vscodeConfigBackupsCmd = &cobra.Command{
+	Use:   "backups",
+	Short: "List VS Code config backups",
+	Long: `List the available VS Code config backups, oldest first.
+
+Examples:
+  acorn vscode config backups
+  acorn vscode config backups -o json`,
+	RunE: runVscodeConfigBackups,
+}
+
+// vscodeConfigRestoreCmd restores a backup
+var vscodeConfigRestoreCmd = &cobra.Command{
+	Use:   "restore <backup>",
+	Short: "Restore VS Code config from a backup",
+	Long: `Replace the live VS Code config with the files in a backup, given by
+name or path.
+
+The current config is backed up before restoring, so a restore can itself
+be rolled back. Files the backup does not contain are left as they are.
+
+Examples:
+  acorn vscode config restore 20240101_120000
+  acorn vscode config restore 20240101_120000 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVscodeConfigRestore,
+}
+
 // vscodeConfigPathCmd is now provided by the universal config router
 
 func init() {
@@ -177,6 +238,9 @@ func init() {
 	vscodeCmd.AddCommand(vscodeExtCmd)
 	vscodeConfigRouter := configcmd.NewConfigRouter("vscode")
 	vscodeConfigRouter.AddCommand(vscodeConfigSyncCmd)
+	vscodeConfigRouter.AddCommand(vscodeConfigBackupCmd)
+	vscodeConfigRouter.AddCommand(vscodeConfigBackupsCmd)
+	vscodeConfigRouter.AddCommand(vscodeConfigRestoreCmd)
 	vscodeCmd.AddCommand(vscodeConfigRouter)
 
 	// Project subcommands
@@ -188,6 +252,16 @@ func init() {
 	vscodeExtCmd.AddCommand(vscodeExtExportCmd)
 	vscodeExtCmd.AddCommand(vscodeExtEssentialsCmd)
 
+	// Essentials flags
+	vscodeExtEssentialsCmd.Flags().StringSliceVar(&vscodeEssentialsLangs, "lang", nil,
+		"Languages to install bundles for (e.g. go,python)")
+	vscodeExtEssentialsCmd.Flags().BoolVar(&vscodeEssentialsList, "list", false,
+		"List the extension bundles without installing")
+
+	// Config sync flags
+	vscodeConfigSyncCmd.Flags().BoolVar(&vscodeNoBackup, "no-backup", false,
+		"Do not back up the live config before syncing")
+
 	// Persistent flags
 	vscodeCmd.PersistentFlags().BoolVar(&vscodeDryRun, "dry-run", false,
 		"Show what would be done without executing")
@@ -345,12 +419,32 @@ func runVscodeExtExport(cmd *cobra.Command, args []string) error {
 }
 
 func runVscodeExtEssentials(cmd *cobra.Command, args []string) error {
-	if !vscode.IsInstalled() {
+	if vscodeEssentialsList {
+		return listVscodeBundles(cmd)
+	}
+
+	if !vscode.IsInstalled() && !vscodeDryRun {
 		return fmt.Errorf("VS Code is not installed")
 	}
 
 	helper := vscode.NewHelper(vscodeVerbose, vscodeDryRun)
 
+	if len(vscodeEssentialsLangs) > 0 {
+		ids, err := vscode.ResolveEssentials(vscodeEssentialsLangs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "Installing essential extensions for %s...\n",
+			strings.Join(vscodeEssentialsLangs, ", "))
+		fmt.Fprintln(os.Stdout)
+
+		if err := helper.InstallExtensions(ids); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "\n%s Essential extensions installed!\n", output.Success("✓"))
+		return nil
+	}
+
 	fmt.Fprintln(os.Stdout, "Installing essential extensions...")
 	fmt.Fprintln(os.Stdout)
 
@@ -362,9 +456,44 @@ func runVscodeExtEssentials(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// listVscodeBundles shows the base and language extension bundles.
+func listVscodeBundles(cmd *cobra.Command) error {
+	ioHelper := ioutils.IO(cmd)
+
+	bundles, err := vscode.EssentialBundles(vscodeEssentialsLangs)
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(bundles)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("Extension Bundles"))
+	for _, b := range bundles {
+		fmt.Fprintf(os.Stdout, "\n  %s:\n", b.Name)
+		for _, id := range b.Extensions {
+			fmt.Fprintf(os.Stdout, "    %s\n", id)
+		}
+	}
+
+	return nil
+}
+
 func runVscodeConfigSync(cmd *cobra.Command, args []string) error {
 	helper := vscode.NewHelper(vscodeVerbose, vscodeDryRun)
 
+	if !vscodeNoBackup {
+		backup, err := helper.CreateBackup()
+		switch {
+		case errors.Is(err, vscode.ErrNoLiveConfig):
+		case err != nil:
+			return fmt.Errorf("%w (use --no-backup to sync anyway)", err)
+		case !vscodeDryRun:
+			fmt.Fprintf(os.Stdout, "%s Backed up current config: %s\n", output.Success("✓"), backup.Name)
+		}
+	}
+
 	fmt.Fprintln(os.Stdout, "Syncing VS Code configuration...")
 
 	if err := helper.SyncConfig(); err != nil {
@@ -375,6 +504,74 @@ func runVscodeConfigSync(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runVscodeConfigBackup(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := vscode.NewHelper(vscodeVerbose, vscodeDryRun)
+
+	backup, err := helper.CreateBackup()
+	if err != nil {
+		if errors.Is(err, vscode.ErrNoLiveConfig) {
+			return fmt.Errorf("%w in %s", err, helper.GetConfigPaths().UserDir)
+		}
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(backup)
+	}
+	if !vscodeDryRun {
+		fmt.Fprintf(os.Stdout, "%s Backup created: %s\n", output.Success("✓"), backup.Path)
+	}
+	return nil
+}
+
+func runVscodeConfigBackups(cmd *cobra.Command, args []string) error {
+	ioHelper := ioutils.IO(cmd)
+	helper := vscode.NewHelper(vscodeVerbose, vscodeDryRun)
+
+	backups, err := helper.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	if ioHelper.IsStructured() {
+		return ioHelper.WriteOutput(map[string]interface{}{"backups": backups})
+	}
+
+	if len(backups) == 0 {
+		fmt.Fprintln(os.Stdout, "No backups found")
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", output.Info("VS Code Config Backups"))
+	fmt.Fprintln(os.Stdout, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	for _, b := range backups {
+		fmt.Fprintf(os.Stdout, "  %-20s %s\n", b.Name, strings.Join(b.Files, ", "))
+	}
+
+	fmt.Fprintf(os.Stdout, "\nTotal: %d backups\n", len(backups))
+	return nil
+}
+
+func runVscodeConfigRestore(cmd *cobra.Command, args []string) error {
+	helper := vscode.NewHelper(vscodeVerbose, vscodeDryRun)
+
+	previous, err := helper.RestoreBackup(args[0])
+	if previous != nil && !vscodeDryRun {
+		fmt.Fprintf(os.Stdout, "%s Backed up current config: %s\n", output.Success("✓"), previous.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if vscodeDryRun {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stdout, "%s Config restored from: %s\n", output.Success("✓"), args[0])
+	return nil
+}
+
 // runVscodeConfigPath has been replaced by the universal config router: acorn vscode config path
 
 func init() {
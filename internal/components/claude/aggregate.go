@@ -19,15 +19,32 @@ type AggregateResult struct {
 	SubagentsAdded int             `json:"subagents_added" yaml:"subagents_added"`
 	Skipped        int             `json:"skipped" yaml:"skipped"`
 	Renamed        int             `json:"renamed" yaml:"renamed"`
+	Linked         bool            `json:"linked,omitempty" yaml:"linked,omitempty"`
 	Items          []AggregateItem `json:"items,omitempty" yaml:"items,omitempty"`
 }
 
+// AggregateOptions controls how aggregated files are placed.
+type AggregateOptions struct {
+	// Link symlinks files to their source instead of copying them, so edits
+	// in the source repo show up in the config.
+	Link bool
+}
+
+// Aggregate placement modes.
+const (
+	AggregateCopied = "copied"
+	AggregateLinked = "linked"
+)
+
 // AggregateItem represents an individual aggregated item.
 type AggregateItem struct {
-	Type       string `json:"type" yaml:"type"` // "agent", "command", "subagent"
-	FileName   string `json:"file_name" yaml:"file_name"`
-	SourceRepo string `json:"source_repo" yaml:"source_repo"`
-	Action     string `json:"action" yaml:"action"` // "added", "skipped", "renamed"
+	Type         string `json:"type" yaml:"type"` // "agent", "command", "subagent"
+	FileName     string `json:"file_name" yaml:"file_name"`
+	OriginalName string `json:"original_name,omitempty" yaml:"original_name,omitempty"` // set when renamed
+	SourceRepo   string `json:"source_repo" yaml:"source_repo"`
+	Action       string `json:"action" yaml:"action"`                     // "added", "skipped", "renamed"
+	Mode         string `json:"mode,omitempty" yaml:"mode,omitempty"`     // "copied" or "linked" when written
+	Source       string `json:"source,omitempty" yaml:"source,omitempty"` // link target when linked
 }
 
 // ListResult holds the list of all aggregated items.
@@ -44,7 +61,9 @@ type ClearResult struct {
 }
 
 // Aggregate scans repositories for .claude directories and aggregates content.
-func (h *Helper) Aggregate(searchDir string) (*AggregateResult, error) {
+// Files are copied unless opts.Link is set, in which case they are symlinked
+// to their source.
+func (h *Helper) Aggregate(searchDir string, opts AggregateOptions) (*AggregateResult, error) {
 	// Get dotfiles root from environment
 	dotfilesRoot := os.Getenv("DOTFILES_ROOT")
 	if dotfilesRoot == "" {
@@ -65,6 +84,7 @@ func (h *Helper) Aggregate(searchDir string) (*AggregateResult, error) {
 	result := &AggregateResult{
 		SearchDir: searchDir,
 		TargetDir: targetDir,
+		Linked:    opts.Link,
 		Items:     []AggregateItem{},
 	}
 
@@ -100,7 +120,7 @@ func (h *Helper) Aggregate(searchDir string) (*AggregateResult, error) {
 		// Process agents
 		agentsDir := filepath.Join(path, "agents")
 		if h.DirExists(agentsDir) {
-			items := h.processDirectory(agentsDir, filepath.Join(targetDir, "agents"), repoName, "agent")
+			items := h.processDirectory(agentsDir, filepath.Join(targetDir, "agents"), repoName, "agent", opts.Link)
 			result.Items = append(result.Items, items...)
 			for _, item := range items {
 				switch item.Action {
@@ -119,7 +139,7 @@ func (h *Helper) Aggregate(searchDir string) (*AggregateResult, error) {
 		// Process commands
 		commandsDir := filepath.Join(path, "commands")
 		if h.DirExists(commandsDir) {
-			items := h.processDirectory(commandsDir, filepath.Join(targetDir, "commands"), repoName, "command")
+			items := h.processDirectory(commandsDir, filepath.Join(targetDir, "commands"), repoName, "command", opts.Link)
 			result.Items = append(result.Items, items...)
 			for _, item := range items {
 				switch item.Action {
@@ -138,7 +158,7 @@ func (h *Helper) Aggregate(searchDir string) (*AggregateResult, error) {
 		// Process subagents
 		subagentsDir := filepath.Join(path, "subagents")
 		if h.DirExists(subagentsDir) {
-			items := h.processDirectory(subagentsDir, filepath.Join(targetDir, "subagents"), repoName, "subagent")
+			items := h.processDirectory(subagentsDir, filepath.Join(targetDir, "subagents"), repoName, "subagent", opts.Link)
 			result.Items = append(result.Items, items...)
 			for _, item := range items {
 				switch item.Action {
@@ -165,11 +185,19 @@ func (h *Helper) Aggregate(searchDir string) (*AggregateResult, error) {
 		return nil, err
 	}
 
+	// Record what was written so the run can be undone
+	if !h.dryRun {
+		if err := h.writeAggregateManifest(result); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
-// processDirectory processes files in a source directory and copies to target.
-func (h *Helper) processDirectory(sourceDir, targetDir, repoName, itemType string) []AggregateItem {
+// processDirectory processes files in a source directory and copies (or
+// links) them to target.
+func (h *Helper) processDirectory(sourceDir, targetDir, repoName, itemType string, link bool) []AggregateItem {
 	var items []AggregateItem
 
 	entries, err := os.ReadDir(sourceDir)
@@ -177,6 +205,11 @@ func (h *Helper) processDirectory(sourceDir, targetDir, repoName, itemType strin
 		return items
 	}
 
+	mode := AggregateCopied
+	if link {
+		mode = AggregateLinked
+	}
+
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
 			continue
@@ -197,32 +230,40 @@ func (h *Helper) processDirectory(sourceDir, targetDir, repoName, itemType strin
 			SourceRepo: repoName,
 		}
 
+		action := "added"
 		if h.FileExists(targetPath) {
 			// Compare contents
 			if h.filesEqual(sourcePath, targetPath) {
 				item.Action = "skipped"
-			} else {
-				// Rename with repo prefix
-				newName := repoName + "-" + name
-				targetPath = filepath.Join(targetDir, newName)
-				item.FileName = newName
-				if !h.dryRun {
-					if err := h.copyFile(sourcePath, targetPath); err == nil {
-						item.Action = "renamed"
-					}
-				} else {
-					item.Action = "renamed"
-				}
+				items = append(items, item)
+				continue
 			}
-		} else {
-			if !h.dryRun {
-				if err := h.copyFile(sourcePath, targetPath); err == nil {
-					item.Action = "added"
-				}
-			} else {
-				item.Action = "added"
+			// Rename with repo prefix
+			newName := repoName + "-" + name
+			targetPath = filepath.Join(targetDir, newName)
+			item.FileName = newName
+			item.OriginalName = name
+			action = "renamed"
+
+			// Already placed under the prefixed name by an earlier run
+			if h.placed(sourcePath, targetPath, link) {
+				item.Action = "skipped"
+				items = append(items, item)
+				continue
+			}
+		}
+
+		if !h.dryRun {
+			if err := h.placeFile(sourcePath, targetPath, link); err != nil {
+				items = append(items, item)
+				continue
 			}
 		}
+		item.Action = action
+		item.Mode = mode
+		if link {
+			item.Source, _ = filepath.Abs(sourcePath)
+		}
 
 		items = append(items, item)
 	}
@@ -230,6 +271,42 @@ func (h *Helper) processDirectory(sourceDir, targetDir, repoName, itemType strin
 	return items
 }
 
+// placeFile copies src to dst, or symlinks dst to src when link is set. An
+// existing file or link at dst is replaced rather than written through, so
+// copying over an earlier link never modifies the link's source.
+func (h *Helper) placeFile(src, dst string, link bool) error {
+	if _, err := os.Lstat(dst); err == nil {
+		if err := os.Remove(dst); err != nil {
+			return err
+		}
+	}
+	if !link {
+		return h.copyFile(src, dst)
+	}
+
+	abs, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	return os.Symlink(abs, dst)
+}
+
+// placed reports whether dst already holds src the way placeFile would put
+// it there: a symlink to src when link is set, otherwise an identical
+// regular file.
+func (h *Helper) placed(src, dst string, link bool) bool {
+	info, err := os.Lstat(dst)
+	if err != nil {
+		return false
+	}
+	if link {
+		abs, err := filepath.Abs(src)
+		target, _ := os.Readlink(dst)
+		return err == nil && target == abs
+	}
+	return info.Mode().IsRegular() && h.filesEqual(src, dst)
+}
+
 // filesEqual compares two files for equality.
 func (h *Helper) filesEqual(path1, path2 string) bool {
 	data1, err1 := os.ReadFile(path1)
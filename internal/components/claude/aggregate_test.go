@@ -0,0 +1,146 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAggregateLink(t *testing.T) {
+	root := t.TempDir()
+	dotfiles := filepath.Join(root, "dotfiles")
+	commandsDir := filepath.Join(dotfiles, "components", "claude", "config", "commands")
+	repoCommands := filepath.Join(root, "repos", "api", ".claude", "commands")
+	for _, dir := range []string{commandsDir, repoCommands} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("DOTFILES_ROOT", dotfiles)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(root, "state"))
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(commandsDir, "review.md"), "existing\n")
+	write(filepath.Join(repoCommands, "review.md"), "from api\n")
+	write(filepath.Join(repoCommands, "deploy.md"), "deploy\n")
+
+	h := NewHelper(false, false)
+	result, err := h.Aggregate(filepath.Join(root, "repos"), AggregateOptions{Link: true})
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if !result.Linked || result.CommandsAdded != 2 || result.Renamed != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	for _, item := range result.Items {
+		if item.Mode != AggregateLinked || item.Source == "" {
+			t.Errorf("item %s: mode %q source %q, want linked", item.FileName, item.Mode, item.Source)
+		}
+	}
+
+	for name, source := range map[string]string{
+		"deploy.md":     "deploy.md",
+		"api-review.md": "review.md",
+	} {
+		target, err := os.Readlink(filepath.Join(commandsDir, name))
+		if err != nil {
+			t.Fatalf("%s is not a symlink: %v", name, err)
+		}
+		if target != filepath.Join(repoCommands, source) {
+			t.Errorf("%s -> %s, want %s", name, target, filepath.Join(repoCommands, source))
+		}
+	}
+
+	// Edits in the source repo show through, and a rerun sees no changes.
+	write(filepath.Join(repoCommands, "deploy.md"), "deploy v2\n")
+	if data, _ := os.ReadFile(filepath.Join(commandsDir, "deploy.md")); string(data) != "deploy v2\n" {
+		t.Errorf("linked deploy.md = %q", data)
+	}
+	result, err = h.Aggregate(filepath.Join(root, "repos"), AggregateOptions{Link: true})
+	if err != nil {
+		t.Fatalf("Aggregate (rerun): %v", err)
+	}
+	if result.CommandsAdded != 0 || result.Renamed != 0 || result.Skipped != 2 {
+		t.Errorf("rerun result = %+v, want both commands skipped", result)
+	}
+	for _, item := range result.Items {
+		if item.Action != "skipped" {
+			t.Errorf("rerun item %s: action %q, want skipped", item.FileName, item.Action)
+		}
+	}
+
+	// Copying over earlier links replaces them instead of writing through.
+	write(filepath.Join(commandsDir, "review.md"), "changed\n")
+	result, err = h.Aggregate(filepath.Join(root, "repos"), AggregateOptions{})
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if info, err := os.Lstat(filepath.Join(commandsDir, "api-review.md")); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("api-review.md should be a regular copy: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(repoCommands, "review.md")); string(data) != "from api\n" {
+		t.Errorf("source review.md was modified: %q", data)
+	}
+	for _, item := range result.Items {
+		if item.Action != "skipped" && item.Mode != AggregateCopied {
+			t.Errorf("item %s: mode %q, want copied", item.FileName, item.Mode)
+		}
+	}
+}
+
+func TestAggregateUndoLinks(t *testing.T) {
+	root := t.TempDir()
+	dotfiles := filepath.Join(root, "dotfiles")
+	commandsDir := filepath.Join(dotfiles, "components", "claude", "config", "commands")
+	repoCommands := filepath.Join(root, "repos", "api", ".claude", "commands")
+	for _, dir := range []string{commandsDir, repoCommands} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("DOTFILES_ROOT", dotfiles)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(root, "state"))
+
+	for _, name := range []string{"deploy.md", "test.md"} {
+		if err := os.WriteFile(filepath.Join(repoCommands, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := NewHelper(false, false)
+	if _, err := h.Aggregate(filepath.Join(root, "repos"), AggregateOptions{Link: true}); err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+
+	// Editing the source keeps the link removable; repointing it does not.
+	if err := os.WriteFile(filepath.Join(repoCommands, "deploy.md"), []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	repointed := filepath.Join(commandsDir, "test.md")
+	if err := os.Remove(repointed); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "elsewhere.md"), repointed); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := h.UndoAggregate(true)
+	if err != nil {
+		t.Fatalf("UndoAggregate: %v", err)
+	}
+	status := map[string]string{}
+	for _, f := range result.Files {
+		status[filepath.Base(f.Path)] = f.Status
+	}
+	if status["deploy.md"] != "removed" || status["test.md"] != "modified" {
+		t.Errorf("statuses = %v", status)
+	}
+	if _, err := os.Stat(filepath.Join(repoCommands, "deploy.md")); err != nil {
+		t.Errorf("undo removed the link's source: %v", err)
+	}
+}
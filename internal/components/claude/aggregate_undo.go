@@ -0,0 +1,211 @@
+package claude
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AggregateManifest records the files written by the last aggregate run.
+type AggregateManifest struct {
+	CreatedAt time.Time      `json:"created_at" yaml:"created_at"`
+	SearchDir string         `json:"search_dir" yaml:"search_dir"`
+	TargetDir string         `json:"target_dir" yaml:"target_dir"`
+	Files     []ManifestFile `json:"files" yaml:"files"`
+}
+
+// ManifestFile is a file written by aggregate.
+type ManifestFile struct {
+	Path         string `json:"path" yaml:"path"`
+	Type         string `json:"type" yaml:"type"`
+	Action       string `json:"action" yaml:"action"` // "added" or "renamed"
+	OriginalName string `json:"original_name,omitempty" yaml:"original_name,omitempty"`
+	SourceRepo   string `json:"source_repo" yaml:"source_repo"`
+	SHA256       string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+	// Link is the symlink target of a linked file, whose content changes
+	// with its source and so is not hashed.
+	Link string `json:"link,omitempty" yaml:"link,omitempty"`
+}
+
+// AggregateUndoResult holds the result of undoing the last aggregate run.
+type AggregateUndoResult struct {
+	Manifest  string           `json:"manifest" yaml:"manifest"`
+	CreatedAt time.Time        `json:"created_at" yaml:"created_at"`
+	DryRun    bool             `json:"dry_run" yaml:"dry_run"`
+	Files     []UndoFileResult `json:"files" yaml:"files"`
+}
+
+// UndoFileResult is the outcome of undoing one aggregated file.
+type UndoFileResult struct {
+	Path   string `json:"path" yaml:"path"`
+	Action string `json:"action" yaml:"action"` // original aggregate action
+	Status string `json:"status" yaml:"status"` // removed, would_remove, missing, modified
+}
+
+// AggregateManifestPath returns where the last aggregate run is recorded.
+func AggregateManifestPath() string {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, _ := os.UserHomeDir()
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "acorn", "claude-aggregate-last.json")
+}
+
+// writeAggregateManifest records the files added or renamed by an aggregate
+// run. Runs that wrote nothing leave the previous manifest in place.
+func (h *Helper) writeAggregateManifest(result *AggregateResult) error {
+	manifest := AggregateManifest{
+		CreatedAt: time.Now().UTC(),
+		SearchDir: result.SearchDir,
+		TargetDir: result.TargetDir,
+		Files:     []ManifestFile{},
+	}
+
+	for _, item := range result.Items {
+		if item.Action != "added" && item.Action != "renamed" {
+			continue
+		}
+		path := filepath.Join(result.TargetDir, item.Type+"s", item.FileName)
+		file := ManifestFile{
+			Path:       path,
+			Type:       item.Type,
+			Action:     item.Action,
+			SourceRepo: item.SourceRepo,
+		}
+		if item.Mode == AggregateLinked {
+			file.Link = item.Source
+		} else {
+			sum, err := fileSHA256(path)
+			if err != nil {
+				continue
+			}
+			file.SHA256 = sum
+		}
+		if item.Action == "renamed" {
+			file.OriginalName = item.OriginalName
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	if len(manifest.Files) == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := AggregateManifestPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write aggregate manifest: %w", err)
+	}
+	return nil
+}
+
+// UndoAggregate removes the files added by the last aggregate run, including
+// the repo-prefixed copies written for conflicting names. Files edited since
+// the run, and links repointed since, are left alone and reported as
+// modified. Requires force unless
+// running in dry-run mode.
+func (h *Helper) UndoAggregate(force bool) (*AggregateUndoResult, error) {
+	path := AggregateManifestPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no aggregate manifest found at %s (nothing to undo)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aggregate manifest: %w", err)
+	}
+
+	var manifest AggregateManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid aggregate manifest %s: %w", path, err)
+	}
+
+	if !h.dryRun && !force {
+		return nil, fmt.Errorf("use --force to remove %d aggregated file(s), or --dry-run to list them", len(manifest.Files))
+	}
+
+	result := &AggregateUndoResult{
+		Manifest:  path,
+		CreatedAt: manifest.CreatedAt,
+		DryRun:    h.dryRun,
+		Files:     []UndoFileResult{},
+	}
+
+	for _, f := range manifest.Files {
+		fr := UndoFileResult{Path: f.Path, Action: f.Action}
+
+		state, err := aggregatedFileState(f)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case state != "":
+			fr.Status = state
+		case h.dryRun:
+			fr.Status = "would_remove"
+		default:
+			if err := os.Remove(f.Path); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", f.Path, err)
+			}
+			fr.Status = "removed"
+		}
+
+		result.Files = append(result.Files, fr)
+	}
+
+	if !h.dryRun {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove aggregate manifest: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// aggregatedFileState reports whether an aggregated file is "missing" or
+// "modified" since the run, or "" when it is as aggregate left it. A linked
+// file is unchanged while it is still a link to the same source.
+func aggregatedFileState(f ManifestFile) (string, error) {
+	if f.Link != "" {
+		target, err := os.Readlink(f.Path)
+		switch {
+		case os.IsNotExist(err):
+			return "missing", nil
+		case err != nil || target != f.Link:
+			return "modified", nil
+		}
+		return "", nil
+	}
+
+	sum, err := fileSHA256(f.Path)
+	switch {
+	case os.IsNotExist(err):
+		return "missing", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to read %s: %w", f.Path, err)
+	case sum != f.SHA256:
+		return "modified", nil
+	}
+	return "", nil
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of a file's contents.
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
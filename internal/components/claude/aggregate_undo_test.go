@@ -0,0 +1,64 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAggregateUndo(t *testing.T) {
+	root := t.TempDir()
+	dotfiles := filepath.Join(root, "dotfiles")
+	commandsDir := filepath.Join(dotfiles, "components", "claude", "config", "commands")
+	repoCommands := filepath.Join(root, "repos", "api", ".claude", "commands")
+	for _, dir := range []string{commandsDir, repoCommands} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("DOTFILES_ROOT", dotfiles)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(root, "state"))
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(commandsDir, "review.md"), "existing\n")
+	write(filepath.Join(repoCommands, "review.md"), "from api\n")
+	write(filepath.Join(repoCommands, "deploy.md"), "deploy\n")
+
+	h := NewHelper(false, false)
+	if _, err := h.UndoAggregate(true); err == nil {
+		t.Fatal("expected error without a manifest")
+	}
+
+	if _, err := h.Aggregate(filepath.Join(root, "repos"), AggregateOptions{}); err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if _, err := h.UndoAggregate(false); err == nil {
+		t.Fatal("expected error without force")
+	}
+
+	result, err := h.UndoAggregate(true)
+	if err != nil {
+		t.Fatalf("UndoAggregate: %v", err)
+	}
+	if len(result.Files) != 2 {
+		t.Fatalf("undid %d files, want 2: %+v", len(result.Files), result.Files)
+	}
+	for _, f := range result.Files {
+		if f.Status != "removed" {
+			t.Errorf("%s status = %s, want removed", f.Path, f.Status)
+		}
+	}
+
+	entries, _ := os.ReadDir(commandsDir)
+	if len(entries) != 1 || entries[0].Name() != "review.md" {
+		t.Errorf("commands after undo = %v, want only review.md", entries)
+	}
+	if _, err := os.Stat(AggregateManifestPath()); !os.IsNotExist(err) {
+		t.Error("manifest should be removed after undo")
+	}
+}
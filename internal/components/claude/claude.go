@@ -114,15 +114,7 @@ func (h *Helper) CountFiles(dir, ext string) int {
 
 // GetInfo returns Claude Code information summary.
 func (h *Helper) GetInfo() (*Info, error) {
-	info := &Info{
-		ClaudeDir:     h.paths.ClaudeDir,
-		ConfigExists:  h.FileExists(h.paths.Config),
-		SettingsExist: h.FileExists(h.paths.Settings),
-		LocalExists:   h.FileExists(h.paths.Local),
-		StatsExist:    h.FileExists(h.paths.StatsCache),
-		AgentCount:    h.CountFiles(h.paths.AgentsDir, ".md"),
-		CommandCount:  h.CountFiles(h.paths.CommandsDir, ".md"),
-	}
+	info := h.getLocalInfo()
 
 	// Get version
 	version, err := h.GetVersion()
@@ -133,6 +125,21 @@ func (h *Helper) GetInfo() (*Info, error) {
 		info.Version = version
 	}
 
+	return info, nil
+}
+
+// getLocalInfo returns the parts of Info read from disk (everything but the version).
+func (h *Helper) getLocalInfo() *Info {
+	info := &Info{
+		ClaudeDir:     h.paths.ClaudeDir,
+		ConfigExists:  h.FileExists(h.paths.Config),
+		SettingsExist: h.FileExists(h.paths.Settings),
+		LocalExists:   h.FileExists(h.paths.Local),
+		StatsExist:    h.FileExists(h.paths.StatsCache),
+		AgentCount:    h.CountFiles(h.paths.AgentsDir, ".md"),
+		CommandCount:  h.CountFiles(h.paths.CommandsDir, ".md"),
+	}
+
 	// Get quick stats if stats file exists
 	if info.StatsExist {
 		if stats, err := h.readStatsQuick(); err == nil {
@@ -141,7 +148,7 @@ func (h *Helper) GetInfo() (*Info, error) {
 		}
 	}
 
-	return info, nil
+	return info
 }
 
 // quickStats is a minimal struct for reading just session/message counts.
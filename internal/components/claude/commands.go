@@ -0,0 +1,218 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintIssue is a single problem found in a command file.
+type LintIssue struct {
+	Level   string `json:"level" yaml:"level"` // "error" or "warning"
+	Message string `json:"message" yaml:"message"`
+}
+
+// CommandLint is the lint result for one command file.
+type CommandLint struct {
+	Name   string      `json:"name" yaml:"name"`
+	Path   string      `json:"path" yaml:"path"`
+	Source string      `json:"source" yaml:"source"`
+	Valid  bool        `json:"valid" yaml:"valid"`
+	Issues []LintIssue `json:"issues" yaml:"issues"`
+}
+
+// knownFrontmatterKeys are the frontmatter fields understood by command files.
+var knownFrontmatterKeys = map[string]bool{
+	"description":              true,
+	"allowed-tools":            true,
+	"argument-hint":            true,
+	"model":                    true,
+	"disable-model-invocation": true,
+}
+
+// positionalRe matches $1..$9 style positional argument placeholders.
+var positionalRe = regexp.MustCompile(`\$([1-9][0-9]*)`)
+
+// LintCommands lints custom command files. With an empty name every user
+// and project command is linted; otherwise only the named command.
+func (h *Helper) LintCommands(name string) ([]CommandLint, error) {
+	view, err := h.GetCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	commands := append(view.UserCommands, view.ProjectCommands...)
+	if name != "" {
+		want := "/" + strings.TrimPrefix(name, "/")
+		var matched []CommandView
+		for _, c := range commands {
+			if c.Name == want {
+				matched = append(matched, c)
+			}
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("command not found: %s", want)
+		}
+		commands = matched
+	}
+
+	results := make([]CommandLint, 0, len(commands))
+	for _, c := range commands {
+		result := CommandLint{Name: c.Name, Path: c.Path, Source: c.Source}
+
+		data, err := os.ReadFile(c.Path)
+		if err != nil {
+			result.Issues = []LintIssue{{Level: "error", Message: fmt.Sprintf("cannot read file: %v", err)}}
+		} else {
+			result.Issues = LintCommandContent(string(data))
+		}
+
+		result.Valid = true
+		for _, issue := range result.Issues {
+			if issue.Level == "error" {
+				result.Valid = false
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// LintCommandContent validates the frontmatter and argument placeholders of
+// a command file's content.
+func LintCommandContent(content string) []LintIssue {
+	issues := []LintIssue{}
+	addf := func(level, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{Level: level, Message: fmt.Sprintf(format, args...)})
+	}
+
+	frontmatter, body, hasFrontmatter, err := splitFrontmatter(content)
+	if err != nil {
+		addf("error", "%v", err)
+		return issues
+	}
+
+	fields := map[string]interface{}{}
+	if hasFrontmatter {
+		if err := yaml.Unmarshal([]byte(frontmatter), &fields); err != nil {
+			addf("error", "invalid frontmatter: %v", err)
+			return issues
+		}
+	}
+
+	// Frontmatter fields
+	var keys []string
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !knownFrontmatterKeys[k] {
+			addf("warning", "unknown frontmatter field %q", k)
+		}
+	}
+
+	if desc, ok := fields["description"]; !ok {
+		addf("warning", "missing description")
+	} else if s, ok := desc.(string); !ok || strings.TrimSpace(s) == "" {
+		addf("error", "description must be a non-empty string")
+	}
+
+	if tools, ok := fields["allowed-tools"]; ok && !isStringOrStringList(tools) {
+		addf("error", "allowed-tools must be a string or a list of strings")
+	}
+	// An unquoted hint like [file] parses as a YAML list, which is fine
+	if hint, ok := fields["argument-hint"]; ok && !isStringOrStringList(hint) {
+		addf("error", "argument-hint must be a string")
+	}
+	if v, ok := fields["model"]; ok {
+		if _, isString := v.(string); !isString {
+			addf("error", "model must be a string")
+		}
+	}
+	if v, ok := fields["disable-model-invocation"]; ok {
+		if _, isBool := v.(bool); !isBool {
+			addf("error", "disable-model-invocation must be true or false")
+		}
+	}
+
+	// Body and placeholders
+	if strings.TrimSpace(body) == "" {
+		addf("error", "command body is empty")
+		return issues
+	}
+
+	usesAll := strings.Contains(body, "$ARGUMENTS")
+	positions := map[int]bool{}
+	maxPos := 0
+	for _, m := range positionalRe.FindAllStringSubmatch(body, -1) {
+		n, _ := strconv.Atoi(m[1])
+		positions[n] = true
+		if n > maxPos {
+			maxPos = n
+		}
+	}
+
+	for i := 1; i < maxPos; i++ {
+		if !positions[i] {
+			addf("warning", "uses $%d but not $%d", maxPos, i)
+			break
+		}
+	}
+	if usesAll && maxPos > 0 {
+		addf("warning", "mixes $ARGUMENTS with positional arguments")
+	}
+
+	_, hasHint := fields["argument-hint"]
+	usesArgs := usesAll || maxPos > 0
+	if hasHint && !usesArgs {
+		addf("warning", "argument-hint is set but the body uses no $ARGUMENTS or $1 placeholders")
+	}
+	if !hasHint && maxPos > 0 {
+		addf("warning", "uses positional arguments but has no argument-hint")
+	}
+
+	return issues
+}
+
+// splitFrontmatter separates a leading --- delimited YAML block from the body.
+func splitFrontmatter(content string) (frontmatter, body string, ok bool, err error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if !strings.HasPrefix(content, "---\n") && !strings.HasPrefix(content, "---\r\n") {
+		return "", content, false, nil
+	}
+
+	rest := content[strings.Index(content, "\n")+1:]
+	lines := strings.SplitAfter(rest, "\n")
+	offset := 0
+	for _, line := range lines {
+		if strings.TrimRight(line, "\r\n") == "---" {
+			return rest[:offset], rest[offset+len(line):], true, nil
+		}
+		offset += len(line)
+	}
+	return "", "", false, fmt.Errorf("frontmatter is not closed with ---")
+}
+
+// isStringOrStringList reports whether v is a string or a list of strings.
+func isStringOrStringList(v interface{}) bool {
+	switch t := v.(type) {
+	case string:
+		return true
+	case []interface{}:
+		for _, item := range t {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package claude
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintCommandContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // substrings expected in issue messages, in order
+	}{
+		{
+			name:    "valid",
+			content: "---\ndescription: Review a file\nargument-hint: [file]\nallowed-tools: Read, Bash(git diff:*)\n---\nReview $1.\n",
+		},
+		{
+			name:    "no frontmatter",
+			content: "Summarize $ARGUMENTS\n",
+			want:    []string{"missing description"},
+		},
+		{
+			name:    "unclosed frontmatter",
+			content: "---\ndescription: x\n",
+			want:    []string{"not closed"},
+		},
+		{
+			name:    "invalid yaml",
+			content: "---\ndescription: [oops\n---\nbody\n",
+			want:    []string{"invalid frontmatter"},
+		},
+		{
+			name:    "bad fields",
+			content: "---\ndescription: x\nallowed-tools: 3\ncolor: red\n---\nbody\n",
+			want:    []string{"unknown frontmatter field \"color\"", "allowed-tools must be"},
+		},
+		{
+			name:    "placeholder gaps",
+			content: "---\ndescription: x\n---\nFix $2 using $ARGUMENTS\n",
+			want:    []string{"uses $2 but not $1", "mixes $ARGUMENTS", "no argument-hint"},
+		},
+		{
+			name:    "unused hint",
+			content: "---\ndescription: x\nargument-hint: <pr>\n---\nbody\n",
+			want:    []string{"argument-hint is set"},
+		},
+		{
+			name:    "empty body",
+			content: "---\ndescription: x\n---\n\n",
+			want:    []string{"body is empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintCommandContent(tt.content)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("got %d issues %v, want %d", len(issues), issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(issues[i].Message, want) {
+					t.Errorf("issue %d = %q, want it to contain %q", i, issues[i].Message, want)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package claude
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,10 +11,11 @@ import (
 
 // MainConfig represents the ~/.claude.json structure.
 type MainConfig struct {
-	NumStartups   int                `json:"numStartups,omitempty" yaml:"numStartups,omitempty"`
-	InstallMethod string             `json:"installMethod,omitempty" yaml:"installMethod,omitempty"`
-	AutoUpdates   bool               `json:"autoUpdates,omitempty" yaml:"autoUpdates,omitempty"`
-	Projects      map[string]Project `json:"projects,omitempty" yaml:"projects,omitempty"`
+	NumStartups   int                  `json:"numStartups,omitempty" yaml:"numStartups,omitempty"`
+	InstallMethod string               `json:"installMethod,omitempty" yaml:"installMethod,omitempty"`
+	AutoUpdates   bool                 `json:"autoUpdates,omitempty" yaml:"autoUpdates,omitempty"`
+	MCPServers    map[string]MCPServer `json:"mcpServers,omitempty" yaml:"mcpServers,omitempty"`
+	Projects      map[string]Project   `json:"projects,omitempty" yaml:"projects,omitempty"`
 }
 
 // Project represents a Claude Code project configuration.
@@ -29,12 +31,37 @@ type Project struct {
 	LastModelUsage         map[string]interface{} `json:"lastModelUsage,omitempty" yaml:"lastModelUsage,omitempty"`
 }
 
+// MCP server transport types.
+const (
+	MCPTypeStdio = "stdio"
+	MCPTypeHTTP  = "http"
+	MCPTypeSSE   = "sse"
+)
+
+// MCP server scopes, in order of precedence when a name is defined at
+// several: local (one project, in ~/.claude.json), project (.mcp.json in
+// the project, shared through version control) and user (every project,
+// top level of ~/.claude.json).
+const (
+	MCPScopeLocal   = "local"
+	MCPScopeProject = "project"
+	MCPScopeUser    = "user"
+	MCPScopeAll     = "all"
+)
+
+// MCPScopes lists the scopes accepted by GetMCPServers.
+var MCPScopes = []string{MCPScopeUser, MCPScopeProject, MCPScopeLocal, MCPScopeAll}
+
+// mcpScopeRank orders scopes by precedence.
+var mcpScopeRank = map[string]int{MCPScopeLocal: 0, MCPScopeProject: 1, MCPScopeUser: 2}
+
 // MCPServer represents an MCP server configuration.
 type MCPServer struct {
-	Type    string   `json:"type" yaml:"type"`
-	URL     string   `json:"url,omitempty" yaml:"url,omitempty"`
-	Command string   `json:"command,omitempty" yaml:"command,omitempty"`
-	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+	Type    string            `json:"type" yaml:"type"`
+	URL     string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Command string            `json:"command,omitempty" yaml:"command,omitempty"`
+	Args    []string          `json:"args,omitempty" yaml:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
 }
 
 // MCPConfig represents the local .mcp.json structure.
@@ -57,15 +84,21 @@ type ProjectsView struct {
 
 // MCPServerView is a simplified view of an MCP server for display.
 type MCPServerView struct {
-	Name    string `json:"name" yaml:"name"`
-	Type    string `json:"type" yaml:"type"`
-	URL     string `json:"url" yaml:"url"`
-	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"`
+	URL         string `json:"url" yaml:"url"`
+	Scope       string `json:"scope" yaml:"scope"`
+	Project     string `json:"project,omitempty" yaml:"project,omitempty"`
+	ProjectPath string `json:"project_path,omitempty" yaml:"project_path,omitempty"`
+	Source      string `json:"source" yaml:"source"` // file the server is defined in
 }
 
-// MCPView is the list of MCP servers for display.
+// MCPView is the list of MCP servers for display. LocalFile and HasLocal
+// describe the .mcp.json in the current directory, whose servers have
+// project scope.
 type MCPView struct {
 	Servers   []MCPServerView `json:"servers" yaml:"servers"`
+	Scope     string          `json:"scope" yaml:"scope"`
 	LocalFile string          `json:"local_file,omitempty" yaml:"local_file,omitempty"`
 	HasLocal  bool            `json:"has_local" yaml:"has_local"`
 }
@@ -128,8 +161,83 @@ func (h *Helper) GetProjects() (*ProjectsView, error) {
 	return view, nil
 }
 
-// GetMCPServers returns a list of MCP servers for display.
-func (h *Helper) GetMCPServers() (*MCPView, error) {
+// PruneResult lists project entries removed (or to be removed) from
+// ~/.claude.json because their directories no longer exist.
+type PruneResult struct {
+	Config    string        `json:"config" yaml:"config"`
+	Removed   []ProjectView `json:"removed" yaml:"removed"`
+	TotalCost float64       `json:"total_cost" yaml:"total_cost"`
+	Applied   bool          `json:"applied" yaml:"applied"`
+}
+
+// PruneProjects finds trusted project entries whose path no longer exists.
+// With apply set they are removed from ~/.claude.json; every other field in
+// the file is kept as is.
+func (h *Helper) PruneProjects(apply bool) (*PruneResult, error) {
+	projects, err := h.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{Config: h.paths.Config, Removed: []ProjectView{}}
+	for _, p := range projects.Projects {
+		if _, err := os.Stat(p.Path); os.IsNotExist(err) {
+			result.Removed = append(result.Removed, p)
+			result.TotalCost += p.Cost
+		}
+	}
+
+	if !apply || len(result.Removed) == 0 {
+		return result, nil
+	}
+
+	// Work on raw JSON so fields not modeled by MainConfig survive the rewrite
+	var raw map[string]json.RawMessage
+	if err := h.ReadJSONFile(h.paths.Config, &raw); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	var rawProjects map[string]json.RawMessage
+	if err := json.Unmarshal(raw["projects"], &rawProjects); err != nil {
+		return nil, fmt.Errorf("failed to read projects: %w", err)
+	}
+
+	for _, p := range result.Removed {
+		delete(rawProjects, p.Path)
+	}
+
+	data, err := json.Marshal(rawProjects)
+	if err != nil {
+		return nil, err
+	}
+	raw["projects"] = data
+
+	if err := h.WriteJSONFile(h.paths.Config, raw); err != nil {
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+	result.Applied = !h.dryRun
+
+	return result, nil
+}
+
+// ValidateMCPScope checks that scope is one of MCPScopes.
+func ValidateMCPScope(scope string) error {
+	for _, s := range MCPScopes {
+		if scope == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown scope %q (use: %s)", scope, strings.Join(MCPScopes, ", "))
+}
+
+// GetMCPServers returns the MCP servers defined at scope (or all scopes),
+// each labeled with the scope and file it comes from. Servers are sorted
+// by name, then by scope precedence.
+func (h *Helper) GetMCPServers(scope string) (*MCPView, error) {
+	if err := ValidateMCPScope(scope); err != nil {
+		return nil, err
+	}
+	want := func(s string) bool { return scope == MCPScopeAll || scope == s }
+
 	config, err := h.GetMainConfig()
 	if err != nil {
 		return nil, err
@@ -137,57 +245,121 @@ func (h *Helper) GetMCPServers() (*MCPView, error) {
 
 	view := &MCPView{
 		Servers: []MCPServerView{},
+		Scope:   scope,
 	}
 
-	// Get servers from projects
-	for path, project := range config.Projects {
-		for name, server := range project.MCPServers {
-			url := server.URL
-			if url == "" {
-				url = server.Command
+	if want(MCPScopeUser) {
+		for name, server := range config.MCPServers {
+			view.Servers = append(view.Servers, newMCPServerView(name, server, MCPScopeUser, "", h.paths.Config))
+		}
+	}
+
+	if want(MCPScopeLocal) {
+		for path, project := range config.Projects {
+			for name, server := range project.MCPServers {
+				view.Servers = append(view.Servers, newMCPServerView(name, server, MCPScopeLocal, path, h.paths.Config))
 			}
-			view.Servers = append(view.Servers, MCPServerView{
-				Name:    name,
-				Type:    server.Type,
-				URL:     url,
-				Project: filepath.Base(path),
-			})
 		}
 	}
 
-	// Check for local .mcp.json
+	// Project servers come from .mcp.json in the current directory
 	localMCPPath := ".mcp.json"
 	if h.FileExists(localMCPPath) {
 		view.HasLocal = true
 		view.LocalFile = localMCPPath
 
 		var mcpConfig MCPConfig
-		if err := h.ReadJSONFile(localMCPPath, &mcpConfig); err == nil {
+		if want(MCPScopeProject) && h.ReadJSONFile(localMCPPath, &mcpConfig) == nil {
+			cwd, _ := os.Getwd()
 			for name, server := range mcpConfig.MCPServers {
-				url := server.URL
-				if url == "" {
-					url = server.Command
-				}
-				view.Servers = append(view.Servers, MCPServerView{
-					Name:    name,
-					Type:    server.Type,
-					URL:     url,
-					Project: "(local)",
-				})
+				view.Servers = append(view.Servers, newMCPServerView(name, server, MCPScopeProject, cwd, localMCPPath))
 			}
 		}
 	}
 
-	// Sort by name
 	sort.Slice(view.Servers, func(i, j int) bool {
-		return view.Servers[i].Name < view.Servers[j].Name
+		a, b := view.Servers[i], view.Servers[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Scope != b.Scope {
+			return mcpScopeRank[a.Scope] < mcpScopeRank[b.Scope]
+		}
+		return a.ProjectPath < b.ProjectPath
 	})
 
 	return view, nil
 }
 
-// AddMCPServer adds an MCP server to the local .mcp.json.
-func (h *Helper) AddMCPServer(name, url, serverType string) error {
+// newMCPServerView builds the display view of a server defined at scope.
+func newMCPServerView(name string, server MCPServer, scope, projectPath, source string) MCPServerView {
+	url := server.URL
+	if url == "" {
+		url = server.Command
+	}
+	view := MCPServerView{
+		Name:        name,
+		Type:        server.Type,
+		URL:         url,
+		Scope:       scope,
+		ProjectPath: projectPath,
+		Source:      source,
+	}
+	if projectPath != "" {
+		view.Project = filepath.Base(projectPath)
+	}
+	return view
+}
+
+// ValidateMCPServer checks that a server has the fields its transport needs:
+// stdio servers are launched from a command, http and sse servers are
+// reached at a URL.
+func ValidateMCPServer(server MCPServer) error {
+	switch server.Type {
+	case MCPTypeStdio:
+		if server.Command == "" {
+			return fmt.Errorf("stdio servers require a command")
+		}
+		if server.URL != "" {
+			return fmt.Errorf("stdio servers take a command, not a URL")
+		}
+	case MCPTypeHTTP, MCPTypeSSE:
+		if server.URL == "" {
+			return fmt.Errorf("%s servers require a URL", server.Type)
+		}
+		if server.Command != "" || len(server.Args) > 0 || len(server.Env) > 0 {
+			return fmt.Errorf("%s servers take a URL, not a command, args or env", server.Type)
+		}
+	default:
+		return fmt.Errorf("unknown server type %q (valid: %s, %s, %s)",
+			server.Type, MCPTypeStdio, MCPTypeHTTP, MCPTypeSSE)
+	}
+	return nil
+}
+
+// ParseMCPEnv parses KEY=VALUE pairs into an environment map.
+func ParseMCPEnv(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	env := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid env %q (expected KEY=VALUE)", pair)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// AddMCPServer validates server and adds it to the .mcp.json in the
+// current directory, giving it project scope.
+func (h *Helper) AddMCPServer(name string, server MCPServer) error {
+	if err := ValidateMCPServer(server); err != nil {
+		return err
+	}
+
 	mcpPath := ".mcp.json"
 
 	var mcpConfig MCPConfig
@@ -205,10 +377,7 @@ func (h *Helper) AddMCPServer(name, url, serverType string) error {
 		mcpConfig.MCPServers = make(map[string]MCPServer)
 	}
 
-	mcpConfig.MCPServers[name] = MCPServer{
-		Type: serverType,
-		URL:  url,
-	}
+	mcpConfig.MCPServers[name] = server
 
 	if err := h.WriteJSONFile(mcpPath, mcpConfig); err != nil {
 		return fmt.Errorf("failed to write .mcp.json: %w", err)
@@ -0,0 +1,181 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPruneProjects(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	live := filepath.Join(home, "live")
+	gone := filepath.Join(home, "gone")
+	if err := os.Mkdir(live, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := map[string]interface{}{
+		"userID": "abc",
+		"projects": map[string]interface{}{
+			live: map[string]interface{}{"hasTrustDialogAccepted": true, "lastCost": 1.0},
+			gone: map[string]interface{}{"hasTrustDialogAccepted": true, "lastCost": 2.5, "custom": "x"},
+		},
+	}
+	data, _ := json.Marshal(config)
+	path := filepath.Join(home, ".claude.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false, false)
+
+	preview, err := h.PruneProjects(false)
+	if err != nil {
+		t.Fatalf("PruneProjects(false): %v", err)
+	}
+	if len(preview.Removed) != 1 || preview.Removed[0].Path != gone || preview.TotalCost != 2.5 || preview.Applied {
+		t.Errorf("preview = %+v", preview)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(data) {
+		t.Error("preview modified the config")
+	}
+
+	result, err := h.PruneProjects(true)
+	if err != nil {
+		t.Fatalf("PruneProjects(true): %v", err)
+	}
+	if !result.Applied {
+		t.Error("result not applied")
+	}
+
+	var got map[string]interface{}
+	after, _ := os.ReadFile(path)
+	if err := json.Unmarshal(after, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["userID"] != "abc" {
+		t.Errorf("unmodeled field lost: %v", got)
+	}
+	projects := got["projects"].(map[string]interface{})
+	if _, ok := projects[gone]; ok {
+		t.Error("stale project not removed")
+	}
+	if _, ok := projects[live]; !ok {
+		t.Error("live project removed")
+	}
+}
+
+func TestValidateMCPServer(t *testing.T) {
+	valid := []MCPServer{
+		{Type: MCPTypeStdio, Command: "npx", Args: []string{"-y", "server"}, Env: map[string]string{"A": "1"}},
+		{Type: MCPTypeHTTP, URL: "http://localhost:8080"},
+		{Type: MCPTypeSSE, URL: "http://localhost:8080/sse"},
+	}
+	for _, server := range valid {
+		if err := ValidateMCPServer(server); err != nil {
+			t.Errorf("ValidateMCPServer(%+v) = %v", server, err)
+		}
+	}
+
+	invalid := []MCPServer{
+		{Type: MCPTypeStdio},
+		{Type: MCPTypeStdio, Command: "npx", URL: "http://localhost"},
+		{Type: MCPTypeHTTP},
+		{Type: MCPTypeHTTP, URL: "http://localhost", Command: "npx"},
+		{Type: "grpc", URL: "http://localhost"},
+	}
+	for _, server := range invalid {
+		if err := ValidateMCPServer(server); err == nil {
+			t.Errorf("ValidateMCPServer(%+v) should fail", server)
+		}
+	}
+}
+
+func TestParseMCPEnv(t *testing.T) {
+	env, err := ParseMCPEnv([]string{"TOKEN=abc", "EMPTY=", "URL=a=b"})
+	if err != nil {
+		t.Fatalf("ParseMCPEnv: %v", err)
+	}
+	if env["TOKEN"] != "abc" || env["EMPTY"] != "" || env["URL"] != "a=b" || len(env) != 3 {
+		t.Errorf("env = %v", env)
+	}
+
+	for _, pair := range []string{"TOKEN", "=abc"} {
+		if _, err := ParseMCPEnv([]string{pair}); err == nil {
+			t.Errorf("ParseMCPEnv(%q) should fail", pair)
+		}
+	}
+}
+
+func TestGetMCPServersScopes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	project := filepath.Join(home, "repo")
+	if err := os.Mkdir(project, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(project)
+
+	config := map[string]interface{}{
+		"mcpServers": map[string]interface{}{
+			"github": map[string]interface{}{"type": "stdio", "command": "gh-mcp"},
+		},
+		"projects": map[string]interface{}{
+			project: map[string]interface{}{
+				"mcpServers": map[string]interface{}{
+					"github": map[string]interface{}{"type": "http", "url": "http://localhost:9000"},
+				},
+			},
+		},
+	}
+	data, _ := json.Marshal(config)
+	if err := os.WriteFile(filepath.Join(home, ".claude.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mcp := `{"mcpServers":{"github":{"type":"sse","url":"http://shared"},"docs":{"type":"http","url":"http://docs"}}}`
+	if err := os.WriteFile(".mcp.json", []byte(mcp), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false, false)
+
+	all, err := h.GetMCPServers(MCPScopeAll)
+	if err != nil {
+		t.Fatalf("GetMCPServers(all): %v", err)
+	}
+	var got []string
+	for _, s := range all.Servers {
+		got = append(got, s.Name+"/"+s.Scope)
+	}
+	want := []string{"docs/project", "github/local", "github/project", "github/user"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("servers = %v, want %v", got, want)
+	}
+	if !all.HasLocal {
+		t.Error("HasLocal should report the .mcp.json")
+	}
+
+	user, err := h.GetMCPServers(MCPScopeUser)
+	if err != nil {
+		t.Fatalf("GetMCPServers(user): %v", err)
+	}
+	if len(user.Servers) != 1 || user.Servers[0].URL != "gh-mcp" || user.Servers[0].Source != h.paths.Config || user.Servers[0].Project != "" {
+		t.Errorf("user scope = %+v", user.Servers)
+	}
+
+	local, err := h.GetMCPServers(MCPScopeLocal)
+	if err != nil {
+		t.Fatalf("GetMCPServers(local): %v", err)
+	}
+	if len(local.Servers) != 1 || local.Servers[0].Project != "repo" || local.Servers[0].ProjectPath != project {
+		t.Errorf("local scope = %+v", local.Servers)
+	}
+
+	if _, err := h.GetMCPServers("global"); err == nil {
+		t.Error("expected error for unknown scope")
+	}
+}
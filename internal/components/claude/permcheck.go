@@ -0,0 +1,335 @@
+package claude
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Permission decisions. A tool use no rule matches is asked about.
+const (
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+	DecisionAsk   = "ask"
+)
+
+// PermissionCheck explains how the permission rules decide a tool use.
+type PermissionCheck struct {
+	Invocation  string `json:"invocation" yaml:"invocation"`
+	Decision    string `json:"decision" yaml:"decision"`
+	MatchedRule string `json:"matched_rule,omitempty" yaml:"matched_rule,omitempty"`
+	List        string `json:"list,omitempty" yaml:"list,omitempty"`
+	// Overridden lists allow rules that also matched but lost to a deny.
+	Overridden []string `json:"overridden,omitempty" yaml:"overridden,omitempty"`
+	// Segments holds the decision for each part of a compound Bash
+	// command; every part must be allowed for the command to be.
+	Segments []PermissionCheck `json:"segments,omitempty" yaml:"segments,omitempty"`
+	File     string            `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+// fileTools take a path specifier matched as a glob.
+var fileTools = map[string]bool{
+	"Read":         true,
+	"Edit":         true,
+	"MultiEdit":    true,
+	"Write":        true,
+	"NotebookEdit": true,
+}
+
+// toolUseRe splits "Tool(specifier)" into its parts.
+var toolUseRe = regexp.MustCompile(`^([A-Za-z0-9_*-]+)(?:\((.*)\))?$`)
+
+// CheckPermission evaluates a tool use such as "Bash(rm -rf /)" against
+// the rules in settings.local.json.
+func (h *Helper) CheckPermission(invocation string) (*PermissionCheck, error) {
+	perms, err := h.GetPermissions()
+	if err != nil {
+		return nil, err
+	}
+	check, err := CheckPermission(invocation, perms.Allow, perms.Deny)
+	if err != nil {
+		return nil, err
+	}
+	check.File = perms.File
+	return check, nil
+}
+
+// CheckPermission evaluates a tool use against allow and deny rules. Deny
+// rules take precedence over allow rules, and a use matching neither is
+// asked about.
+func CheckPermission(invocation string, allow, deny []string) (*PermissionCheck, error) {
+	tool, arg, ok := parseToolUse(invocation)
+	if !ok {
+		return nil, fmt.Errorf("invalid tool use %q (expected Tool or Tool(argument))", invocation)
+	}
+
+	if tool == "Bash" && arg != "" {
+		if parts := splitShellCommand(arg); len(parts) > 1 {
+			return checkCompound(invocation, parts, allow, deny), nil
+		}
+	}
+	return checkSingle(invocation, tool, arg, allow, deny), nil
+}
+
+// checkSingle evaluates one tool use.
+func checkSingle(invocation, tool, arg string, allow, deny []string) *PermissionCheck {
+	check := &PermissionCheck{Invocation: invocation, Decision: DecisionAsk}
+
+	for _, rule := range deny {
+		if ruleMatches(rule, tool, arg) {
+			check.Decision = DecisionDeny
+			check.MatchedRule = rule
+			check.List = DecisionDeny
+			break
+		}
+	}
+	for _, rule := range allow {
+		if !ruleMatches(rule, tool, arg) {
+			continue
+		}
+		if check.Decision == DecisionDeny {
+			check.Overridden = append(check.Overridden, rule)
+			continue
+		}
+		check.Decision = DecisionAllow
+		check.MatchedRule = rule
+		check.List = DecisionAllow
+		break
+	}
+	return check
+}
+
+// checkCompound evaluates each part of a compound Bash command. A rule
+// for one command does not cover others chained to it, so the command is
+// denied if any part is denied and allowed only if every part is allowed.
+func checkCompound(invocation string, parts []string, allow, deny []string) *PermissionCheck {
+	check := &PermissionCheck{Invocation: invocation, Decision: DecisionAllow}
+	for _, part := range parts {
+		seg := checkSingle("Bash("+part+")", "Bash", part, allow, deny)
+		check.Segments = append(check.Segments, *seg)
+	}
+
+	for _, seg := range check.Segments {
+		switch {
+		case seg.Decision == DecisionDeny:
+			check.Decision = DecisionDeny
+			check.MatchedRule = seg.MatchedRule
+			check.List = DecisionDeny
+			return check
+		case seg.Decision == DecisionAsk:
+			check.Decision = DecisionAsk
+		}
+	}
+	if check.Decision == DecisionAllow {
+		check.MatchedRule = check.Segments[0].MatchedRule
+		check.List = DecisionAllow
+	}
+	return check
+}
+
+// parseToolUse splits "Tool(argument)" or "Tool".
+func parseToolUse(s string) (tool, arg string, ok bool) {
+	m := toolUseRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// ruleMatches reports whether a permission rule covers a tool use. A rule
+// without a specifier covers every use of the tool; an MCP server rule
+// such as mcp__github covers all of the server's tools.
+func ruleMatches(rule, tool, arg string) bool {
+	ruleTool, spec, ok := parseToolUse(rule)
+	if !ok {
+		return false
+	}
+	if !toolMatches(ruleTool, tool) {
+		return false
+	}
+	if spec == "" {
+		return true
+	}
+	if arg == "" {
+		return false
+	}
+
+	switch {
+	case tool == "Bash":
+		return bashRuleMatches(spec, arg)
+	case fileTools[tool]:
+		return pathRuleMatches(spec, arg)
+	case tool == "WebFetch":
+		return domainRuleMatches(spec, arg)
+	default:
+		return globMatch(spec, arg, false)
+	}
+}
+
+// toolMatches compares a rule's tool name with a tool.
+func toolMatches(ruleTool, tool string) bool {
+	if ruleTool == tool {
+		return true
+	}
+	if strings.HasPrefix(ruleTool, "mcp__") {
+		if strings.HasPrefix(tool, ruleTool+"__") {
+			return true
+		}
+		return strings.Contains(ruleTool, "*") && globMatch(ruleTool, tool, false)
+	}
+	return false
+}
+
+// bashRuleMatches matches a Bash specifier. "cmd:*" covers cmd and cmd
+// followed by arguments, "*" is a wildcard, anything else must match
+// exactly.
+func bashRuleMatches(spec, command string) bool {
+	command = strings.TrimSpace(command)
+	if prefix, ok := strings.CutSuffix(spec, ":*"); ok {
+		return command == prefix || strings.HasPrefix(command, prefix+" ")
+	}
+	if strings.Contains(spec, "*") {
+		return globMatch(spec, command, false)
+	}
+	return command == spec
+}
+
+// pathRuleMatches matches a file tool specifier, a glob where ** spans
+// directories. "//path" is absolute, "~/path" is under the home directory
+// and other paths are relative to the current (project) directory.
+func pathRuleMatches(spec, path string) bool {
+	return globMatch(resolveRulePath(spec), resolvePath(path), true)
+}
+
+// resolveRulePath turns a path rule into an absolute glob.
+func resolveRulePath(spec string) string {
+	switch {
+	case strings.HasPrefix(spec, "//"):
+		return filepath.Clean(spec[1:])
+	case strings.HasPrefix(spec, "~/"):
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, spec[2:])
+	}
+	// "/path" and "path" are both relative to the project directory.
+	cwd, _ := os.Getwd()
+	return filepath.Join(cwd, spec)
+}
+
+// resolvePath makes the path of a tool use absolute.
+func resolvePath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		home, _ := os.UserHomeDir()
+		return filepath.Join(home, path[2:])
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// domainRuleMatches matches a WebFetch "domain:host" specifier against a
+// URL or "domain:host" argument. The host may use * wildcards.
+func domainRuleMatches(spec, arg string) bool {
+	pattern, ok := strings.CutPrefix(spec, "domain:")
+	if !ok {
+		return globMatch(spec, arg, false)
+	}
+
+	host, isDomain := strings.CutPrefix(arg, "domain:")
+	if !isDomain {
+		u, err := url.Parse(arg)
+		if err != nil || u.Hostname() == "" {
+			return false
+		}
+		host = u.Hostname()
+	}
+	return globMatch(strings.ToLower(pattern), strings.ToLower(host), false)
+}
+
+// globMatch matches s against a pattern with * and ? wildcards. With
+// paths, * and ? stop at a slash and ** matches across directories.
+func globMatch(pattern, s string, paths bool) bool {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && paths && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				// "**/" also matches no directories at all.
+				i++
+				b.WriteString("(?:.*/)?")
+			} else {
+				b.WriteString(".*")
+			}
+		case c == '*' && paths:
+			b.WriteString("[^/]*")
+		case c == '*':
+			b.WriteString(".*")
+		case c == '?' && paths:
+			b.WriteString("[^/]")
+		case c == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(s)
+}
+
+// splitShellCommand splits a command line on unquoted &&, ||, ; and |.
+func splitShellCommand(command string) []string {
+	var parts []string
+	var cur strings.Builder
+	var quote byte
+	flush := func() {
+		if part := strings.TrimSpace(cur.String()); part != "" {
+			parts = append(parts, part)
+		}
+		cur.Reset()
+	}
+
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' && i+1 < len(command) {
+				cur.WriteByte(c)
+				i++
+				c = command[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\\' && i+1 < len(command):
+			cur.WriteByte(c)
+			i++
+			c = command[i]
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ';':
+			flush()
+			continue
+		case c == '&' && i+1 < len(command) && command[i+1] == '&',
+			c == '|' && i+1 < len(command) && command[i+1] == '|':
+			flush()
+			i++
+			continue
+		case c == '|':
+			flush()
+			continue
+		}
+		cur.WriteByte(c)
+	}
+	flush()
+	return parts
+}
@@ -1,10 +1,13 @@
 package claude
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/editor"
+	"github.com/mistergrinvalds/acorn/internal/utils/textdiff"
 )
 
 // Settings represents a Claude Code settings file.
@@ -114,24 +117,82 @@ func (h *Helper) GetSettingsRaw(st SettingsType) (map[string]interface{}, error)
 	return raw, nil
 }
 
+// EditResult is the outcome of editing a settings file with snapshotting.
+type EditResult struct {
+	Path       string `json:"path" yaml:"path"`
+	Changed    bool   `json:"changed" yaml:"changed"`
+	Valid      bool   `json:"valid" yaml:"valid"`
+	ParseError string `json:"parse_error,omitempty" yaml:"parse_error,omitempty"`
+	Diff       string `json:"diff,omitempty" yaml:"diff,omitempty"`
+	Reverted   bool   `json:"reverted,omitempty" yaml:"reverted,omitempty"`
+
+	session *editor.Session
+}
+
 // EditSettings opens the settings file in the user's editor.
 func (h *Helper) EditSettings(st SettingsType) error {
 	path := h.GetSettingsPath(st)
 	if !h.FileExists(path) {
 		return fmt.Errorf("settings file not found: %s", path)
 	}
+	return editor.Run(path)
+}
+
+// EditSettingsWithDiff snapshots the settings file, opens it in the user's
+// editor and, once the editor exits, reports what changed and whether the
+// file is still valid JSON. Use RevertEdit to restore the snapshot.
+func (h *Helper) EditSettingsWithDiff(st SettingsType) (*EditResult, error) {
+	path := h.GetSettingsPath(st)
+	if !h.FileExists(path) {
+		return nil, fmt.Errorf("settings file not found: %s", path)
+	}
+
+	session, err := editor.Start(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	after, err := session.Edit()
+	if err != nil {
+		return nil, err
+	}
+
+	return compareEdit(path, session.Original(), after), nil
+}
+
+// RevertEdit restores the settings file to its content before the edit.
+func (h *Helper) RevertEdit(result *EditResult) error {
+	if result.session == nil {
+		return fmt.Errorf("no snapshot to revert to")
+	}
+	if h.dryRun {
+		fmt.Printf("[dry-run] would restore %s\n", result.Path)
+		return nil
+	}
 
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
+	if err := result.session.Revert(); err != nil {
+		return err
 	}
+	result.Reverted = true
+	return nil
+}
 
-	cmd := exec.Command(editor, path)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// compareEdit builds an EditResult from the file content before and after
+// editing.
+func compareEdit(path string, before, after []byte) *EditResult {
+	result := &EditResult{Path: path, Valid: true, session: editor.NewSession(path, before)}
 
-	return cmd.Run()
+	var v interface{}
+	if err := json.Unmarshal(after, &v); err != nil {
+		result.Valid = false
+		result.ParseError = err.Error()
+	}
+
+	if bytes.Equal(before, after) {
+		return result
+	}
+	result.Changed = true
+	result.Diff = textdiff.Unified(path+" (before)", string(before), path+" (after)", string(after))
+	return result
 }
 
 // GetPermissions reads permissions from settings.local.json.
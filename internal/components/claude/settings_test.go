@@ -0,0 +1,47 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareEdit(t *testing.T) {
+	before := []byte(`{"a": 1}`)
+
+	same := compareEdit("settings.json", before, before)
+	if same.Changed || !same.Valid || same.Diff != "" {
+		t.Errorf("unchanged: got %+v", same)
+	}
+
+	broken := compareEdit("settings.json", before, []byte(`{"a": 2,`))
+	if !broken.Changed || broken.Valid || broken.ParseError == "" {
+		t.Errorf("broken: got %+v", broken)
+	}
+}
+
+func TestRevertEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(`{"a": 2,`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result := compareEdit(path, []byte(`{"a": 1}`), []byte(`{"a": 2,`))
+	if err := NewHelper(false, false).RevertEdit(result); err != nil {
+		t.Fatalf("RevertEdit: %v", err)
+	}
+	if !result.Reverted {
+		t.Error("result not marked reverted")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a": 1}` {
+		t.Errorf("content = %q", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
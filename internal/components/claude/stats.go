@@ -231,3 +231,74 @@ func (h *Helper) GetDailyUsage(days int) (*DailyUsage, error) {
 
 	return usage, nil
 }
+
+// CostUsage is a view of token usage and cost by model.
+type CostUsage struct {
+	Models   []ModelCost `json:"models" yaml:"models"`
+	TotalUSD float64     `json:"total_usd" yaml:"total_usd"`
+}
+
+// ModelCost shows token counts and recorded cost for a model.
+type ModelCost struct {
+	Model                    string  `json:"model" yaml:"model"`
+	InputTokens              int     `json:"input_tokens" yaml:"input_tokens"`
+	OutputTokens             int     `json:"output_tokens" yaml:"output_tokens"`
+	CacheReadInputTokens     int     `json:"cache_read_tokens" yaml:"cache_read_tokens"`
+	CacheCreationInputTokens int     `json:"cache_creation_tokens" yaml:"cache_creation_tokens"`
+	CostUSD                  float64 `json:"cost_usd" yaml:"cost_usd"`
+}
+
+// GetCostUsage returns token usage and cost by model, most expensive first.
+func (h *Helper) GetCostUsage() (*CostUsage, error) {
+	stats, err := h.GetStats()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &CostUsage{Models: []ModelCost{}}
+	for model, mu := range stats.ModelUsage {
+		usage.Models = append(usage.Models, ModelCost{
+			Model:                    model,
+			InputTokens:              mu.InputTokens,
+			OutputTokens:             mu.OutputTokens,
+			CacheReadInputTokens:     mu.CacheReadInputTokens,
+			CacheCreationInputTokens: mu.CacheCreationInputTokens,
+			CostUSD:                  mu.CostUSD,
+		})
+		usage.TotalUSD += mu.CostUSD
+	}
+
+	sort.Slice(usage.Models, func(i, j int) bool {
+		if usage.Models[i].CostUSD != usage.Models[j].CostUSD {
+			return usage.Models[i].CostUSD > usage.Models[j].CostUSD
+		}
+		return usage.Models[i].Model < usage.Models[j].Model
+	})
+
+	return usage, nil
+}
+
+// DailyUsageRow is one model's tokens on one day, the flat form of
+// DailyUsage used for CSV output.
+type DailyUsageRow struct {
+	Date     string `json:"date" yaml:"date"`
+	Model    string `json:"model" yaml:"model"`
+	Tokens   int    `json:"tokens" yaml:"tokens"`
+	DayTotal int    `json:"day_total" yaml:"day_total"`
+}
+
+// Rows flattens daily usage to one row per day per model.
+func (u *DailyUsage) Rows() []DailyUsageRow {
+	rows := []DailyUsageRow{}
+	for _, day := range u.Days {
+		for _, m := range day.Models {
+			rows = append(rows, DailyUsageRow{
+				Date:     day.Date,
+				Model:    m.Model,
+				Tokens:   m.Tokens,
+				DayTotal: day.Total,
+			})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,139 @@
+package claude
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
+)
+
+// StatsManifestFile is written last by ExportStats and marks a directory as
+// holding a stats export.
+const StatsManifestFile = "manifest.json"
+
+// statsExportFiles are the reports written by ExportStats.
+var statsExportFiles = []string{"summary.json", "tokens-by-model.json", "daily.csv", "cost.csv"}
+
+// StatsExport describes a stats export; it is also the manifest.
+type StatsExport struct {
+	Dir           string   `json:"-" yaml:"-"`
+	ExportedAt    string   `json:"exported_at" yaml:"exported_at"`
+	ClaudeVersion string   `json:"claude_version" yaml:"claude_version"`
+	LastComputed  string   `json:"stats_last_computed,omitempty" yaml:"stats_last_computed,omitempty"`
+	Files         []string `json:"files" yaml:"files"`
+}
+
+// ExportStats writes the summary, token and cost reports and the full
+// daily history into dir, followed by a manifest. It refuses to overwrite
+// an earlier export unless force is set.
+func (h *Helper) ExportStats(dir string, force bool) (*StatsExport, error) {
+	stats, err := h.GetStats()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExportDir(dir, force, h.dryRun); err != nil {
+		return nil, err
+	}
+
+	export := &StatsExport{
+		Dir:           dir,
+		ExportedAt:    time.Now().UTC().Format(time.RFC3339),
+		ClaudeVersion: "not found",
+		LastComputed:  stats.LastComputedDate,
+		Files:         append([]string{}, statsExportFiles...),
+	}
+	if version, err := h.GetVersion(); err == nil {
+		export.ClaudeVersion = version
+	}
+
+	if h.dryRun {
+		for _, name := range append(export.Files, StatsManifestFile) {
+			fmt.Printf("[dry-run] would write: %s\n", filepath.Join(dir, name))
+		}
+		return export, nil
+	}
+
+	summary, err := h.GetStatsSummary()
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := h.GetTokenUsage()
+	if err != nil {
+		return nil, err
+	}
+	daily, err := h.GetDailyUsage(math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := h.GetCostUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.WriteJSONFile(filepath.Join(dir, "summary.json"), summary); err != nil {
+		return nil, fmt.Errorf("failed to write summary.json: %w", err)
+	}
+	if err := h.WriteJSONFile(filepath.Join(dir, "tokens-by-model.json"), tokens); err != nil {
+		return nil, fmt.Errorf("failed to write tokens-by-model.json: %w", err)
+	}
+	if err := writeCSVFile(filepath.Join(dir, "daily.csv"), daily.Rows()); err != nil {
+		return nil, err
+	}
+	if err := writeCSVFile(filepath.Join(dir, "cost.csv"), cost.Models); err != nil {
+		return nil, err
+	}
+	if err := h.WriteJSONFile(filepath.Join(dir, StatsManifestFile), export); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", StatsManifestFile, err)
+	}
+
+	return export, nil
+}
+
+// checkExportDir ensures dir is a writable directory, creating it unless
+// dryRun is set, that does not already hold an export unless force is set.
+func checkExportDir(dir string, force, dryRun bool) error {
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err) && dryRun:
+		return nil
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create export directory: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to check export directory: %w", err)
+	case !info.IsDir():
+		return fmt.Errorf("%s exists and is not a directory", dir)
+	}
+
+	if !force {
+		for _, name := range append(statsExportFiles, StatsManifestFile) {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return fmt.Errorf("%s already contains a stats export (use --force to overwrite)", dir)
+			}
+		}
+	}
+
+	if err := ioutils.CheckWritableDir(dir); err != nil {
+		return fmt.Errorf("export directory %s is not writable: %w", dir, err)
+	}
+	return nil
+}
+
+// writeCSVFile writes rows as CSV to path, with a header of their fields.
+func writeCSVFile(path string, rows any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+
+	if err := ioutils.WriteCSV(f, rows, nil); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return f.Close()
+}
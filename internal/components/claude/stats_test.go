@@ -0,0 +1,142 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatsRows(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stats := `{
+  "dailyModelTokens": [
+    {"date": "2026-01-01", "tokensByModel": {"opus": 300, "haiku": 100}},
+    {"date": "2026-01-02", "tokensByModel": {"haiku": 50}}
+  ],
+  "modelUsage": {
+    "opus":  {"inputTokens": 10, "outputTokens": 20, "cacheReadInputTokens": 30, "cacheCreationInputTokens": 40, "costUSD": 1.5},
+    "haiku": {"inputTokens": 1, "outputTokens": 2, "cacheReadInputTokens": 3, "cacheCreationInputTokens": 4, "costUSD": 0.25}
+  }
+}`
+	if err := os.WriteFile(filepath.Join(home, ".claude", "stats-cache.json"), []byte(stats), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false, false)
+
+	daily, err := h.GetDailyUsage(7)
+	if err != nil {
+		t.Fatalf("GetDailyUsage: %v", err)
+	}
+	wantDaily := []DailyUsageRow{
+		{"2026-01-01", "opus", 300, 400},
+		{"2026-01-01", "haiku", 100, 400},
+		{"2026-01-02", "haiku", 50, 50},
+	}
+	if got := daily.Rows(); !reflect.DeepEqual(got, wantDaily) {
+		t.Errorf("daily rows = %v, want %v", got, wantDaily)
+	}
+
+	cost, err := h.GetCostUsage()
+	if err != nil {
+		t.Fatalf("GetCostUsage: %v", err)
+	}
+	if cost.TotalUSD != 1.75 {
+		t.Errorf("total = %v, want 1.75", cost.TotalUSD)
+	}
+
+	dir := t.TempDir()
+	if err := writeCSVFile(filepath.Join(dir, "daily.csv"), daily.Rows()); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeCSVFile(filepath.Join(dir, "cost.csv"), cost.Models); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"daily.csv": "date,model,tokens,day_total\n" +
+			"2026-01-01,opus,300,400\n" +
+			"2026-01-01,haiku,100,400\n" +
+			"2026-01-02,haiku,50,50\n",
+		"cost.csv": "model,input_tokens,output_tokens,cache_read_tokens,cache_creation_tokens,cost_usd\n" +
+			"opus,10,20,30,40,1.5\n" +
+			"haiku,1,2,3,4,0.25\n",
+	} {
+		got, _ := os.ReadFile(filepath.Join(dir, name))
+		if string(got) != want {
+			t.Errorf("%s =\n%s\nwant\n%s", name, got, want)
+		}
+	}
+}
+
+func TestExportStats(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stats := `{
+  "lastComputedDate": "2026-01-09",
+  "dailyModelTokens": [
+    {"date": "2026-01-01", "tokensByModel": {"opus": 300}},
+    {"date": "2026-01-02", "tokensByModel": {"opus": 1}},
+    {"date": "2026-01-03", "tokensByModel": {"opus": 2}},
+    {"date": "2026-01-04", "tokensByModel": {"opus": 3}},
+    {"date": "2026-01-05", "tokensByModel": {"opus": 4}},
+    {"date": "2026-01-06", "tokensByModel": {"opus": 5}},
+    {"date": "2026-01-07", "tokensByModel": {"opus": 6}},
+    {"date": "2026-01-08", "tokensByModel": {"opus": 7}}
+  ],
+  "modelUsage": {"opus": {"inputTokens": 10, "outputTokens": 20, "costUSD": 1.5}}
+}`
+	if err := os.WriteFile(filepath.Join(home, ".claude", "stats-cache.json"), []byte(stats), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\necho '2.1.0 (Claude Code)'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	h := NewHelper(false, false)
+	dir := filepath.Join(t.TempDir(), "2026-01")
+	export, err := h.ExportStats(dir, false)
+	if err != nil {
+		t.Fatalf("ExportStats: %v", err)
+	}
+	if export.ClaudeVersion != "2.1.0 (Claude Code)" || export.LastComputed != "2026-01-09" {
+		t.Errorf("export = %+v", export)
+	}
+
+	var manifest StatsExport
+	if err := h.ReadJSONFile(filepath.Join(dir, StatsManifestFile), &manifest); err != nil {
+		t.Fatalf("reading manifest: %v", err)
+	}
+	if manifest.ExportedAt == "" || len(manifest.Files) != 4 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	for _, name := range manifest.Files {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+
+	// The daily report holds the full history, not just the last week.
+	daily, _ := os.ReadFile(filepath.Join(dir, "daily.csv"))
+	if !strings.Contains(string(daily), "2026-01-01,opus,300,300") {
+		t.Errorf("daily.csv is missing the first day:\n%s", daily)
+	}
+
+	if _, err := h.ExportStats(dir, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("second export without force: err = %v", err)
+	}
+	if _, err := h.ExportStats(dir, true); err != nil {
+		t.Errorf("second export with force: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package claude
+
+import (
+	"os"
+	"time"
+)
+
+// WatchSnapshot is one reading of the info summary taken by a Watcher.
+type WatchSnapshot struct {
+	Info        *Info     `json:"info" yaml:"info"`
+	TotalTokens int       `json:"total_tokens" yaml:"total_tokens"`
+	UpdatedAt   time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+// WatchDelta is the change between two snapshots.
+type WatchDelta struct {
+	Sessions int `json:"sessions" yaml:"sessions"`
+	Messages int `json:"messages" yaml:"messages"`
+	Tokens   int `json:"tokens" yaml:"tokens"`
+}
+
+// IsZero reports whether nothing changed.
+func (d WatchDelta) IsZero() bool {
+	return d.Sessions == 0 && d.Messages == 0 && d.Tokens == 0
+}
+
+// fileStamp identifies a version of a file without reading it.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// equal reports whether two stamps are of the same file version. Times are
+// compared with Equal, since == also compares location and monotonic
+// clock readings.
+func (s fileStamp) equal(o fileStamp) bool {
+	return s.exists == o.exists && s.size == o.size && s.modTime.Equal(o.modTime)
+}
+
+// Watcher re-reads the stats cache and config when they change on disk.
+// Files are stat'ed on each poll and only re-parsed when their size or
+// modification time differ from the previous poll.
+type Watcher struct {
+	helper  *Helper
+	version string
+	stamps  map[string]fileStamp
+	last    *WatchSnapshot
+}
+
+// NewWatcher creates a Watcher. The CLI version is looked up once.
+func (h *Helper) NewWatcher() *Watcher {
+	version, err := h.GetVersion()
+	if err != nil {
+		version = "not found"
+	}
+	return &Watcher{
+		helper:  h,
+		version: version,
+		stamps:  make(map[string]fileStamp),
+	}
+}
+
+// Poll returns the current snapshot, whether the files changed since the
+// previous poll, and the delta from the previous snapshot.
+func (w *Watcher) Poll() (*WatchSnapshot, bool, WatchDelta) {
+	paths := w.helper.paths
+	changed := w.last == nil
+	for _, path := range []string{paths.StatsCache, paths.Config} {
+		stamp := statFile(path)
+		if !stamp.equal(w.stamps[path]) {
+			w.stamps[path] = stamp
+			changed = true
+		}
+	}
+
+	if !changed {
+		return w.last, false, WatchDelta{}
+	}
+
+	info := w.helper.getLocalInfo()
+	info.Version = w.version
+
+	snap := &WatchSnapshot{Info: info, UpdatedAt: time.Now()}
+	if info.StatsExist {
+		if stats, err := w.helper.GetStats(); err == nil {
+			for _, usage := range stats.ModelUsage {
+				snap.TotalTokens += usage.InputTokens + usage.OutputTokens
+			}
+		}
+	}
+
+	var delta WatchDelta
+	if w.last != nil {
+		delta = WatchDelta{
+			Sessions: info.TotalSessions - w.last.Info.TotalSessions,
+			Messages: info.TotalMessages - w.last.Info.TotalMessages,
+			Tokens:   snap.TotalTokens - w.last.TotalTokens,
+		}
+	}
+
+	w.last = snap
+	return snap, true, delta
+}
+
+// statFile returns the current stamp for path.
+func statFile(path string) fileStamp {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: fi.ModTime(), size: fi.Size(), exists: true}
+}
@@ -0,0 +1,82 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStampEqual(t *testing.T) {
+	now := time.Now()
+	base := fileStamp{modTime: now, size: 10, exists: true}
+
+	// Same instant, but without the monotonic reading and in another zone
+	same := fileStamp{modTime: now.Round(0).In(time.FixedZone("X", 3600)), size: 10, exists: true}
+	if base == same {
+		t.Fatal("test stamps should differ under ==")
+	}
+	if !base.equal(same) {
+		t.Error("stamps of the same instant should be equal")
+	}
+
+	for name, other := range map[string]fileStamp{
+		"mtime":   {modTime: now.Add(time.Second), size: 10, exists: true},
+		"size":    {modTime: now, size: 11, exists: true},
+		"missing": {},
+	} {
+		if base.equal(other) {
+			t.Errorf("%s: stamps should differ", name)
+		}
+	}
+	if !(fileStamp{}).equal(fileStamp{}) {
+		t.Error("two missing files should be equal")
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", t.TempDir())
+	statsFile := filepath.Join(home, ".claude", "stats-cache.json")
+	if err := os.MkdirAll(filepath.Dir(statsFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeStats := func(sessions, messages, tokens int, mtime time.Time) {
+		t.Helper()
+		data := fmt.Sprintf(`{"totalSessions": %d, "totalMessages": %d, "modelUsage": {"opus": {"inputTokens": %d}}}`,
+			sessions, messages, tokens)
+		if err := os.WriteFile(statsFile, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(statsFile, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	writeStats(1, 10, 100, start)
+
+	w := NewHelper(false, false).NewWatcher()
+	snap, changed, _ := w.Poll()
+	if !changed || snap.TotalTokens != 100 {
+		t.Fatalf("first poll: changed = %v, snap = %+v", changed, snap)
+	}
+
+	if _, changed, _ := w.Poll(); changed {
+		t.Error("second poll reported a change with no file changes")
+	}
+
+	writeStats(2, 15, 250, start.Add(time.Minute))
+	snap, changed, delta := w.Poll()
+	if !changed {
+		t.Fatal("poll after a write reported no change")
+	}
+	if want := (WatchDelta{Sessions: 1, Messages: 5, Tokens: 150}); delta != want {
+		t.Errorf("delta = %+v, want %+v", delta, want)
+	}
+	if snap.TotalTokens != 250 {
+		t.Errorf("total tokens = %d, want 250", snap.TotalTokens)
+	}
+}
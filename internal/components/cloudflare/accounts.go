@@ -0,0 +1,131 @@
+package cloudflare
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// AccountEnv is the environment variable wrangler reads the target
+// account from.
+const AccountEnv = "CLOUDFLARE_ACCOUNT_ID"
+
+// Account represents a CloudFlare account the logged-in user can access.
+type Account struct {
+	Name   string `json:"name" yaml:"name"`
+	ID     string `json:"id" yaml:"id"`
+	Active bool   `json:"active" yaml:"active"`
+}
+
+// SetAccount makes every wrangler invocation target the given account ID
+// by setting CLOUDFLARE_ACCOUNT_ID. An empty ID uses wrangler's default.
+func (h *Helper) SetAccount(id string) {
+	h.accountID = id
+}
+
+// wrangler returns a wrangler command targeting the helper's account.
+func (h *Helper) wrangler(args ...string) *exec.Cmd {
+	cmd := exec.Command("wrangler", args...)
+	h.setAccountEnv(cmd)
+	return cmd
+}
+
+// setAccountEnv points cmd at the helper's account, if one is set.
+func (h *Helper) setAccountEnv(cmd *exec.Cmd) {
+	if h.accountID != "" {
+		cmd.Env = append(os.Environ(), AccountEnv+"="+h.accountID)
+	}
+}
+
+// ListAccounts lists the accounts shown by `wrangler whoami`. The active
+// account is the one set with SetAccount, or CLOUDFLARE_ACCOUNT_ID.
+func (h *Helper) ListAccounts() ([]Account, error) {
+	out, err := h.runWrangler("whoami")
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := ParseAccounts(out)
+	active := h.accountID
+	if active == "" {
+		active = os.Getenv(AccountEnv)
+	}
+	for i := range accounts {
+		accounts[i].Active = accounts[i].ID == active
+	}
+	return accounts, nil
+}
+
+// ResolveAccount validates an account given by ID or name against the
+// accounts available to the logged-in user.
+func (h *Helper) ResolveAccount(idOrName string) (*Account, error) {
+	accounts, err := h.ListAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	return findAccount(accounts, idOrName)
+}
+
+// findAccount looks an account up by ID, then by case-insensitive name.
+func findAccount(accounts []Account, idOrName string) (*Account, error) {
+	for i := range accounts {
+		if accounts[i].ID == idOrName {
+			return &accounts[i], nil
+		}
+	}
+	for i := range accounts {
+		if strings.EqualFold(accounts[i].Name, idOrName) {
+			return &accounts[i], nil
+		}
+	}
+
+	ids := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.ID)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("account not found: %s (no accounts listed)", idOrName)
+	}
+	return nil, fmt.Errorf("account not found: %s (available: %s)", idOrName, strings.Join(ids, ", "))
+}
+
+// ParseAccounts parses the account table printed by `wrangler whoami`:
+//
+//	│ Account Name   │ Account ID                       │
+//	├────────────────┼──────────────────────────────────┤
+//	│ Personal       │ 0123456789abcdef0123456789abcdef │
+//
+// Older versions print "Account Name:" and "Account ID:" lines instead.
+func ParseAccounts(out string) []Account {
+	accounts := []Account{}
+	var pending Account
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+
+		if name, ok := strings.CutPrefix(line, "Account Name:"); ok {
+			pending.Name = strings.TrimSpace(name)
+			continue
+		}
+		if id, ok := strings.CutPrefix(line, "Account ID:"); ok {
+			pending.ID = strings.TrimSpace(id)
+			accounts = append(accounts, pending)
+			pending = Account{}
+			continue
+		}
+
+		if !strings.HasPrefix(line, "│") {
+			continue
+		}
+		cells := strings.Split(strings.Trim(line, "│"), "│")
+		if len(cells) < 2 {
+			continue
+		}
+		name, id := strings.TrimSpace(cells[0]), strings.TrimSpace(cells[1])
+		if id == "" || strings.EqualFold(id, "account id") {
+			continue
+		}
+		accounts = append(accounts, Account{Name: name, ID: id})
+	}
+	return accounts
+}
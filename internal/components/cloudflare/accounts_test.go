@@ -0,0 +1,30 @@
+package cloudflare
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAccounts(t *testing.T) {
+	table := "👋 You are logged in with an OAuth Token, associated with the email me@example.com.\n┌──────────┬──────────┐\n│ Account Name │ Account ID │\n├──────────┼──────────┤\n│ Personal │ aaaa1111 │\n│ Work Co  │ bbbb2222 │\n└──────────┴──────────┘\n"
+	got := ParseAccounts(table)
+	want := []Account{{Name: "Personal", ID: "aaaa1111"}, {Name: "Work Co", ID: "bbbb2222"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("table: got %+v, want %+v", got, want)
+	}
+
+	got = ParseAccounts("You are logged in\nAccount Name: Personal\nAccount ID: aaaa1111\n")
+	if !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("lines: got %+v", got)
+	}
+
+	if a, err := findAccount(want, "bbbb2222"); err != nil || a.Name != "Work Co" {
+		t.Errorf("findAccount(id) = %+v, %v", a, err)
+	}
+	if a, err := findAccount(want, "work co"); err != nil || a.ID != "bbbb2222" {
+		t.Errorf("findAccount(name) = %+v, %v", a, err)
+	}
+	if _, err := findAccount(want, "missing"); err == nil {
+		t.Error("findAccount(missing) should fail")
+	}
+}
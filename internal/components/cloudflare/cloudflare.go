@@ -4,8 +4,12 @@ package cloudflare
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -17,6 +21,7 @@ type Status struct {
 	AccountName    string `json:"account_name,omitempty" yaml:"account_name,omitempty"`
 	AccountID      string `json:"account_id,omitempty" yaml:"account_id,omitempty"`
 	WranglerHome   string `json:"wrangler_home,omitempty" yaml:"wrangler_home,omitempty"`
+	AccountOverride bool   `json:"account_override,omitempty" yaml:"account_override,omitempty"`
 }
 
 // Worker represents a CloudFlare Worker.
@@ -29,9 +34,28 @@ type Worker struct {
 
 // PagesProject represents a CloudFlare Pages project.
 type PagesProject struct {
-	Name       string `json:"name" yaml:"name"`
-	Subdomain  string `json:"subdomain,omitempty" yaml:"subdomain,omitempty"`
-	CreatedOn  string `json:"created_on,omitempty" yaml:"created_on,omitempty"`
+	Name         string   `json:"name" yaml:"name"`
+	Subdomain    string   `json:"subdomain,omitempty" yaml:"subdomain,omitempty"`
+	CreatedOn    string   `json:"created_on,omitempty" yaml:"created_on,omitempty"`
+	Domains      []string `json:"domains,omitempty" yaml:"domains,omitempty"`
+	GitProvider  string   `json:"git_provider,omitempty" yaml:"git_provider,omitempty"`
+	LastModified string   `json:"last_modified,omitempty" yaml:"last_modified,omitempty"`
+}
+
+// PagesDeployOptions configures a Pages deployment.
+type PagesDeployOptions struct {
+	Directory     string
+	ProjectName   string
+	Branch        string
+	CommitMessage string
+}
+
+// PagesDeployment is the result of a Pages deployment.
+type PagesDeployment struct {
+	Project   string `json:"project,omitempty" yaml:"project,omitempty"`
+	Branch    string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Directory string `json:"directory" yaml:"directory"`
+	URL       string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 // R2Bucket represents a CloudFlare R2 bucket.
@@ -40,10 +64,12 @@ type R2Bucket struct {
 	CreationDate string `json:"creation_date,omitempty" yaml:"creation_date,omitempty"`
 }
 
-// KVNamespace represents a CloudFlare KV namespace.
+// KVNamespace represents a CloudFlare KV namespace. Binding is set when the
+// namespace is bound in the wrangler config of the current directory.
 type KVNamespace struct {
-	ID    string `json:"id" yaml:"id"`
-	Title string `json:"title" yaml:"title"`
+	ID      string `json:"id" yaml:"id"`
+	Title   string `json:"title" yaml:"title"`
+	Binding string `json:"binding,omitempty" yaml:"binding,omitempty"`
 }
 
 // D1Database represents a CloudFlare D1 database.
@@ -51,6 +77,22 @@ type D1Database struct {
 	UUID      string `json:"uuid" yaml:"uuid"`
 	Name      string `json:"name" yaml:"name"`
 	CreatedAt string `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	NumTables int    `json:"num_tables,omitempty" yaml:"num_tables,omitempty"`
+	FileSize  int64  `json:"file_size,omitempty" yaml:"file_size,omitempty"`
+}
+
+// D1Migration represents a D1 migration file and whether it has been applied.
+type D1Migration struct {
+	Name    string `json:"name" yaml:"name"`
+	Applied bool   `json:"applied" yaml:"applied"`
+}
+
+// D1MigrationOptions selects the database and target for migration commands.
+type D1MigrationOptions struct {
+	Database      string
+	MigrationsDir string // must match the directory wrangler uses; see D1MigrationsDir
+	Local         bool
+	Remote        bool
 }
 
 // Overview contains all CloudFlare resources.
@@ -65,8 +107,9 @@ type Overview struct {
 
 // Helper provides CloudFlare CLI helper operations.
 type Helper struct {
-	verbose bool
-	dryRun  bool
+	verbose   bool
+	dryRun    bool
+	accountID string
 }
 
 // NewHelper creates a new CloudFlare Helper.
@@ -84,7 +127,7 @@ func (h *Helper) GetStatus() (*Status, error) {
 	}
 
 	// Check if wrangler is installed
-	versionCmd := exec.Command("wrangler", "--version")
+	versionCmd := h.wrangler("--version")
 	versionOut, err := versionCmd.Output()
 	if err != nil {
 		status.Installed = false
@@ -95,7 +138,7 @@ func (h *Helper) GetStatus() (*Status, error) {
 	status.Version = strings.TrimSpace(string(versionOut))
 
 	// Check authentication
-	whoamiCmd := exec.Command("wrangler", "whoami")
+	whoamiCmd := h.wrangler("whoami")
 	whoamiOut, err := whoamiCmd.Output()
 	if err != nil {
 		status.Authenticated = false
@@ -121,6 +164,22 @@ func (h *Helper) GetStatus() (*Status, error) {
 				}
 			}
 		}
+
+		// Newer wrangler versions list every account in a table
+		if accounts := ParseAccounts(whoamiStr); len(accounts) > 0 && status.AccountID == "" {
+			status.AccountName = accounts[0].Name
+			status.AccountID = accounts[0].ID
+			if h.accountID != "" {
+				if account, err := findAccount(accounts, h.accountID); err == nil {
+					status.AccountName = account.Name
+				}
+			}
+		}
+	}
+
+	if h.accountID != "" {
+		status.AccountID = h.accountID
+		status.AccountOverride = true
 	}
 
 	return status, nil
@@ -128,7 +187,7 @@ func (h *Helper) GetStatus() (*Status, error) {
 
 // Whoami returns the current CloudFlare account.
 func (h *Helper) Whoami() (string, error) {
-	cmd := exec.Command("wrangler", "whoami")
+	cmd := h.wrangler("whoami")
 	out, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get account info: %w", err)
@@ -138,7 +197,7 @@ func (h *Helper) Whoami() (string, error) {
 
 // ListWorkers lists all Workers deployments.
 func (h *Helper) ListWorkers() (string, error) {
-	cmd := exec.Command("wrangler", "deployments", "list")
+	cmd := h.wrangler("deployments", "list")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		if len(out) > 0 {
@@ -151,7 +210,7 @@ func (h *Helper) ListWorkers() (string, error) {
 
 // ListPages lists all Pages projects.
 func (h *Helper) ListPages() (string, error) {
-	cmd := exec.Command("wrangler", "pages", "project", "list")
+	cmd := h.wrangler("pages", "project", "list")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		if len(out) > 0 {
@@ -162,48 +221,125 @@ func (h *Helper) ListPages() (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-// ListR2Buckets lists all R2 buckets.
-func (h *Helper) ListR2Buckets() (string, error) {
-	cmd := exec.Command("wrangler", "r2", "bucket", "list")
-	out, err := cmd.CombinedOutput()
+// ListPagesProjects returns the Pages projects parsed from wrangler's table output.
+func (h *Helper) ListPagesProjects() ([]PagesProject, error) {
+	out, err := h.ListPages()
 	if err != nil {
-		if len(out) > 0 {
-			return "", fmt.Errorf("%s", strings.TrimSpace(string(out)))
-		}
-		return "", fmt.Errorf("failed to list R2 buckets: %w", err)
+		return nil, err
 	}
-	return strings.TrimSpace(string(out)), nil
+	return ParsePagesProjects(out), nil
 }
 
-// ListKVNamespaces lists all KV namespaces.
-func (h *Helper) ListKVNamespaces() (string, error) {
-	cmd := exec.Command("wrangler", "kv", "namespace", "list")
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		if len(out) > 0 {
-			return "", fmt.Errorf("%s", strings.TrimSpace(string(out)))
+// ParsePagesProjects parses the box-drawn table printed by
+// `wrangler pages project list`.
+func ParsePagesProjects(out string) []PagesProject {
+	projects := []PagesProject{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "│") {
+			continue
+		}
+
+		var cells []string
+		for _, cell := range strings.Split(strings.Trim(line, "│"), "│") {
+			cells = append(cells, strings.TrimSpace(cell))
+		}
+		if len(cells) < 2 || cells[0] == "" || cells[0] == "Project Name" {
+			continue
+		}
+
+		project := PagesProject{Name: cells[0]}
+		for _, d := range strings.Split(cells[1], ",") {
+			if d = strings.TrimSpace(d); d != "" {
+				project.Domains = append(project.Domains, d)
+				if project.Subdomain == "" && strings.HasSuffix(d, ".pages.dev") {
+					project.Subdomain = d
+				}
+			}
+		}
+		if len(cells) > 2 {
+			project.GitProvider = cells[2]
+		}
+		if len(cells) > 3 {
+			project.LastModified = cells[3]
 		}
-		return "", fmt.Errorf("failed to list KV namespaces: %w", err)
+		projects = append(projects, project)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return projects
 }
 
-// ListD1Databases lists all D1 databases.
-func (h *Helper) ListD1Databases() (string, error) {
-	cmd := exec.Command("wrangler", "d1", "list")
-	out, err := cmd.CombinedOutput()
+// pagesURLPattern matches the deployment URL reported by wrangler pages deploy.
+var pagesURLPattern = regexp.MustCompile(`https://[A-Za-z0-9.-]+\.pages\.dev\S*`)
+
+// pagesBuildMarkers are files that indicate a directory holds Pages build output.
+var pagesBuildMarkers = []string{"index.html", "_worker.js", "404.html"}
+
+// DeployPages deploys a directory to CloudFlare Pages. wrangler's output is
+// copied to stream and scanned for the deployment URL.
+func (h *Helper) DeployPages(opts PagesDeployOptions, stream io.Writer) (*PagesDeployment, error) {
+	if err := validatePagesDirectory(opts.Directory); err != nil {
+		return nil, err
+	}
+
+	args := []string{"pages", "deploy", opts.Directory}
+	if opts.ProjectName != "" {
+		args = append(args, "--project-name", opts.ProjectName)
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.CommitMessage != "" {
+		args = append(args, "--commit-message", opts.CommitMessage)
+	}
+
+	deployment := &PagesDeployment{
+		Project:   opts.ProjectName,
+		Branch:    opts.Branch,
+		Directory: opts.Directory,
+	}
+
+	if h.dryRun {
+		fmt.Fprintf(stream, "[dry-run] would run: wrangler %s\n", strings.Join(args, " "))
+		return deployment, nil
+	}
+
+	var buf bytes.Buffer
+	cmd := h.wrangler(args...)
+	cmd.Stdout = io.MultiWriter(stream, &buf)
+	cmd.Stderr = io.MultiWriter(stream, &buf)
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("wrangler pages deploy failed: %w", err)
+	}
+
+	deployment.URL = pagesURLPattern.FindString(buf.String())
+	return deployment, nil
+}
+
+// validatePagesDirectory checks that dir exists and contains build output.
+func validatePagesDirectory(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("directory is required")
+	}
+	info, err := os.Stat(dir)
 	if err != nil {
-		if len(out) > 0 {
-			return "", fmt.Errorf("%s", strings.TrimSpace(string(out)))
+		return fmt.Errorf("directory not found: %s", dir)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+
+	for _, marker := range pagesBuildMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return nil
 		}
-		return "", fmt.Errorf("failed to list D1 databases: %w", err)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return fmt.Errorf("no build output in %s (expected one of: %s)", dir, strings.Join(pagesBuildMarkers, ", "))
 }
 
 // ListSecrets lists secrets for the current worker.
 func (h *Helper) ListSecrets() (string, error) {
-	cmd := exec.Command("wrangler", "secret", "list")
+	cmd := h.wrangler("secret", "list")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		if len(out) > 0 {
@@ -225,7 +361,7 @@ func (h *Helper) TailLogs(workerName string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "tail", workerName)
+	cmd := h.wrangler("tail", workerName)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -247,7 +383,7 @@ func (h *Helper) Deploy(args ...string) error {
 	}
 
 	cmdArgs := append([]string{"deploy"}, args...)
-	cmd := exec.Command("wrangler", cmdArgs...)
+	cmd := h.wrangler(cmdArgs...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -265,7 +401,7 @@ func (h *Helper) InitWorker(name string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "init", name)
+	cmd := h.wrangler("init", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -283,7 +419,7 @@ func (h *Helper) InitPages(name string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "pages", "project", "create", name)
+	cmd := h.wrangler("pages", "project", "create", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -301,7 +437,7 @@ func (h *Helper) CreateR2Bucket(name string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "r2", "bucket", "create", name)
+	cmd := h.wrangler("r2", "bucket", "create", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
@@ -318,7 +454,7 @@ func (h *Helper) CreateKVNamespace(name string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "kv", "namespace", "create", name)
+	cmd := h.wrangler("kv", "namespace", "create", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
@@ -335,12 +471,120 @@ func (h *Helper) CreateD1Database(name string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "d1", "create", name)
+	cmd := h.wrangler("d1", "create", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
+var d1NamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+var d1MigrationPattern = regexp.MustCompile(`[\w.-]+\.sql`)
+
+// ListD1Migrations lists migrations in the migrations directory along with
+// whether each has been applied to the target database.
+func (h *Helper) ListD1Migrations(opts D1MigrationOptions) ([]D1Migration, error) {
+	files, err := h.validateD1Migrations(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]string{"d1", "migrations", "list", opts.Database}, d1TargetArgs(opts)...)
+	cmd := h.wrangler(args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(out) > 0 {
+			return nil, fmt.Errorf("%s", strings.TrimSpace(string(out)))
+		}
+		return nil, fmt.Errorf("failed to list D1 migrations: %w", err)
+	}
+
+	// wrangler only lists migrations that are still pending
+	pending := make(map[string]bool)
+	for _, name := range d1MigrationPattern.FindAllString(string(out), -1) {
+		pending[name] = true
+	}
+
+	migrations := make([]D1Migration, 0, len(files))
+	for _, name := range files {
+		migrations = append(migrations, D1Migration{Name: name, Applied: !pending[name]})
+		delete(pending, name)
+	}
+	for name := range pending {
+		migrations = append(migrations, D1Migration{Name: name})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Name < migrations[j].Name })
+
+	return migrations, nil
+}
+
+// ApplyD1Migrations applies pending migrations to the target database.
+func (h *Helper) ApplyD1Migrations(opts D1MigrationOptions) error {
+	if _, err := h.validateD1Migrations(&opts); err != nil {
+		return err
+	}
+
+	args := append([]string{"d1", "migrations", "apply", opts.Database}, d1TargetArgs(opts)...)
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: wrangler %s\n", strings.Join(args, " "))
+		return nil
+	}
+
+	cmd := h.wrangler(args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// validateD1Migrations checks the options and returns the migration files found.
+func (h *Helper) validateD1Migrations(opts *D1MigrationOptions) ([]string, error) {
+	if opts.Database == "" {
+		return nil, fmt.Errorf("database name is required")
+	}
+	if !d1NamePattern.MatchString(opts.Database) {
+		return nil, fmt.Errorf("invalid database name: %q", opts.Database)
+	}
+	if opts.Local && opts.Remote {
+		return nil, fmt.Errorf("--local and --remote are mutually exclusive")
+	}
+	wranglerDir := D1MigrationsDir(".", opts.Database)
+	if opts.MigrationsDir == "" {
+		opts.MigrationsDir = wranglerDir
+	} else if filepath.Clean(opts.MigrationsDir) != filepath.Clean(wranglerDir) {
+		return nil, fmt.Errorf("wrangler applies migrations for %s from %s, not %s (set migrations_dir = %q on its d1_databases entry in the wrangler config)",
+			opts.Database, wranglerDir, opts.MigrationsDir, opts.MigrationsDir)
+	}
+
+	entries, err := os.ReadDir(opts.MigrationsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("migrations directory not found: %s (create it with 'wrangler d1 migrations create %s <name>')", opts.MigrationsDir, opts.Database)
+		}
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".sql" {
+			files = append(files, e.Name())
+		}
+	}
+	return files, nil
+}
+
+// d1TargetArgs returns the wrangler flags selecting a local or remote database.
+func d1TargetArgs(opts D1MigrationOptions) []string {
+	switch {
+	case opts.Remote:
+		return []string{"--remote"}
+	case opts.Local:
+		return []string{"--local"}
+	}
+	return nil
+}
+
 // PutSecret puts a secret for the current worker.
 func (h *Helper) PutSecret(name string) error {
 	if name == "" {
@@ -352,13 +596,35 @@ func (h *Helper) PutSecret(name string) error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "secret", "put", name)
+	cmd := h.wrangler("secret", "put", name)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 	return cmd.Run()
 }
 
+// PutSecretValue puts a secret for the current worker without prompting,
+// passing value to wrangler on stdin. The value is never printed.
+func (h *Helper) PutSecretValue(name, value string) error {
+	if name == "" {
+		return fmt.Errorf("secret name is required")
+	}
+	if value == "" {
+		return fmt.Errorf("secret value for %s is empty", name)
+	}
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: wrangler secret put %s (value: %d bytes)\n", name, len(value))
+		return nil
+	}
+
+	cmd := h.wrangler("secret", "put", name)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = strings.NewReader(value)
+	return cmd.Run()
+}
+
 // GetOverview returns an overview of all CloudFlare resources.
 func (h *Helper) GetOverview() (*Overview, error) {
 	overview := &Overview{}
@@ -401,6 +667,7 @@ func (h *Helper) GetOverview() (*Overview, error) {
 // captureList runs a command and captures its output.
 func (h *Helper) captureList(name string, args ...string) (string, error) {
 	cmd := exec.Command(name, args...)
+	h.setAccountEnv(cmd)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -433,7 +700,7 @@ func (h *Helper) Login() error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "login")
+	cmd := h.wrangler("login")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -447,7 +714,7 @@ func (h *Helper) Logout() error {
 		return nil
 	}
 
-	cmd := exec.Command("wrangler", "logout")
+	cmd := h.wrangler("logout")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
@@ -0,0 +1,75 @@
+package cloudflare
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePagesProjects(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []PagesProject
+	}{
+		{
+			name: "wrangler 3 table",
+			out: ` ⛅️ wrangler 3.78.2
+-------------------
+
+┌──────────────┬──────────────────────────────────────┬──────────────┬───────────────┐
+│ Project Name │ Project Domains                      │ Git Provider │ Last Modified │
+├──────────────┼──────────────────────────────────────┼──────────────┼───────────────┤
+│ my-site      │ my-site.pages.dev, www.example.com   │ Yes          │ 2 days ago    │
+├──────────────┼──────────────────────────────────────┼──────────────┼───────────────┤
+│ docs         │ docs.example.com, docs-4ix.pages.dev │ No           │ 3 weeks ago   │
+└──────────────┴──────────────────────────────────────┴──────────────┴───────────────┘
+`,
+			want: []PagesProject{
+				{
+					Name:         "my-site",
+					Subdomain:    "my-site.pages.dev",
+					Domains:      []string{"my-site.pages.dev", "www.example.com"},
+					GitProvider:  "Yes",
+					LastModified: "2 days ago",
+				},
+				{
+					Name:         "docs",
+					Subdomain:    "docs-4ix.pages.dev",
+					Domains:      []string{"docs.example.com", "docs-4ix.pages.dev"},
+					GitProvider:  "No",
+					LastModified: "3 weeks ago",
+				},
+			},
+		},
+		{
+			name: "project without domains",
+			out: `┌──────────────┬─────────────────┬──────────────┬───────────────┐
+│ Project Name │ Project Domains │ Git Provider │ Last Modified │
+├──────────────┼─────────────────┼──────────────┼───────────────┤
+│ scratch      │                 │ No           │ 1 minute ago  │
+└──────────────┴─────────────────┴──────────────┴───────────────┘`,
+			want: []PagesProject{{Name: "scratch", GitProvider: "No", LastModified: "1 minute ago"}},
+		},
+		{
+			name: "header only",
+			out: `┌──────────────┬─────────────────┬──────────────┬───────────────┐
+│ Project Name │ Project Domains │ Git Provider │ Last Modified │
+├──────────────┼─────────────────┼──────────────┼───────────────┤
+└──────────────┴─────────────────┴──────────────┴───────────────┘`,
+			want: []PagesProject{},
+		},
+		{
+			name: "empty",
+			out:  "",
+			want: []PagesProject{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParsePagesProjects(tt.out); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePagesProjects = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
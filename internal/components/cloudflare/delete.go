@@ -0,0 +1,141 @@
+package cloudflare
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceDeleteResult describes the deletion of an R2 bucket, KV namespace
+// or D1 database.
+type ResourceDeleteResult struct {
+	Type    string `json:"type" yaml:"type"` // r2, kv or d1
+	Name    string `json:"name" yaml:"name"`
+	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
+	Deleted bool   `json:"deleted" yaml:"deleted"`
+	DryRun  bool   `json:"dry_run" yaml:"dry_run"`
+}
+
+// FindR2Bucket returns the named bucket, or an error when it does not exist.
+func (h *Helper) FindR2Bucket(name string) (*R2Bucket, error) {
+	if name == "" {
+		return nil, fmt.Errorf("bucket name is required")
+	}
+	buckets, err := h.ListR2Buckets()
+	if err != nil {
+		return nil, err
+	}
+	return findR2Bucket(buckets, name)
+}
+
+// findR2Bucket looks name up in buckets.
+func findR2Bucket(buckets []R2Bucket, name string) (*R2Bucket, error) {
+	for i := range buckets {
+		if buckets[i].Name == name {
+			return &buckets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("R2 bucket %q not found (see: acorn cf r2 list)", name)
+}
+
+// FindKVNamespace returns the KV namespace with the given ID, or title when
+// only one namespace has it.
+func (h *Helper) FindKVNamespace(idOrTitle string) (*KVNamespace, error) {
+	if idOrTitle == "" {
+		return nil, fmt.Errorf("namespace ID is required")
+	}
+	namespaces, err := h.ListKVNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	return findKVNamespace(namespaces, idOrTitle)
+}
+
+// findKVNamespace looks idOrTitle up in namespaces, by ID first.
+func findKVNamespace(namespaces []KVNamespace, idOrTitle string) (*KVNamespace, error) {
+	var matches []*KVNamespace
+	for i := range namespaces {
+		if namespaces[i].ID == idOrTitle {
+			return &namespaces[i], nil
+		}
+		if namespaces[i].Title == idOrTitle {
+			matches = append(matches, &namespaces[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("KV namespace %q not found (see: acorn cf kv list)", idOrTitle)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, ns := range matches {
+		ids[i] = ns.ID
+	}
+	return nil, fmt.Errorf("several KV namespaces are titled %q; use an ID: %s", idOrTitle, strings.Join(ids, ", "))
+}
+
+// FindD1Database returns the D1 database with the given name or UUID.
+func (h *Helper) FindD1Database(nameOrUUID string) (*D1Database, error) {
+	if nameOrUUID == "" {
+		return nil, fmt.Errorf("database name is required")
+	}
+	databases, err := h.ListD1Databases()
+	if err != nil {
+		return nil, err
+	}
+	return findD1Database(databases, nameOrUUID)
+}
+
+// findD1Database looks nameOrUUID up in databases.
+func findD1Database(databases []D1Database, nameOrUUID string) (*D1Database, error) {
+	for i := range databases {
+		if databases[i].Name == nameOrUUID || databases[i].UUID == nameOrUUID {
+			return &databases[i], nil
+		}
+	}
+	return nil, fmt.Errorf("D1 database %q not found (see: acorn cf d1 list)", nameOrUUID)
+}
+
+// DeleteR2Bucket deletes an R2 bucket, which wrangler refuses while it
+// still holds objects. Callers are expected to confirm the deletion and
+// check the bucket exists first.
+func (h *Helper) DeleteR2Bucket(name string) (*ResourceDeleteResult, error) {
+	result := &ResourceDeleteResult{Type: "r2", Name: name, DryRun: h.dryRun}
+	if err := h.deleteResource(result, "r2", "bucket", "delete", name); err != nil {
+		return nil, fmt.Errorf("failed to delete R2 bucket %s: %w", name, err)
+	}
+	return result, nil
+}
+
+// DeleteKVNamespace deletes a KV namespace and every key in it.
+func (h *Helper) DeleteKVNamespace(ns KVNamespace) (*ResourceDeleteResult, error) {
+	result := &ResourceDeleteResult{Type: "kv", Name: ns.Title, ID: ns.ID, DryRun: h.dryRun}
+	if err := h.deleteResource(result, "kv", "namespace", "delete", "--namespace-id", ns.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete KV namespace %s: %w", ns.Title, err)
+	}
+	return result, nil
+}
+
+// DeleteD1Database deletes a D1 database. wrangler's own prompt is skipped,
+// as callers confirm the deletion themselves.
+func (h *Helper) DeleteD1Database(db D1Database) (*ResourceDeleteResult, error) {
+	result := &ResourceDeleteResult{Type: "d1", Name: db.Name, ID: db.UUID, DryRun: h.dryRun}
+	if err := h.deleteResource(result, "d1", "delete", db.Name, "--skip-confirmation"); err != nil {
+		return nil, fmt.Errorf("failed to delete D1 database %s: %w", db.Name, err)
+	}
+	return result, nil
+}
+
+// deleteResource runs a wrangler delete command, or reports it in dry-run
+// mode, marking result as deleted on success.
+func (h *Helper) deleteResource(result *ResourceDeleteResult, args ...string) error {
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: wrangler %s\n", strings.Join(args, " "))
+		return nil
+	}
+	if _, err := h.runWrangler(args...); err != nil {
+		return err
+	}
+	result.Deleted = true
+	return nil
+}
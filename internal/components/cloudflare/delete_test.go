@@ -0,0 +1,81 @@
+package cloudflare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindResources(t *testing.T) {
+	namespaces := []KVNamespace{
+		{ID: "id-1", Title: "cache"},
+		{ID: "id-2", Title: "sessions"},
+		{ID: "id-3", Title: "sessions"},
+	}
+	if ns, err := findKVNamespace(namespaces, "id-2"); err != nil || ns.Title != "sessions" {
+		t.Errorf("by ID: got %+v, %v", ns, err)
+	}
+	if ns, err := findKVNamespace(namespaces, "cache"); err != nil || ns.ID != "id-1" {
+		t.Errorf("by title: got %+v, %v", ns, err)
+	}
+	if _, err := findKVNamespace(namespaces, "sessions"); err == nil || !strings.Contains(err.Error(), "id-2, id-3") {
+		t.Errorf("ambiguous title: err = %v", err)
+	}
+	if _, err := findKVNamespace(namespaces, "missing"); err == nil {
+		t.Error("missing namespace should fail")
+	}
+
+	databases := []D1Database{{UUID: "u-1", Name: "main"}}
+	for _, key := range []string{"main", "u-1"} {
+		if db, err := findD1Database(databases, key); err != nil || db.Name != "main" {
+			t.Errorf("findD1Database(%s) = %+v, %v", key, db, err)
+		}
+	}
+	if _, err := findD1Database(databases, "other"); err == nil {
+		t.Error("missing database should fail")
+	}
+
+	if _, err := findR2Bucket([]R2Bucket{{Name: "assets"}}, "logs"); err == nil {
+		t.Error("missing bucket should fail")
+	}
+}
+
+func TestDeleteResources(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	script := "#!/bin/sh\necho \"$*\" >> " + log + "\ncase \"$*\" in\n  \"r2 bucket delete full\") echo 'The bucket you tried to delete is not empty' >&2; exit 1;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(dir, "wrangler"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	h := NewHelper(false, false)
+	if r, err := h.DeleteR2Bucket("assets"); err != nil || !r.Deleted {
+		t.Errorf("DeleteR2Bucket = %+v, %v", r, err)
+	}
+	if r, err := h.DeleteKVNamespace(KVNamespace{ID: "id-1", Title: "cache"}); err != nil || !r.Deleted || r.ID != "id-1" {
+		t.Errorf("DeleteKVNamespace = %+v, %v", r, err)
+	}
+	if r, err := h.DeleteD1Database(D1Database{UUID: "u-1", Name: "main"}); err != nil || !r.Deleted {
+		t.Errorf("DeleteD1Database = %+v, %v", r, err)
+	}
+	if _, err := h.DeleteR2Bucket("full"); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("non-empty bucket: err = %v", err)
+	}
+
+	calls, _ := os.ReadFile(log)
+	want := "r2 bucket delete assets\nkv namespace delete --namespace-id id-1\nd1 delete main --skip-confirmation\nr2 bucket delete full\n"
+	if string(calls) != want {
+		t.Errorf("wrangler calls:\n%s\nwant:\n%s", calls, want)
+	}
+
+	// Dry runs do not call wrangler.
+	os.Remove(log)
+	if r, err := NewHelper(false, true).DeleteR2Bucket("assets"); err != nil || r.Deleted || !r.DryRun {
+		t.Errorf("dry run = %+v, %v", r, err)
+	}
+	if _, err := os.Stat(log); !os.IsNotExist(err) {
+		t.Error("dry run called wrangler")
+	}
+}
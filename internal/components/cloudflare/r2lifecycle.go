@@ -0,0 +1,137 @@
+package cloudflare
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// allPrefixes is how wrangler shows a lifecycle rule without a prefix.
+const allPrefixes = "(all prefixes)"
+
+// R2LifecycleRule is a lifecycle rule of an R2 bucket. ExpireDays is set
+// when the rule expires objects after a number of days.
+type R2LifecycleRule struct {
+	Name       string `json:"name" yaml:"name"`
+	Enabled    bool   `json:"enabled" yaml:"enabled"`
+	Prefix     string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Action     string `json:"action" yaml:"action"`
+	ExpireDays int    `json:"expire_days,omitempty" yaml:"expire_days,omitempty"`
+}
+
+// R2LifecycleOptions describes an expiration rule to add to a bucket.
+type R2LifecycleOptions struct {
+	Bucket     string
+	Name       string // generated from the prefix and days when empty
+	Prefix     string // "" applies to every object
+	ExpireDays int
+}
+
+var (
+	// expireDaysRe matches wrangler's description of an expiration action.
+	expireDaysRe = regexp.MustCompile(`(?i)expire objects after (\d+) days?`)
+
+	// ruleNameUnsafeRe matches runs of characters left out of rule names.
+	ruleNameUnsafeRe = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// GetR2Lifecycle lists the lifecycle rules of a bucket, which must exist.
+func (h *Helper) GetR2Lifecycle(bucket string) ([]R2LifecycleRule, error) {
+	if err := h.requireR2Bucket(bucket); err != nil {
+		return nil, err
+	}
+	return h.listR2Lifecycle(bucket)
+}
+
+// SetR2Lifecycle adds (or replaces, by name) an expiration rule on a
+// bucket and returns the bucket's resulting rules.
+func (h *Helper) SetR2Lifecycle(opts R2LifecycleOptions) ([]R2LifecycleRule, error) {
+	if opts.ExpireDays <= 0 {
+		return nil, fmt.Errorf("--expire-days must be a positive number of days")
+	}
+	if opts.Name == "" {
+		opts.Name = DefaultLifecycleRuleName(opts.Prefix, opts.ExpireDays)
+	}
+	if err := h.requireR2Bucket(opts.Bucket); err != nil {
+		return nil, err
+	}
+
+	args := []string{"r2", "bucket", "lifecycle", "add", opts.Bucket, opts.Name, opts.Prefix,
+		"--expire-days", strconv.Itoa(opts.ExpireDays)}
+	if h.dryRun {
+		shown := strings.Join(args, " ")
+		if opts.Prefix == "" {
+			shown = strings.Replace(shown, opts.Name+"  ", opts.Name+` "" `, 1)
+		}
+		fmt.Printf("[dry-run] would run: wrangler %s\n", shown)
+		return nil, nil
+	}
+	if _, err := h.runWrangler(args...); err != nil {
+		return nil, fmt.Errorf("failed to set lifecycle rule on %s: %w", opts.Bucket, err)
+	}
+	return h.listR2Lifecycle(opts.Bucket)
+}
+
+// DefaultLifecycleRuleName names an expiration rule after its prefix and
+// days, e.g. "expire-logs-30d".
+func DefaultLifecycleRuleName(prefix string, days int) string {
+	slug := strings.Trim(ruleNameUnsafeRe.ReplaceAllString(prefix, "-"), "-")
+	if slug == "" {
+		return fmt.Sprintf("expire-all-%dd", days)
+	}
+	return fmt.Sprintf("expire-%s-%dd", strings.ToLower(slug), days)
+}
+
+// requireR2Bucket checks that bucket is in the account's bucket list.
+func (h *Helper) requireR2Bucket(bucket string) error {
+	_, err := h.FindR2Bucket(bucket)
+	return err
+}
+
+// listR2Lifecycle runs wrangler r2 bucket lifecycle list.
+func (h *Helper) listR2Lifecycle(bucket string) ([]R2LifecycleRule, error) {
+	out, err := h.runWrangler("r2", "bucket", "lifecycle", "list", bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lifecycle rules of %s: %w", bucket, err)
+	}
+	return ParseR2LifecycleRules(out), nil
+}
+
+// ParseR2LifecycleRules parses `wrangler r2 bucket lifecycle list` output,
+// which prints a "name:" / "enabled:" / "prefix:" / "action:" block per
+// rule. The rule name is also accepted when labelled "id:".
+func ParseR2LifecycleRules(out string) []R2LifecycleRule {
+	rules := []R2LifecycleRule{}
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "name" || key == "id" {
+			rules = append(rules, R2LifecycleRule{Name: value})
+			continue
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		rule := &rules[len(rules)-1]
+		switch key {
+		case "enabled":
+			rule.Enabled = strings.EqualFold(value, "yes") || strings.EqualFold(value, "true")
+		case "prefix":
+			if value != allPrefixes {
+				rule.Prefix = value
+			}
+		case "action":
+			rule.Action = value
+			if m := expireDaysRe.FindStringSubmatch(value); m != nil {
+				rule.ExpireDays, _ = strconv.Atoi(m[1])
+			}
+		}
+	}
+	return rules
+}
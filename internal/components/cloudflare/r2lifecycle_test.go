@@ -0,0 +1,52 @@
+package cloudflare
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseR2LifecycleRules(t *testing.T) {
+	out := `
+ ⛅️ wrangler 3.99.0
+-------------------
+
+Listing lifecycle rules for bucket 'logs'...
+name:      expire-tmp-7d
+enabled:   Yes
+prefix:    tmp/
+action:    Expire objects after 7 days
+
+name:      ia
+enabled:   No
+prefix:    (all prefixes)
+action:    Transition to Infrequent Access after 30 days, Abort incomplete multipart uploads after 1 day
+`
+	want := []R2LifecycleRule{
+		{Name: "expire-tmp-7d", Enabled: true, Prefix: "tmp/", Action: "Expire objects after 7 days", ExpireDays: 7},
+		{Name: "ia", Action: "Transition to Infrequent Access after 30 days, Abort incomplete multipart uploads after 1 day"},
+	}
+	if got := ParseR2LifecycleRules(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	if got := ParseR2LifecycleRules("There are no lifecycle rules for bucket 'logs'.\n"); len(got) != 0 {
+		t.Errorf("expected no rules, got %+v", got)
+	}
+}
+
+func TestDefaultLifecycleRuleName(t *testing.T) {
+	tests := []struct {
+		prefix string
+		days   int
+		want   string
+	}{
+		{"", 30, "expire-all-30d"},
+		{"logs/", 7, "expire-logs-7d"},
+		{"Uploads/tmp files/", 1, "expire-uploads-tmp-files-1d"},
+	}
+	for _, tt := range tests {
+		if got := DefaultLifecycleRuleName(tt.prefix, tt.days); got != tt.want {
+			t.Errorf("DefaultLifecycleRuleName(%q, %d) = %q, want %q", tt.prefix, tt.days, got, tt.want)
+		}
+	}
+}
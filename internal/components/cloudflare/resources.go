@@ -0,0 +1,212 @@
+package cloudflare
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrNotAuthenticated is returned when wrangler reports that no account is
+// logged in.
+var ErrNotAuthenticated = errors.New("not authenticated with CloudFlare (run: acorn cf login)")
+
+// notAuthenticatedMarkers are fragments of wrangler's not-logged-in errors.
+var notAuthenticatedMarkers = []string{
+	"not authenticated",
+	"not logged in",
+	"authentication error",
+	"run `wrangler login`",
+}
+
+// ListR2Buckets lists all R2 buckets.
+func (h *Helper) ListR2Buckets() ([]R2Bucket, error) {
+	out, err := h.runWrangler("r2", "bucket", "list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list R2 buckets: %w", err)
+	}
+	return ParseR2Buckets(out)
+}
+
+// ListKVNamespaces lists all KV namespaces, with bindings filled in from the
+// wrangler config in the current directory when present.
+func (h *Helper) ListKVNamespaces() ([]KVNamespace, error) {
+	out, err := h.runWrangler("kv", "namespace", "list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KV namespaces: %w", err)
+	}
+
+	namespaces, err := ParseKVNamespaces(out)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := kvBindings(".")
+	for i := range namespaces {
+		namespaces[i].Binding = bindings[namespaces[i].ID]
+	}
+	return namespaces, nil
+}
+
+// ListD1Databases lists all D1 databases.
+func (h *Helper) ListD1Databases() ([]D1Database, error) {
+	out, err := h.runWrangler("d1", "list", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list D1 databases: %w", err)
+	}
+	return ParseD1Databases(out)
+}
+
+// ParseR2Buckets parses `wrangler r2 bucket list` output. Current wrangler
+// versions print "name:" / "creation_date:" blocks; older ones print JSON.
+func ParseR2Buckets(out string) ([]R2Bucket, error) {
+	buckets := []R2Bucket{}
+	if data := jsonArray(out); data != "" {
+		if err := json.Unmarshal([]byte(data), &buckets); err != nil {
+			return nil, fmt.Errorf("failed to parse R2 buckets: %w", err)
+		}
+		return buckets, nil
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "name":
+			buckets = append(buckets, R2Bucket{Name: value})
+		case "creation_date":
+			if len(buckets) > 0 {
+				buckets[len(buckets)-1].CreationDate = value
+			}
+		}
+	}
+	return buckets, nil
+}
+
+// ParseKVNamespaces parses the JSON printed by `wrangler kv namespace list`.
+func ParseKVNamespaces(out string) ([]KVNamespace, error) {
+	namespaces := []KVNamespace{}
+	if data := jsonArray(out); data != "" {
+		if err := json.Unmarshal([]byte(data), &namespaces); err != nil {
+			return nil, fmt.Errorf("failed to parse KV namespaces: %w", err)
+		}
+	}
+	return namespaces, nil
+}
+
+// ParseD1Databases parses the JSON printed by `wrangler d1 list --json`.
+func ParseD1Databases(out string) ([]D1Database, error) {
+	databases := []D1Database{}
+	if data := jsonArray(out); data != "" {
+		if err := json.Unmarshal([]byte(data), &databases); err != nil {
+			return nil, fmt.Errorf("failed to parse D1 databases: %w", err)
+		}
+	}
+	return databases, nil
+}
+
+// jsonArray returns the JSON array in out, skipping any banner wrangler
+// prints before it, or "" if there is none.
+func jsonArray(out string) string {
+	start := strings.Index(out, "[")
+	end := strings.LastIndex(out, "]")
+	if start < 0 || end < start {
+		return ""
+	}
+	// A "[" inside a text line (e.g. a log prefix) is not the start of JSON
+	if prefix := strings.TrimSpace(out[strings.LastIndex(out[:start], "\n")+1 : start]); prefix != "" {
+		return ""
+	}
+	return out[start : end+1]
+}
+
+// kvBindings maps KV namespace IDs to their binding names from the wrangler
+// config (wrangler.toml or wrangler.json) in dir.
+func kvBindings(dir string) map[string]string {
+	bindings := map[string]string{}
+	for _, name := range []string{"wrangler.toml", "wrangler.json"} {
+		v := viper.New()
+		v.SetConfigFile(filepath.Join(dir, name))
+		if err := v.ReadInConfig(); err != nil {
+			continue
+		}
+
+		var entries []struct {
+			Binding string `mapstructure:"binding"`
+			ID      string `mapstructure:"id"`
+		}
+		if err := v.UnmarshalKey("kv_namespaces", &entries); err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.ID != "" {
+				bindings[e.ID] = e.Binding
+			}
+		}
+	}
+	return bindings
+}
+
+// D1MigrationsDir returns the directory wrangler reads database's
+// migrations from: the migrations_dir of its d1_databases entry (matched by
+// database name or binding) in the wrangler config in dir, or "migrations".
+// wrangler has no flag to override it.
+func D1MigrationsDir(dir, database string) string {
+	for _, name := range []string{"wrangler.toml", "wrangler.json"} {
+		v := viper.New()
+		v.SetConfigFile(filepath.Join(dir, name))
+		if err := v.ReadInConfig(); err != nil {
+			continue
+		}
+
+		var entries []struct {
+			Binding       string `mapstructure:"binding"`
+			DatabaseName  string `mapstructure:"database_name"`
+			MigrationsDir string `mapstructure:"migrations_dir"`
+		}
+		if err := v.UnmarshalKey("d1_databases", &entries); err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if (e.DatabaseName == database || e.Binding == database) && e.MigrationsDir != "" {
+				return e.MigrationsDir
+			}
+		}
+	}
+	return "migrations"
+}
+
+// runWrangler runs wrangler and returns its stdout. Failures caused by a
+// missing login are reported as ErrNotAuthenticated.
+func (h *Helper) runWrangler(args ...string) (string, error) {
+	if h.verbose {
+		fmt.Fprintf(os.Stderr, "Running: wrangler %s\n", strings.Join(args, " "))
+	}
+
+	cmd := h.wrangler(args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String() + "\n" + stdout.String())
+		lower := strings.ToLower(msg)
+		for _, marker := range notAuthenticatedMarkers {
+			if strings.Contains(lower, marker) {
+				return "", ErrNotAuthenticated
+			}
+		}
+		if msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,126 @@
+package cloudflare
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseR2Buckets(t *testing.T) {
+	want := []R2Bucket{
+		{Name: "assets", CreationDate: "2025-01-01T00:00:00.000Z"},
+		{Name: "logs", CreationDate: "2025-02-01T00:00:00.000Z"},
+	}
+
+	text := "Listing buckets...\nname:           assets\ncreation_date:  2025-01-01T00:00:00.000Z\n\nname:           logs\ncreation_date:  2025-02-01T00:00:00.000Z\n"
+	got, err := ParseR2Buckets(text)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("text: got %+v, %v; want %+v", got, err, want)
+	}
+
+	legacy := `[{"name":"assets","creation_date":"2025-01-01T00:00:00.000Z"},{"name":"logs","creation_date":"2025-02-01T00:00:00.000Z"}]`
+	got, err = ParseR2Buckets(legacy)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("json: got %+v, %v; want %+v", got, err, want)
+	}
+}
+
+func TestParseKVNamespaces(t *testing.T) {
+	out := " ⛅️ wrangler 3.99.0\n-------------------\n[\n  {\"id\": \"abc\", \"title\": \"cache\", \"supports_url_encoding\": true}\n]\n"
+	got, err := ParseKVNamespaces(out)
+	if err != nil {
+		t.Fatalf("ParseKVNamespaces: %v", err)
+	}
+	want := []KVNamespace{{ID: "abc", Title: "cache"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = ParseKVNamespaces("[]")
+	if err != nil || len(got) != 0 {
+		t.Errorf("empty: got %+v, %v", got, err)
+	}
+}
+
+func TestParseD1Databases(t *testing.T) {
+	out := `[{"uuid":"u-1","name":"main","created_at":"2025-01-01","version":"production","num_tables":3,"file_size":1024}]`
+	got, err := ParseD1Databases(out)
+	if err != nil {
+		t.Fatalf("ParseD1Databases: %v", err)
+	}
+	want := []D1Database{{UUID: "u-1", Name: "main", CreatedAt: "2025-01-01", NumTables: 3, FileSize: 1024}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestKVBindings(t *testing.T) {
+	dir := t.TempDir()
+	toml := "name = \"app\"\n\n[[kv_namespaces]]\nbinding = \"CACHE\"\nid = \"abc\"\n\n[[kv_namespaces]]\nbinding = \"SESSIONS\"\nid = \"def\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "wrangler.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := kvBindings(dir)
+	want := map[string]string{"abc": "CACHE", "def": "SESSIONS"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := kvBindings(t.TempDir()); len(got) != 0 {
+		t.Errorf("no config: got %v", got)
+	}
+}
+
+func TestD1MigrationsDir(t *testing.T) {
+	dir := t.TempDir()
+	toml := "name = \"app\"\n\n[[d1_databases]]\nbinding = \"DB\"\ndatabase_name = \"app-db\"\nmigrations_dir = \"db/migrations\"\n\n[[d1_databases]]\nbinding = \"LOGS\"\ndatabase_name = \"logs\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "wrangler.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for database, want := range map[string]string{
+		"app-db": "db/migrations",
+		"DB":     "db/migrations",
+		"logs":   "migrations",
+		"other":  "migrations",
+	} {
+		if got := D1MigrationsDir(dir, database); got != want {
+			t.Errorf("D1MigrationsDir(%s) = %q, want %q", database, got, want)
+		}
+	}
+	if got := D1MigrationsDir(t.TempDir(), "app-db"); got != "migrations" {
+		t.Errorf("no config: got %q", got)
+	}
+}
+
+func TestValidateD1MigrationsDir(t *testing.T) {
+	dir := t.TempDir()
+	toml := "[[d1_databases]]\nbinding = \"DB\"\ndatabase_name = \"app-db\"\nmigrations_dir = \"db/migrations\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "wrangler.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "db", "migrations"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "migrations", "0001_init.sql"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	h := NewHelper(false, false)
+	for _, migrationsDir := range []string{"", "db/migrations", "./db/migrations/"} {
+		opts := D1MigrationOptions{Database: "app-db", MigrationsDir: migrationsDir}
+		files, err := h.validateD1Migrations(&opts)
+		if err != nil || !reflect.DeepEqual(files, []string{"0001_init.sql"}) {
+			t.Errorf("--migrations-dir %q: files = %v, err = %v", migrationsDir, files, err)
+		}
+	}
+
+	opts := D1MigrationOptions{Database: "app-db", MigrationsDir: "migrations"}
+	if _, err := h.validateD1Migrations(&opts); err == nil || !strings.Contains(err.Error(), "migrations_dir") {
+		t.Errorf("mismatched --migrations-dir: err = %v", err)
+	}
+}
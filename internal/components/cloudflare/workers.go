@@ -0,0 +1,144 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTailDuration is the capture window of a bounded tail when no
+// duration is given.
+const DefaultTailDuration = 30 * time.Second
+
+// WorkerDeleteResult describes a worker deletion.
+type WorkerDeleteResult struct {
+	Name    string `json:"name" yaml:"name"`
+	Deleted bool   `json:"deleted" yaml:"deleted"`
+	DryRun  bool   `json:"dry_run" yaml:"dry_run"`
+}
+
+// TailResult summarizes a bounded log tail.
+type TailResult struct {
+	Worker   string `json:"worker" yaml:"worker"`
+	Duration string `json:"duration" yaml:"duration"`
+	Events   int    `json:"events" yaml:"events"`
+	DryRun   bool   `json:"dry_run" yaml:"dry_run"`
+}
+
+// workerNotFoundMarkers are fragments of the Cloudflare API error wrangler
+// prints when deleting a worker that does not exist (code 10007).
+var workerNotFoundMarkers = []string{"[code: 10007]", "this worker does not exist"}
+
+// DeleteWorker deletes a worker with `wrangler delete --name`. Callers are
+// expected to confirm the deletion. wrangler cannot list worker scripts,
+// so a missing worker is only detected from the delete error.
+func (h *Helper) DeleteWorker(name string) (*WorkerDeleteResult, error) {
+	if name == "" {
+		return nil, fmt.Errorf("worker name is required")
+	}
+
+	result := &WorkerDeleteResult{Name: name, DryRun: h.dryRun}
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: wrangler delete --name %s\n", name)
+		return result, nil
+	}
+
+	if _, err := h.runWrangler("delete", "--name", name); err != nil {
+		return nil, workerDeleteError(name, err)
+	}
+	result.Deleted = true
+	return result, nil
+}
+
+// workerDeleteError describes a failed worker deletion, reporting a worker
+// that does not exist as such rather than as wrangler's API error.
+func workerDeleteError(name string, err error) error {
+	lower := strings.ToLower(err.Error())
+	for _, marker := range workerNotFoundMarkers {
+		if strings.Contains(lower, strings.ToLower(marker)) {
+			return fmt.Errorf("worker not found: %s", name)
+		}
+	}
+	return fmt.Errorf("failed to delete worker %s: %w", name, err)
+}
+
+// TailLogsFor tails a worker's logs in JSON format for duration d, copying
+// the events to w, then stops the tail and reports how many events were
+// captured. A d of zero or less uses DefaultTailDuration.
+func (h *Helper) TailLogsFor(workerName string, d time.Duration, w io.Writer) (*TailResult, error) {
+	if workerName == "" {
+		return nil, fmt.Errorf("worker name is required")
+	}
+	if d <= 0 {
+		d = DefaultTailDuration
+	}
+
+	result := &TailResult{Worker: workerName, Duration: d.String(), DryRun: h.dryRun}
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: wrangler tail %s --format json (for %s)\n", workerName, d)
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	counter := &eventCounter{w: w}
+	cmd := exec.CommandContext(ctx, "wrangler", "tail", workerName, "--format", "json")
+	h.setAccountEnv(cmd)
+	cmd.Stdout = counter
+	cmd.Stderr = os.Stderr
+	// Interrupt rather than kill so wrangler can close the tail session
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = 5 * time.Second
+
+	err := cmd.Run()
+	result.Events = counter.events
+	if err != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return result, fmt.Errorf("wrangler tail failed: %w", err)
+	}
+	return result, nil
+}
+
+// eventCounter passes tail output through to w while counting the
+// top-level JSON objects in it. Text outside objects is ignored.
+type eventCounter struct {
+	w        io.Writer
+	events   int
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+func (c *eventCounter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		switch {
+		case c.inString:
+			switch {
+			case c.escaped:
+				c.escaped = false
+			case b == '\\':
+				c.escaped = true
+			case b == '"':
+				c.inString = false
+			}
+		case b == '"' && c.depth > 0:
+			c.inString = true
+		case b == '{':
+			c.depth++
+		case b == '}' && c.depth > 0:
+			c.depth--
+			if c.depth == 0 {
+				c.events++
+			}
+		}
+	}
+	if c.w == nil {
+		return len(p), nil
+	}
+	return c.w.Write(p)
+}
@@ -0,0 +1,39 @@
+package cloudflare
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWorkerDeleteError(t *testing.T) {
+	// wrangler delete --name for a worker missing from the account
+	missing := errors.New("✘ [ERROR] A request to the Cloudflare API (/accounts/0123abcd/workers/scripts/ghost) failed.\n\n  This Worker does not exist on your account. [code: 10007]")
+	if err := workerDeleteError("ghost", missing); err == nil || err.Error() != "worker not found: ghost" {
+		t.Errorf("missing worker: got %v", err)
+	}
+
+	other := errors.New("✘ [ERROR] A request to the Cloudflare API (/accounts/0123abcd/workers/scripts/api) failed.\n\n  Authentication error [code: 10000]")
+	err := workerDeleteError("api", other)
+	if err == nil || !strings.Contains(err.Error(), "failed to delete worker api") || !errors.Is(err, other) {
+		t.Errorf("other failure: got %v", err)
+	}
+}
+
+func TestEventCounter(t *testing.T) {
+	var buf bytes.Buffer
+	c := &eventCounter{w: &buf}
+
+	stream := "Connected to api, waiting for logs...\n{\n  \"outcome\": \"ok\",\n  \"logs\": [{\"message\": [\"}{ \\\" brace\"]}]\n}\n{\"outcome\":\"exception\"}\n"
+	// Split mid-object to check state carries across writes
+	c.Write([]byte(stream[:50]))
+	c.Write([]byte(stream[50:]))
+
+	if c.events != 2 {
+		t.Errorf("events = %d, want 2", c.events)
+	}
+	if buf.String() != stream {
+		t.Errorf("output not passed through: %q", buf.String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // ServiceStatus represents the status of a database service.
@@ -365,3 +366,206 @@ func (h *Helper) GetSupportedServices() []string {
 		"zookeeper",
 	}
 }
+
+// BackupResult contains the result of a database backup.
+type BackupResult struct {
+	Engine   string `json:"engine" yaml:"engine"`
+	Database string `json:"database,omitempty" yaml:"database,omitempty"`
+	File     string `json:"file" yaml:"file"`
+	Size     int64  `json:"size" yaml:"size"`
+}
+
+// GetBackupEngines returns list of engines supported by backup and restore.
+func (h *Helper) GetBackupEngines() []string {
+	return []string{"postgres", "mysql", "mongodb", "redis"}
+}
+
+// Backup dumps a local database to outputFile using the engine's native dump tool.
+// If outputFile is empty, a timestamped file is created in the current directory.
+func (h *Helper) Backup(engine, db, outputFile string) (*BackupResult, error) {
+	engine = h.normalizeEngine(engine)
+	if engine == "" {
+		return nil, fmt.Errorf("unsupported engine (supported: %s)", strings.Join(h.GetBackupEngines(), ", "))
+	}
+
+	if outputFile == "" {
+		outputFile = h.defaultBackupFile(engine, db)
+	}
+
+	var args []string
+	switch engine {
+	case "postgres":
+		if db == "" {
+			db = "postgres"
+		}
+		args = []string{"pg_dump", "--file", outputFile, db}
+	case "mysql":
+		args = []string{"mysqldump", "-u", "root", "--result-file=" + outputFile}
+		if db == "" {
+			args = append(args, "--all-databases")
+		} else {
+			args = append(args, db)
+		}
+	case "mongodb":
+		args = []string{"mongodump", "--archive=" + outputFile}
+		if db != "" {
+			args = append(args, "--db", db)
+		}
+	case "redis":
+		args = []string{"redis-cli", "--rdb", outputFile}
+	}
+
+	if err := h.checkReady(engine, args[0]); err != nil {
+		return nil, err
+	}
+
+	result := &BackupResult{Engine: engine, Database: db, File: outputFile}
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: %s\n", strings.Join(args, " "))
+		return result, nil
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if h.verbose {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w", args[0], err)
+	}
+
+	info, err := os.Stat(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("backup file not created: %w", err)
+	}
+	result.Size = info.Size()
+
+	return result, nil
+}
+
+// Restore loads a backup file produced by Backup into a local database.
+// This overwrites existing data; callers are responsible for confirmation.
+func (h *Helper) Restore(engine, file, db string) error {
+	engine = h.normalizeEngine(engine)
+	if engine == "" {
+		return fmt.Errorf("unsupported engine (supported: %s)", strings.Join(h.GetBackupEngines(), ", "))
+	}
+
+	if _, err := os.Stat(file); err != nil {
+		return fmt.Errorf("backup file not found: %s", file)
+	}
+
+	var args []string
+	var stdin string
+	switch engine {
+	case "postgres":
+		if db == "" {
+			db = "postgres"
+		}
+		args = []string{"psql", "--quiet", "--dbname", db, "--file", file}
+	case "mysql":
+		args = []string{"mysql", "-u", "root"}
+		if db != "" {
+			args = append(args, db)
+		}
+		stdin = file
+	case "mongodb":
+		args = []string{"mongorestore", "--drop", "--archive=" + file}
+		if db != "" {
+			args = append(args, "--nsInclude", db+".*")
+		}
+	case "redis":
+		return fmt.Errorf("redis restore is not supported: copy %s over the server's dump.rdb and restart redis", file)
+	}
+
+	if err := h.checkReady(engine, args[0]); err != nil {
+		return err
+	}
+
+	if h.dryRun {
+		if stdin != "" {
+			fmt.Printf("[dry-run] would run: %s < %s\n", strings.Join(args, " "), stdin)
+		} else {
+			fmt.Printf("[dry-run] would run: %s\n", strings.Join(args, " "))
+		}
+		return nil
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if stdin != "" {
+		f, err := os.Open(stdin)
+		if err != nil {
+			return fmt.Errorf("failed to open backup file: %w", err)
+		}
+		defer f.Close()
+		cmd.Stdin = f
+	}
+	if h.verbose {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", args[0], err)
+	}
+
+	return nil
+}
+
+// checkReady verifies the dump tool is installed and the service is reachable.
+func (h *Helper) checkReady(engine, tool string) error {
+	if _, err := exec.LookPath(tool); err != nil {
+		return fmt.Errorf("%s not found in PATH", tool)
+	}
+
+	var status *ServiceStatus
+	switch engine {
+	case "postgres":
+		status = h.CheckPostgreSQL()
+	case "mysql":
+		status = h.CheckMySQL()
+	case "mongodb":
+		status = h.CheckMongoDB()
+	case "redis":
+		status = h.CheckRedis()
+	}
+
+	if status != nil && !status.Running {
+		return fmt.Errorf("%s is not reachable (%s)", status.Name, strings.ToLower(status.Status))
+	}
+
+	return nil
+}
+
+// defaultBackupFile returns a timestamped backup file name for an engine.
+func (h *Helper) defaultBackupFile(engine, db string) string {
+	ext := map[string]string{
+		"postgres": "sql",
+		"mysql":    "sql",
+		"mongodb":  "archive",
+		"redis":    "rdb",
+	}[engine]
+
+	name := engine
+	if db != "" {
+		name += "-" + db
+	}
+
+	return fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), ext)
+}
+
+// normalizeEngine maps engine aliases to canonical backup engine names.
+func (h *Helper) normalizeEngine(engine string) string {
+	switch strings.ToLower(engine) {
+	case "postgres", "postgresql", "pg":
+		return "postgres"
+	case "mysql", "my":
+		return "mysql"
+	case "mongodb", "mongo":
+		return "mongodb"
+	case "redis", "rd":
+		return "redis"
+	default:
+		return ""
+	}
+}
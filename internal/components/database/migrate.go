@@ -0,0 +1,351 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MigrationsTable records which migrations have been applied.
+const MigrationsTable = "schema_migrations"
+
+// Migration statuses reported in MigrationResult.
+const (
+	MigrationApplied  = "applied"
+	MigrationSkipped  = "skipped"
+	MigrationPending  = "pending"
+	MigrationReverted = "reverted"
+	MigrationFailed   = "failed"
+)
+
+// migrationFileRe matches 001_create_users.sql, 001_create_users.up.sql and
+// 001_create_users.down.sql.
+var migrationFileRe = regexp.MustCompile(`^(\d+)(?:[_-](.*?))?(\.up|\.down)?\.sql$`)
+
+// Migration is a numbered migration with its up file and optional down file.
+type Migration struct {
+	Version  int64  `json:"version" yaml:"version"`
+	Name     string `json:"name" yaml:"name"`
+	UpFile   string `json:"up_file" yaml:"up_file"`
+	DownFile string `json:"down_file,omitempty" yaml:"down_file,omitempty"`
+}
+
+// MigrateOptions configures Migrate.
+type MigrateOptions struct {
+	Dir  string // directory containing the migration files
+	DB   string // database name, or file for sqlite
+	To   *int64 // target version; nil applies all (up) or reverts one (down)
+	Down bool   // revert applied migrations using their .down.sql files
+}
+
+// MigrationResult is the outcome of one migration.
+type MigrationResult struct {
+	Version int64  `json:"version" yaml:"version"`
+	Name    string `json:"name" yaml:"name"`
+	File    string `json:"file" yaml:"file"`
+	Status  string `json:"status" yaml:"status"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// GetMigrateEngines returns the engines supported by Migrate.
+func (h *Helper) GetMigrateEngines() []string {
+	return []string{"postgres", "mysql", "sqlite"}
+}
+
+// LoadMigrations reads the migrations in dir, ordered by version. Files
+// that do not start with a version number are ignored.
+func LoadMigrations(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		m := migrationFileRe.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		path := filepath.Join(dir, entry.Name())
+		if m[3] == ".down" {
+			if mig.DownFile != "" {
+				return nil, fmt.Errorf("duplicate down migration for version %d: %s", version, entry.Name())
+			}
+			mig.DownFile = path
+			continue
+		}
+		if mig.UpFile != "" {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s",
+				version, filepath.Base(mig.UpFile), entry.Name())
+		}
+		mig.UpFile = path
+		mig.Name = m[2]
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.UpFile == "" {
+			return nil, fmt.Errorf("down migration %s has no matching up migration", filepath.Base(mig.DownFile))
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// planUp returns the migrations to apply: those not yet applied, up to and
+// including to when it is set.
+func planUp(migrations []Migration, applied map[int64]bool, to *int64) []Migration {
+	var plan []Migration
+	for _, mig := range migrations {
+		if applied[mig.Version] || (to != nil && mig.Version > *to) {
+			continue
+		}
+		plan = append(plan, mig)
+	}
+	return plan
+}
+
+// planDown returns the applied migrations to revert, newest first: those
+// above to when it is set, otherwise only the latest one. Every migration in
+// the plan must have a down file.
+func planDown(migrations []Migration, applied map[int64]bool, to *int64) ([]Migration, error) {
+	var plan []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		mig := migrations[i]
+		if !applied[mig.Version] {
+			continue
+		}
+		if to != nil && mig.Version <= *to {
+			break
+		}
+		if mig.DownFile == "" {
+			return nil, fmt.Errorf("migration %d has no .down.sql file", mig.Version)
+		}
+		plan = append(plan, mig)
+		if to == nil {
+			break
+		}
+	}
+	return plan, nil
+}
+
+// Migrate applies pending migrations from opts.Dir in version order, or
+// with opts.Down reverts applied ones, recording each in MigrationsTable.
+// With dry-run nothing is executed and the planned migrations are
+// reported as pending. It stops at the first failure, returning the
+// results so far along with the error.
+func (h *Helper) Migrate(engine string, opts MigrateOptions) ([]MigrationResult, error) {
+	engine = h.normalizeMigrateEngine(engine)
+	if engine == "" {
+		return nil, fmt.Errorf("unsupported engine (supported: %s)", strings.Join(h.GetMigrateEngines(), ", "))
+	}
+	if engine == "sqlite" && opts.DB == "" {
+		return nil, fmt.Errorf("sqlite requires a database file")
+	}
+
+	migrations, err := LoadMigrations(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	client := h.sqlClient(engine, opts.DB)
+	if err := h.checkReady(engine, client[0]); err != nil {
+		return nil, err
+	}
+
+	if !h.dryRun {
+		if err := h.runSQL(client, createMigrationsTable); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", MigrationsTable, err)
+		}
+	}
+	applied, err := h.appliedMigrations(engine, opts.DB)
+	if err != nil {
+		// The table does not exist before the first real run
+		if !h.dryRun {
+			return nil, err
+		}
+		applied = map[int64]bool{}
+	}
+
+	if opts.Down {
+		return h.migrateDown(client, engine, migrations, applied, opts.To)
+	}
+	return h.migrateUp(client, engine, migrations, applied, opts.To)
+}
+
+func (h *Helper) migrateUp(client []string, engine string, migrations []Migration, applied map[int64]bool, to *int64) ([]MigrationResult, error) {
+	pending := make(map[int64]bool)
+	for _, mig := range planUp(migrations, applied, to) {
+		pending[mig.Version] = true
+	}
+
+	var results []MigrationResult
+	for _, mig := range migrations {
+		result := MigrationResult{Version: mig.Version, Name: mig.Name, File: filepath.Base(mig.UpFile)}
+		switch {
+		case applied[mig.Version]:
+			result.Status = MigrationSkipped
+		case !pending[mig.Version]:
+			// Beyond --to; neither applied nor planned
+			continue
+		case h.dryRun:
+			result.Status = MigrationPending
+		default:
+			record := fmt.Sprintf("INSERT INTO %s (version, name) VALUES (%d, '%s');",
+				MigrationsTable, mig.Version, strings.ReplaceAll(mig.Name, "'", "''"))
+			if err := h.runMigrationFile(client, engine, mig.UpFile, record); err != nil {
+				result.Status = MigrationFailed
+				result.Error = err.Error()
+				return append(results, result), fmt.Errorf("migration %s failed: %w", result.File, err)
+			}
+			result.Status = MigrationApplied
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (h *Helper) migrateDown(client []string, engine string, migrations []Migration, applied map[int64]bool, to *int64) ([]MigrationResult, error) {
+	plan, err := planDown(migrations, applied, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []MigrationResult
+	for _, mig := range plan {
+		result := MigrationResult{Version: mig.Version, Name: mig.Name, File: filepath.Base(mig.DownFile)}
+		if h.dryRun {
+			result.Status = MigrationPending
+			results = append(results, result)
+			continue
+		}
+
+		record := fmt.Sprintf("DELETE FROM %s WHERE version = %d;", MigrationsTable, mig.Version)
+		if err := h.runMigrationFile(client, engine, mig.DownFile, record); err != nil {
+			result.Status = MigrationFailed
+			result.Error = err.Error()
+			return append(results, result), fmt.Errorf("migration %s failed: %w", result.File, err)
+		}
+		result.Status = MigrationReverted
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// createMigrationsTable is valid for postgres, mysql and sqlite.
+var createMigrationsTable = "CREATE TABLE IF NOT EXISTS " + MigrationsTable + ` (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+
+// normalizeMigrateEngine maps engine aliases to the SQL engines Migrate
+// supports.
+func (h *Helper) normalizeMigrateEngine(engine string) string {
+	switch strings.ToLower(engine) {
+	case "sqlite", "sqlite3":
+		return "sqlite"
+	}
+	switch e := h.normalizeEngine(engine); e {
+	case "postgres", "mysql":
+		return e
+	}
+	return ""
+}
+
+// sqlClient returns the client command that reads SQL from stdin, using
+// the same connection defaults as backup and restore.
+func (h *Helper) sqlClient(engine, db string) []string {
+	switch engine {
+	case "postgres":
+		if db == "" {
+			db = "postgres"
+		}
+		return []string{"psql", "--quiet", "--no-psqlrc", "-v", "ON_ERROR_STOP=1", "--dbname", db}
+	case "mysql":
+		args := []string{"mysql", "-u", "root"}
+		if db != "" {
+			args = append(args, db)
+		}
+		return args
+	default:
+		return []string{"sqlite3", "-bail", db}
+	}
+}
+
+// appliedMigrations returns the versions recorded in MigrationsTable.
+func (h *Helper) appliedMigrations(engine, db string) (map[int64]bool, error) {
+	query := "SELECT version FROM " + MigrationsTable + ";"
+	var args []string
+	switch engine {
+	case "postgres":
+		args = append(h.sqlClient(engine, db), "--tuples-only", "--no-align", "--command", query)
+	case "mysql":
+		args = append(h.sqlClient(engine, db), "--skip-column-names", "--batch", "--execute", query)
+	default:
+		args = append(h.sqlClient(engine, db), query)
+	}
+
+	out, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", MigrationsTable, err)
+	}
+
+	applied := make(map[int64]bool)
+	for _, field := range strings.Fields(string(out)) {
+		if v, err := strconv.ParseInt(field, 10, 64); err == nil {
+			applied[v] = true
+		}
+	}
+	return applied, nil
+}
+
+// runMigrationFile runs a migration file followed by the statement that
+// records it. Postgres and sqlite run both in one transaction; MySQL
+// commits DDL implicitly, so there the record is written after the file.
+func (h *Helper) runMigrationFile(client []string, engine, file, record string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read migration: %w", err)
+	}
+
+	script := string(data) + "\n" + record + "\n"
+	if engine != "mysql" {
+		script = "BEGIN;\n" + script + "COMMIT;\n"
+	}
+	return h.runSQL(client, script)
+}
+
+// runSQL feeds script to the engine's client on stdin.
+func (h *Helper) runSQL(client []string, script string) error {
+	cmd := exec.Command(client[0], client[1:]...)
+	cmd.Stdin = strings.NewReader(script)
+	if h.verbose {
+		cmd.Stdout = os.Stdout
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %s", client[0], msg)
+		}
+		return fmt.Errorf("%s failed: %w", client[0], err)
+	}
+	return nil
+}
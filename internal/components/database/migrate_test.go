@@ -0,0 +1,96 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeMigrations(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func versions(migrations []Migration) []int64 {
+	var v []int64
+	for _, m := range migrations {
+		v = append(v, m.Version)
+	}
+	return v
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := writeMigrations(t,
+		"010_posts.sql",
+		"001_create_users.sql",
+		"001_create_users.down.sql",
+		"2_add_email.up.sql",
+		"README.md",
+		"seed.sql",
+	)
+
+	migrations, err := LoadMigrations(dir)
+	if err != nil {
+		t.Fatalf("LoadMigrations failed: %v", err)
+	}
+	if got := versions(migrations); !reflect.DeepEqual(got, []int64{1, 2, 10}) {
+		t.Fatalf("versions = %v, want [1 2 10]", got)
+	}
+	if migrations[0].Name != "create_users" || migrations[0].DownFile == "" {
+		t.Errorf("migration 1 = %+v", migrations[0])
+	}
+	if migrations[1].Name != "add_email" || migrations[1].DownFile != "" {
+		t.Errorf("migration 2 = %+v", migrations[1])
+	}
+}
+
+func TestLoadMigrationsInvalid(t *testing.T) {
+	tests := map[string][]string{
+		"duplicate version": {"001_a.sql", "1_b.sql"},
+		"orphan down":       {"001_a.sql", "002_b.down.sql"},
+	}
+	for name, files := range tests {
+		if _, err := LoadMigrations(writeMigrations(t, files...)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestPlanMigrations(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, DownFile: "1.down.sql"},
+		{Version: 2, DownFile: "2.down.sql"},
+		{Version: 3},
+		{Version: 4, DownFile: "4.down.sql"},
+	}
+	applied := map[int64]bool{1: true, 2: true}
+	to := func(v int64) *int64 { return &v }
+
+	if got := versions(planUp(migrations, applied, nil)); !reflect.DeepEqual(got, []int64{3, 4}) {
+		t.Errorf("planUp = %v, want [3 4]", got)
+	}
+	if got := versions(planUp(migrations, applied, to(3))); !reflect.DeepEqual(got, []int64{3}) {
+		t.Errorf("planUp(to 3) = %v, want [3]", got)
+	}
+
+	plan, err := planDown(migrations, applied, nil)
+	if err != nil || !reflect.DeepEqual(versions(plan), []int64{2}) {
+		t.Errorf("planDown = %v, %v, want [2]", versions(plan), err)
+	}
+	plan, err = planDown(migrations, applied, to(0))
+	if err != nil || !reflect.DeepEqual(versions(plan), []int64{2, 1}) {
+		t.Errorf("planDown(to 0) = %v, %v, want [2 1]", versions(plan), err)
+	}
+
+	applied[3] = true
+	if _, err := planDown(migrations, applied, to(0)); err == nil {
+		t.Error("expected error reverting a migration without a down file")
+	}
+}
@@ -0,0 +1,222 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultPorts maps engines to the port they listen on by default.
+var defaultPorts = map[string]int{
+	"postgres": 5432,
+	"mysql":    3306,
+	"mongodb":  27017,
+	"redis":    6379,
+	"neo4j":    7687,
+}
+
+// permanentPingMarkers are ping failures, matched case-insensitively, that
+// retrying cannot fix: the server is up but rejects the client.
+var permanentPingMarkers = []string{
+	"password authentication failed", // postgres
+	"no password supplied",           // postgres
+	"access denied for user",         // mysql
+	"authentication failed",          // mongodb
+	"requires authentication",        // mongodb
+	"noauth",                         // redis
+	"wrongpass",                      // redis
+	"unauthorized",                   // neo4j
+}
+
+// permanentError is a probe failure that retrying will not fix, such as a
+// missing client or rejected credentials. WaitReady stops waiting on it.
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+// pingError returns the error for a failed ping with the client's output,
+// marked permanent when the output says the server rejected the client.
+func pingError(output string) error {
+	err := fmt.Errorf("ping failed: %s", output)
+	lower := strings.ToLower(output)
+	for _, marker := range permanentPingMarkers {
+		if strings.Contains(lower, marker) {
+			return permanentError{err}
+		}
+	}
+	return err
+}
+
+// WaitOptions configures a readiness wait.
+type WaitOptions struct {
+	Targets  []string      // engine or engine:port, e.g. "postgres" or "redis:6380"
+	Host     string        // host to probe (default: localhost)
+	Timeout  time.Duration // total time to wait; 0 checks once
+	Interval time.Duration // delay between attempts (default: 1s)
+	Ping     bool          // also run an engine-specific ping once the port is open
+}
+
+// WaitResult is the readiness of one engine.
+type WaitResult struct {
+	Engine    string `json:"engine" yaml:"engine"`
+	Address   string `json:"address" yaml:"address"`
+	Ready     bool   `json:"ready" yaml:"ready"`
+	Attempts  int    `json:"attempts" yaml:"attempts"`
+	ElapsedMs int64  `json:"elapsed_ms" yaml:"elapsed_ms"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// GetWaitEngines returns the engines supported by WaitReady.
+func (h *Helper) GetWaitEngines() []string {
+	return []string{"postgres", "mysql", "mongodb", "redis", "neo4j"}
+}
+
+// parseWaitTarget splits "engine[:port]" into a canonical engine and port.
+func (h *Helper) parseWaitTarget(target string) (string, int, error) {
+	name, portStr, hasPort := strings.Cut(target, ":")
+
+	engine := h.normalizeEngine(name)
+	if strings.EqualFold(name, "neo4j") {
+		engine = "neo4j"
+	}
+	if engine == "" {
+		return "", 0, fmt.Errorf("unsupported engine %q (supported: %s)", name, strings.Join(h.GetWaitEngines(), ", "))
+	}
+
+	port := defaultPorts[engine]
+	if hasPort {
+		p, err := strconv.Atoi(portStr)
+		if err != nil || p < 1 || p > 65535 {
+			return "", 0, fmt.Errorf("invalid port in %q", target)
+		}
+		port = p
+	}
+	return engine, port, nil
+}
+
+// WaitReady polls each target until it accepts TCP connections (and, with
+// Ping, answers an engine-specific ping) or the shared timeout elapses.
+// A target whose ping fails permanently, e.g. with bad credentials or no
+// client installed, is reported at once instead of retried.
+func (h *Helper) WaitReady(opts WaitOptions) ([]WaitResult, error) {
+	if len(opts.Targets) == 0 {
+		return nil, fmt.Errorf("at least one engine is required")
+	}
+	if opts.Host == "" {
+		opts.Host = "localhost"
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+
+	type target struct {
+		engine string
+		port   int
+	}
+	targets := make([]target, 0, len(opts.Targets))
+	for _, t := range opts.Targets {
+		engine, port, err := h.parseWaitTarget(t)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target{engine, port})
+	}
+
+	start := time.Now()
+	deadline := start.Add(opts.Timeout)
+	results := make([]WaitResult, 0, len(targets))
+
+	for _, t := range targets {
+		result := WaitResult{
+			Engine:  t.engine,
+			Address: net.JoinHostPort(opts.Host, strconv.Itoa(t.port)),
+		}
+
+		for {
+			result.Attempts++
+			err := h.probe(t.engine, opts.Host, t.port, opts.Ping)
+			if err == nil {
+				result.Ready = true
+				result.Error = ""
+				break
+			}
+			result.Error = err.Error()
+
+			var permanent permanentError
+			if errors.As(err, &permanent) {
+				break
+			}
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				break
+			}
+			if h.verbose {
+				fmt.Printf("Waiting for %s at %s: %v\n", t.engine, result.Address, err)
+			}
+			time.Sleep(min(opts.Interval, remaining))
+		}
+
+		result.ElapsedMs = time.Since(start).Milliseconds()
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// probe checks that an engine's port is open and optionally pings it.
+func (h *Helper) probe(engine, host string, port int, ping bool) error {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return fmt.Errorf("port %d not accepting connections", port)
+	}
+	conn.Close()
+
+	if !ping {
+		return nil
+	}
+	return h.ping(engine, host, port)
+}
+
+// ping runs a trivial engine-specific query against a running server.
+func (h *Helper) ping(engine, host string, port int) error {
+	p := strconv.Itoa(port)
+
+	var args []string
+	switch engine {
+	case "postgres":
+		args = []string{"psql", "-h", host, "-p", p, "-U", "postgres", "-tAc", "SELECT 1"}
+	case "mysql":
+		args = []string{"mysqladmin", "ping", "-h", host, "-P", p, "-u", "root", "--silent"}
+	case "mongodb":
+		args = []string{"mongosh", "--host", host, "--port", p, "--quiet", "--eval", "db.runCommand({ping:1}).ok"}
+	case "redis":
+		args = []string{"redis-cli", "-h", host, "-p", p, "ping"}
+	case "neo4j":
+		args = []string{"cypher-shell", "-a", "neo4j://" + net.JoinHostPort(host, p), "RETURN 1"}
+	}
+
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return permanentError{fmt.Errorf("%s not found in PATH (needed for --ping)", args[0])}
+	}
+
+	out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return pingError(msg)
+		}
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	if engine == "redis" && strings.TrimSpace(string(out)) != "PONG" {
+		return pingError(strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseWaitTarget(t *testing.T) {
+	h := NewHelper(false, false)
+	tests := []struct {
+		target string
+		engine string
+		port   int
+		ok     bool
+	}{
+		{"postgres", "postgres", 5432, true},
+		{"redis:6380", "redis", 6380, true},
+		{"neo4j", "neo4j", 7687, true},
+		{"oracle", "", 0, false},
+		{"redis:0", "", 0, false},
+		{"redis:http", "", 0, false},
+	}
+	for _, tt := range tests {
+		engine, port, err := h.parseWaitTarget(tt.target)
+		if (err == nil) != tt.ok || engine != tt.engine || port != tt.port {
+			t.Errorf("parseWaitTarget(%q) = %s, %d, %v", tt.target, engine, port, err)
+		}
+	}
+}
+
+func TestPingError(t *testing.T) {
+	permanent := []string{
+		`psql: error: connection to server at "localhost" failed: FATAL:  password authentication failed for user "postgres"`,
+		"mysqladmin: connect to server at 'localhost' failed\nerror: 'Access denied for user 'root'@'localhost' (using password: NO)'",
+		"MongoServerError: Authentication failed.",
+		"NOAUTH Authentication required.",
+		"WRONGPASS invalid username-password pair or user is disabled.",
+		"The client is unauthorized due to authentication failure.",
+	}
+	transient := []string{
+		`psql: error: connection to server at "localhost" failed: FATAL:  the database system is starting up`,
+		"LOADING Redis is loading the dataset in memory",
+		"Could not connect to Redis at localhost:6379: Connection refused",
+	}
+
+	var p permanentError
+	for _, out := range permanent {
+		if err := pingError(out); !errors.As(err, &p) {
+			t.Errorf("pingError(%q) should be permanent", out)
+		}
+	}
+	for _, out := range transient {
+		if err := pingError(out); errors.As(err, &p) {
+			t.Errorf("pingError(%q) should be retried", out)
+		}
+	}
+}
+
+// fakeRedis listens on a local port and installs a redis-cli that prints
+// reply, returning the port.
+func fakeRedis(t *testing.T, reply string) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho '" + reply + "'\n"
+	if err := os.WriteFile(filepath.Join(bin, "redis-cli"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestWaitReady(t *testing.T) {
+	h := NewHelper(false, false)
+	wait := func(port int, timeout time.Duration) WaitResult {
+		t.Helper()
+		results, err := h.WaitReady(WaitOptions{
+			Targets:  []string{"redis:" + strconv.Itoa(port)},
+			Host:     "127.0.0.1",
+			Timeout:  timeout,
+			Interval: 10 * time.Millisecond,
+			Ping:     true,
+		})
+		if err != nil {
+			t.Fatalf("WaitReady: %v", err)
+		}
+		return results[0]
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		if r := wait(fakeRedis(t, "PONG"), time.Second); !r.Ready || r.Attempts != 1 {
+			t.Errorf("result = %+v", r)
+		}
+	})
+
+	t.Run("bad credentials fail fast", func(t *testing.T) {
+		r := wait(fakeRedis(t, "NOAUTH Authentication required."), 5*time.Second)
+		if r.Ready || r.Attempts != 1 || r.ElapsedMs >= 1000 {
+			t.Errorf("result = %+v, want one failed attempt", r)
+		}
+	})
+
+	t.Run("loading is retried", func(t *testing.T) {
+		r := wait(fakeRedis(t, "LOADING Redis is loading the dataset in memory"), 100*time.Millisecond)
+		if r.Ready || r.Attempts < 2 {
+			t.Errorf("result = %+v, want several attempts", r)
+		}
+	})
+
+	t.Run("missing client fails fast", func(t *testing.T) {
+		port := fakeRedis(t, "PONG")
+		t.Setenv("PATH", t.TempDir())
+		if r := wait(port, 5*time.Second); r.Ready || r.Attempts != 1 {
+			t.Errorf("result = %+v, want one failed attempt", r)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameOptions configures a blame of a single file.
+type BlameOptions struct {
+	File  string
+	Start int // first line to blame (1-based, 0 for the start of the file)
+	End   int // last line to blame (0 for the end of the file)
+}
+
+// BlameLine is the blame information for one line of a file.
+type BlameLine struct {
+	Line    int    `json:"line" yaml:"line"`
+	Hash    string `json:"hash" yaml:"hash"`
+	Author  string `json:"author" yaml:"author"`
+	Date    string `json:"date" yaml:"date"`
+	Content string `json:"content" yaml:"content"`
+}
+
+// BlameAuthor summarizes how many blamed lines an author owns.
+type BlameAuthor struct {
+	Author  string  `json:"author" yaml:"author"`
+	Lines   int     `json:"lines" yaml:"lines"`
+	Percent float64 `json:"percent" yaml:"percent"`
+}
+
+// ParseLineRange parses a "start:end" line range. Either side may be
+// omitted ("10:" or ":20"), and a single number blames just that line.
+func ParseLineRange(s string) (start, end int, err error) {
+	startStr, endStr, found := strings.Cut(s, ":")
+	if !found {
+		endStr = startStr
+	}
+
+	parse := func(v string) (int, error) {
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid line range %q: lines must be positive integers", s)
+		}
+		return n, nil
+	}
+
+	if start, err = parse(startStr); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parse(endStr); err != nil {
+		return 0, 0, err
+	}
+	if start > 0 && end > 0 && end < start {
+		return 0, 0, fmt.Errorf("invalid line range %q: end is before start", s)
+	}
+	return start, end, nil
+}
+
+// Blame runs git blame on a tracked file and returns one entry per line.
+func (h *Helper) Blame(opts BlameOptions) ([]BlameLine, error) {
+	if opts.File == "" {
+		return nil, fmt.Errorf("file is required")
+	}
+	if !h.IsGitRepo() {
+		return nil, fmt.Errorf("not a git repository")
+	}
+
+	if err := exec.Command("git", "ls-files", "--error-unmatch", "--", opts.File).Run(); err != nil {
+		return nil, fmt.Errorf("file is not tracked by git: %s", opts.File)
+	}
+
+	args := []string{"blame", "--line-porcelain"}
+	if opts.Start > 0 || opts.End > 0 {
+		start := max(opts.Start, 1)
+		lineRange := strconv.Itoa(start) + ","
+		if opts.End > 0 {
+			lineRange += strconv.Itoa(opts.End)
+		}
+		args = append(args, "-L", lineRange)
+	}
+	args = append(args, "--", opts.File)
+
+	if h.verbose {
+		fmt.Printf("Running: git %s\n", strings.Join(args, " "))
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git blame failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	return parseBlamePorcelain(string(out)), nil
+}
+
+// parseBlamePorcelain parses git blame --line-porcelain output. Each line
+// starts with a "<hash> <orig-line> <final-line>" header, followed by
+// key/value headers and the line content prefixed with a tab.
+func parseBlamePorcelain(out string) []BlameLine {
+	var lines []BlameLine
+	var cur BlameLine
+	var authorTime int64
+	var authorTZ string
+	inEntry := false
+
+	for _, raw := range strings.Split(out, "\n") {
+		if strings.HasPrefix(raw, "\t") {
+			if inEntry {
+				cur.Content = raw[1:]
+				cur.Date = blameDate(authorTime, authorTZ)
+				lines = append(lines, cur)
+			}
+			inEntry = false
+			continue
+		}
+
+		if !inEntry {
+			fields := strings.Fields(raw)
+			if len(fields) < 3 || len(fields[0]) < 40 {
+				continue
+			}
+			n, err := strconv.Atoi(fields[2])
+			if err != nil {
+				continue
+			}
+			cur = BlameLine{Line: n, Hash: fields[0]}
+			authorTime, authorTZ = 0, ""
+			inEntry = true
+			continue
+		}
+
+		key, value, _ := strings.Cut(raw, " ")
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-time":
+			authorTime, _ = strconv.ParseInt(value, 10, 64)
+		case "author-tz":
+			authorTZ = value
+		}
+	}
+	return lines
+}
+
+// blameDate formats a unix timestamp in the author's timezone (e.g. "+0200").
+func blameDate(ts int64, tz string) string {
+	if ts == 0 {
+		return ""
+	}
+	t := time.Unix(ts, 0).UTC()
+	if len(tz) == 5 {
+		hours, errH := strconv.Atoi(tz[1:3])
+		mins, errM := strconv.Atoi(tz[3:5])
+		if errH == nil && errM == nil {
+			offset := hours*3600 + mins*60
+			if tz[0] == '-' {
+				offset = -offset
+			}
+			t = t.In(time.FixedZone(tz, offset))
+		}
+	}
+	return t.Format(time.RFC3339)
+}
+
+// SummarizeBlame counts blamed lines per author, most lines first.
+func SummarizeBlame(lines []BlameLine) []BlameAuthor {
+	counts := make(map[string]int)
+	for _, l := range lines {
+		counts[l.Author]++
+	}
+
+	authors := make([]BlameAuthor, 0, len(counts))
+	for name, n := range counts {
+		authors = append(authors, BlameAuthor{
+			Author:  name,
+			Lines:   n,
+			Percent: float64(n) * 100 / float64(len(lines)),
+		})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Lines != authors[j].Lines {
+			return authors[i].Lines > authors[j].Lines
+		}
+		return authors[i].Author < authors[j].Author
+	})
+	return authors
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"os"
+	"testing"
+)
+
+const porcelainFixture = `1111111111111111111111111111111111111111 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1700000000
+author-tz +0200
+summary first
+filename main.go
+	package main
+1111111111111111111111111111111111111111 2 2
+author Alice
+author-mail <alice@example.com>
+author-time 1700000000
+author-tz +0200
+summary first
+filename main.go
+	
+2222222222222222222222222222222222222222 5 3 1
+author Bob
+author-mail <bob@example.com>
+author-time 1710000000
+author-tz -0500
+summary second
+previous 1111111111111111111111111111111111111111 main.go
+filename main.go
+	func main() {}
+`
+
+func TestParseBlamePorcelain(t *testing.T) {
+	lines := parseBlamePorcelain(porcelainFixture)
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %+v", len(lines), lines)
+	}
+
+	want := []BlameLine{
+		{Line: 1, Author: "Alice", Date: "2023-11-15T00:13:20+02:00", Content: "package main"},
+		{Line: 2, Author: "Alice", Date: "2023-11-15T00:13:20+02:00", Content: ""},
+		{Line: 3, Author: "Bob", Date: "2024-03-09T11:00:00-05:00", Content: "func main() {}"},
+	}
+	for i, w := range want {
+		got := lines[i]
+		if got.Line != w.Line || got.Author != w.Author || got.Date != w.Date || got.Content != w.Content {
+			t.Errorf("line %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	authors := SummarizeBlame(lines)
+	if len(authors) != 2 || authors[0].Author != "Alice" || authors[0].Lines != 2 {
+		t.Errorf("summary = %+v, want Alice first with 2 lines", authors)
+	}
+}
+
+func TestParseLineRange(t *testing.T) {
+	tests := []struct {
+		in         string
+		start, end int
+		wantErr    bool
+	}{
+		{in: "10:20", start: 10, end: 20},
+		{in: "10:", start: 10},
+		{in: ":5", end: 5},
+		{in: "7", start: 7, end: 7},
+		{in: "20:10", wantErr: true},
+		{in: "0:3", wantErr: true},
+		{in: "a:b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		start, end, err := ParseLineRange(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLineRange(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (start != tt.start || end != tt.end) {
+			t.Errorf("ParseLineRange(%q) = %d, %d, want %d, %d", tt.in, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestBlame(t *testing.T) {
+	newFixtureRepo(t)
+	h := NewHelper(false)
+
+	lines, err := h.Blame(BlameOptions{File: "a.txt"})
+	if err != nil {
+		t.Fatalf("Blame: %v", err)
+	}
+	if len(lines) != 1 || lines[0].Content != "gamma" || lines[0].Author != "Tester" {
+		t.Errorf("blame = %+v, want one gamma line by Tester", lines)
+	}
+
+	if err := os.WriteFile("untracked.txt", []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Blame(BlameOptions{File: "untracked.txt"}); err == nil {
+		t.Error("expected error for untracked file")
+	}
+}
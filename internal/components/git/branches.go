@@ -0,0 +1,71 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/config"
+)
+
+// DefaultProtectedBranches are never removed by CleanMergedBranches.
+var DefaultProtectedBranches = []string{"main", "master", "develop"}
+
+// CleanBranchesOptions configures CleanMergedBranches.
+type CleanBranchesOptions struct {
+	DryRun       bool
+	KeepPatterns []string // glob patterns (e.g. release/*) of branches to keep
+}
+
+// ProtectedBranch is a merged branch kept because it matched a pattern.
+type ProtectedBranch struct {
+	Name    string `json:"name" yaml:"name"`
+	Pattern string `json:"pattern" yaml:"pattern"`
+}
+
+// CleanBranchesResult is the outcome of CleanMergedBranches.
+type CleanBranchesResult struct {
+	Deleted   []string          `json:"deleted" yaml:"deleted"`
+	Protected []ProtectedBranch `json:"protected" yaml:"protected"`
+	DryRun    bool              `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+// componentConfig is the part of the git component config read here.
+type componentConfig struct {
+	CleanBranches struct {
+		Protected []string `yaml:"protected"`
+	} `yaml:"clean_branches"`
+}
+
+// LoadProtectedBranches returns the protected branch patterns from the
+// clean_branches.protected list in the git component config. A missing
+// config yields no patterns.
+func LoadProtectedBranches() ([]string, error) {
+	var cfg componentConfig
+	if err := config.NewComponentLoader().Load("git", &cfg); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, pattern := range cfg.CleanBranches.Protected {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid protected branch pattern %q: %w", pattern, err)
+		}
+	}
+	return cfg.CleanBranches.Protected, nil
+}
+
+// matchProtected returns the first pattern matching branch. Patterns are
+// globs in which * does not cross a slash, so release/* matches release/1.0
+// but not release/1.0/hotfix.
+func matchProtected(branch string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
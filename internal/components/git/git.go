@@ -29,6 +29,22 @@ type Contributor struct {
 	Commits int    `json:"commits" yaml:"commits"`
 }
 
+// DiffSearchOptions configures a search of commit diffs.
+type DiffSearchOptions struct {
+	Pattern string
+	Regex   bool // use -G (regex match in diff) instead of -S (occurrence count change)
+	All     bool // search all branches
+}
+
+// DiffCommit represents a commit whose diff matched a search.
+type DiffCommit struct {
+	Hash         string   `json:"hash" yaml:"hash"`
+	Date         string   `json:"date" yaml:"date"`
+	Author       string   `json:"author" yaml:"author"`
+	Subject      string   `json:"subject" yaml:"subject"`
+	FilesTouched []string `json:"files_touched" yaml:"files_touched"`
+}
+
 // Helper provides Git helper operations.
 type Helper struct {
 	verbose bool
@@ -170,8 +186,85 @@ func (h *Helper) FindCommits(search string) ([]string, error) {
 	return commits, nil
 }
 
-// CleanMergedBranches removes merged branches.
-func (h *Helper) CleanMergedBranches(dryRun bool) ([]string, error) {
+// ShowCommit runs git show for a commit with output going to the terminal.
+func (h *Helper) ShowCommit(hash string) error {
+	cmd := exec.Command("git", "show", hash)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// FindInDiffs finds commits whose diffs add or remove content matching a pattern.
+// By default it uses git's pickaxe (-S), which matches commits that change the
+// number of occurrences of the string. With Regex it uses -G, which matches
+// commits whose diff contains a line matching the regular expression.
+func (h *Helper) FindInDiffs(opts DiffSearchOptions) ([]DiffCommit, error) {
+	if opts.Pattern == "" {
+		return nil, fmt.Errorf("search pattern is required")
+	}
+	if !h.IsGitRepo() {
+		return nil, fmt.Errorf("not a git repository")
+	}
+
+	args := []string{"log", "--name-only", "--format=%x1e%H%x1f%aI%x1f%an%x1f%s"}
+	if opts.All {
+		args = append(args, "--all")
+	}
+	if opts.Regex {
+		args = append(args, "-G"+opts.Pattern)
+	} else {
+		args = append(args, "-S"+opts.Pattern)
+	}
+
+	cmd := exec.Command("git", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	return parseDiffCommits(string(out)), nil
+}
+
+// parseDiffCommits parses git log output produced by FindInDiffs.
+func parseDiffCommits(out string) []DiffCommit {
+	var commits []DiffCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		lines := strings.Split(record, "\n")
+		fields := strings.SplitN(lines[0], "\x1f", 4)
+		if len(fields) < 4 {
+			continue
+		}
+
+		commit := DiffCommit{
+			Hash:         fields[0],
+			Date:         fields[1],
+			Author:       fields[2],
+			Subject:      fields[3],
+			FilesTouched: []string{},
+		}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				commit.FilesTouched = append(commit.FilesTouched, line)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// CleanMergedBranches removes local branches merged into the current
+// branch. The current branch and branches matching a protected pattern are
+// never deleted; the latter are reported in the result.
+func (h *Helper) CleanMergedBranches(opts CleanBranchesOptions) (*CleanBranchesResult, error) {
 	if !h.IsGitRepo() {
 		return nil, fmt.Errorf("not a git repository")
 	}
@@ -183,31 +276,38 @@ func (h *Helper) CleanMergedBranches(dryRun bool) ([]string, error) {
 		return nil, err
 	}
 
-	var deleted []string
+	patterns := append(append([]string{}, DefaultProtectedBranches...), opts.KeepPatterns...)
+	result := &CleanBranchesResult{
+		Deleted:   []string{},
+		Protected: []ProtectedBranch{},
+		DryRun:    opts.DryRun,
+	}
+
 	lines := strings.Split(string(out), "\n")
 	for _, line := range lines {
 		branch := strings.TrimSpace(line)
-		// Skip current branch (*) and main/master
+		// Skip current branch (*)
 		if branch == "" || strings.HasPrefix(branch, "*") {
 			continue
 		}
-		if branch == "main" || branch == "master" || branch == "develop" {
+		if pattern, ok := matchProtected(branch, patterns); ok {
+			result.Protected = append(result.Protected, ProtectedBranch{Name: branch, Pattern: pattern})
 			continue
 		}
 
-		if dryRun {
-			deleted = append(deleted, branch)
+		if opts.DryRun {
+			result.Deleted = append(result.Deleted, branch)
 			continue
 		}
 
 		// Delete the branch
 		delCmd := exec.Command("git", "branch", "-d", branch)
 		if err := delCmd.Run(); err == nil {
-			deleted = append(deleted, branch)
+			result.Deleted = append(result.Deleted, branch)
 		}
 	}
 
-	return deleted, nil
+	return result, nil
 }
 
 // GetReposDir returns the default repos directory.
@@ -0,0 +1,170 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newFixtureRepo creates a repository with known diffs and chdirs into it:
+//
+//	c1: add a.txt containing "alpha"
+//	c2: add b.txt containing "beta", append "alpha" comment line to a.txt
+//	c3: remove "alpha" lines from a.txt
+func newFixtureRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Tester", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=Tester", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	write("a.txt", "alpha\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "add alpha")
+
+	write("a.txt", "alpha\n# alpha again\n")
+	write("b.txt", "beta\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "add beta")
+
+	write("a.txt", "gamma\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "drop alpha")
+
+	run("checkout", "-q", "-b", "side")
+	write("c.txt", "delta\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "add delta")
+	run("checkout", "-q", "main")
+}
+
+func TestFindInDiffsPickaxe(t *testing.T) {
+	newFixtureRepo(t)
+	h := NewHelper(false)
+
+	commits, err := h.FindInDiffs(DiffSearchOptions{Pattern: "alpha"})
+	if err != nil {
+		t.Fatalf("FindInDiffs: %v", err)
+	}
+
+	var subjects []string
+	for _, c := range commits {
+		subjects = append(subjects, c.Subject)
+	}
+	want := []string{"drop alpha", "add beta", "add alpha"}
+	if len(subjects) != len(want) {
+		t.Fatalf("subjects = %v, want %v", subjects, want)
+	}
+	for i := range want {
+		if subjects[i] != want[i] {
+			t.Errorf("subjects[%d] = %q, want %q", i, subjects[i], want[i])
+		}
+	}
+
+	if c := commits[1]; len(c.FilesTouched) != 1 || c.FilesTouched[0] != "a.txt" {
+		t.Errorf("files touched = %v, want [a.txt]", c.FilesTouched)
+	}
+	if commits[0].Author != "Tester" || commits[0].Hash == "" || commits[0].Date == "" {
+		t.Errorf("incomplete commit: %+v", commits[0])
+	}
+}
+
+func TestFindInDiffsRegex(t *testing.T) {
+	newFixtureRepo(t)
+	h := NewHelper(false)
+
+	// -S counts occurrences of the literal string, so "^#" matches nothing
+	commits, err := h.FindInDiffs(DiffSearchOptions{Pattern: "^# alpha"})
+	if err != nil {
+		t.Fatalf("FindInDiffs: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("pickaxe matched %d commits, want 0", len(commits))
+	}
+
+	// -G matches diff lines against the regex: added in c2, removed in c3
+	commits, err = h.FindInDiffs(DiffSearchOptions{Pattern: "^# alpha", Regex: true})
+	if err != nil {
+		t.Fatalf("FindInDiffs: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Errorf("regex matched %d commits, want 2", len(commits))
+	}
+}
+
+func TestFindInDiffsAllBranches(t *testing.T) {
+	newFixtureRepo(t)
+	h := NewHelper(false)
+
+	commits, err := h.FindInDiffs(DiffSearchOptions{Pattern: "delta"})
+	if err != nil {
+		t.Fatalf("FindInDiffs: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("current branch matched %d commits, want 0", len(commits))
+	}
+
+	commits, err = h.FindInDiffs(DiffSearchOptions{Pattern: "delta", All: true})
+	if err != nil {
+		t.Fatalf("FindInDiffs: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Subject != "add delta" {
+		t.Errorf("all branches = %+v, want [add delta]", commits)
+	}
+}
+
+func TestCleanMergedBranchesProtected(t *testing.T) {
+	newFixtureRepo(t)
+	for _, branch := range []string{"feat/a", "release/1.0", "develop", "staging"} {
+		if err := exec.Command("git", "branch", branch, "main").Run(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NewHelper(false).CleanMergedBranches(CleanBranchesOptions{
+		KeepPatterns: []string{"release/*", "staging"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Deleted) != 1 || result.Deleted[0] != "feat/a" {
+		t.Errorf("deleted = %v", result.Deleted)
+	}
+	want := []ProtectedBranch{
+		{Name: "develop", Pattern: "develop"},
+		{Name: "release/1.0", Pattern: "release/*"},
+		{Name: "staging", Pattern: "staging"},
+	}
+	if len(result.Protected) != len(want) {
+		t.Fatalf("protected = %+v", result.Protected)
+	}
+	for i := range want {
+		if result.Protected[i] != want[i] {
+			t.Errorf("protected[%d] = %+v, want %+v", i, result.Protected[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package golang
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// CobraOptions configures a new Cobra CLI project.
+type CobraOptions struct {
+	Name    string // app and directory name
+	Module  string // module path (default: Name)
+	Git     bool   // git init and make an initial commit
+	License string // mit, apache2, or none
+}
+
+// CobraProject describes a scaffolded Cobra CLI project.
+type CobraProject struct {
+	Name    string   `json:"name" yaml:"name"`
+	Path    string   `json:"path" yaml:"path"`
+	Module  string   `json:"module" yaml:"module"`
+	License string   `json:"license" yaml:"license"`
+	Git     bool     `json:"git" yaml:"git"`
+	Files   []string `json:"files" yaml:"files"`
+}
+
+// Licenses lists the supported --license values.
+var Licenses = []string{"mit", "apache2", "none"}
+
+// moduleElemRe matches a single module path element.
+var moduleElemRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*$`)
+
+// ValidateModulePath checks that path is a well-formed Go module path,
+// e.g. "github.com/user/mycli" or "mycli".
+func ValidateModulePath(path string) error {
+	if path == "" {
+		return fmt.Errorf("module path is required")
+	}
+	if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return fmt.Errorf("invalid module path %q: must not start or end with /", path)
+	}
+	for _, elem := range strings.Split(path, "/") {
+		if !moduleElemRe.MatchString(elem) || strings.HasSuffix(elem, ".") {
+			return fmt.Errorf("invalid module path %q: bad element %q", path, elem)
+		}
+	}
+	return nil
+}
+
+// InitCobraProject scaffolds a new Cobra CLI project with go.mod, main.go
+// and cmd/root.go, then runs go mod tidy.
+func (h *Helper) InitCobraProject(opts CobraOptions) (*CobraProject, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("app name is required")
+	}
+	if opts.Module == "" {
+		opts.Module = opts.Name
+	}
+	if err := ValidateModulePath(opts.Module); err != nil {
+		return nil, err
+	}
+	if opts.License == "" {
+		opts.License = "none"
+	}
+	if opts.License != "mit" && opts.License != "apache2" && opts.License != "none" {
+		return nil, fmt.Errorf("unsupported license %q (use: %s)", opts.License, strings.Join(Licenses, ", "))
+	}
+
+	projectPath, err := filepath.Abs(opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "go.mod")); err == nil {
+		return nil, fmt.Errorf("go.mod already exists in %s", projectPath)
+	}
+
+	project := &CobraProject{
+		Name:    opts.Name,
+		Path:    projectPath,
+		Module:  opts.Module,
+		License: opts.License,
+		Git:     opts.Git,
+	}
+
+	if !h.dryRun {
+		if err := os.MkdirAll(filepath.Join(projectPath, "cmd"), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := h.runInDir(projectPath, "go", "mod", "init", opts.Module); err != nil {
+		return nil, fmt.Errorf("go mod init failed: %w", err)
+	}
+	project.Files = append(project.Files, "go.mod")
+
+	data := cobraTemplateData{
+		Name:   opts.Name,
+		Module: opts.Module,
+		Year:   time.Now().Year(),
+		Holder: gitUserName(),
+	}
+
+	files := []struct {
+		path string
+		tmpl string
+	}{
+		{"main.go", cobraMainTemplate},
+		{filepath.Join("cmd", "root.go"), cobraRootTemplate},
+	}
+	switch opts.License {
+	case "mit":
+		files = append(files, struct{ path, tmpl string }{"LICENSE", mitLicenseTemplate})
+	case "apache2":
+		files = append(files, struct{ path, tmpl string }{"LICENSE", apacheLicenseTemplate})
+	}
+
+	for _, f := range files {
+		if err := h.writeTemplate(filepath.Join(projectPath, f.path), f.tmpl, data); err != nil {
+			return nil, err
+		}
+		project.Files = append(project.Files, f.path)
+	}
+
+	if err := h.runInDir(projectPath, "go", "mod", "tidy"); err != nil {
+		return nil, fmt.Errorf("go mod tidy failed: %w", err)
+	}
+	project.Files = append(project.Files, "go.sum")
+
+	if opts.Git {
+		if err := h.runInDir(projectPath, "git", "init", "-q"); err != nil {
+			return nil, fmt.Errorf("git init failed: %w", err)
+		}
+		if err := h.runInDir(projectPath, "git", "add", "-A"); err != nil {
+			return nil, fmt.Errorf("git add failed: %w", err)
+		}
+		if err := h.runInDir(projectPath, "git", "commit", "-q", "-m", "Initial commit"); err != nil {
+			return nil, fmt.Errorf("git commit failed: %w", err)
+		}
+	}
+
+	return project, nil
+}
+
+// cobraTemplateData is the data passed to the scaffold templates.
+type cobraTemplateData struct {
+	Name   string
+	Module string
+	Year   int
+	Holder string
+}
+
+// writeTemplate renders tmpl to path.
+func (h *Helper) writeTemplate(path, tmpl string, data cobraTemplateData) error {
+	if h.dryRun {
+		fmt.Printf("[dry-run] would create: %s\n", path)
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+
+	if err := template.Must(template.New(filepath.Base(path)).Parse(tmpl)).Execute(f, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// gitUserName returns the configured git user name, for license headers.
+func gitUserName() string {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil || strings.TrimSpace(string(out)) == "" {
+		return "the authors"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+const cobraMainTemplate = `package main
+
+import "{{.Module}}/cmd"
+
+func main() {
+	cmd.Execute()
+}
+`
+
+const cobraRootTemplate = `package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "{{.Name}}",
+	Short: "A brief description of {{.Name}}",
+	Long:  ` + "`" + `A longer description of {{.Name}}.` + "`" + `,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+}
+`
+
+const mitLicenseTemplate = `MIT License
+
+Copyright (c) {{.Year}} {{.Holder}}
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+//go:embed licenses/apache-2.0.txt
+var apacheLicenseText string
+
+// apacheLicenseTemplate is the full Apache License 2.0 text, with the
+// copyright placeholder in its appendix filled in from the template data.
+var apacheLicenseTemplate = strings.Replace(apacheLicenseText,
+	"[yyyy] [name of copyright owner]", "{{.Year}} {{.Holder}}", 1)
@@ -0,0 +1,58 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLicenseTemplates(t *testing.T) {
+	data := cobraTemplateData{Name: "app", Module: "app", Year: 2026, Holder: "Jane Doe"}
+	h := NewHelper(false, false)
+	dir := t.TempDir()
+
+	for _, tt := range []struct {
+		name string
+		tmpl string
+		want []string
+	}{
+		{"mit", mitLicenseTemplate, []string{
+			"MIT License",
+			"Copyright (c) 2026 Jane Doe",
+			`THE SOFTWARE IS PROVIDED "AS IS"`,
+		}},
+		{"apache2", apacheLicenseTemplate, []string{
+			"Apache License\n                           Version 2.0, January 2004",
+			"TERMS AND CONDITIONS FOR USE, REPRODUCTION, AND DISTRIBUTION",
+			"9. Accepting Warranty or Additional Liability.",
+			"END OF TERMS AND CONDITIONS",
+			"Copyright 2026 Jane Doe",
+		}},
+	} {
+		path := filepath.Join(dir, tt.name)
+		if err := h.writeTemplate(path, tt.tmpl, data); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("%s license missing %q", tt.name, want)
+			}
+		}
+		if strings.Contains(string(content), "{{") || strings.Contains(string(content), "[yyyy]") {
+			t.Errorf("%s license has an unfilled placeholder", tt.name)
+		}
+	}
+}
+
+func TestInitCobraProjectLicense(t *testing.T) {
+	h := NewHelper(false, true)
+	if _, err := h.InitCobraProject(CobraOptions{Name: "app", License: "gpl"}); err == nil ||
+		!strings.Contains(err.Error(), "unsupported license") {
+		t.Errorf("InitCobraProject(gpl) = %v, want unsupported license error", err)
+	}
+}
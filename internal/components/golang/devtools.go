@@ -0,0 +1,385 @@
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ToolsManifest is the acorn dev-tools manifest: one package@version per
+// line, with # comments.
+const ToolsManifest = ".acorn-gotools"
+
+// ToolsFile is the conventional file of blank imports pinning dev tools to
+// the versions required in go.mod.
+const ToolsFile = "tools.go"
+
+// Dev tool states.
+const (
+	ToolInstalled = "installed"
+	ToolOK        = "ok"
+	ToolMissing   = "missing"
+	ToolMismatch  = "mismatch"
+	ToolFailed    = "failed"
+)
+
+// DevTool is a pinned development tool.
+type DevTool struct {
+	Package   string `json:"package" yaml:"package"`
+	Module    string `json:"module,omitempty" yaml:"module,omitempty"`
+	Version   string `json:"version" yaml:"version"`
+	Binary    string `json:"binary" yaml:"binary"`
+	Installed string `json:"installed_version,omitempty" yaml:"installed_version,omitempty"`
+	Status    string `json:"status,omitempty" yaml:"status,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// fromModule means the version comes from go.mod, so go install runs
+	// without @version inside the module.
+	fromModule bool
+}
+
+// ToolsReport is the result of installing or checking dev tools.
+type ToolsReport struct {
+	Source string    `json:"source" yaml:"source"`
+	Tools  []DevTool `json:"tools" yaml:"tools"`
+	OK     bool      `json:"ok" yaml:"ok"`
+}
+
+var (
+	// modulePathElemRe matches one element of a module or package path.
+	modulePathElemRe = regexp.MustCompile(`^[A-Za-z0-9_.~+-]+$`)
+
+	// moduleVersionRe matches a semantic or pseudo-version.
+	moduleVersionRe = regexp.MustCompile(`^v\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+	// majorSuffixRe matches a major version path suffix such as v2.
+	majorSuffixRe = regexp.MustCompile(`^v\d+$`)
+)
+
+// FindToolsSource returns the tools manifest in dir, preferring
+// .acorn-gotools over tools.go.
+func FindToolsSource(dir string) (string, error) {
+	for _, name := range []string{ToolsManifest, ToolsFile} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no %s or %s in %s", ToolsManifest, ToolsFile, dir)
+}
+
+// LoadDevTools reads the tools declared in path. A tools.go takes its
+// versions from the go.mod next to it.
+func LoadDevTools(path string) ([]DevTool, error) {
+	if filepath.Ext(path) == ".go" {
+		return loadToolsGo(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tools manifest: %w", err)
+	}
+	defer f.Close()
+	return ParseToolsManifest(f)
+}
+
+// ParseToolsManifest parses package@version lines. Every entry must be a
+// valid package path pinned to a semantic version.
+func ParseToolsManifest(r io.Reader) ([]DevTool, error) {
+	var tools []DevTool
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		pkg, version, ok := strings.Cut(line, "@")
+		if !ok {
+			return nil, fmt.Errorf("line %d: %q has no @version", n, line)
+		}
+		if err := checkPackagePath(pkg); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if !moduleVersionRe.MatchString(version) {
+			return nil, fmt.Errorf("line %d: %q is not a pinned version (want vX.Y.Z)", n, version)
+		}
+		if seen[pkg] {
+			return nil, fmt.Errorf("line %d: %s is listed twice", n, pkg)
+		}
+		seen[pkg] = true
+		tools = append(tools, DevTool{Package: pkg, Version: version, Binary: toolBinary(pkg)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tools manifest: %w", err)
+	}
+	return tools, nil
+}
+
+// loadToolsGo reads the blank imports of a tools.go and resolves each to
+// the module version required in go.mod.
+func loadToolsGo(path string) ([]DevTool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	gomod, err := os.ReadFile(filepath.Join(filepath.Dir(path), "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod for %s: %w", path, err)
+	}
+	requires := parseRequires(gomod)
+
+	var tools []DevTool
+	for _, imp := range file.Imports {
+		if imp.Name == nil || imp.Name.Name != "_" {
+			continue
+		}
+		pkg, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid import %s: %w", imp.Path.Value, err)
+		}
+		if err := checkPackagePath(pkg); err != nil {
+			return nil, err
+		}
+		module := requiringModule(pkg, requires)
+		if module == "" {
+			return nil, fmt.Errorf("%s is not required in go.mod", pkg)
+		}
+		tools = append(tools, DevTool{
+			Package:    pkg,
+			Module:     module,
+			Version:    requires[module],
+			Binary:     toolBinary(pkg),
+			fromModule: true,
+		})
+	}
+	if len(tools) == 0 {
+		return nil, fmt.Errorf("%s has no blank imports", path)
+	}
+	return tools, nil
+}
+
+// parseRequires returns the module versions required by a go.mod, in both
+// single-line and block form.
+func parseRequires(gomod []byte) map[string]string {
+	requires := make(map[string]string)
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(gomod))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		if len(fields) >= 2 {
+			requires[strings.Trim(fields[0], `"`)] = fields[1]
+		}
+	}
+	return requires
+}
+
+// requiringModule returns the longest required module path containing pkg.
+func requiringModule(pkg string, requires map[string]string) string {
+	best := ""
+	for module := range requires {
+		if (pkg == module || strings.HasPrefix(pkg, module+"/")) && len(module) > len(best) {
+			best = module
+		}
+	}
+	return best
+}
+
+// checkPackagePath reports whether pkg looks like an installable package
+// path: slash-separated elements with a dotted host first.
+func checkPackagePath(pkg string) error {
+	elems := strings.Split(pkg, "/")
+	if len(elems) < 2 || !strings.Contains(elems[0], ".") {
+		return fmt.Errorf("%q is not a module path", pkg)
+	}
+	for _, e := range elems {
+		if !modulePathElemRe.MatchString(e) || e == "." || e == ".." {
+			return fmt.Errorf("%q is not a module path", pkg)
+		}
+	}
+	return nil
+}
+
+// toolBinary returns the name go install gives the binary of pkg, which
+// skips a trailing major version element.
+func toolBinary(pkg string) string {
+	elems := strings.Split(pkg, "/")
+	name := elems[len(elems)-1]
+	if majorSuffixRe.MatchString(name) && len(elems) > 2 {
+		name = elems[len(elems)-2]
+	}
+	return name
+}
+
+// ToolBinDir returns the directory go install writes binaries to.
+func (h *Helper) ToolBinDir() (string, error) {
+	env := h.GetGoEnv()
+	if bin := env["GOBIN"]; bin != "" {
+		return bin, nil
+	}
+	gopath := filepath.SplitList(env["GOPATH"])
+	if len(gopath) == 0 || gopath[0] == "" {
+		return "", fmt.Errorf("cannot determine go install directory: GOBIN and GOPATH are unset")
+	}
+	return filepath.Join(gopath[0], "bin"), nil
+}
+
+// InstalledToolVersion returns the version of module a Go binary was built
+// with, or "" when it is not installed. An empty module means the binary's
+// main module.
+func InstalledToolVersion(binary, module string) (string, error) {
+	if _, err := os.Stat(binary); err != nil {
+		return "", nil
+	}
+	out, err := exec.Command("go", "version", "-m", binary).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read build info of %s: %w", binary, err)
+	}
+	return parseBuildInfoVersion(string(out), module), nil
+}
+
+// parseBuildInfoVersion extracts a module version from go version -m
+// output. A tool installed from inside a module is a dependency of that
+// module rather than the main module.
+func parseBuildInfoVersion(out, module string) string {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		if module == "" && fields[0] == "mod" {
+			return fields[2]
+		}
+		if module != "" && (fields[0] == "mod" || fields[0] == "dep") && fields[1] == module {
+			return fields[2]
+		}
+	}
+	return ""
+}
+
+// CheckDevTools compares installed tool binaries with their pinned
+// versions without installing anything.
+func (h *Helper) CheckDevTools(source string, tools []DevTool) (*ToolsReport, error) {
+	binDir, err := h.ToolBinDir()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ToolsReport{Source: source, Tools: tools, OK: true}
+	for i := range report.Tools {
+		t := &report.Tools[i]
+		installed, err := InstalledToolVersion(filepath.Join(binDir, t.Binary), t.Module)
+		if err != nil {
+			return nil, err
+		}
+		t.Installed = installed
+		switch {
+		case installed == "":
+			t.Status = ToolMissing
+		case installed != t.Version:
+			t.Status = ToolMismatch
+		default:
+			t.Status = ToolOK
+		}
+		if t.Status != ToolOK {
+			report.OK = false
+		}
+	}
+	return report, nil
+}
+
+// InstallDevTools runs go install for each tool and reports the versions
+// installed. Manifest tools install from dir; tools.go tools install from
+// the directory of source, so they resolve against the module declaring
+// them even when it is nested (e.g. tools/tools.go). A failed install is
+// recorded and the rest continue. Command output goes to stderr so
+// structured output on stdout stays clean.
+func (h *Helper) InstallDevTools(dir, source string, tools []DevTool) (*ToolsReport, error) {
+	binDir, err := h.ToolBinDir()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ToolsReport{Source: source, Tools: tools, OK: true}
+	for i := range report.Tools {
+		t := &report.Tools[i]
+		target := t.Package + "@" + t.Version
+		if t.fromModule {
+			target = t.Package
+		}
+
+		if h.dryRun {
+			fmt.Printf("[dry-run] would run: go install %s\n", target)
+			continue
+		}
+		if h.verbose {
+			fmt.Fprintf(os.Stderr, "Running: go install %s\n", target)
+		}
+
+		cmd := exec.Command("go", "install", target)
+		cmd.Dir = toolInstallDir(dir, source, *t)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			t.Status = ToolFailed
+			t.Error = err.Error()
+			report.OK = false
+			continue
+		}
+
+		installed, err := InstalledToolVersion(filepath.Join(binDir, t.Binary), t.Module)
+		if err != nil {
+			return nil, err
+		}
+		t.Installed = installed
+		t.Status = ToolInstalled
+		if installed != t.Version {
+			t.Status = ToolMismatch
+			report.OK = false
+		}
+	}
+	return report, nil
+}
+
+// toolInstallDir returns the directory go install runs in for t: the
+// directory of the tools.go declaring it when its version comes from that
+// module's go.mod, or dir otherwise.
+func toolInstallDir(dir, source string, t DevTool) string {
+	if t.fromModule {
+		return filepath.Dir(source)
+	}
+	return dir
+}
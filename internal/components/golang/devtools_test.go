@@ -0,0 +1,152 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseToolsManifest(t *testing.T) {
+	manifest := `# linters
+github.com/golangci/golangci-lint/cmd/golangci-lint@v1.59.1
+go.uber.org/mock/mockgen@v0.4.0   # mocks
+
+golang.org/x/tools/cmd/goimports@v0.22.1-0.20240612183325-0a6fb4c13c1a
+`
+	tools, err := ParseToolsManifest(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tools) != 3 {
+		t.Fatalf("got %d tools, want 3", len(tools))
+	}
+	if tools[0].Binary != "golangci-lint" || tools[0].Version != "v1.59.1" {
+		t.Errorf("tools[0] = %+v", tools[0])
+	}
+	if tools[1].Package != "go.uber.org/mock/mockgen" || tools[1].Version != "v0.4.0" {
+		t.Errorf("tools[1] = %+v", tools[1])
+	}
+
+	invalid := map[string]string{
+		"no version":    "golang.org/x/tools/cmd/goimports\n",
+		"latest":        "golang.org/x/tools/cmd/goimports@latest\n",
+		"not a module":  "goimports@v0.1.0\n",
+		"bad element":   "golang.org/x/../cmd@v0.1.0\n",
+		"listed twice":  "example.com/a/b@v1.0.0\nexample.com/a/b@v1.1.0\n",
+		"space in path": "example.com/a b@v1.0.0\n",
+	}
+	for name, data := range invalid {
+		if _, err := ParseToolsManifest(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadToolsGo(t *testing.T) {
+	dir := t.TempDir()
+	gomod := `module example.com/app
+
+go 1.22
+
+require example.com/lint v1.2.0 // indirect
+
+require (
+	example.com/tools v0.3.0
+	example.com/tools/nested v0.9.0
+)
+`
+	toolsGo := `//go:build tools
+
+package tools
+
+import (
+	_ "example.com/lint/cmd/lint"
+	_ "example.com/tools/nested/cmd/gen/v2"
+	"fmt"
+)
+
+var _ = fmt.Sprint
+`
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ToolsFile)
+	if err := os.WriteFile(path, []byte(toolsGo), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tools, err := LoadDevTools(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tools) != 2 {
+		t.Fatalf("got %d tools, want 2", len(tools))
+	}
+	if tools[0].Module != "example.com/lint" || tools[0].Version != "v1.2.0" || tools[0].Binary != "lint" {
+		t.Errorf("tools[0] = %+v", tools[0])
+	}
+	if tools[1].Module != "example.com/tools/nested" || tools[1].Version != "v0.9.0" || tools[1].Binary != "gen" {
+		t.Errorf("tools[1] = %+v", tools[1])
+	}
+
+	if err := os.WriteFile(path, []byte("package tools\n\nimport _ \"example.com/other/cmd/x\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDevTools(path); err == nil {
+		t.Error("import not required in go.mod should fail")
+	}
+}
+
+func TestNestedToolsModule(t *testing.T) {
+	repo := t.TempDir()
+	toolsDir := filepath.Join(repo, "tools")
+	if err := os.MkdirAll(toolsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(repo, "go.mod"):      "module example.com/app\n\ngo 1.22\n",
+		filepath.Join(toolsDir, "go.mod"):  "module example.com/app/tools\n\ngo 1.22\n\nrequire example.com/lint v1.2.0\n",
+		filepath.Join(toolsDir, ToolsFile): "//go:build tools\n\npackage tools\n\nimport _ \"example.com/lint/cmd/lint\"\n",
+	}
+	for path, data := range files {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	source := filepath.Join(toolsDir, ToolsFile)
+	tools, err := LoadDevTools(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tools) != 1 || tools[0].Version != "v1.2.0" {
+		t.Fatalf("tools = %+v", tools)
+	}
+	if got := toolInstallDir(repo, source, tools[0]); got != toolsDir {
+		t.Errorf("tools.go tool installs from %s, want the tools module %s", got, toolsDir)
+	}
+
+	manifest := DevTool{Package: "example.com/lint/cmd/lint", Version: "v1.2.0"}
+	if got := toolInstallDir(repo, filepath.Join(repo, ToolsManifest), manifest); got != repo {
+		t.Errorf("manifest tool installs from %s, want %s", got, repo)
+	}
+}
+
+func TestParseBuildInfoVersion(t *testing.T) {
+	out := `/go/bin/gen: go1.22.4
+	path	example.com/tools/nested/cmd/gen/v2
+	mod	example.com/app	(devel)
+	dep	example.com/tools/nested	v0.9.0	h1:abc=
+	build	-compiler=gc
+`
+	if got := parseBuildInfoVersion(out, ""); got != "(devel)" {
+		t.Errorf("main module version = %q", got)
+	}
+	if got := parseBuildInfoVersion(out, "example.com/tools/nested"); got != "v0.9.0" {
+		t.Errorf("dependency version = %q", got)
+	}
+	if got := parseBuildInfoVersion(out, "example.com/missing"); got != "" {
+		t.Errorf("missing module version = %q", got)
+	}
+}
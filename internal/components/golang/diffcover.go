@@ -0,0 +1,309 @@
+package golang
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiffCoverage reports coverage of the lines changed since a base ref.
+// Only changed lines inside a coverage block count; blank lines, comments
+// and declarations have no statements and are ignored.
+type DiffCoverage struct {
+	Base      string         `json:"base" yaml:"base"`
+	MergeBase string         `json:"merge_base" yaml:"merge_base"`
+	Files     []FileCoverage `json:"files" yaml:"files"`
+	Changed   int            `json:"changed" yaml:"changed"`
+	Covered   int            `json:"covered" yaml:"covered"`
+	Percent   float64        `json:"percent" yaml:"percent"`
+}
+
+// FileCoverage is the changed-line coverage of a single file.
+type FileCoverage struct {
+	File      string  `json:"file" yaml:"file"`
+	Changed   int     `json:"changed" yaml:"changed"`
+	Covered   int     `json:"covered" yaml:"covered"`
+	Percent   float64 `json:"percent" yaml:"percent"`
+	Uncovered []int   `json:"uncovered,omitempty" yaml:"uncovered,omitempty"`
+}
+
+// coverBlock is one line of a coverage profile.
+type coverBlock struct {
+	StartLine int
+	EndLine   int
+	Count     int
+}
+
+var (
+	// profileLineRe matches "file.go:12.5,14.2 3 1" profile entries.
+	profileLineRe = regexp.MustCompile(`^(.+):(\d+)\.\d+,(\d+)\.(\d+) \d+ (\d+)$`)
+
+	// hunkRe matches the new-file range of a unified diff hunk header.
+	hunkRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+)
+
+// RunCoverProfile runs the tests and writes a coverage profile, sending
+// test output to stderr so structured output on stdout stays clean.
+func (h *Helper) RunCoverProfile(profile string) error {
+	args := []string{"test", "./...", "-coverprofile=" + profile}
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: go %s\n", strings.Join(args, " "))
+		return nil
+	}
+	if h.verbose {
+		fmt.Fprintf(os.Stderr, "Running: go %s\n", strings.Join(args, " "))
+	}
+
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("tests failed: %w", err)
+	}
+	return nil
+}
+
+// DiffCoverage intersects a coverage profile with the lines changed since
+// the merge base of base and HEAD, including uncommitted changes and
+// untracked files. It must run from the module root.
+func (h *Helper) DiffCoverage(base, profile string) (*DiffCoverage, error) {
+	module, err := ModulePath(".")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := exec.Command("git", "merge-base", base, "HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base with %s: %w", base, err)
+	}
+	mergeBase := strings.TrimSpace(string(out))
+
+	diff, err := exec.Command("git", "diff", "--unified=0", "--no-color", "--relative",
+		mergeBase, "--", "*.go").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", base, err)
+	}
+	changed, err := parseDiff(strings.NewReader(string(diff)))
+	if err != nil {
+		return nil, err
+	}
+	if err := addUntrackedFiles(changed); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open coverage profile: %w", err)
+	}
+	defer f.Close()
+	blocks, err := parseProfile(f, module)
+	if err != nil {
+		return nil, err
+	}
+
+	result := computeDiffCoverage(changed, blocks)
+	result.Base = base
+	result.MergeBase = mergeBase
+	return result, nil
+}
+
+// parseProfile reads a coverage profile, keyed by file path relative to
+// the module root. Files outside module are skipped.
+func parseProfile(r io.Reader, module string) (map[string][]coverBlock, error) {
+	blocks := make(map[string][]coverBlock)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		m := profileLineRe.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("invalid coverage profile line: %q", line)
+		}
+		file, ok := strings.CutPrefix(m[1], module+"/")
+		if !ok {
+			continue
+		}
+		start, _ := strconv.Atoi(m[2])
+		end, _ := strconv.Atoi(m[3])
+		endCol, _ := strconv.Atoi(m[4])
+		count, _ := strconv.Atoi(m[5])
+		// A block ending at column 1 stops before that line's text (the
+		// closing brace), so the line holds none of its statements.
+		if endCol <= 1 && end > start {
+			end--
+		}
+		blocks[file] = append(blocks[file], coverBlock{StartLine: start, EndLine: end, Count: count})
+	}
+	return blocks, scanner.Err()
+}
+
+// parseDiff returns the added or modified line numbers per file in a
+// unified diff. Deleted files have no new lines and are omitted.
+func parseDiff(r io.Reader) (map[string][]int, error) {
+	changed := make(map[string][]int)
+	var file string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if rest, ok := strings.CutPrefix(line, "+++ "); ok {
+			file = ""
+			if rest != "/dev/null" {
+				file = strings.TrimPrefix(rest, "b/")
+			}
+			continue
+		}
+		if file == "" {
+			continue
+		}
+		m := hunkRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		for n := start; n < start+count; n++ {
+			changed[file] = append(changed[file], n)
+		}
+	}
+	return changed, scanner.Err()
+}
+
+// addUntrackedFiles marks every line of the untracked .go files reported
+// by git status as changed, since git diff leaves them out.
+func addUntrackedFiles(changed map[string][]int) error {
+	prefix, err := exec.Command("git", "rev-parse", "--show-prefix").Output()
+	if err != nil {
+		return fmt.Errorf("failed to locate module in repository: %w", err)
+	}
+	status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=all", "--", "*.go").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	for _, file := range parseUntracked(string(status), strings.TrimSpace(string(prefix))) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		lines := strings.Count(string(data), "\n")
+		if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+			lines++
+		}
+		for n := 1; n <= lines; n++ {
+			changed[file] = append(changed[file], n)
+		}
+	}
+	return nil
+}
+
+// parseUntracked returns the untracked .go files in git status --porcelain
+// output, whose paths are relative to the repository root, as paths
+// relative to the directory at prefix.
+func parseUntracked(status, prefix string) []string {
+	var files []string
+	for _, line := range strings.Split(status, "\n") {
+		path, ok := strings.CutPrefix(line, "?? ")
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(path, `"`) {
+			if unquoted, err := strconv.Unquote(path); err == nil {
+				path = unquoted
+			}
+		}
+		if path, ok = strings.CutPrefix(path, prefix); ok && strings.HasSuffix(path, ".go") {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// computeDiffCoverage counts changed lines that fall in a coverage block
+// and how many of those ran. A line shared by several blocks is covered
+// if any of them ran.
+func computeDiffCoverage(changed map[string][]int, blocks map[string][]coverBlock) *DiffCoverage {
+	result := &DiffCoverage{Files: []FileCoverage{}}
+
+	files := make([]string, 0, len(changed))
+	for file := range changed {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		fileBlocks, ok := blocks[file]
+		if !ok {
+			continue
+		}
+		fc := FileCoverage{File: file}
+		for _, line := range changed[file] {
+			instrumented, covered := false, false
+			for _, b := range fileBlocks {
+				if line >= b.StartLine && line <= b.EndLine {
+					instrumented = true
+					if b.Count > 0 {
+						covered = true
+						break
+					}
+				}
+			}
+			if !instrumented {
+				continue
+			}
+			fc.Changed++
+			if covered {
+				fc.Covered++
+			} else {
+				fc.Uncovered = append(fc.Uncovered, line)
+			}
+		}
+		if fc.Changed == 0 {
+			continue
+		}
+		fc.Percent = percent(fc.Covered, fc.Changed)
+		result.Files = append(result.Files, fc)
+		result.Changed += fc.Changed
+		result.Covered += fc.Covered
+	}
+
+	result.Percent = percent(result.Covered, result.Changed)
+	return result
+}
+
+// percent returns covered/total as a percentage; nothing to cover is 100%.
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+// FormatLineRanges collapses sorted line numbers into ranges like "3-5, 9".
+func FormatLineRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
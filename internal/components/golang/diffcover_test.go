@@ -0,0 +1,152 @@
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testProfile = `mode: set
+example.com/dc/a.go:4.2,5.1 1 1
+example.com/dc/a.go:9.2,9.11 1 1
+example.com/dc/a.go:10.3,11.1 1 0
+example.com/dc/a.go:12.2,12.14 1 1
+example.com/dc/a.go:16.2,17.1 1 0
+example.com/other/b.go:1.1,2.2 1 1
+`
+
+const testDiff = `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -5,0 +6,12 @@ func Add(a, b int) int {
++// new lines
+diff --git a/a_test.go b/a_test.go
+--- a/a_test.go
++++ b/a_test.go
+@@ -9 +10 @@ func TestAdd(t *testing.T) {
++	t.Log("x")
+diff --git a/gone.go b/gone.go
+--- a/gone.go
++++ /dev/null
+@@ -1,3 +0,0 @@
+`
+
+func TestParseDiff(t *testing.T) {
+	changed, err := parseDiff(strings.NewReader(testDiff))
+	if err != nil {
+		t.Fatalf("parseDiff failed: %v", err)
+	}
+	if len(changed["a.go"]) != 12 || changed["a.go"][0] != 6 || changed["a.go"][11] != 17 {
+		t.Errorf("a.go lines = %v, want 6-17", changed["a.go"])
+	}
+	if !reflect.DeepEqual(changed["a_test.go"], []int{10}) {
+		t.Errorf("a_test.go lines = %v, want [10]", changed["a_test.go"])
+	}
+	if _, ok := changed["gone.go"]; ok {
+		t.Error("deleted file should have no changed lines")
+	}
+}
+
+func TestComputeDiffCoverage(t *testing.T) {
+	blocks, err := parseProfile(strings.NewReader(testProfile), "example.com/dc")
+	if err != nil {
+		t.Fatalf("parseProfile failed: %v", err)
+	}
+	if _, ok := blocks["../other/b.go"]; ok || len(blocks) != 1 {
+		t.Errorf("files outside the module should be skipped: %v", blocks)
+	}
+
+	changed, _ := parseDiff(strings.NewReader(testDiff))
+	result := computeDiffCoverage(changed, blocks)
+
+	if result.Changed != 4 || result.Covered != 2 || result.Percent != 50 {
+		t.Errorf("total = %d/%d (%.1f%%), want 2/4 (50%%)", result.Covered, result.Changed, result.Percent)
+	}
+	if len(result.Files) != 1 || !reflect.DeepEqual(result.Files[0].Uncovered, []int{10, 16}) {
+		t.Errorf("files = %+v, want a.go uncovered [10 16]", result.Files)
+	}
+
+	empty := computeDiffCoverage(map[string][]int{"a.go": {1, 2}}, blocks)
+	if empty.Changed != 0 || empty.Percent != 100 {
+		t.Errorf("no changed statements = %+v, want 100%%", empty)
+	}
+}
+
+func TestParseProfileInvalid(t *testing.T) {
+	if _, err := parseProfile(strings.NewReader("mode: set\nnot a profile\n"), "m"); err == nil {
+		t.Error("expected error for malformed profile")
+	}
+}
+
+func TestFormatLineRanges(t *testing.T) {
+	if got := FormatLineRanges([]int{3, 4, 5, 9, 11, 12}); got != "3-5, 9, 11-12" {
+		t.Errorf("FormatLineRanges = %q", got)
+	}
+	if got := FormatLineRanges(nil); got != "" {
+		t.Errorf("FormatLineRanges(nil) = %q", got)
+	}
+}
+
+func TestParseUntracked(t *testing.T) {
+	status := " M mod/a.go\n?? mod/new.go\n?? mod/sub/b.go\n?? other/c.go\n?? mod/notes.txt\n?? \"mod/sp ace.go\"\nA  mod/staged.go\n"
+	want := []string{"new.go", "sub/b.go", "sp ace.go"}
+	if got := parseUntracked(status, "mod/"); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUntracked = %q, want %q", got, want)
+	}
+	if got := parseUntracked(status, ""); len(got) != 4 {
+		t.Errorf("parseUntracked at root = %q", got)
+	}
+}
+
+func TestDiffCoverageUntracked(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	repo := t.TempDir()
+	mod := filepath.Join(repo, "mod")
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(mod, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	if err := os.MkdirAll(mod, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write("go.mod", "module example.com/dc\n\ngo 1.22\n")
+	write("a.go", "package dc\n")
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "base")
+
+	// Never added to the index, so git diff does not see it
+	write("new.go", "package dc\n\nfunc New() int {\n\treturn 1\n}\n")
+	write("cover.out", "mode: set\nexample.com/dc/new.go:3.16,5.2 1 0\n")
+	t.Chdir(mod)
+
+	result, err := NewHelper(false, false).DiffCoverage("HEAD", "cover.out")
+	if err != nil {
+		t.Fatalf("DiffCoverage: %v", err)
+	}
+	if len(result.Files) != 1 || result.Files[0].File != "new.go" || result.Changed != 3 || result.Covered != 0 {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -2,11 +2,16 @@
 package golang
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -25,6 +30,51 @@ type TestResult struct {
 	Coverage string `json:"coverage,omitempty" yaml:"coverage,omitempty"`
 }
 
+// TestOptions configures a structured test run.
+type TestOptions struct {
+	Pattern  string   // -run pattern
+	Packages []string // package patterns (default: ./...)
+	FailFast bool
+	Race     bool
+}
+
+// PackageSummary contains per-package test counts.
+type PackageSummary struct {
+	Package string  `json:"package" yaml:"package"`
+	Status  string  `json:"status" yaml:"status"` // "ok", "FAIL", or "no tests"
+	Passed  int     `json:"passed" yaml:"passed"`
+	Failed  int     `json:"failed" yaml:"failed"`
+	Skipped int     `json:"skipped" yaml:"skipped"`
+	Elapsed float64 `json:"elapsed" yaml:"elapsed"`
+}
+
+// TestFailure describes a failing test (or a package that failed to build).
+type TestFailure struct {
+	Package string `json:"package" yaml:"package"`
+	Test    string `json:"test,omitempty" yaml:"test,omitempty"`
+	Output  string `json:"output" yaml:"output"`
+}
+
+// TestSummary aggregates the results of a go test -json run.
+type TestSummary struct {
+	Packages []PackageSummary `json:"packages" yaml:"packages"`
+	Failures []TestFailure    `json:"failures" yaml:"failures"`
+	Passed   int              `json:"passed" yaml:"passed"`
+	Failed   int              `json:"failed" yaml:"failed"`
+	Skipped  int              `json:"skipped" yaml:"skipped"`
+	Success  bool             `json:"success" yaml:"success"`
+}
+
+// testEvent is a single line of go test -json output.
+type testEvent struct {
+	Action     string  `json:"Action"`
+	Package    string  `json:"Package"`
+	ImportPath string  `json:"ImportPath"` // set on build-output/build-fail events
+	Test       string  `json:"Test"`
+	Elapsed    float64 `json:"Elapsed"`
+	Output     string  `json:"Output"`
+}
+
 // BuildTarget represents a build target platform.
 type BuildTarget struct {
 	OS     string `json:"os" yaml:"os"`
@@ -99,68 +149,203 @@ func main() {
 	}, nil
 }
 
-// InitCobraProject initializes a new Cobra CLI project.
-func (h *Helper) InitCobraProject(name string) (*Project, error) {
-	if name == "" {
-		return nil, fmt.Errorf("app name is required")
+// AddCobraCommand adds a command to a Cobra project.
+func (h *Helper) AddCobraCommand(cmdName string) error {
+	if cmdName == "" {
+		return fmt.Errorf("command name is required")
 	}
 
 	// Check if cobra-cli is installed
 	if _, err := exec.LookPath("cobra-cli"); err != nil {
-		fmt.Println("cobra-cli not installed. Installing...")
-		if err := h.run("go", "install", "github.com/spf13/cobra-cli@latest"); err != nil {
-			return nil, fmt.Errorf("failed to install cobra-cli: %w", err)
-		}
+		return fmt.Errorf("cobra-cli not installed, run: go install github.com/spf13/cobra-cli@latest")
 	}
 
-	// Create directory and initialize
-	if err := os.MkdirAll(name, 0o755); err != nil {
-		return nil, fmt.Errorf("failed to create directory: %w", err)
+	return h.run("cobra-cli", "add", cmdName)
+}
+
+// RunTests runs Go tests with optional pattern filter.
+func (h *Helper) RunTests(pattern string) error {
+	args := []string{"test", "./..."}
+	if pattern != "" {
+		args = append(args, "-run", pattern)
 	}
+	return h.run("go", args...)
+}
 
-	projectPath, err := filepath.Abs(name)
+// RunTestsSummary runs go test -json and aggregates the results.
+// Test failures are reported in the summary, not as an error; an error is
+// returned only when go test could not be run or produced no results.
+func (h *Helper) RunTestsSummary(opts TestOptions) (*TestSummary, error) {
+	args := []string{"test", "-json"}
+	if opts.FailFast {
+		args = append(args, "-failfast")
+	}
+	if opts.Race {
+		args = append(args, "-race")
+	}
+	if opts.Pattern != "" {
+		args = append(args, "-run", opts.Pattern)
+	}
+	if len(opts.Packages) > 0 {
+		args = append(args, opts.Packages...)
+	} else {
+		args = append(args, "./...")
+	}
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: go %s\n", strings.Join(args, " "))
+		return &TestSummary{Success: true}, nil
+	}
+
+	if h.verbose {
+		fmt.Printf("Running: go %s\n", strings.Join(args, " "))
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, err
 	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run go test: %w", err)
+	}
 
-	// Initialize cobra project
-	if err := h.runInDir(projectPath, "cobra-cli", "init", "."); err != nil {
-		return nil, fmt.Errorf("cobra-cli init failed: %w", err)
+	summary, parseErr := ParseTestEvents(stdout)
+	runErr := cmd.Wait()
+	if parseErr != nil {
+		return nil, parseErr
 	}
 
-	// Run go mod tidy
-	if err := h.runInDir(projectPath, "go", "mod", "tidy"); err != nil {
-		return nil, fmt.Errorf("go mod tidy failed: %w", err)
+	// go test exits non-zero on failures; only treat it as an error when
+	// there were no results to report (e.g. invalid package pattern).
+	if runErr != nil && len(summary.Packages) == 0 {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = runErr.Error()
+		}
+		return nil, fmt.Errorf("go test failed: %s", msg)
+	}
+	if runErr != nil && summary.Success {
+		summary.Success = false
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			summary.Failures = append(summary.Failures, TestFailure{Output: msg})
+		}
 	}
 
-	return &Project{
-		Name:   name,
-		Path:   projectPath,
-		Module: name,
-	}, nil
+	return summary, nil
 }
 
-// AddCobraCommand adds a command to a Cobra project.
-func (h *Helper) AddCobraCommand(cmdName string) error {
-	if cmdName == "" {
-		return fmt.Errorf("command name is required")
-	}
+// ParseTestEvents parses a go test -json stream into a TestSummary.
+// Lines that are not JSON events (e.g. build errors) are ignored.
+func ParseTestEvents(r io.Reader) (*TestSummary, error) {
+	packages := make(map[string]*PackageSummary)
+	pkgOutput := make(map[string]*strings.Builder)
+	testOutput := make(map[string]*strings.Builder)
+	pkgHasTestFailure := make(map[string]bool)
+	summary := &TestSummary{Failures: []TestFailure{}}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var ev testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		if ev.Package == "" {
+			// Build events use ImportPath, e.g. "pkg" or "pkg [pkg.test]"
+			ev.Package, _, _ = strings.Cut(ev.ImportPath, " ")
+		}
+		if ev.Package == "" {
+			continue
+		}
 
-	// Check if cobra-cli is installed
-	if _, err := exec.LookPath("cobra-cli"); err != nil {
-		return fmt.Errorf("cobra-cli not installed, run: go install github.com/spf13/cobra-cli@latest")
+		pkg, ok := packages[ev.Package]
+		if !ok {
+			pkg = &PackageSummary{Package: ev.Package}
+			packages[ev.Package] = pkg
+			pkgOutput[ev.Package] = &strings.Builder{}
+		}
+
+		key := ev.Package + "\x00" + ev.Test
+		switch ev.Action {
+		case "output", "build-output":
+			if ev.Test == "" {
+				pkgOutput[ev.Package].WriteString(ev.Output)
+				continue
+			}
+			if testOutput[key] == nil {
+				testOutput[key] = &strings.Builder{}
+			}
+			testOutput[key].WriteString(ev.Output)
+		case "pass":
+			if ev.Test == "" {
+				pkg.Status = "ok"
+				pkg.Elapsed = ev.Elapsed
+			} else {
+				pkg.Passed++
+				delete(testOutput, key)
+			}
+		case "skip":
+			if ev.Test == "" {
+				pkg.Status = "no tests"
+			} else {
+				pkg.Skipped++
+				delete(testOutput, key)
+			}
+		case "build-fail":
+			pkg.Status = "FAIL"
+		case "fail":
+			if ev.Test == "" {
+				pkg.Status = "FAIL"
+				pkg.Elapsed = ev.Elapsed
+				continue
+			}
+			pkg.Failed++
+			pkgHasTestFailure[ev.Package] = true
+			var out string
+			if b := testOutput[key]; b != nil {
+				out = b.String()
+			}
+			summary.Failures = append(summary.Failures, TestFailure{
+				Package: ev.Package,
+				Test:    ev.Test,
+				Output:  out,
+			})
+			delete(testOutput, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read test output: %w", err)
 	}
 
-	return h.run("cobra-cli", "add", cmdName)
-}
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-// RunTests runs Go tests with optional pattern filter.
-func (h *Helper) RunTests(pattern string) error {
-	args := []string{"test", "./..."}
-	if pattern != "" {
-		args = append(args, "-run", pattern)
+	for _, name := range names {
+		pkg := packages[name]
+		if pkg.Status == "" {
+			pkg.Status = "no tests"
+		}
+		// A package that failed without a failing test did not build or crashed
+		if pkg.Status == "FAIL" && !pkgHasTestFailure[name] {
+			summary.Failures = append(summary.Failures, TestFailure{
+				Package: name,
+				Output:  pkgOutput[name].String(),
+			})
+		}
+		summary.Passed += pkg.Passed
+		summary.Failed += pkg.Failed
+		summary.Skipped += pkg.Skipped
+		summary.Packages = append(summary.Packages, *pkg)
 	}
-	return h.run("go", args...)
+
+	summary.Success = len(summary.Failures) == 0
+	return summary, nil
 }
 
 // RunTestsWithCoverage runs tests with coverage report.
@@ -188,26 +373,61 @@ func (h *Helper) RunBenchmarks(pattern string) error {
 	return h.run("go", "test", "-bench="+benchPattern, "./...")
 }
 
-// BuildAll builds for multiple platforms.
-func (h *Helper) BuildAll(name string) error {
+// BuildAll builds for multiple platforms into dist/ and, depending on
+// opts, archives each binary and writes checksums of the artifacts.
+// Progress goes to stderr so structured output on stdout stays clean.
+func (h *Helper) BuildAll(name string, opts BuildAllOptions) (*BuildAllResult, error) {
 	if name == "" {
 		name = "app"
 	}
 
-	// Create dist directory
-	if err := os.MkdirAll("dist", 0o755); err != nil {
-		return fmt.Errorf("failed to create dist directory: %w", err)
+	if err := checkDistDir(DistDir, h.dryRun); err != nil {
+		return nil, err
 	}
 
+	result := &BuildAllResult{Artifacts: []Artifact{}}
 	targets := DefaultBuildTargets(name)
 	for _, target := range targets {
-		fmt.Printf("Building %s/%s -> %s\n", target.OS, target.Arch, target.Output)
+		fmt.Fprintf(os.Stderr, "Building %s/%s -> %s\n", target.OS, target.Arch, target.Output)
 		if err := h.buildFor(target); err != nil {
-			return fmt.Errorf("build failed for %s/%s: %w", target.OS, target.Arch, err)
+			return nil, fmt.Errorf("build failed for %s/%s: %w", target.OS, target.Arch, err)
+		}
+		if h.dryRun {
+			continue
 		}
+
+		path := target.Output
+		if opts.Compress {
+			var err error
+			if path, err = ArchiveTarget(target); err != nil {
+				return nil, fmt.Errorf("archive failed for %s/%s: %w", target.OS, target.Arch, err)
+			}
+		}
+		artifact, err := newArtifact(path, target)
+		if err != nil {
+			return nil, err
+		}
+		result.Artifacts = append(result.Artifacts, artifact)
 	}
 
-	return nil
+	if h.dryRun {
+		if opts.Compress {
+			fmt.Printf("[dry-run] would archive each binary in %s\n", DistDir)
+		}
+		if opts.Checksums {
+			fmt.Printf("[dry-run] would write %s\n", filepath.Join(DistDir, ChecksumsFile))
+		}
+		return result, nil
+	}
+
+	if opts.Checksums {
+		path, err := WriteChecksums(DistDir, result.Artifacts)
+		if err != nil {
+			return nil, err
+		}
+		result.Checksums = path
+	}
+	return result, nil
 }
 
 // buildFor builds for a specific target.
@@ -223,7 +443,7 @@ func (h *Helper) buildFor(target BuildTarget) error {
 		"GOOS="+target.OS,
 		"GOARCH="+target.Arch,
 	)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = os.Stderr
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
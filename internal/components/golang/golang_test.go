@@ -0,0 +1,107 @@
+package golang
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testStream is trimmed `go test -json ./...` output for a module with a
+// failing test, a package that does not build, a package without tests and
+// a passing package with a skipped test.
+const testStream = `{"ImportPath":"example.com/m/broken [example.com/m/broken.test]","Action":"build-output","Output":"# example.com/m/broken [example.com/m/broken.test]\n"}
+{"ImportPath":"example.com/m/broken [example.com/m/broken.test]","Action":"build-output","Output":"broken/broken_test.go:5:28: undefined: undefined\n"}
+{"ImportPath":"example.com/m/broken [example.com/m/broken.test]","Action":"build-fail"}
+{"Action":"start","Package":"example.com/m/bad"}
+{"Action":"run","Package":"example.com/m/bad","Test":"TestGood"}
+{"Action":"output","Package":"example.com/m/bad","Test":"TestGood","Output":"=== RUN   TestGood\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/m/bad","Test":"TestGood","Output":"--- PASS: TestGood (0.00s)\n","OutputType":"frame"}
+{"Action":"pass","Package":"example.com/m/bad","Test":"TestGood","Elapsed":0}
+{"Action":"run","Package":"example.com/m/bad","Test":"TestBad"}
+{"Action":"output","Package":"example.com/m/bad","Test":"TestBad","Output":"=== RUN   TestBad\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/m/bad","Test":"TestBad","Output":"    bad_test.go:6: boom\n","OutputType":"error"}
+{"Action":"output","Package":"example.com/m/bad","Test":"TestBad","Output":"--- FAIL: TestBad (0.00s)\n","OutputType":"frame"}
+{"Action":"fail","Package":"example.com/m/bad","Test":"TestBad","Elapsed":0}
+{"Action":"output","Package":"example.com/m/bad","Output":"FAIL\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/m/bad","Output":"FAIL\texample.com/m/bad\t0.002s\n","OutputType":"frame"}
+{"Action":"fail","Package":"example.com/m/bad","Elapsed":0.003}
+{"Action":"start","Package":"example.com/m/broken"}
+{"Action":"output","Package":"example.com/m/broken","Output":"FAIL\texample.com/m/broken [build failed]\n","OutputType":"frame"}
+{"Action":"fail","Package":"example.com/m/broken","Elapsed":0,"FailedBuild":"example.com/m/broken [example.com/m/broken.test]"}
+{"Action":"start","Package":"example.com/m/empty"}
+{"Action":"output","Package":"example.com/m/empty","Output":"?   \texample.com/m/empty\t[no test files]\n"}
+{"Action":"skip","Package":"example.com/m/empty","Elapsed":0}
+{"Action":"start","Package":"example.com/m/ok"}
+{"Action":"run","Package":"example.com/m/ok","Test":"TestA"}
+{"Action":"output","Package":"example.com/m/ok","Test":"TestA","Output":"=== RUN   TestA\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/m/ok","Test":"TestA","Output":"--- PASS: TestA (0.00s)\n","OutputType":"frame"}
+{"Action":"pass","Package":"example.com/m/ok","Test":"TestA","Elapsed":0}
+{"Action":"run","Package":"example.com/m/ok","Test":"TestSkip"}
+{"Action":"output","Package":"example.com/m/ok","Test":"TestSkip","Output":"=== RUN   TestSkip\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/m/ok","Test":"TestSkip","Output":"    ok_test.go:6: needs network\n"}
+{"Action":"output","Package":"example.com/m/ok","Test":"TestSkip","Output":"--- SKIP: TestSkip (0.00s)\n","OutputType":"frame"}
+{"Action":"skip","Package":"example.com/m/ok","Test":"TestSkip","Elapsed":0}
+{"Action":"output","Package":"example.com/m/ok","Output":"PASS\n","OutputType":"frame"}
+{"Action":"output","Package":"example.com/m/ok","Output":"ok  \texample.com/m/ok\t0.001s\n"}
+{"Action":"pass","Package":"example.com/m/ok","Elapsed":0.001}
+`
+
+func TestParseTestEvents(t *testing.T) {
+	summary, err := ParseTestEvents(strings.NewReader(testStream))
+	if err != nil {
+		t.Fatalf("ParseTestEvents: %v", err)
+	}
+
+	wantPackages := []PackageSummary{
+		{Package: "example.com/m/bad", Status: "FAIL", Passed: 1, Failed: 1, Elapsed: 0.003},
+		{Package: "example.com/m/broken", Status: "FAIL"},
+		{Package: "example.com/m/empty", Status: "no tests"},
+		{Package: "example.com/m/ok", Status: "ok", Passed: 1, Skipped: 1, Elapsed: 0.001},
+	}
+	if !reflect.DeepEqual(summary.Packages, wantPackages) {
+		t.Errorf("packages =\n%+v\nwant\n%+v", summary.Packages, wantPackages)
+	}
+
+	wantFailures := []TestFailure{
+		{
+			Package: "example.com/m/bad",
+			Test:    "TestBad",
+			Output:  "=== RUN   TestBad\n    bad_test.go:6: boom\n--- FAIL: TestBad (0.00s)\n",
+		},
+		{
+			Package: "example.com/m/broken",
+			Output: "# example.com/m/broken [example.com/m/broken.test]\n" +
+				"broken/broken_test.go:5:28: undefined: undefined\n" +
+				"FAIL\texample.com/m/broken [build failed]\n",
+		},
+	}
+	if !reflect.DeepEqual(summary.Failures, wantFailures) {
+		t.Errorf("failures =\n%+v\nwant\n%+v", summary.Failures, wantFailures)
+	}
+
+	if summary.Passed != 2 || summary.Failed != 1 || summary.Skipped != 1 || summary.Success {
+		t.Errorf("totals = %d passed, %d failed, %d skipped, success %v",
+			summary.Passed, summary.Failed, summary.Skipped, summary.Success)
+	}
+}
+
+func TestParseTestEventsPassing(t *testing.T) {
+	// Non-JSON lines such as go command warnings are ignored.
+	stream := "go: downloading example.com/dep v1.0.0\n" +
+		`{"Action":"run","Package":"example.com/m/ok","Test":"TestA"}` + "\n" +
+		`{"Action":"pass","Package":"example.com/m/ok","Test":"TestA","Elapsed":0}` + "\n" +
+		`{"Action":"pass","Package":"example.com/m/ok","Elapsed":0.01}` + "\n"
+
+	summary, err := ParseTestEvents(strings.NewReader(stream))
+	if err != nil {
+		t.Fatalf("ParseTestEvents: %v", err)
+	}
+	if !summary.Success || summary.Passed != 1 || len(summary.Failures) != 0 || len(summary.Packages) != 1 {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	empty, err := ParseTestEvents(strings.NewReader(""))
+	if err != nil || !empty.Success || len(empty.Packages) != 0 || empty.Failures == nil {
+		t.Errorf("empty stream = %+v, %v", empty, err)
+	}
+}
@@ -0,0 +1,228 @@
+package golang
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
+)
+
+// DistDir is the directory build-all writes its artifacts to.
+const DistDir = "dist"
+
+// ChecksumsFile is the name of the checksums file written next to the
+// artifacts, in sha256sum format.
+const ChecksumsFile = "SHA256SUMS"
+
+// BuildAllOptions selects the release steps run after building.
+type BuildAllOptions struct {
+	Compress  bool // archive each binary: zip for windows, tar.gz otherwise
+	Checksums bool // write SHA256SUMS for the produced artifacts
+}
+
+// Artifact is a file produced by BuildAll.
+type Artifact struct {
+	Name   string `json:"name" yaml:"name"`
+	Path   string `json:"path" yaml:"path"`
+	OS     string `json:"os" yaml:"os"`
+	Arch   string `json:"arch" yaml:"arch"`
+	Size   int64  `json:"size" yaml:"size"`
+	SHA256 string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+}
+
+// BuildAllResult lists the artifacts of a BuildAll run: the archives when
+// compressing, otherwise the binaries.
+type BuildAllResult struct {
+	Artifacts []Artifact `json:"artifacts" yaml:"artifacts"`
+	Checksums string     `json:"checksums,omitempty" yaml:"checksums,omitempty"`
+}
+
+// checkDistDir ensures dir is a writable directory, creating it unless
+// dryRun is set.
+func checkDistDir(dir string, dryRun bool) error {
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err) && dryRun:
+		return nil
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s directory: %w", dir, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to check %s directory: %w", dir, err)
+	case !info.IsDir():
+		return fmt.Errorf("%s exists and is not a directory", dir)
+	}
+
+	if err := ioutils.CheckWritableDir(dir); err != nil {
+		return fmt.Errorf("%s directory is not writable: %w", dir, err)
+	}
+	return nil
+}
+
+// archiveTime is the modification time stored in archives, so the same
+// binary always produces the same archive. SOURCE_DATE_EPOCH overrides it.
+func archiveTime() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if secs, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC()
+		}
+	}
+	// The earliest time a zip header can represent.
+	return time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ArchiveTarget packs the binary of target into a zip (windows) or tar.gz
+// next to it. The archive is named after the binary without its .exe
+// suffix and holds the binary at its root.
+func ArchiveTarget(target BuildTarget) (string, error) {
+	base := strings.TrimSuffix(filepath.Base(target.Output), ".exe")
+	dir := filepath.Dir(target.Output)
+
+	if target.OS == "windows" {
+		path := filepath.Join(dir, base+".zip")
+		return path, writeZip(path, target.Output)
+	}
+	path := filepath.Join(dir, base+".tar.gz")
+	return path, writeTarGz(path, target.Output)
+}
+
+// writeTarGz writes a gzipped tar holding the file src.
+func writeTarGz(path, src string) (err error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	hdr := &tar.Header{
+		Name:     filepath.Base(src),
+		Mode:     0o755,
+		Size:     int64(len(data)),
+		ModTime:  archiveTime(),
+		Typeflag: tar.TypeReg,
+		Format:   tar.FormatUSTAR,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeZip writes a zip holding the file src.
+func writeZip(path, src string) (err error) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	zw := zip.NewWriter(f)
+	hdr := &zip.FileHeader{
+		Name:     filepath.Base(src),
+		Method:   zip.Deflate,
+		Modified: archiveTime(),
+	}
+	hdr.SetMode(0o755)
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// newArtifact describes the file at path built for target.
+func newArtifact(path string, target BuildTarget) (Artifact, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return Artifact{
+		Name: filepath.Base(path),
+		Path: path,
+		OS:   target.OS,
+		Arch: target.Arch,
+		Size: info.Size(),
+	}, nil
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// WriteChecksums hashes artifacts and writes them, sorted by name, to a
+// SHA256SUMS file in dir that `sha256sum -c` can verify.
+func WriteChecksums(dir string, artifacts []Artifact) (string, error) {
+	lines := make([]string, 0, len(artifacts))
+	for i := range artifacts {
+		sum, err := fileSHA256(artifacts[i].Path)
+		if err != nil {
+			return "", err
+		}
+		artifacts[i].SHA256 = sum
+		lines = append(lines, sum+"  "+artifacts[i].Name)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i][66:] < lines[j][66:] })
+
+	path := filepath.Join(dir, ChecksumsFile)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
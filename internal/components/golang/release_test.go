@@ -0,0 +1,151 @@
+package golang
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveTarget(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		target  BuildTarget
+		archive string
+	}{
+		{BuildTarget{OS: "linux", Arch: "amd64", Output: filepath.Join(dir, "app-linux-amd64")}, "app-linux-amd64.tar.gz"},
+		{BuildTarget{OS: "windows", Arch: "amd64", Output: filepath.Join(dir, "app-windows-amd64.exe")}, "app-windows-amd64.zip"},
+	} {
+		if err := os.WriteFile(tt.target.Output, []byte("binary "+tt.target.OS), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		path, err := ArchiveTarget(tt.target)
+		if err != nil {
+			t.Fatalf("ArchiveTarget(%s): %v", tt.target.OS, err)
+		}
+		if filepath.Base(path) != tt.archive {
+			t.Errorf("archive = %s, want %s", filepath.Base(path), tt.archive)
+		}
+		first, _ := os.ReadFile(path)
+
+		// Touching the binary must not change the archive.
+		if err := os.Chtimes(tt.target.Output, time.Now(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ArchiveTarget(tt.target); err != nil {
+			t.Fatal(err)
+		}
+		second, _ := os.ReadFile(path)
+		if !bytes.Equal(first, second) {
+			t.Errorf("%s is not deterministic", tt.archive)
+		}
+
+		name, content := readArchive(t, path)
+		if name != filepath.Base(tt.target.Output) || content != "binary "+tt.target.OS {
+			t.Errorf("%s holds %s = %q", tt.archive, name, content)
+		}
+	}
+}
+
+// readArchive returns the name and content of the single file in a zip or
+// tar.gz archive.
+func readArchive(t *testing.T, path string) (string, string) {
+	t.Helper()
+	if strings.HasSuffix(path, ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer zr.Close()
+		rc, err := zr.File[0].Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		data, _ := io.ReadAll(rc)
+		return zr.File[0].Name, string(data)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(tr)
+	return hdr.Name, string(data)
+}
+
+func TestWriteChecksums(t *testing.T) {
+	dir := t.TempDir()
+	var artifacts []Artifact
+	for _, name := range []string{"b.tar.gz", "a.zip"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		artifacts = append(artifacts, Artifact{Name: name, Path: path})
+	}
+
+	path, err := WriteChecksums(dir, artifacts)
+	if err != nil {
+		t.Fatalf("WriteChecksums: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const sum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	want := sum + "  a.zip\n" + sum + "  b.tar.gz\n"
+	if string(data) != want {
+		t.Errorf("SHA256SUMS =\n%s\nwant\n%s", data, want)
+	}
+	if artifacts[0].SHA256 != sum {
+		t.Errorf("artifact SHA256 = %q", artifacts[0].SHA256)
+	}
+}
+
+func TestCheckDistDir(t *testing.T) {
+	dir := t.TempDir()
+
+	dist := filepath.Join(dir, "dist")
+	if err := checkDistDir(dist, true); err != nil {
+		t.Fatalf("checkDistDir (dry run): %v", err)
+	}
+	if _, err := os.Stat(dist); !os.IsNotExist(err) {
+		t.Fatalf("dry run created dist: %v", err)
+	}
+	if err := checkDistDir(dist, false); err != nil {
+		t.Fatalf("checkDistDir (missing): %v", err)
+	}
+	if info, err := os.Stat(dist); err != nil || !info.IsDir() {
+		t.Fatalf("dist was not created: %v", err)
+	}
+	if err := checkDistDir(dist, false); err != nil {
+		t.Errorf("checkDistDir (existing): %v", err)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkDistDir(file, false); err == nil {
+		t.Error("expected an error for a file")
+	}
+}
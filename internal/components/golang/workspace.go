@@ -0,0 +1,191 @@
+package golang
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace describes a go.work file and the modules it uses.
+type Workspace struct {
+	Path      string            `json:"path" yaml:"path"`
+	GoVersion string            `json:"go_version,omitempty" yaml:"go_version,omitempty"`
+	Modules   []WorkspaceModule `json:"modules" yaml:"modules"`
+}
+
+// WorkspaceModule is a module referenced by a use directive in go.work.
+type WorkspaceModule struct {
+	Dir    string `json:"dir" yaml:"dir"`
+	Module string `json:"module,omitempty" yaml:"module,omitempty"`
+}
+
+// InitWorkspace creates a go.work in dir (or reuses an existing one) and
+// adds each of modules to it. Every module directory must contain a go.mod.
+func (h *Helper) InitWorkspace(dir string, modules []string) (*Workspace, error) {
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("at least one module directory is required")
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	uses := make([]string, 0, len(modules))
+	for _, m := range modules {
+		modDir := m
+		if !filepath.IsAbs(modDir) {
+			modDir = filepath.Join(root, m)
+		}
+		if _, err := ModulePath(modDir); err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(root, modDir)
+		if err != nil {
+			return nil, err
+		}
+		uses = append(uses, "./"+filepath.ToSlash(rel))
+	}
+
+	workFile := filepath.Join(root, "go.work")
+	if _, err := os.Stat(workFile); os.IsNotExist(err) {
+		if err := h.runInDir(root, "go", "work", "init"); err != nil {
+			return nil, fmt.Errorf("go work init failed: %w", err)
+		}
+	}
+
+	args := append([]string{"work", "use"}, uses...)
+	if err := h.runInDir(root, "go", args...); err != nil {
+		return nil, fmt.Errorf("go work use failed: %w", err)
+	}
+
+	if h.dryRun {
+		ws := &Workspace{Path: workFile}
+		for _, use := range uses {
+			mod, _ := ModulePath(filepath.Join(root, use))
+			ws.Modules = append(ws.Modules, WorkspaceModule{Dir: use, Module: mod})
+		}
+		return ws, nil
+	}
+
+	return h.GetWorkspace(root)
+}
+
+// InitWorkspaceProject creates a new directory holding a go.work and one
+// module per entry in modules, named <name>/<module>.
+func (h *Helper) InitWorkspaceProject(name string, modules []string) (*Workspace, error) {
+	if name == "" {
+		return nil, fmt.Errorf("workspace name is required")
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("at least one module is required")
+	}
+
+	root, err := filepath.Abs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range modules {
+		modDir := filepath.Join(root, m)
+		if h.dryRun {
+			fmt.Printf("[dry-run] would create module %s in %s\n", name+"/"+m, modDir)
+			continue
+		}
+		if err := os.MkdirAll(modDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := h.runInDir(modDir, "go", "mod", "init", name+"/"+m); err != nil {
+			return nil, fmt.Errorf("go mod init failed for %s: %w", m, err)
+		}
+	}
+
+	if h.dryRun {
+		ws := &Workspace{Path: filepath.Join(root, "go.work")}
+		for _, m := range modules {
+			ws.Modules = append(ws.Modules, WorkspaceModule{Dir: "./" + m, Module: name + "/" + m})
+		}
+		fmt.Printf("[dry-run] would run in %s: go work init %s\n", root, strings.Join(ws.dirs(), " "))
+		return ws, nil
+	}
+
+	return h.InitWorkspace(root, modules)
+}
+
+// SyncWorkspace runs go work sync for the workspace in dir.
+func (h *Helper) SyncWorkspace(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.work")); os.IsNotExist(err) {
+		return fmt.Errorf("no go.work found in %s (run: acorn go work init <dirs...>)", dir)
+	}
+	return h.runInDir(dir, "go", "work", "sync")
+}
+
+// GetWorkspace reads the go.work in dir.
+func (h *Helper) GetWorkspace(dir string) (*Workspace, error) {
+	workFile := filepath.Join(dir, "go.work")
+
+	cmd := exec.Command("go", "work", "edit", "-json", workFile)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", workFile, err)
+	}
+
+	var raw struct {
+		Go  string
+		Use []struct {
+			DiskPath   string
+			ModulePath string
+		}
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", workFile, err)
+	}
+
+	ws := &Workspace{Path: workFile, GoVersion: raw.Go, Modules: []WorkspaceModule{}}
+	for _, use := range raw.Use {
+		mod := use.ModulePath
+		if mod == "" {
+			mod, _ = ModulePath(filepath.Join(dir, use.DiskPath))
+		}
+		ws.Modules = append(ws.Modules, WorkspaceModule{Dir: use.DiskPath, Module: mod})
+	}
+	return ws, nil
+}
+
+// ModulePath returns the module path declared in dir/go.mod.
+func ModulePath(dir string) (string, error) {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s is not a Go module (no go.mod)", dir)
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module"); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("%s/go.mod has no module directive", dir)
+}
+
+// dirs returns the use directories of the workspace.
+func (w *Workspace) dirs() []string {
+	dirs := make([]string, len(w.Modules))
+	for i, m := range w.Modules {
+		dirs[i] = m.Dir
+	}
+	return dirs
+}
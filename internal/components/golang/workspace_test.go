@@ -0,0 +1,46 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModulePath(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ModulePath(dir); err == nil {
+		t.Error("directory without go.mod should fail")
+	}
+
+	gomod := "// comment\nmodule \"example.com/api\"\n\ngo 1.22\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ModulePath(dir)
+	if err != nil || got != "example.com/api" {
+		t.Errorf("ModulePath = %q, %v", got, err)
+	}
+}
+
+func TestInitWorkspaceRequiresModules(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false, true)
+	if _, err := h.InitWorkspace(root, []string{"api"}); err == nil {
+		t.Error("directory without go.mod should be rejected")
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "api", "go.mod"), []byte("module example.com/api\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ws, err := h.InitWorkspace(root, []string{"api"})
+	if err != nil {
+		t.Fatalf("InitWorkspace: %v", err)
+	}
+	if len(ws.Modules) != 1 || ws.Modules[0].Dir != "./api" || ws.Modules[0].Module != "example.com/api" {
+		t.Errorf("modules = %+v", ws.Modules)
+	}
+}
@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Jsonpath fields for the resources summarized by GetPods, GetDeployments
+// and GetServices, in the order their parsers expect.
+const (
+	podFields        = "{.metadata.name},{.status.containerStatuses[0].ready}/{len .status.containerStatuses},{.status.phase},{.status.containerStatuses[0].restartCount},{.metadata.creationTimestamp}"
+	deploymentFields = "{.metadata.name},{.status.readyReplicas}/{.spec.replicas},{.status.updatedReplicas},{.status.availableReplicas},{.metadata.creationTimestamp}"
+	serviceFields    = "{.metadata.name},{.spec.type},{.spec.clusterIP},{.spec.externalIPs[0]},{.spec.ports[*].port},{.metadata.creationTimestamp}"
+)
+
+// ResourceKinds are the kinds gathered by GetAll, in display order.
+var ResourceKinds = []string{"pods", "services", "deployments"}
+
+// kindAliases maps singular and short kind names to ResourceKinds entries.
+var kindAliases = map[string]string{
+	"pod":        "pods",
+	"po":         "pods",
+	"service":    "services",
+	"svc":        "services",
+	"deployment": "deployments",
+	"deploy":     "deployments",
+}
+
+// AllOptions selects what GetAll gathers.
+type AllOptions struct {
+	Namespace     string   // empty means the current namespace
+	AllNamespaces bool     // overrides Namespace
+	Kinds         []string // empty means every kind in ResourceKinds
+}
+
+// AllResources is the combined result of GetAll. Kinds that were not
+// requested are left empty.
+type AllResources struct {
+	Namespace     string       `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	AllNamespaces bool         `json:"all_namespaces" yaml:"all_namespaces"`
+	Kinds         []string     `json:"kinds" yaml:"kinds"`
+	Pods          []Pod        `json:"pods" yaml:"pods"`
+	Services      []Service    `json:"services" yaml:"services"`
+	Deployments   []Deployment `json:"deployments" yaml:"deployments"`
+}
+
+// NormalizeKinds resolves aliases, drops duplicates and orders kinds as in
+// ResourceKinds. No kinds selects all of them.
+func NormalizeKinds(kinds []string) ([]string, error) {
+	if len(kinds) == 0 {
+		return ResourceKinds, nil
+	}
+
+	selected := make(map[string]bool)
+	for _, kind := range kinds {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if alias, ok := kindAliases[kind]; ok {
+			kind = alias
+		}
+		valid := false
+		for _, k := range ResourceKinds {
+			if k == kind {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown kind %q (valid: %s)", kind, strings.Join(ResourceKinds, ", "))
+		}
+		selected[kind] = true
+	}
+
+	var normalized []string
+	for _, k := range ResourceKinds {
+		if selected[k] {
+			normalized = append(normalized, k)
+		}
+	}
+	return normalized, nil
+}
+
+// GetAll gathers pods, services and deployments in one namespace or across
+// all of them. The kinds are fetched in parallel.
+func (h *Helper) GetAll(opts AllOptions) (*AllResources, error) {
+	kinds, err := NormalizeKinds(opts.Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AllResources{
+		AllNamespaces: opts.AllNamespaces,
+		Kinds:         kinds,
+		Pods:          []Pod{},
+		Services:      []Service{},
+		Deployments:   []Deployment{},
+	}
+	namespace := ""
+	if !opts.AllNamespaces {
+		namespace = opts.Namespace
+		if namespace == "" {
+			namespace = h.CurrentNamespace()
+		}
+		result.Namespace = namespace
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	for _, kind := range kinds {
+		wg.Add(1)
+		go func(kind string) {
+			defer wg.Done()
+			switch kind {
+			case "pods":
+				pods, err := h.listPods(namespace, opts.AllNamespaces)
+				if err != nil {
+					fail(err)
+				} else if pods != nil {
+					result.Pods = pods
+				}
+			case "services":
+				services, err := h.listServices(namespace, opts.AllNamespaces)
+				if err != nil {
+					fail(err)
+				} else if services != nil {
+					result.Services = services
+				}
+			case "deployments":
+				deployments, err := h.listDeployments(namespace, opts.AllNamespaces)
+				if err != nil {
+					fail(err)
+				} else if deployments != nil {
+					result.Deployments = deployments
+				}
+			}
+		}(kind)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// listPods returns the pods in namespace (the current one when empty), or
+// in every namespace when allNamespaces is set.
+func (h *Helper) listPods(namespace string, allNamespaces bool) ([]Pod, error) {
+	args := append([]string{"get", "pods", "-o", "jsonpath={range .items[*]}{.metadata.namespace}," + podFields + "{\"\\n\"}{end}"},
+		scopeArgs(namespace, allNamespaces)...)
+
+	cmd := exec.Command("kubectl", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	now := time.Now()
+	var pods []Pod
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		ns, rest, _ := strings.Cut(line, ",")
+		pod := parsePod(rest, now)
+		pod.Namespace = namespace
+		if allNamespaces {
+			pod.Namespace = ns
+		}
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// scopeArgs returns the kubectl namespace arguments for a list call.
+func scopeArgs(namespace string, allNamespaces bool) []string {
+	if allNamespaces {
+		return []string{"--all-namespaces"}
+	}
+	if namespace != "" {
+		return []string{"-n", namespace}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNormalizeKinds(t *testing.T) {
+	got, err := NormalizeKinds(nil)
+	if err != nil || !reflect.DeepEqual(got, ResourceKinds) {
+		t.Errorf("no kinds: got %v, %v", got, err)
+	}
+
+	got, err = NormalizeKinds([]string{"deploy", "po", "pods", " SVC "})
+	if err != nil {
+		t.Fatalf("NormalizeKinds: %v", err)
+	}
+	if want := []string{"pods", "services", "deployments"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := NormalizeKinds([]string{"pods", "nodes"}); err == nil {
+		t.Error("unknown kind should fail")
+	}
+}
+
+func TestParseResourceLines(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	d := parseDeployment("web,2/3,3,2,2025-01-01T12:00:00Z", now)
+	want := Deployment{Name: "web", Ready: "2/3", UpToDate: "3", Available: "2", Age: "2025-01-01T12:00:00Z", AgeHuman: "24h"}
+	if d != want {
+		t.Errorf("deployment = %+v, want %+v", d, want)
+	}
+
+	s := parseService("web,ClusterIP,10.0.0.1,,80 443,2025-01-02T11:00:00Z", now)
+	if s.Name != "web" || s.ExternalIP != "" || s.Ports != "80 443" || s.Age != "2025-01-02T11:00:00Z" || s.AgeHuman != "1h" {
+		t.Errorf("service = %+v", s)
+	}
+}
@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// requestTimeout bounds each live cluster lookup so an unreachable cluster
+// does not stall the command.
+const requestTimeout = "--request-timeout=5s"
+
+// ClusterInfo extends ContextInfo with details from the live cluster. When
+// the cluster cannot be reached, only the kubeconfig fields are set and
+// Reachable is false.
+type ClusterInfo struct {
+	ContextInfo `yaml:",inline"`
+
+	User            string `json:"user,omitempty" yaml:"user,omitempty"`
+	Reachable       bool   `json:"reachable" yaml:"reachable"`
+	Error           string `json:"error,omitempty" yaml:"error,omitempty"`
+	ServerVersion   string `json:"server_version,omitempty" yaml:"server_version,omitempty"`
+	Nodes           *int   `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	AuthenticatedAs string `json:"authenticated_as,omitempty" yaml:"authenticated_as,omitempty"`
+}
+
+// versionResult memoizes the `kubectl version` lookup.
+type versionResult struct {
+	server string
+	err    error
+}
+
+// GetClusterInfo returns the current context info along with the cluster's
+// Kubernetes version, node count and the user the API server sees.
+func (h *Helper) GetClusterInfo() (*ClusterInfo, error) {
+	ctxInfo, err := h.GetContextInfo()
+	if err != nil {
+		return nil, err
+	}
+	info := &ClusterInfo{ContextInfo: *ctxInfo}
+
+	if out, err := exec.Command("kubectl", "config", "view", "--minify", "--output", "jsonpath={.contexts[0].context.user}").Output(); err == nil {
+		info.User = strings.TrimSpace(string(out))
+	}
+
+	version, err := h.ServerVersion()
+	if err != nil {
+		info.Error = err.Error()
+		return info, nil
+	}
+	info.Reachable = true
+	info.ServerVersion = version
+
+	if out, err := exec.Command("kubectl", "get", "nodes", "-o", "name", requestTimeout).Output(); err == nil {
+		n := len(strings.Fields(string(out)))
+		info.Nodes = &n
+	}
+
+	// auth whoami needs Kubernetes 1.28+; older clusters leave it empty
+	if out, err := exec.Command("kubectl", "auth", "whoami", "-o", "jsonpath={.status.userInfo.username}", requestTimeout).Output(); err == nil {
+		info.AuthenticatedAs = strings.TrimSpace(string(out))
+	}
+
+	return info, nil
+}
+
+// ServerVersion returns the API server's git version, e.g. v1.30.2. The
+// lookup is made once per Helper.
+func (h *Helper) ServerVersion() (string, error) {
+	if h.version == nil {
+		h.version = &versionResult{}
+		h.version.server, h.version.err = fetchServerVersion()
+	}
+	return h.version.server, h.version.err
+}
+
+// fetchServerVersion asks kubectl for the server version. kubectl still
+// prints the client version when the server is unreachable, so a missing
+// serverVersion is treated as unreachable.
+func fetchServerVersion() (string, error) {
+	cmd := exec.Command("kubectl", "version", "-o", "json", requestTimeout)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	var version struct {
+		ServerVersion *struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"serverVersion"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &version); err == nil && version.ServerVersion != nil {
+		return version.ServerVersion.GitVersion, nil
+	}
+
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return "", fmt.Errorf("cluster unreachable: %s", strings.Split(msg, "\n")[0])
+	}
+	if runErr != nil {
+		return "", fmt.Errorf("cluster unreachable: %w", runErr)
+	}
+	return "", fmt.Errorf("cluster unreachable: no server version reported")
+}
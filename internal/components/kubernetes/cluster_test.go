@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeKubectl puts a kubectl script on PATH that answers the cluster info
+// lookups and logs its invocations to the returned file.
+func fakeKubectl(t *testing.T, reachable bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+
+	version := `printf '{"clientVersion":{"gitVersion":"v1.31.0"},"serverVersion":{"gitVersion":"v1.30.2"}}'`
+	if !reachable {
+		version = `printf '{"clientVersion":{"gitVersion":"v1.31.0"}}'; echo "The connection to the server was refused" >&2; exit 1`
+	}
+	script := `#!/bin/sh
+echo "$*" >> ` + log + `
+case "$*" in
+  "config current-context") echo dev;;
+  "version -o json"*) ` + version + `;;
+  "get nodes -o name"*) printf 'node/a\nnode/b\n';;
+  "auth whoami"*) printf 'admin';;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestGetClusterInfo(t *testing.T) {
+	log := fakeKubectl(t, true)
+	h := NewHelper(false, false)
+
+	info, err := h.GetClusterInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Reachable || info.Context != "dev" || info.ServerVersion != "v1.30.2" ||
+		info.Nodes == nil || *info.Nodes != 2 || info.AuthenticatedAs != "admin" {
+		t.Errorf("info = %+v", info)
+	}
+
+	if _, err := h.ServerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(log)
+	if n := strings.Count(string(data), "version -o json"); n != 1 {
+		t.Errorf("kubectl version ran %d times, want 1", n)
+	}
+}
+
+func TestGetClusterInfoUnreachable(t *testing.T) {
+	fakeKubectl(t, false)
+
+	info, err := NewHelper(false, false).GetClusterInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Reachable || info.Context != "dev" || info.Nodes != nil || !strings.Contains(info.Error, "refused") {
+		t.Errorf("info = %+v", info)
+	}
+}
@@ -0,0 +1,346 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Diff actions for a resource.
+const (
+	DiffCreated = "created"
+	DiffChanged = "changed"
+	DiffDeleted = "deleted"
+)
+
+// ResourceDiff summarizes what applying a manifest would do to a resource.
+type ResourceDiff struct {
+	Kind          string   `json:"kind" yaml:"kind"`
+	Name          string   `json:"name" yaml:"name"`
+	Namespace     string   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Action        string   `json:"action" yaml:"action"`
+	ChangedFields []string `json:"changed_fields,omitempty" yaml:"changed_fields,omitempty"`
+}
+
+// DiffOptions configures a manifest diff.
+type DiffOptions struct {
+	Files     []string // manifests, directories or URLs (passed to -f)
+	Manifest  []byte   // manifest content, passed on stdin as -f -
+	Namespace string
+}
+
+// fullContextDiff makes kubectl diff print whole files, so every changed
+// line can be placed at its YAML path. kubectl only passes through external
+// diff arguments made of letters, digits, dashes and equals signs.
+const fullContextDiff = "diff -N --unified=1000000"
+
+// ignoredDiffFields change on every server-side dry run and are not part
+// of what the manifest changes.
+var ignoredDiffFields = map[string]bool{
+	"metadata.generation": true,
+}
+
+var (
+	// diffHeaderRe matches the "diff" line kubectl prints per object.
+	diffHeaderRe = regexp.MustCompile(`^diff .* (\S+)/(\S+) (\S+)/(\S+)$`)
+
+	// diffHunkRe matches a hunk header's old and new line counts.
+	diffHunkRe = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+	// apiVersionRe matches the version segment of a kubectl diff file name.
+	apiVersionRe = regexp.MustCompile(`^v\d+((alpha|beta)\d+)?$`)
+
+	// yamlKeyRe matches the key of a YAML mapping line.
+	yamlKeyRe = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s#'"][^:]*?):(\s|$)`)
+)
+
+// diffArgs builds the kubectl diff arguments.
+func diffArgs(opts DiffOptions) []string {
+	args := []string{"diff"}
+	for _, f := range opts.Files {
+		args = append(args, "-f", f)
+	}
+	if len(opts.Manifest) > 0 {
+		args = append(args, "-f", "-")
+	}
+	if opts.Namespace != "" {
+		args = append(args, "-n", opts.Namespace)
+	}
+	return args
+}
+
+// runKubectlDiff runs kubectl diff with manifest on stdin and returns its
+// output. kubectl exits 1 when there are differences, which is not an error.
+func runKubectlDiff(args []string, env []string, manifest []byte) ([]byte, bool, error) {
+	cmd := exec.Command("kubectl", args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(manifest)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err == nil {
+		return out, false, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(out) > 0 {
+		return out, true, nil
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return nil, false, fmt.Errorf("kubectl diff failed: %s", msg)
+	}
+	return nil, false, fmt.Errorf("kubectl diff failed: %w", err)
+}
+
+// Diff compares manifests with the live cluster and summarizes which
+// resources would be created, changed or deleted.
+func (h *Helper) Diff(opts DiffOptions) ([]ResourceDiff, error) {
+	args := diffArgs(opts)
+	if h.verbose {
+		fmt.Fprintf(os.Stderr, "Running: kubectl %s\n", strings.Join(args, " "))
+	}
+
+	out, _, err := runKubectlDiff(args, []string{"KUBECTL_EXTERNAL_DIFF=" + fullContextDiff}, opts.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiff(out)
+}
+
+// RawDiff returns kubectl's own unified diff, and whether it found
+// differences.
+func (h *Helper) RawDiff(opts DiffOptions) (string, bool, error) {
+	args := diffArgs(opts)
+	if h.verbose {
+		fmt.Fprintf(os.Stderr, "Running: kubectl %s\n", strings.Join(args, " "))
+	}
+
+	out, changed, err := runKubectlDiff(args, nil, opts.Manifest)
+	if err != nil {
+		return "", false, err
+	}
+	return string(out), changed, nil
+}
+
+// ParseDiff summarizes kubectl diff output. Each object's diff compares
+// files named <group>.<version>.<kind>.<namespace>.<name>; an object with
+// no lines on one side is being created or deleted.
+func ParseDiff(data []byte) ([]ResourceDiff, error) {
+	diffs := []ResourceDiff{}
+
+	var cur *ResourceDiff
+	var oldLines, newLines int
+	var oldPath, newPath yamlPath
+	seen := map[string]bool{}
+
+	finish := func() {
+		if cur == nil {
+			return
+		}
+		switch {
+		case oldLines == 0 && newLines > 0:
+			cur.Action = DiffCreated
+			cur.ChangedFields = nil
+		case newLines == 0 && oldLines > 0:
+			cur.Action = DiffDeleted
+			cur.ChangedFields = nil
+		default:
+			cur.Action = DiffChanged
+			cur.ChangedFields = collapseFields(cur.ChangedFields)
+		}
+		diffs = append(diffs, *cur)
+		cur = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if m := diffHeaderRe.FindStringSubmatch(line); m != nil {
+			finish()
+			kind, namespace, name, ok := parseDiffObjectName(m[4])
+			if !ok {
+				return nil, fmt.Errorf("unrecognized object in kubectl diff: %s", m[4])
+			}
+			cur = &ResourceDiff{Kind: kind, Name: name, Namespace: namespace}
+			oldLines, newLines = 0, 0
+			oldPath, newPath = yamlPath{}, yamlPath{}
+			seen = map[string]bool{}
+			continue
+		}
+		if cur == nil || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ") {
+			continue
+		}
+		if m := diffHunkRe.FindStringSubmatch(line); m != nil {
+			oldLines += hunkCount(m[1])
+			newLines += hunkCount(m[2])
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		var field string
+		switch line[0] {
+		case ' ':
+			oldPath.feed(line[1:])
+			newPath.feed(line[1:])
+			continue
+		case '-':
+			field = oldPath.feed(line[1:])
+		case '+':
+			field = newPath.feed(line[1:])
+		default:
+			continue
+		}
+		if field != "" && !ignoredDiffFields[field] && !seen[field] {
+			seen[field] = true
+			cur.ChangedFields = append(cur.ChangedFields, field)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read kubectl diff: %w", err)
+	}
+	finish()
+
+	return diffs, nil
+}
+
+// hunkCount parses a hunk line count, which defaults to 1 when omitted.
+func hunkCount(s string) int {
+	if s == "" {
+		return 1
+	}
+	var n int
+	fmt.Sscanf(s, "%d", &n)
+	return n
+}
+
+// parseDiffObjectName splits a kubectl diff file name. The group may
+// contain dots and is absent for core resources, and cluster-scoped
+// objects have an empty namespace; names may contain dots too.
+func parseDiffObjectName(file string) (kind, namespace, name string, ok bool) {
+	parts := strings.Split(file, ".")
+	for i := 0; i+3 < len(parts); i++ {
+		k := parts[i+1]
+		if apiVersionRe.MatchString(parts[i]) && k != "" && k[0] >= 'A' && k[0] <= 'Z' {
+			return k, parts[i+2], strings.Join(parts[i+3:], "."), true
+		}
+	}
+	return "", "", "", false
+}
+
+// collapseFields drops fields whose parent is also listed, so a new or
+// removed block is reported once rather than field by field.
+func collapseFields(fields []string) []string {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+
+	var out []string
+	for _, f := range fields {
+		covered := false
+		for parent := parentField(f); parent != ""; parent = parentField(parent) {
+			if set[parent] {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// parentField returns the enclosing field of a path, or "".
+func parentField(field string) string {
+	if strings.HasSuffix(field, "[]") {
+		return strings.TrimSuffix(field, "[]")
+	}
+	if i := strings.LastIndex(field, "."); i > 0 {
+		return field[:i]
+	}
+	return ""
+}
+
+// yamlPath tracks the key path of lines in a YAML document as kubectl
+// prints it, where list items sit at their parent key's indentation.
+type yamlPath struct {
+	stack []yamlPathEntry
+}
+
+type yamlPathEntry struct {
+	indent int
+	key    string // "" for a list item
+}
+
+// feed consumes a line and returns the path of the field it holds.
+func (p *yamlPath) feed(line string) string {
+	text := strings.TrimLeft(line, " ")
+	if text == "" || strings.HasPrefix(text, "#") {
+		return p.String()
+	}
+	indent := len(line) - len(text)
+
+	// Lines deeper than the innermost key without being a key themselves
+	// continue a multi-line value.
+	if n := len(p.stack); n > 0 && indent > p.stack[n-1].indent && !yamlKeyRe.MatchString(text) && !isListItem(text) {
+		return p.String()
+	}
+
+	for isListItem(text) {
+		for len(p.stack) > 0 {
+			top := p.stack[len(p.stack)-1]
+			if top.indent > indent || (top.indent == indent && top.key == "") {
+				p.stack = p.stack[:len(p.stack)-1]
+				continue
+			}
+			break
+		}
+		p.stack = append(p.stack, yamlPathEntry{indent: indent})
+		text = strings.TrimLeft(strings.TrimPrefix(text, "-"), " ")
+		indent = len(line) - len(text)
+		if text == "" || !yamlKeyRe.MatchString(text) && !isListItem(text) {
+			return p.String()
+		}
+	}
+
+	m := yamlKeyRe.FindStringSubmatch(text)
+	if m == nil {
+		return p.String()
+	}
+	for len(p.stack) > 0 && p.stack[len(p.stack)-1].indent >= indent {
+		p.stack = p.stack[:len(p.stack)-1]
+	}
+	p.stack = append(p.stack, yamlPathEntry{indent: indent, key: strings.Trim(m[1], `"'`)})
+	return p.String()
+}
+
+// isListItem reports whether text starts a YAML sequence item.
+func isListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// String renders the path as dotted keys with [] for list items.
+func (p *yamlPath) String() string {
+	var b strings.Builder
+	for _, e := range p.stack {
+		if e.key == "" {
+			b.WriteString("[]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(e.key)
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testKubectlDiff = `diff -N --unified=1000000 /tmp/LIVE-1/apps.v1.Deployment.default.web /tmp/MERGED-2/apps.v1.Deployment.default.web
+--- /tmp/LIVE-1/apps.v1.Deployment.default.web	2026-01-01 00:00:00.000000000 +0000
++++ /tmp/MERGED-2/apps.v1.Deployment.default.web	2026-01-01 00:00:00.000000000 +0000
+@@ -1,15 +1,18 @@
+ apiVersion: apps/v1
+ kind: Deployment
+ metadata:
+-  generation: 3
++  generation: 4
+   name: web
+ spec:
+-  replicas: 2
++  replicas: 3
+   template:
+     spec:
+       containers:
+       - image: nginx:1.25
+         name: web
++        env:
++        - name: A
++          value: b
+       - image: sidecar:1
+         name: side
+-        args:
+-        - --old
++        args:
++        - --new
+diff -N --unified=1000000 /tmp/LIVE-1/v1.ConfigMap.default.my.cfg /tmp/MERGED-2/v1.ConfigMap.default.my.cfg
+--- /tmp/LIVE-1/v1.ConfigMap.default.my.cfg	1970-01-01 00:00:00.000000000 +0000
++++ /tmp/MERGED-2/v1.ConfigMap.default.my.cfg	2026-01-01 00:00:00.000000000 +0000
+@@ -0,0 +1,4 @@
++apiVersion: v1
++kind: ConfigMap
++metadata:
++  name: my.cfg
+diff -N --unified=1000000 /tmp/LIVE-1/networking.k8s.io.v1.IngressClass..old /tmp/MERGED-2/networking.k8s.io.v1.IngressClass..old
+--- /tmp/LIVE-1/networking.k8s.io.v1.IngressClass..old	2026-01-01 00:00:00.000000000 +0000
++++ /tmp/MERGED-2/networking.k8s.io.v1.IngressClass..old	1970-01-01 00:00:00.000000000 +0000
+@@ -1,2 +0,0 @@
+-apiVersion: networking.k8s.io/v1
+-kind: IngressClass
+`
+
+func TestParseDiff(t *testing.T) {
+	got, err := ParseDiff([]byte(testKubectlDiff))
+	if err != nil {
+		t.Fatalf("ParseDiff: %v", err)
+	}
+	want := []ResourceDiff{
+		{Kind: "Deployment", Name: "web", Namespace: "default", Action: DiffChanged, ChangedFields: []string{
+			"spec.replicas",
+			"spec.template.spec.containers[].env",
+			"spec.template.spec.containers[].args",
+		}},
+		{Kind: "ConfigMap", Name: "my.cfg", Namespace: "default", Action: DiffCreated},
+		{Kind: "IngressClass", Name: "old", Action: DiffDeleted},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	got, err = ParseDiff(nil)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("no differences: got %+v, %v", got, err)
+	}
+}
+
+func TestParseDiffObjectName(t *testing.T) {
+	tests := []struct {
+		file, kind, namespace, name string
+	}{
+		{"apps.v1.Deployment.default.web", "Deployment", "default", "web"},
+		{"v1.Service.prod.api.v2", "Service", "prod", "api.v2"},
+		{"rbac.authorization.k8s.io.v1.ClusterRole..admin", "ClusterRole", "", "admin"},
+		{"autoscaling.v2beta2.HorizontalPodAutoscaler.ns.hpa", "HorizontalPodAutoscaler", "ns", "hpa"},
+	}
+	for _, tt := range tests {
+		kind, namespace, name, ok := parseDiffObjectName(tt.file)
+		if !ok || kind != tt.kind || namespace != tt.namespace || name != tt.name {
+			t.Errorf("%s: got %q %q %q %v", tt.file, kind, namespace, name, ok)
+		}
+	}
+	if _, _, _, ok := parseDiffObjectName("garbage"); ok {
+		t.Error("expected garbage to be rejected")
+	}
+}
+
+func TestRawDiffManifestOnStdin(t *testing.T) {
+	log := fakePortForward(t, `cat > "$(dirname "$0")/stdin"
+echo "+  replicas: 3"
+exit 1`)
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\n"
+	out, changed, err := NewHelper(false, false).RawDiff(DiffOptions{
+		Files:     []string{"extra.yaml"},
+		Manifest:  []byte(manifest),
+		Namespace: "staging",
+	})
+	if err != nil || !changed || out != "+  replicas: 3\n" {
+		t.Fatalf("RawDiff = %q, %v, %v", out, changed, err)
+	}
+
+	args, _ := os.ReadFile(log)
+	if string(args) != "diff -f extra.yaml -f - -n staging\n" {
+		t.Errorf("kubectl args = %q", args)
+	}
+	stdin, _ := os.ReadFile(filepath.Join(filepath.Dir(log), "stdin"))
+	if string(stdin) != manifest {
+		t.Errorf("kubectl stdin = %q, want the manifest", stdin)
+	}
+}
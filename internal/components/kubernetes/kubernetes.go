@@ -2,11 +2,15 @@
 package kubernetes
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
+	"time"
 )
 
 // ContextInfo represents current kubernetes context info.
@@ -36,7 +40,8 @@ type Pod struct {
 	Ready     string `json:"ready" yaml:"ready"`
 	Status    string `json:"status" yaml:"status"`
 	Restarts  string `json:"restarts" yaml:"restarts"`
-	Age       string `json:"age" yaml:"age"`
+	Age       string `json:"age" yaml:"age"`                                 // creation timestamp
+	AgeHuman  string `json:"age_human,omitempty" yaml:"age_human,omitempty"` // compact age, e.g. 3d
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
@@ -44,6 +49,7 @@ type Pod struct {
 type Helper struct {
 	verbose bool
 	dryRun  bool
+	version *versionResult
 }
 
 // NewHelper creates a new Kubernetes Helper.
@@ -166,7 +172,7 @@ func (h *Helper) UseNamespace(name string) error {
 
 // GetPods returns list of pods with optional filter.
 func (h *Helper) GetPods(filter string) ([]Pod, error) {
-	cmd := exec.Command("kubectl", "get", "pods", "-o", "jsonpath={range .items[*]}{.metadata.name},{.status.containerStatuses[0].ready}/{len .status.containerStatuses},{.status.phase},{.status.containerStatuses[0].restartCount},{.metadata.creationTimestamp}{\"\\n\"}{end}")
+	cmd := exec.Command("kubectl", "get", "pods", "-o", "jsonpath={range .items[*]}"+podFields+"{\"\\n\"}{end}")
 	out, err := cmd.Output()
 	if err != nil {
 		// Fall back to simple output
@@ -176,68 +182,19 @@ func (h *Helper) GetPods(filter string) ([]Pod, error) {
 		return nil, cmd.Run()
 	}
 
-	var pods []Pod
-	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
-		if line == "" {
-			continue
-		}
-		if filter != "" && !strings.Contains(line, filter) {
-			continue
-		}
-		parts := strings.SplitN(line, ",", 5)
-		pod := Pod{Name: parts[0]}
-		if len(parts) > 1 {
-			pod.Ready = parts[1]
-		}
-		if len(parts) > 2 {
-			pod.Status = parts[2]
-		}
-		if len(parts) > 3 {
-			pod.Restarts = parts[3]
-		}
-		if len(parts) > 4 {
-			pod.Age = parts[4]
-		}
-		pods = append(pods, pod)
-	}
-
-	return pods, nil
+	return ParsePods(string(out), filter, time.Now()), nil
 }
 
-// GetAllResources returns all resources in a namespace.
-func (h *Helper) GetAllResources(namespace string) error {
-	if namespace == "" {
-		// Get current namespace
-		cmd := exec.Command("kubectl", "config", "view", "--minify", "--output", "jsonpath={..namespace}")
-		if out, err := cmd.Output(); err == nil {
-			namespace = strings.TrimSpace(string(out))
-		}
-		if namespace == "" {
-			namespace = "default"
+// CurrentNamespace returns the namespace of the current context, or
+// "default" when none is set.
+func (h *Helper) CurrentNamespace() string {
+	cmd := exec.Command("kubectl", "config", "view", "--minify", "--output", "jsonpath={..namespace}")
+	if out, err := cmd.Output(); err == nil {
+		if namespace := strings.TrimSpace(string(out)); namespace != "" {
+			return namespace
 		}
 	}
-
-	fmt.Println("=== Pods ===")
-	cmd := exec.Command("kubectl", "get", "pods", "-n", namespace)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Run()
-
-	fmt.Println()
-	fmt.Println("=== Services ===")
-	cmd = exec.Command("kubectl", "get", "services", "-n", namespace)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Run()
-
-	fmt.Println()
-	fmt.Println("=== Deployments ===")
-	cmd = exec.Command("kubectl", "get", "deployments", "-n", namespace)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Run()
-
-	return nil
+	return "default"
 }
 
 // CleanEvictedPods deletes all evicted pods.
@@ -290,7 +247,8 @@ type Deployment struct {
 	Ready     string `json:"ready" yaml:"ready"`
 	UpToDate  string `json:"up_to_date" yaml:"up_to_date"`
 	Available string `json:"available" yaml:"available"`
-	Age       string `json:"age" yaml:"age"`
+	Age       string `json:"age" yaml:"age"`                                 // creation timestamp
+	AgeHuman  string `json:"age_human,omitempty" yaml:"age_human,omitempty"` // compact age, e.g. 3d
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
@@ -301,7 +259,8 @@ type Service struct {
 	ClusterIP  string `json:"cluster_ip" yaml:"cluster_ip"`
 	ExternalIP string `json:"external_ip,omitempty" yaml:"external_ip,omitempty"`
 	Ports      string `json:"ports" yaml:"ports"`
-	Age        string `json:"age" yaml:"age"`
+	Age        string `json:"age" yaml:"age"`                                 // creation timestamp
+	AgeHuman   string `json:"age_human,omitempty" yaml:"age_human,omitempty"` // compact age, e.g. 3d
 	Namespace  string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
@@ -317,10 +276,14 @@ type Event struct {
 
 // GetDeployments returns list of deployments.
 func (h *Helper) GetDeployments(namespace string) ([]Deployment, error) {
-	args := []string{"get", "deployments", "-o", "jsonpath={range .items[*]}{.metadata.name},{.status.readyReplicas}/{.spec.replicas},{.status.updatedReplicas},{.status.availableReplicas},{.metadata.creationTimestamp}{\"\\n\"}{end}"}
-	if namespace != "" {
-		args = append(args, "-n", namespace)
-	}
+	return h.listDeployments(namespace, false)
+}
+
+// listDeployments returns the deployments in namespace (the current one
+// when empty), or in every namespace when allNamespaces is set.
+func (h *Helper) listDeployments(namespace string, allNamespaces bool) ([]Deployment, error) {
+	args := append([]string{"get", "deployments", "-o", "jsonpath={range .items[*]}{.metadata.namespace}," + deploymentFields + "{\"\\n\"}{end}"},
+		scopeArgs(namespace, allNamespaces)...)
 
 	cmd := exec.Command("kubectl", args...)
 	out, err := cmd.Output()
@@ -328,24 +291,17 @@ func (h *Helper) GetDeployments(namespace string) ([]Deployment, error) {
 		return nil, fmt.Errorf("failed to get deployments: %w", err)
 	}
 
+	now := time.Now()
 	var deployments []Deployment
 	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, ",", 5)
-		d := Deployment{Name: parts[0], Namespace: namespace}
-		if len(parts) > 1 {
-			d.Ready = parts[1]
-		}
-		if len(parts) > 2 {
-			d.UpToDate = parts[2]
-		}
-		if len(parts) > 3 {
-			d.Available = parts[3]
-		}
-		if len(parts) > 4 {
-			d.Age = parts[4]
+		ns, rest, _ := strings.Cut(line, ",")
+		d := parseDeployment(rest, now)
+		d.Namespace = namespace
+		if allNamespaces {
+			d.Namespace = ns
 		}
 		deployments = append(deployments, d)
 	}
@@ -353,12 +309,36 @@ func (h *Helper) GetDeployments(namespace string) ([]Deployment, error) {
 	return deployments, nil
 }
 
+// parseDeployment parses one line of deploymentFields.
+func parseDeployment(line string, now time.Time) Deployment {
+	parts := strings.SplitN(line, ",", 5)
+	d := Deployment{Name: parts[0]}
+	if len(parts) > 1 {
+		d.Ready = parts[1]
+	}
+	if len(parts) > 2 {
+		d.UpToDate = parts[2]
+	}
+	if len(parts) > 3 {
+		d.Available = parts[3]
+	}
+	if len(parts) > 4 {
+		d.Age = parts[4]
+		d.AgeHuman = ageSince(d.Age, now)
+	}
+	return d
+}
+
 // GetServices returns list of services.
 func (h *Helper) GetServices(namespace string) ([]Service, error) {
-	args := []string{"get", "services", "-o", "jsonpath={range .items[*]}{.metadata.name},{.spec.type},{.spec.clusterIP},{.spec.externalIPs[0]},{.spec.ports[*].port},{.metadata.creationTimestamp}{\"\\n\"}{end}"}
-	if namespace != "" {
-		args = append(args, "-n", namespace)
-	}
+	return h.listServices(namespace, false)
+}
+
+// listServices returns the services in namespace (the current one when
+// empty), or in every namespace when allNamespaces is set.
+func (h *Helper) listServices(namespace string, allNamespaces bool) ([]Service, error) {
+	args := append([]string{"get", "services", "-o", "jsonpath={range .items[*]}{.metadata.namespace}," + serviceFields + "{\"\\n\"}{end}"},
+		scopeArgs(namespace, allNamespaces)...)
 
 	cmd := exec.Command("kubectl", args...)
 	out, err := cmd.Output()
@@ -366,34 +346,47 @@ func (h *Helper) GetServices(namespace string) ([]Service, error) {
 		return nil, fmt.Errorf("failed to get services: %w", err)
 	}
 
+	now := time.Now()
 	var services []Service
 	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, ",", 6)
-		s := Service{Name: parts[0], Namespace: namespace}
-		if len(parts) > 1 {
-			s.Type = parts[1]
-		}
-		if len(parts) > 2 {
-			s.ClusterIP = parts[2]
+		ns, rest, _ := strings.Cut(line, ",")
+		svc := parseService(rest, now)
+		svc.Namespace = namespace
+		if allNamespaces {
+			svc.Namespace = ns
 		}
-		if len(parts) > 3 {
-			s.ExternalIP = parts[3]
-		}
-		if len(parts) > 4 {
-			s.Ports = parts[4]
-		}
-		if len(parts) > 5 {
-			s.Age = parts[5]
-		}
-		services = append(services, s)
+		services = append(services, svc)
 	}
 
 	return services, nil
 }
 
+// parseService parses one line of serviceFields.
+func parseService(line string, now time.Time) Service {
+	parts := strings.SplitN(line, ",", 6)
+	s := Service{Name: parts[0]}
+	if len(parts) > 1 {
+		s.Type = parts[1]
+	}
+	if len(parts) > 2 {
+		s.ClusterIP = parts[2]
+	}
+	if len(parts) > 3 {
+		s.ExternalIP = parts[3]
+	}
+	if len(parts) > 4 {
+		s.Ports = parts[4]
+	}
+	if len(parts) > 5 {
+		s.Age = parts[5]
+		s.AgeHuman = ageSince(s.Age, now)
+	}
+	return s
+}
+
 // GetEvents returns recent events sorted by time.
 func (h *Helper) GetEvents(namespace string) ([]Event, error) {
 	args := []string{"get", "events", "--sort-by=.lastTimestamp", "-o", "jsonpath={range .items[*]}{.metadata.namespace},{.lastTimestamp},{.type},{.reason},{.involvedObject.kind}/{.involvedObject.name},{.message}{\"\\n\"}{end}"}
@@ -530,14 +523,28 @@ func (h *Helper) GetLogs(pod, namespace string, follow bool, tail int) error {
 	return cmd.Run()
 }
 
-// ExecInPod executes a command in a pod.
-func (h *Helper) ExecInPod(pod, namespace string, command []string) error {
-	args := []string{"exec", "-it"}
-	if namespace != "" {
-		args = append(args, "-n", namespace)
+// ExecOptions configures a command run inside a pod.
+type ExecOptions struct {
+	Pod         string
+	Namespace   string
+	Container   string
+	Command     []string // defaults to /bin/sh when interactive
+	Interactive bool     // attach stdin and allocate a TTY
+}
+
+// ExecResult contains the captured output of a non-interactive exec.
+type ExecResult struct {
+	Stdout   string `json:"stdout" yaml:"stdout"`
+	Stderr   string `json:"stderr" yaml:"stderr"`
+	ExitCode int    `json:"exit_code" yaml:"exit_code"`
+}
+
+// ExecInPod runs a command in a pod attached to the terminal (-it).
+func (h *Helper) ExecInPod(opts ExecOptions) error {
+	if len(opts.Command) == 0 {
+		opts.Command = []string{"/bin/sh"}
 	}
-	args = append(args, pod, "--")
-	args = append(args, command...)
+	args := execArgs(opts, true)
 
 	if h.dryRun {
 		fmt.Printf("[dry-run] would run: kubectl %s\n", strings.Join(args, " "))
@@ -551,6 +558,59 @@ func (h *Helper) ExecInPod(pod, namespace string, command []string) error {
 	return cmd.Run()
 }
 
+// ExecCapture runs a command in a pod without a TTY and captures its output.
+// A non-zero exit from the command is reported in ExitCode rather than as an
+// error; errors are returned only when kubectl itself could not be run.
+func (h *Helper) ExecCapture(opts ExecOptions) (*ExecResult, error) {
+	if len(opts.Command) == 0 {
+		return nil, fmt.Errorf("a command is required for non-interactive exec")
+	}
+	args := execArgs(opts, false)
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: kubectl %s\n", strings.Join(args, " "))
+		return &ExecResult{}, nil
+	}
+
+	if h.verbose {
+		fmt.Fprintf(os.Stderr, "Running: kubectl %s\n", strings.Join(args, " "))
+	}
+
+	var stdout, stderr strings.Builder
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	result := &ExecResult{}
+	err := cmd.Run()
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return nil, fmt.Errorf("failed to run kubectl exec: %w", err)
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+	return result, nil
+}
+
+// execArgs builds the kubectl exec arguments for opts.
+func execArgs(opts ExecOptions, tty bool) []string {
+	args := []string{"exec"}
+	if tty {
+		args = append(args, "-it")
+	}
+	if opts.Namespace != "" {
+		args = append(args, "-n", opts.Namespace)
+	}
+	if opts.Container != "" {
+		args = append(args, "-c", opts.Container)
+	}
+	args = append(args, opts.Pod, "--")
+	return append(args, opts.Command...)
+}
+
 // PortForward forwards a local port to a pod port.
 func (h *Helper) PortForward(pod, namespace, ports string) error {
 	args := []string{"port-forward"}
@@ -570,6 +630,217 @@ func (h *Helper) PortForward(pod, namespace, ports string) error {
 	return cmd.Run()
 }
 
+// PortForwardRetryDelay is the wait between port-forward reconnection attempts.
+const PortForwardRetryDelay = 2 * time.Second
+
+// PortForwardMaxFailures is the number of attempts in a row that may fail
+// to connect before a retrying port-forward gives up.
+const PortForwardMaxFailures = 5
+
+// PortForwardOptions configures a port-forward session.
+type PortForwardOptions struct {
+	Target    string // pod name, pod/<name>, svc/<name>, or deploy/<name>
+	Namespace string
+	Ports     string // e.g. "8080:80" or "8080:80 9090"
+	Retry     bool   // re-establish the forward when it drops
+
+	// RetryDelay is the wait between attempts (default: PortForwardRetryDelay).
+	RetryDelay time.Duration
+
+	// OnConnect is called each time kubectl reports the forward is listening,
+	// with the number of connections made so far.
+	OnConnect func(resolved string, connection int)
+	// OnDisconnect is called when a forward drops and will be retried.
+	OnDisconnect func(err error)
+}
+
+// ResolvePortForwardTarget normalizes a port-forward target.
+// Services are forwarded directly; deployments are resolved to one of their
+// running pods so reconnects pick up replacement pods after a rollout.
+func (h *Helper) ResolvePortForwardTarget(target, namespace string) (string, error) {
+	kind, name, err := portForwardKind(target)
+	if err != nil {
+		return "", err
+	}
+	if kind != "deploy" {
+		return kind + "/" + name, nil
+	}
+
+	pod, err := h.getRunningPodForDeployment(name, namespace)
+	if err != nil {
+		return "", err
+	}
+	return "pod/" + pod, nil
+}
+
+// portForwardKind splits a port-forward target into its kind (pod, svc or
+// deploy) and name. A bare name is a pod.
+func portForwardKind(target string) (string, string, error) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		return "pod", target, nil
+	}
+
+	switch kind {
+	case "pod", "pods", "po":
+		return "pod", name, nil
+	case "svc", "service", "services":
+		return "svc", name, nil
+	case "deploy", "deployment", "deployments":
+		return "deploy", name, nil
+	default:
+		return "", "", fmt.Errorf("unsupported port-forward target: %s (use pod/, svc/, or deploy/)", target)
+	}
+}
+
+// getRunningPodForDeployment returns the name of a running pod selected by a deployment.
+func (h *Helper) getRunningPodForDeployment(deployment, namespace string) (string, error) {
+	args := []string{"get", "deployment", deployment, "-o", "jsonpath={.spec.selector.matchLabels}"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	out, err := exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get deployment %s: %w", deployment, err)
+	}
+
+	var labels map[string]string
+	if err := json.Unmarshal(out, &labels); err != nil || len(labels) == 0 {
+		return "", fmt.Errorf("deployment %s has no label selector", deployment)
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	selectors := make([]string, 0, len(keys))
+	for _, k := range keys {
+		selectors = append(selectors, k+"="+labels[k])
+	}
+
+	args = []string{"get", "pods", "-l", strings.Join(selectors, ","),
+		"--field-selector=status.phase=Running",
+		"-o", "jsonpath={.items[*].metadata.name}"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	out, err = exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get pods for deployment %s: %w", deployment, err)
+	}
+
+	pods := strings.Fields(string(out))
+	if len(pods) == 0 {
+		return "", fmt.Errorf("no running pods for deployment %s", deployment)
+	}
+
+	return pods[0], nil
+}
+
+// PortForwardTarget forwards local ports to a pod, service, or deployment.
+// With Retry set, the forward is re-established whenever it exits until ctx
+// is cancelled, giving up after PortForwardMaxFailures attempts in a row
+// that never connect. Cancelling ctx stops the forward and returns nil.
+func (h *Helper) PortForwardTarget(ctx context.Context, opts PortForwardOptions) error {
+	ports := strings.Fields(opts.Ports)
+	if len(ports) == 0 {
+		return fmt.Errorf("no ports specified")
+	}
+	if _, _, err := portForwardKind(opts.Target); err != nil {
+		return err
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = PortForwardRetryDelay
+	}
+
+	if h.dryRun {
+		args := []string{"port-forward"}
+		if opts.Namespace != "" {
+			args = append(args, "-n", opts.Namespace)
+		}
+		args = append(args, opts.Target)
+		args = append(args, ports...)
+		fmt.Printf("[dry-run] would run: kubectl %s\n", strings.Join(args, " "))
+		return nil
+	}
+
+	connections, failures := 0, 0
+	for {
+		connected := false
+		resolved, err := h.ResolvePortForwardTarget(opts.Target, opts.Namespace)
+		if err == nil {
+			args := []string{"port-forward"}
+			if opts.Namespace != "" {
+				args = append(args, "-n", opts.Namespace)
+			}
+			args = append(args, resolved)
+			args = append(args, ports...)
+
+			connected, err = h.forwardOnce(ctx, args, func() {
+				connections++
+				if opts.OnConnect != nil {
+					opts.OnConnect(resolved, connections)
+				}
+			})
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !opts.Retry {
+			return err
+		}
+		if err == nil {
+			err = fmt.Errorf("port-forward exited")
+		}
+		if connected {
+			failures = 0
+		} else if failures++; failures >= PortForwardMaxFailures {
+			return fmt.Errorf("port-forward failed %d times without connecting: %w", failures, err)
+		}
+		if opts.OnDisconnect != nil {
+			opts.OnDisconnect(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.RetryDelay):
+		}
+	}
+}
+
+// forwardOnce runs kubectl with args until it exits, calling onConnect when
+// kubectl reports that it is listening. It returns whether that happened.
+func (h *Helper) forwardOnce(ctx context.Context, args []string, onConnect func()) (bool, error) {
+	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false, err
+	}
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+
+	connected := false
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if h.verbose {
+			fmt.Println(line)
+		}
+		if !connected && strings.HasPrefix(line, "Forwarding from") {
+			connected = true
+			onConnect()
+		}
+	}
+	return connected, cmd.Wait()
+}
+
 // DescribePod describes a pod.
 func (h *Helper) DescribePod(pod, namespace string) error {
 	args := []string{"describe", "pod"}
@@ -672,42 +943,160 @@ func (h *Helper) WatchResource(resource, namespace string) error {
 	return cmd.Run()
 }
 
+// GetOptions selects resources for a generic kubectl get.
+type GetOptions struct {
+	Resource      string
+	Name          string
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+}
+
+// Resource is a generic kubernetes object summarized by its common columns.
+type Resource struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Status    string `json:"status,omitempty" yaml:"status,omitempty"`
+	Age       string `json:"age" yaml:"age"`                                 // creation timestamp
+	AgeHuman  string `json:"age_human,omitempty" yaml:"age_human,omitempty"` // compact age, e.g. 3d
+}
+
+// resourceObject holds the fields of a kubernetes object used for summaries.
+type resourceObject struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name              string `json:"name"`
+		Namespace         string `json:"namespace"`
+		CreationTimestamp string `json:"creationTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas *int `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Phase         string `json:"phase"`
+		ReadyReplicas int    `json:"readyReplicas"`
+	} `json:"status"`
+	Items []resourceObject `json:"items"`
+}
+
 // GetAsYAML returns a resource as YAML.
 func (h *Helper) GetAsYAML(resource, name, namespace string) (string, error) {
-	args := []string{"get", resource}
-	if name != "" {
-		args = append(args, name)
-	}
-	if namespace != "" {
-		args = append(args, "-n", namespace)
-	}
-	args = append(args, "-o", "yaml")
+	return h.GetRaw(GetOptions{Resource: resource, Name: name, Namespace: namespace}, "yaml")
+}
+
+// GetAsJSON returns a resource as JSON.
+func (h *Helper) GetAsJSON(resource, name, namespace string) (string, error) {
+	return h.GetRaw(GetOptions{Resource: resource, Name: name, Namespace: namespace}, "json")
+}
+
+// GetRaw returns the kubectl get output for the selected resources in the
+// given format (json or yaml).
+func (h *Helper) GetRaw(opts GetOptions, format string) (string, error) {
+	args := append(getArgs(opts), "-o", format)
 
 	cmd := exec.Command("kubectl", args...)
 	out, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s: %w", resource, err)
+		return "", kubectlGetError(opts.Resource, err)
 	}
 
 	return string(out), nil
 }
 
-// GetAsJSON returns a resource as JSON.
-func (h *Helper) GetAsJSON(resource, name, namespace string) (string, error) {
-	args := []string{"get", resource}
-	if name != "" {
-		args = append(args, name)
+// GetResources returns a summary of any resource kind, including CRDs.
+func (h *Helper) GetResources(opts GetOptions) ([]Resource, error) {
+	out, err := h.GetRaw(opts, "json")
+	if err != nil {
+		return nil, err
 	}
-	if namespace != "" {
-		args = append(args, "-n", namespace)
+
+	var obj resourceObject
+	if err := json.Unmarshal([]byte(out), &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse kubectl output: %w", err)
 	}
-	args = append(args, "-o", "json")
 
-	cmd := exec.Command("kubectl", args...)
-	out, err := cmd.Output()
+	// A named get returns the object itself rather than a List
+	items := obj.Items
+	if opts.Name != "" && obj.Metadata.Name != "" {
+		items = []resourceObject{obj}
+	}
+
+	now := time.Now()
+	resources := make([]Resource, 0, len(items))
+	for _, item := range items {
+		r := Resource{
+			Kind:      item.Kind,
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			Status:    item.Status.Phase,
+			Age:       item.Metadata.CreationTimestamp,
+		}
+		if r.Status == "" && item.Spec.Replicas != nil {
+			r.Status = fmt.Sprintf("%d/%d ready", item.Status.ReadyReplicas, *item.Spec.Replicas)
+		}
+		r.AgeHuman = ageSince(r.Age, now)
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// getArgs builds the kubectl get arguments for opts, without an output format.
+func getArgs(opts GetOptions) []string {
+	args := []string{"get", opts.Resource}
+	if opts.Name != "" {
+		args = append(args, opts.Name)
+	}
+	if opts.AllNamespaces {
+		args = append(args, "--all-namespaces")
+	} else if opts.Namespace != "" {
+		args = append(args, "-n", opts.Namespace)
+	}
+	if opts.Selector != "" {
+		args = append(args, "-l", opts.Selector)
+	}
+	return args
+}
+
+// kubectlGetError converts a kubectl get failure into a readable error.
+func kubectlGetError(resource string, err error) error {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return fmt.Errorf("failed to get %s: %w", resource, err)
+	}
+
+	msg := strings.TrimSpace(string(exitErr.Stderr))
+	msg = strings.TrimPrefix(msg, "error: ")
+	if strings.Contains(msg, "doesn't have a resource type") {
+		return fmt.Errorf("unknown resource type: %s", resource)
+	}
+	if msg == "" {
+		return fmt.Errorf("failed to get %s: %w", resource, err)
+	}
+	return fmt.Errorf("failed to get %s: %s", resource, msg)
+}
+
+// ageSince returns the compact age of an RFC 3339 creation timestamp
+// relative to now, or an empty string if the timestamp cannot be parsed.
+func ageSince(timestamp string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
 	if err != nil {
-		return "", fmt.Errorf("failed to get %s: %w", resource, err)
+		return ""
 	}
+	return formatAge(now.Sub(t))
+}
 
-	return string(out), nil
+// formatAge formats a duration in kubectl's compact age style (e.g. 5m, 3h, 12d).
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
 }
@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// NamespaceResult is the outcome of creating or deleting a namespace.
+type NamespaceResult struct {
+	Name      string            `json:"name" yaml:"name"`
+	Action    string            `json:"action" yaml:"action"` // created, deleted
+	Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Resources []Resource        `json:"resources,omitempty" yaml:"resources,omitempty"`
+	DryRun    bool              `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+// namespaceNameRe matches a valid namespace name (an RFC 1123 label).
+var namespaceNameRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ValidateNamespaceName checks that name is a valid namespace name.
+func ValidateNamespaceName(name string) error {
+	if len(name) > 63 || !namespaceNameRe.MatchString(name) {
+		return fmt.Errorf("invalid namespace name %q: must be at most 63 lowercase alphanumeric characters or '-', starting and ending with an alphanumeric", name)
+	}
+	return nil
+}
+
+// ParseLabels parses key=value label arguments.
+func ParseLabels(args []string) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q (expected key=value)", arg)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// NamespaceExists reports whether a namespace exists in the current cluster.
+func (h *Helper) NamespaceExists(name string) (bool, error) {
+	namespaces, err := h.GetNamespaces()
+	if err != nil {
+		return false, err
+	}
+	for _, ns := range namespaces {
+		if ns.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateNamespace creates a namespace and applies the given labels.
+func (h *Helper) CreateNamespace(name string, labels map[string]string) (*NamespaceResult, error) {
+	if err := ValidateNamespaceName(name); err != nil {
+		return nil, err
+	}
+
+	exists, err := h.NamespaceExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("namespace %q already exists", name)
+	}
+
+	result := &NamespaceResult{Name: name, Action: "created", Labels: labels, DryRun: h.dryRun}
+
+	if err := h.runKubectl("create", "namespace", name); err != nil {
+		return nil, fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+
+	if len(labels) > 0 {
+		args := []string{"label", "namespace", name}
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			args = append(args, k+"="+labels[k])
+		}
+		if err := h.runKubectl(args...); err != nil {
+			return nil, fmt.Errorf("namespace %s created but labeling failed: %w", name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// NamespaceResources returns the resources that would be removed along with
+// a namespace.
+func (h *Helper) NamespaceResources(name string) ([]Resource, error) {
+	return h.GetResources(GetOptions{Resource: "all", Namespace: name})
+}
+
+// DeleteNamespace deletes a namespace and everything in it. The resources
+// found in the namespace beforehand are included in the result.
+func (h *Helper) DeleteNamespace(name string) (*NamespaceResult, error) {
+	exists, err := h.NamespaceExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("namespace %q not found", name)
+	}
+
+	result := &NamespaceResult{Name: name, Action: "deleted", DryRun: h.dryRun}
+	if resources, err := h.NamespaceResources(name); err == nil {
+		result.Resources = resources
+	}
+
+	if err := h.runKubectl("delete", "namespace", name); err != nil {
+		return nil, fmt.Errorf("failed to delete namespace %s: %w", name, err)
+	}
+
+	return result, nil
+}
+
+// runKubectl runs kubectl with args, or prints it in dry-run mode.
+func (h *Helper) runKubectl(args ...string) error {
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: kubectl %s\n", strings.Join(args, " "))
+		return nil
+	}
+
+	cmd := exec.Command("kubectl", args...)
+	if h.verbose {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateNamespaceName(t *testing.T) {
+	for _, name := range []string{"scratch", "team-a", "a1", strings.Repeat("a", 63)} {
+		if err := ValidateNamespaceName(name); err != nil {
+			t.Errorf("ValidateNamespaceName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "Scratch", "-a", "a-", "a_b", "a.b", strings.Repeat("a", 64)} {
+		if err := ValidateNamespaceName(name); err == nil {
+			t.Errorf("ValidateNamespaceName(%q) should fail", name)
+		}
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels([]string{"team=platform", "env=", "tier=a=b"})
+	if err != nil {
+		t.Fatalf("ParseLabels: %v", err)
+	}
+	want := map[string]string{"team": "platform", "env": "", "tier": "a=b"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+
+	for _, arg := range []string{"team", "=x"} {
+		if _, err := ParseLabels([]string{arg}); err == nil {
+			t.Errorf("ParseLabels(%q) should fail", arg)
+		}
+	}
+}
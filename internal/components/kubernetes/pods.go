@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PodSortKeys are the fields pods can be sorted by.
+var PodSortKeys = []string{"name", "status", "restarts", "age"}
+
+// PodColumns are the pod fields that can be shown in a table.
+var PodColumns = []string{"name", "ready", "status", "restarts", "age", "created"}
+
+// DefaultPodColumns are the columns shown when none are selected.
+var DefaultPodColumns = []string{"name", "ready", "status", "restarts", "age"}
+
+// ParsePods parses the name,ready,phase,restarts,creationTimestamp lines
+// produced by GetPods' jsonpath query, keeping lines that contain filter.
+// Age keeps the creation timestamp; AgeHuman is the compact age relative
+// to now.
+func ParsePods(out, filter string, now time.Time) []Pod {
+	var pods []Pod
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+		if filter != "" && !strings.Contains(line, filter) {
+			continue
+		}
+		pods = append(pods, parsePod(line, now))
+	}
+	return pods
+}
+
+// parsePod parses one name,ready,phase,restarts,creationTimestamp line.
+func parsePod(line string, now time.Time) Pod {
+	parts := strings.SplitN(line, ",", 5)
+	pod := Pod{Name: parts[0]}
+	if len(parts) > 1 {
+		pod.Ready = parts[1]
+	}
+	if len(parts) > 2 {
+		pod.Status = parts[2]
+	}
+	if len(parts) > 3 {
+		pod.Restarts = parts[3]
+	}
+	if len(parts) > 4 {
+		pod.Age = parts[4]
+		pod.AgeHuman = ageSince(pod.Age, now)
+	}
+	return pod
+}
+
+// SortPods sorts pods in place by key: name and status alphabetically,
+// restarts highest first and age newest first. Ties are broken by name.
+func SortPods(pods []Pod, key string) error {
+	var compare func(a, b Pod) int
+	switch key {
+	case "name":
+		compare = func(a, b Pod) int { return 0 }
+	case "status":
+		compare = func(a, b Pod) int { return strings.Compare(a.Status, b.Status) }
+	case "restarts":
+		compare = func(a, b Pod) int { return podRestarts(b) - podRestarts(a) }
+	case "age":
+		compare = func(a, b Pod) int { return podCreated(b).Compare(podCreated(a)) }
+	default:
+		return fmt.Errorf("invalid sort key %q (valid: %s)", key, strings.Join(PodSortKeys, ", "))
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		if c := compare(pods[i], pods[j]); c != 0 {
+			return c < 0
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return nil
+}
+
+// ValidatePodColumns checks that every column is a known pod field.
+func ValidatePodColumns(columns []string) error {
+	for _, col := range columns {
+		if podColumn(Pod{}, col) == nil {
+			return fmt.Errorf("invalid column %q (valid: %s)", col, strings.Join(PodColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// PodRow returns the values of the given columns for a pod.
+func PodRow(pod Pod, columns []string) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		if v := podColumn(pod, col); v != nil {
+			row[i] = *v
+		}
+	}
+	return row
+}
+
+// podColumn returns the value of a named pod field, or nil if the name is
+// not a known column.
+func podColumn(pod Pod, column string) *string {
+	switch strings.ToLower(column) {
+	case "name":
+		return &pod.Name
+	case "ready":
+		return &pod.Ready
+	case "status":
+		return &pod.Status
+	case "restarts":
+		return &pod.Restarts
+	case "age":
+		return &pod.AgeHuman
+	case "created":
+		return &pod.Age
+	}
+	return nil
+}
+
+// podRestarts returns the restart count of a pod, or 0 if unknown.
+func podRestarts(pod Pod) int {
+	n, _ := strconv.Atoi(pod.Restarts)
+	return n
+}
+
+// podCreated returns the creation time of a pod, or the zero time if unknown.
+func podCreated(pod Pod) time.Time {
+	t, _ := time.Parse(time.RFC3339, pod.Age)
+	return t
+}
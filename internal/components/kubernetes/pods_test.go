@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAndSortPods(t *testing.T) {
+	out := "web-1,true/1,Running,0,2026-01-01T00:00:00Z\n" +
+		"web-2,true/1,Running,12,2026-01-09T00:00:00Z\n" +
+		"api-1,false/1,CrashLoopBackOff,3,2026-01-09T22:00:00Z\n"
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	pods := ParsePods(out, "", now)
+	if len(pods) != 3 {
+		t.Fatalf("got %d pods", len(pods))
+	}
+	if pods[0].AgeHuman != "9d" || pods[1].AgeHuman != "24h" || pods[2].AgeHuman != "2h" {
+		t.Errorf("ages = %s, %s, %s", pods[0].AgeHuman, pods[1].AgeHuman, pods[2].AgeHuman)
+	}
+	if pods[0].Age != "2026-01-01T00:00:00Z" {
+		t.Errorf("Age = %q, want the raw creation timestamp", pods[0].Age)
+	}
+	if got := ParsePods(out, "web", now); len(got) != 2 {
+		t.Errorf("filter: got %d pods", len(got))
+	}
+
+	names := func() []string {
+		var n []string
+		for _, p := range pods {
+			n = append(n, p.Name)
+		}
+		return n
+	}
+	for key, want := range map[string][]string{
+		"name":     {"api-1", "web-1", "web-2"},
+		"status":   {"api-1", "web-1", "web-2"},
+		"restarts": {"web-2", "api-1", "web-1"},
+		"age":      {"api-1", "web-2", "web-1"},
+	} {
+		if err := SortPods(pods, key); err != nil {
+			t.Fatalf("SortPods(%s): %v", key, err)
+		}
+		if got := names(); !reflect.DeepEqual(got, want) {
+			t.Errorf("sort %s = %v, want %v", key, got, want)
+		}
+	}
+
+	if err := SortPods(pods, "size"); err == nil {
+		t.Error("unknown sort key should fail")
+	}
+}
+
+func TestPodRow(t *testing.T) {
+	pod := Pod{Name: "web-1", Status: "Running", Restarts: "2", Age: "2026-01-07T00:00:00Z", AgeHuman: "3d"}
+	if got := PodRow(pod, []string{"name", "AGE", "restarts", "created"}); !reflect.DeepEqual(got, []string{"web-1", "3d", "2", "2026-01-07T00:00:00Z"}) {
+		t.Errorf("PodRow = %v", got)
+	}
+	if err := ValidatePodColumns([]string{"name", "node"}); err == nil {
+		t.Error("unknown column should fail")
+	}
+}
+
+func TestAgeSince(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]string{
+		"2026-01-10T11:59:30Z": "30s",
+		"2026-01-10T11:55:00Z": "5m",
+		"2026-01-10T09:00:00Z": "3h",
+		"2026-01-08T12:00:00Z": "2d",
+		"2025-12-13T12:00:00Z": "28d",
+		"not-a-time":           "",
+		"":                     "",
+	}
+	for ts, want := range tests {
+		if got := ageSince(ts, now); got != want {
+			t.Errorf("ageSince(%q) = %q, want %q", ts, got, want)
+		}
+	}
+}
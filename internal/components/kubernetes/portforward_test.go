@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakePortForward puts a kubectl script on PATH that runs body for
+// port-forward and logs each invocation to the returned file.
+func fakePortForward(t *testing.T, body string) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	script := "#!/bin/sh\necho \"$*\" >> " + log + "\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func invocations(t *testing.T, log string) int {
+	t.Helper()
+	data, _ := os.ReadFile(log)
+	return strings.Count(string(data), "\n")
+}
+
+func TestPortForwardKind(t *testing.T) {
+	for target, want := range map[string]string{
+		"web":            "pod/web",
+		"po/web":         "pod/web",
+		"service/api":    "svc/api",
+		"deployments/ui": "deploy/ui",
+	} {
+		kind, name, err := portForwardKind(target)
+		if err != nil || kind+"/"+name != want {
+			t.Errorf("portForwardKind(%s) = %s/%s, %v; want %s", target, kind, name, err, want)
+		}
+	}
+	if _, _, err := portForwardKind("job/x"); err == nil {
+		t.Error("unsupported kind should fail")
+	}
+}
+
+func TestPortForwardConnects(t *testing.T) {
+	log := fakePortForward(t, `echo "Forwarding from 127.0.0.1:8080 -> 80"
+echo "Forwarding from [::1]:8080 -> 80"
+exit 0`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var connections []int
+	var drops int
+	err := NewHelper(false, false).PortForwardTarget(ctx, PortForwardOptions{
+		Target:     "svc/api",
+		Ports:      "8080:80",
+		Retry:      true,
+		RetryDelay: time.Millisecond,
+		OnConnect: func(resolved string, n int) {
+			if resolved != "svc/api" {
+				t.Errorf("resolved = %s", resolved)
+			}
+			connections = append(connections, n)
+			if n == 3 {
+				cancel()
+			}
+		},
+		OnDisconnect: func(error) { drops++ },
+	})
+	if err != nil {
+		t.Fatalf("PortForwardTarget: %v", err)
+	}
+	if len(connections) != 3 || connections[2] != 3 {
+		t.Errorf("connections = %v, want one per run", connections)
+	}
+	if drops != 2 || invocations(t, log) != 3 {
+		t.Errorf("drops = %d, runs = %d", drops, invocations(t, log))
+	}
+}
+
+func TestPortForwardGivesUp(t *testing.T) {
+	log := fakePortForward(t, `echo "error: unable to listen on any of the requested ports" >&2
+exit 1`)
+
+	connected := false
+	err := NewHelper(false, false).PortForwardTarget(context.Background(), PortForwardOptions{
+		Target:     "web",
+		Ports:      "8080:80",
+		Retry:      true,
+		RetryDelay: time.Millisecond,
+		OnConnect:  func(string, int) { connected = true },
+	})
+	if err == nil || !strings.Contains(err.Error(), "without connecting") {
+		t.Fatalf("err = %v, want give-up error", err)
+	}
+	if connected {
+		t.Error("OnConnect called although kubectl never listened")
+	}
+	if n := invocations(t, log); n != PortForwardMaxFailures {
+		t.Errorf("ran kubectl %d times, want %d", n, PortForwardMaxFailures)
+	}
+}
+
+func TestPortForwardNoRetry(t *testing.T) {
+	log := fakePortForward(t, "exit 1")
+
+	err := NewHelper(false, false).PortForwardTarget(context.Background(), PortForwardOptions{
+		Target: "web",
+		Ports:  "8080:80",
+		OnConnect: func(string, int) {
+			t.Error("OnConnect called although kubectl never listened")
+		},
+	})
+	if err == nil || invocations(t, log) != 1 {
+		t.Errorf("err = %v, runs = %d; want one failed run", err, invocations(t, log))
+	}
+
+	if err := NewHelper(false, false).PortForwardTarget(context.Background(), PortForwardOptions{
+		Target: "job/x", Ports: "80", Retry: true,
+	}); err == nil || invocations(t, log) != 1 {
+		t.Errorf("unsupported target: err = %v, runs = %d", err, invocations(t, log))
+	}
+}
@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultRestartTimeout bounds how long --wait waits for each rollout.
+const DefaultRestartTimeout = 5 * time.Minute
+
+// Restart statuses.
+const (
+	RestartDone      = "restarted"
+	RestartRolledOut = "rolled_out"
+	RestartWould     = "would_restart"
+	RestartFailed    = "failed"
+)
+
+// RestartOptions controls RestartDeployments.
+type RestartOptions struct {
+	Namespace string
+	Wait      bool          // wait for each rollout to complete
+	Timeout   time.Duration // per-rollout limit with Wait
+}
+
+// RestartResult is the outcome of restarting one deployment.
+type RestartResult struct {
+	Deployment string `json:"deployment" yaml:"deployment"`
+	Status     string `json:"status" yaml:"status"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// RestartReport is the outcome of RestartDeployments.
+type RestartReport struct {
+	Context   string          `json:"context,omitempty" yaml:"context,omitempty"`
+	Namespace string          `json:"namespace" yaml:"namespace"`
+	DryRun    bool            `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+	Results   []RestartResult `json:"results" yaml:"results"`
+	Failed    int             `json:"failed" yaml:"failed"`
+}
+
+// SelectDeployments returns the deployments to restart in namespace: the
+// named ones, which must exist, or those matching selector, or with
+// neither all of them.
+func (h *Helper) SelectDeployments(namespace string, names []string, selector string) ([]string, error) {
+	if len(names) > 0 {
+		for _, name := range names {
+			if err := h.deploymentExists(name, namespace); err != nil {
+				return nil, err
+			}
+		}
+		return names, nil
+	}
+
+	if selector == "" {
+		deployments, err := h.GetDeployments(namespace)
+		if err != nil {
+			return nil, err
+		}
+		selected := make([]string, 0, len(deployments))
+		for _, d := range deployments {
+			selected = append(selected, d.Name)
+		}
+		return selected, nil
+	}
+
+	args := append([]string{"get", "deployments", "-l", selector, "-o", "jsonpath={.items[*].metadata.name}"},
+		scopeArgs(namespace, false)...)
+	out, err := exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments matching %q: %w", selector, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// RestartDeployments rolls each deployment in turn and, with opts.Wait,
+// waits for its rollout to finish before moving on. Failures are recorded
+// and the remaining deployments are still restarted.
+func (h *Helper) RestartDeployments(deployments []string, opts RestartOptions) *RestartReport {
+	report := &RestartReport{
+		Namespace: opts.Namespace,
+		DryRun:    h.dryRun,
+		Results:   []RestartResult{},
+	}
+	if info, err := h.GetContextInfo(); err == nil {
+		report.Context = info.Context
+		if report.Namespace == "" {
+			report.Namespace = info.Namespace
+		}
+	}
+
+	for _, name := range deployments {
+		result := RestartResult{Deployment: name}
+		switch {
+		case h.dryRun:
+			result.Status = RestartWould
+		default:
+			result.Status = RestartDone
+			if err := h.restartQuiet(name, opts.Namespace); err != nil {
+				result.Status = RestartFailed
+				result.Error = err.Error()
+			} else if opts.Wait {
+				if err := h.WaitRollout(name, opts.Namespace, opts.Timeout); err != nil {
+					result.Status = RestartFailed
+					result.Error = err.Error()
+				} else {
+					result.Status = RestartRolledOut
+				}
+			}
+		}
+		if result.Status == RestartFailed {
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// restartQuiet runs kubectl rollout restart, returning its error output
+// instead of printing it.
+func (h *Helper) restartQuiet(deployment, namespace string) error {
+	args := append([]string{"rollout", "restart", "deployment/" + deployment}, scopeArgs(namespace, false)...)
+	return runKubectlQuiet(args...)
+}
+
+// WaitRollout blocks until the rollout of a deployment completes, as
+// RolloutStatus does, but without printing progress. It fails after
+// timeout, when positive.
+func (h *Helper) WaitRollout(deployment, namespace string, timeout time.Duration) error {
+	args := append([]string{"rollout", "status", "deployment/" + deployment}, scopeArgs(namespace, false)...)
+	if timeout > 0 {
+		args = append(args, "--timeout="+timeout.String())
+	}
+	return runKubectlQuiet(args...)
+}
+
+// runKubectlQuiet runs kubectl and turns a failure into an error carrying
+// the last line kubectl wrote to stderr.
+func runKubectlQuiet(args ...string) error {
+	cmd := exec.Command("kubectl", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		if msg := lines[len(lines)-1]; msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return err
+	}
+	return nil
+}
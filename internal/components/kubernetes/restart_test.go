@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRestartKubectl puts a kubectl on PATH that knows the deployments api,
+// web and slow, whose restart of slow never finishes rolling out.
+func fakeRestartKubectl(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	script := `#!/bin/sh
+echo "$*" >> ` + log + `
+case "$*" in
+  "config current-context") echo dev;;
+  "get deployment/api -o name"*|"get deployment/web -o name"*|"get deployment/slow -o name"*) ;;
+  "get deployment/"*) echo "not found" >&2; exit 1;;
+  "get deployments -l tier=front"*) printf 'web slow';;
+  "rollout restart"*) ;;
+  "rollout status deployment/slow"*) echo "error: timed out waiting for the condition" >&2; exit 1;;
+  "rollout status"*) echo "successfully rolled out";;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestSelectDeployments(t *testing.T) {
+	fakeRestartKubectl(t)
+	h := NewHelper(false, false)
+
+	got, err := h.SelectDeployments("prod", nil, "tier=front")
+	if err != nil {
+		t.Fatalf("SelectDeployments: %v", err)
+	}
+	if want := []string{"web", "slow"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selector: got %v, want %v", got, want)
+	}
+
+	if _, err := h.SelectDeployments("prod", []string{"api", "missing"}, ""); err == nil ||
+		!strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("expected a not found error for missing, got %v", err)
+	}
+}
+
+func TestRestartDeployments(t *testing.T) {
+	log := fakeRestartKubectl(t)
+	h := NewHelper(false, false)
+
+	report := h.RestartDeployments([]string{"api", "slow", "web"}, RestartOptions{
+		Namespace: "prod",
+		Wait:      true,
+		Timeout:   90 * time.Second,
+	})
+	if report.Context != "dev" || report.Namespace != "prod" || report.Failed != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	statuses := map[string]string{}
+	for _, r := range report.Results {
+		statuses[r.Deployment] = r.Status
+	}
+	want := map[string]string{"api": RestartRolledOut, "slow": RestartFailed, "web": RestartRolledOut}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+	if msg := report.Results[1].Error; msg != "error: timed out waiting for the condition" {
+		t.Errorf("slow error = %q", msg)
+	}
+
+	calls, _ := os.ReadFile(log)
+	if !strings.Contains(string(calls), "rollout status deployment/web -n prod --timeout=1m30s") {
+		t.Errorf("web rollout was not awaited with the timeout:\n%s", calls)
+	}
+}
+
+func TestRestartDeploymentsDryRun(t *testing.T) {
+	log := fakeRestartKubectl(t)
+	h := NewHelper(false, true)
+
+	report := h.RestartDeployments([]string{"api"}, RestartOptions{Namespace: "prod"})
+	if !report.DryRun || report.Results[0].Status != RestartWould {
+		t.Errorf("report = %+v", report)
+	}
+	calls, _ := os.ReadFile(log)
+	if strings.Contains(string(calls), "rollout") {
+		t.Errorf("dry run called kubectl rollout:\n%s", calls)
+	}
+}
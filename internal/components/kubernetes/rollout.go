@@ -0,0 +1,182 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/textdiff"
+)
+
+// RolloutRevision is a recorded revision of a deployment.
+type RolloutRevision struct {
+	Revision    int    `json:"revision" yaml:"revision"`
+	ChangeCause string `json:"change_cause" yaml:"change_cause"`
+	Created     string `json:"created" yaml:"created"`
+	ReplicaSet  string `json:"replicaset" yaml:"replicaset"`
+}
+
+// RevisionDetail is the pod template of a revision, optionally compared
+// with another revision.
+type RevisionDetail struct {
+	Deployment string `json:"deployment" yaml:"deployment"`
+	Revision   int    `json:"revision" yaml:"revision"`
+	Template   string `json:"template" yaml:"template"`
+	CompareTo  int    `json:"compare_to,omitempty" yaml:"compare_to,omitempty"`
+	Identical  bool   `json:"identical,omitempty" yaml:"identical,omitempty"` // templates of Revision and CompareTo match
+	Diff       string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// RolloutHistory returns the revisions of a deployment, oldest first. The
+// revisions are read from the deployment's ReplicaSets, which also carry
+// their creation time and change-cause annotation.
+func (h *Helper) RolloutHistory(deployment, namespace string) ([]RolloutRevision, error) {
+	if err := h.deploymentExists(deployment, namespace); err != nil {
+		return nil, err
+	}
+
+	args := []string{"get", "replicasets", "-o", "json"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	out, err := exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replicasets: %w", err)
+	}
+
+	return ParseRolloutHistory(out, deployment)
+}
+
+// ParseRolloutHistory extracts a deployment's revisions from the JSON of
+// `kubectl get replicasets -o json`.
+func ParseRolloutHistory(data []byte, deployment string) ([]RolloutRevision, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name              string            `json:"name"`
+				CreationTimestamp string            `json:"creationTimestamp"`
+				Annotations       map[string]string `json:"annotations"`
+				OwnerReferences   []struct {
+					Kind string `json:"kind"`
+					Name string `json:"name"`
+				} `json:"ownerReferences"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse replicasets: %w", err)
+	}
+
+	revisions := []RolloutRevision{}
+	for _, rs := range list.Items {
+		owned := false
+		for _, ref := range rs.Metadata.OwnerReferences {
+			if ref.Kind == "Deployment" && ref.Name == deployment {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			continue
+		}
+
+		rev, err := strconv.Atoi(rs.Metadata.Annotations["deployment.kubernetes.io/revision"])
+		if err != nil {
+			continue
+		}
+
+		revisions = append(revisions, RolloutRevision{
+			Revision:    rev,
+			ChangeCause: rs.Metadata.Annotations["kubernetes.io/change-cause"],
+			Created:     rs.Metadata.CreationTimestamp,
+			ReplicaSet:  rs.Metadata.Name,
+		})
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// RevisionTemplate returns the pod template recorded for a revision.
+func (h *Helper) RevisionTemplate(deployment, namespace string, revision int) (*RevisionDetail, error) {
+	args := []string{"rollout", "history", "deployment/" + deployment, fmt.Sprintf("--revision=%d", revision)}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	cmd := exec.Command("kubectl", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to get revision %d of %s: %s", revision, deployment, msg)
+		}
+		return nil, fmt.Errorf("failed to get revision %d of %s: %w", revision, deployment, err)
+	}
+
+	return &RevisionDetail{
+		Deployment: deployment,
+		Revision:   revision,
+		Template:   string(out),
+	}, nil
+}
+
+// CompareRevisions returns the pod template of revision along with a
+// unified diff from the template of compareTo.
+func (h *Helper) CompareRevisions(deployment, namespace string, revision, compareTo int) (*RevisionDetail, error) {
+	detail, err := h.RevisionTemplate(deployment, namespace, revision)
+	if err != nil {
+		return nil, err
+	}
+	base, err := h.RevisionTemplate(deployment, namespace, compareTo)
+	if err != nil {
+		return nil, err
+	}
+
+	compareTemplates(detail, base)
+	return detail, nil
+}
+
+// compareTemplates records on detail whether its template matches base's,
+// ignoring the revision header line, and the unified diff when it does not.
+func compareTemplates(detail, base *RevisionDetail) {
+	detail.CompareTo = base.Revision
+	from, to := stripRevisionHeader(base.Template), stripRevisionHeader(detail.Template)
+	if from == to {
+		detail.Identical = true
+		return
+	}
+	detail.Diff = textdiff.Unified(
+		fmt.Sprintf("revision %d", base.Revision), from,
+		fmt.Sprintf("revision %d", detail.Revision), to,
+	)
+}
+
+// deploymentExists returns an error if the deployment cannot be found.
+func (h *Helper) deploymentExists(deployment, namespace string) error {
+	args := []string{"get", "deployment/" + deployment, "-o", "name"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+	if err := exec.Command("kubectl", args...).Run(); err != nil {
+		return fmt.Errorf("deployment %q not found", deployment)
+	}
+	return nil
+}
+
+// stripRevisionHeader drops the leading "deployment.apps/x with revision #N"
+// line so that templates of different revisions can be compared.
+func stripRevisionHeader(template string) string {
+	if first, rest, ok := strings.Cut(template, "\n"); ok && strings.Contains(first, "with revision #") {
+		return rest
+	}
+	return template
+}
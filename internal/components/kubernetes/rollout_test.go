@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRolloutHistory(t *testing.T) {
+	data := []byte(`{"items":[
+  {"metadata":{"name":"web-b","creationTimestamp":"2026-01-02T10:00:00Z",
+    "annotations":{"deployment.kubernetes.io/revision":"2","kubernetes.io/change-cause":"set image"},
+    "ownerReferences":[{"kind":"Deployment","name":"web"}]}},
+  {"metadata":{"name":"web-a","creationTimestamp":"2026-01-01T10:00:00Z",
+    "annotations":{"deployment.kubernetes.io/revision":"1"},
+    "ownerReferences":[{"kind":"Deployment","name":"web"}]}},
+  {"metadata":{"name":"api-a","creationTimestamp":"2026-01-01T10:00:00Z",
+    "annotations":{"deployment.kubernetes.io/revision":"1"},
+    "ownerReferences":[{"kind":"Deployment","name":"api"}]}},
+  {"metadata":{"name":"web-orphan","annotations":{}}}
+]}`)
+
+	got, err := ParseRolloutHistory(data, "web")
+	if err != nil {
+		t.Fatalf("ParseRolloutHistory: %v", err)
+	}
+	want := []RolloutRevision{
+		{Revision: 1, Created: "2026-01-01T10:00:00Z", ReplicaSet: "web-a"},
+		{Revision: 2, ChangeCause: "set image", Created: "2026-01-02T10:00:00Z", ReplicaSet: "web-b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = ParseRolloutHistory([]byte(`{"items":[]}`), "web")
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("empty: got %+v, %v", got, err)
+	}
+}
+
+func TestStripRevisionHeader(t *testing.T) {
+	in := "deployment.apps/web with revision #3\nPod Template:\n"
+	if got := stripRevisionHeader(in); got != "Pod Template:\n" {
+		t.Errorf("got %q", got)
+	}
+	if got := stripRevisionHeader("Pod Template:\n"); got != "Pod Template:\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestCompareTemplates(t *testing.T) {
+	base := &RevisionDetail{Revision: 2, Template: "deployment.apps/web with revision #2\nPod Template:\n  Image: web:1\n"}
+
+	same := &RevisionDetail{Revision: 3, Template: "deployment.apps/web with revision #3\nPod Template:\n  Image: web:1\n"}
+	compareTemplates(same, base)
+	if !same.Identical || same.Diff != "" || same.CompareTo != 2 {
+		t.Errorf("identical templates: got %+v", same)
+	}
+
+	changed := &RevisionDetail{Revision: 3, Template: "deployment.apps/web with revision #3\nPod Template:\n  Image: web:2\n"}
+	compareTemplates(changed, base)
+	if changed.Identical || !strings.Contains(changed.Diff, "-  Image: web:1\n+  Image: web:2\n") {
+		t.Errorf("changed templates: got %+v", changed)
+	}
+}
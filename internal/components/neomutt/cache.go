@@ -0,0 +1,161 @@
+package neomutt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/fsutil"
+)
+
+// Cache types stored under the cache directory.
+const (
+	CacheHeaders = "headers"
+	CacheBodies  = "bodies"
+)
+
+// CacheCleanOptions selects which cached data CleanCache removes.
+type CacheCleanOptions struct {
+	Account     string // only entries belonging to this account
+	HeadersOnly bool
+	BodiesOnly  bool
+}
+
+// CleanedPath is a cache path removed (or to be removed) by CleanCache.
+type CleanedPath struct {
+	Path  string `json:"path" yaml:"path"`
+	Type  string `json:"type" yaml:"type"`
+	Bytes int64  `json:"bytes" yaml:"bytes"`
+}
+
+// CacheCleanResult is the outcome of CleanCache.
+type CacheCleanResult struct {
+	Account    string        `json:"account,omitempty" yaml:"account,omitempty"`
+	Paths      []CleanedPath `json:"paths" yaml:"paths"`
+	TotalBytes int64         `json:"total_bytes" yaml:"total_bytes"`
+	DryRun     bool          `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+// CleanCache removes cached headers and message bodies. By default both
+// caches are wiped; opts can limit cleaning to one cache type and to the
+// entries of a single account.
+func (h *Helper) CleanCache(opts CacheCleanOptions) (*CacheCleanResult, error) {
+	if opts.HeadersOnly && opts.BodiesOnly {
+		return nil, fmt.Errorf("--headers-only and --bodies-only are mutually exclusive")
+	}
+
+	types := []string{CacheHeaders, CacheBodies}
+	if opts.HeadersOnly {
+		types = []string{CacheHeaders}
+	} else if opts.BodiesOnly {
+		types = []string{CacheBodies}
+	}
+
+	var keys []string
+	if opts.Account != "" {
+		var err error
+		if keys, err = h.accountCacheKeys(opts.Account); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CacheCleanResult{Account: opts.Account, Paths: []CleanedPath{}, DryRun: h.dryRun}
+	for _, typ := range types {
+		dir := filepath.Join(h.cacheDir, typ)
+
+		targets := []string{dir}
+		if opts.Account != "" {
+			targets = matchCacheEntries(dir, keys)
+		}
+
+		for _, target := range targets {
+			if _, err := os.Stat(target); os.IsNotExist(err) {
+				continue
+			}
+			size := fsutil.DirSize(target)
+
+			if !h.dryRun {
+				if err := os.RemoveAll(target); err != nil {
+					return result, fmt.Errorf("failed to clean %s cache: %w", typ, err)
+				}
+			}
+
+			result.Paths = append(result.Paths, CleanedPath{Path: target, Type: typ, Bytes: size})
+			result.TotalBytes += size
+		}
+
+		// Keep the cache directories in place for NeoMutt
+		if !h.dryRun {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return result, fmt.Errorf("failed to recreate %s cache: %w", typ, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// accountCacheKeys returns the identifiers used to recognize an account's
+// entries in the cache. The account may be given by name or email address.
+func (h *Helper) accountCacheKeys(name string) ([]string, error) {
+	accounts, err := h.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.Name != name && account.Email != name {
+			continue
+		}
+		if account.Email == "" {
+			return nil, fmt.Errorf("account %s has no email address configured", account.Name)
+		}
+		return []string{account.Email}, nil
+	}
+	return nil, fmt.Errorf("account not found: %s", name)
+}
+
+// matchCacheEntries returns the top-level entries of dir whose names contain
+// any of keys as a login. NeoMutt names cache entries after the mailbox URL,
+// e.g. "imaps:me@example.com@imap.example.com", so a key must start the name
+// or follow ':' or '/', and must end the name or be followed by "@host".
+func matchCacheEntries(dir string, keys []string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Name())
+		for _, key := range keys {
+			if containsLogin(name, strings.ToLower(key)) {
+				matches = append(matches, filepath.Join(dir, entry.Name()))
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// containsLogin reports whether login appears in name as a delimited token.
+func containsLogin(name, login string) bool {
+	if login == "" {
+		return false
+	}
+	for offset := 0; ; {
+		idx := strings.Index(name[offset:], login)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(login)
+		before := start == 0 || name[start-1] == ':' || name[start-1] == '/'
+		after := end == len(name) || name[end] == '@'
+		if before && after {
+			return true
+		}
+		offset = start + 1
+	}
+}
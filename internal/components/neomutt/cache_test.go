@@ -0,0 +1,84 @@
+package neomutt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanCacheAccount(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(root, "cache"))
+
+	accounts := filepath.Join(root, "config", "neomutt", "accounts")
+	if err := os.MkdirAll(accounts, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(accounts, "gmail-me.muttrc"), []byte(`set from = "me@example.com"`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(rel string, size int) {
+		path := filepath.Join(root, "cache", "neomutt", rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("headers/imaps:me@example.com@imap.example.com/INBOX", 100)
+	write("bodies/imaps:me@example.com@imap.example.com/1", 50)
+	write("bodies/imaps:other@example.com@imap.example.com/1", 10)
+	write("bodies/imaps:home@example.com@imap.example.com/1", 10)
+	write("bodies/imaps:someme@example.com@imap.example.com/1", 10)
+	write("bodies/imaps:me@example.com.au@imap.example.com/1", 10)
+
+	result, err := NewHelper(false, false).CleanCache(CacheCleanOptions{Account: "gmail-me", BodiesOnly: true})
+	if err != nil {
+		t.Fatalf("CleanCache: %v", err)
+	}
+	if len(result.Paths) != 1 || result.Paths[0].Type != CacheBodies || result.TotalBytes != 50 {
+		t.Errorf("result = %+v", result)
+	}
+
+	cache := filepath.Join(root, "cache", "neomutt")
+	for rel, want := range map[string]bool{
+		"headers/imaps:me@example.com@imap.example.com":    true,
+		"bodies/imaps:me@example.com@imap.example.com":     false,
+		"bodies/imaps:other@example.com@imap.example.com":  true,
+		"bodies/imaps:home@example.com@imap.example.com":   true,
+		"bodies/imaps:someme@example.com@imap.example.com": true,
+		"bodies/imaps:me@example.com.au@imap.example.com":  true,
+	} {
+		_, err := os.Stat(filepath.Join(cache, rel))
+		if exists := err == nil; exists != want {
+			t.Errorf("%s exists = %v, want %v", rel, exists, want)
+		}
+	}
+
+	if _, err := NewHelper(false, false).CleanCache(CacheCleanOptions{Account: "missing"}); err == nil {
+		t.Error("unknown account should fail")
+	}
+}
+
+func TestContainsLogin(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"imaps:me@example.com@imap.example.com", true},
+		{"imaps://me@example.com@imap.example.com", true},
+		{"me@example.com", true},
+		{"imaps:someme@example.com@imap.example.com", false},
+		{"imaps:home@example.com@imap.example.com", false},
+		{"imaps:me@example.company@imap.example.com", false},
+		{"imaps:home@example.com@x:me@example.com@imap.example.com", true},
+	}
+	for _, tt := range tests {
+		if got := containsLogin(tt.name, "me@example.com"); got != tt.want {
+			t.Errorf("containsLogin(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
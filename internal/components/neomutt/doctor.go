@@ -0,0 +1,366 @@
+package neomutt
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/component"
+	"github.com/mistergrinvalds/acorn/internal/utils/fsutil"
+	"github.com/mistergrinvalds/acorn/internal/utils/output"
+)
+
+// DefaultIMAPTimeout is how long Doctor waits for each IMAP server.
+const DefaultIMAPTimeout = 5 * time.Second
+
+// CacheSizeWarning is the cache size above which Doctor warns.
+const CacheSizeWarning int64 = 1 << 30
+
+// Doctor runs the installation, account, token, IMAP and cache checks in
+// one pass. Each IMAP server gets imapTimeout to accept a connection.
+func (h *Helper) Doctor(imapTimeout time.Duration) *component.DoctorReport {
+	report := component.NewDoctorReport()
+
+	status := h.GetStatus()
+	if status.Installed {
+		report.Add(component.DoctorCheck{Name: "neomutt", Status: component.CheckPass, Message: "installed " + status.Version})
+	} else {
+		report.Add(component.DoctorCheck{Name: "neomutt", Status: component.CheckFail, Message: "neomutt not found",
+			Hint: "run 'acorn mail neomutt install'"})
+	}
+
+	mainConfig := filepath.Join(h.configDir, "neomuttrc")
+	if _, err := os.Stat(mainConfig); err == nil {
+		report.Add(component.DoctorCheck{Name: "config", Status: component.CheckPass, Message: mainConfig})
+	} else {
+		report.Add(component.DoctorCheck{Name: "config", Status: component.CheckWarn, Message: "no neomuttrc in " + h.configDir,
+			Hint: "run 'acorn mail neomutt generate'"})
+	}
+
+	accounts, err := h.ListAccounts()
+	if err != nil {
+		report.Add(component.DoctorCheck{Name: "accounts", Status: component.CheckFail, Message: err.Error()})
+		accounts = nil
+	} else if len(accounts) == 0 {
+		report.Add(component.DoctorCheck{Name: "accounts", Status: component.CheckWarn, Message: "no accounts configured",
+			Hint: "run 'acorn mail neomutt accounts add gmail <email> <name>'"})
+	}
+
+	tokens, _ := h.GetTokenStatus()
+	tokenByAccount := map[string]TokenInfo{}
+	for _, t := range tokens {
+		tokenByAccount[t.Account] = t
+	}
+
+	oauth := false
+	for _, account := range accounts {
+		settings, err := parseAccountConfig(account.File)
+		if err != nil {
+			report.Add(component.DoctorCheck{Name: "account config", Account: account.Name, Status: component.CheckFail,
+				Message: err.Error(), Hint: "fix or regenerate " + account.File})
+			continue
+		}
+		report.Add(component.DoctorCheck{Name: "account config", Account: account.Name, Status: component.CheckPass, Message: account.File})
+
+		if account.Type == "gmail" || account.Type == "microsoft" {
+			oauth = true
+			for _, check := range tokenChecks(tokenByAccount[account.Name]) {
+				check.Account = account.Name
+				report.Add(check)
+			}
+		}
+
+		check := checkIMAP(settings["folder"], imapTimeout)
+		check.Account = account.Name
+		report.Add(check)
+	}
+
+	if oauth {
+		script := filepath.Join(h.configDir, "mutt_oauth2.py")
+		if _, err := os.Stat(script); err == nil {
+			report.Add(component.DoctorCheck{Name: "oauth2 script", Status: component.CheckPass, Message: script})
+		} else {
+			report.Add(component.DoctorCheck{Name: "oauth2 script", Status: component.CheckFail, Message: "missing " + script,
+				Hint: "download mutt_oauth2.py from the NeoMutt contrib directory"})
+		}
+	}
+
+	for _, typ := range []string{CacheHeaders, CacheBodies} {
+		report.Add(cacheCheck(filepath.Join(h.cacheDir, typ), typ))
+	}
+
+	return report
+}
+
+// parseAccountConfig reads the `set` assignments from an account muttrc,
+// failing on unreadable files and malformed assignments. Lines follow
+// muttrc syntax: quoted values, backslash escapes and continuations, #
+// comments and several commands separated by semicolons.
+func parseAccountConfig(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account config: %w", err)
+	}
+
+	settings := map[string]string{}
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimRight(lines[i], "\r")
+		for strings.HasSuffix(line, `\`) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimRight(lines[i], "\r")
+		}
+		if err := parseMuttrcLine(line, settings); err != nil {
+			return nil, fmt.Errorf("line %d: %w: %s", lineNo, err, strings.TrimSpace(line))
+		}
+	}
+	return settings, nil
+}
+
+// parseMuttrcLine records the variables assigned by the set commands on
+// line and skips every other command.
+func parseMuttrcLine(line string, settings map[string]string) error {
+	l := &muttrcLine{s: line}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.s) || l.s[l.pos] == '#' {
+			return nil
+		}
+		if l.s[l.pos] == ';' {
+			l.pos++
+			continue
+		}
+
+		command, err := l.token(false)
+		if err != nil {
+			return err
+		}
+		if command == "set" {
+			err = l.parseSet(settings)
+		} else {
+			err = l.skipCommand()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// muttrcLine scans the tokens of a single muttrc line.
+type muttrcLine struct {
+	s   string
+	pos int
+}
+
+func (l *muttrcLine) skipSpace() {
+	for l.pos < len(l.s) && (l.s[l.pos] == ' ' || l.s[l.pos] == '\t') {
+		l.pos++
+	}
+}
+
+// endOfCommand reports whether the current command has no more tokens.
+func (l *muttrcLine) endOfCommand() bool {
+	return l.pos >= len(l.s) || l.s[l.pos] == '#' || l.s[l.pos] == ';'
+}
+
+// token reads one word, resolving quotes and escapes. Backtick commands
+// are kept verbatim. With stopAtEqual, an unquoted = ends the word.
+func (l *muttrcLine) token(stopAtEqual bool) (string, error) {
+	var b strings.Builder
+	for l.pos < len(l.s) {
+		c := l.s[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '#' || c == ';' || (stopAtEqual && c == '='):
+			return b.String(), nil
+		case c == '\\':
+			l.pos++
+			if l.pos < len(l.s) {
+				b.WriteByte(l.s[l.pos])
+				l.pos++
+			}
+		case c == '"' || c == '\'' || c == '`':
+			end := l.pos + 1
+			for end < len(l.s) && l.s[end] != c {
+				if c == '"' && l.s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(l.s) {
+				return "", fmt.Errorf("unterminated quote")
+			}
+			switch c {
+			case '"':
+				for i := l.pos + 1; i < end; i++ {
+					if l.s[i] == '\\' {
+						i++
+					}
+					b.WriteByte(l.s[i])
+				}
+			case '\'':
+				b.WriteString(l.s[l.pos+1 : end])
+			default:
+				b.WriteString(l.s[l.pos : end+1])
+			}
+			l.pos = end + 1
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return b.String(), nil
+}
+
+// parseSet reads the arguments of a set command: name = value
+// assignments and bare boolean or query names, which are not recorded.
+func (l *muttrcLine) parseSet(settings map[string]string) error {
+	for {
+		l.skipSpace()
+		if l.endOfCommand() {
+			return nil
+		}
+
+		name, err := l.token(true)
+		if err != nil {
+			return err
+		}
+		l.skipSpace()
+		if l.pos >= len(l.s) || l.s[l.pos] != '=' {
+			continue
+		}
+		if name == "" {
+			return fmt.Errorf("malformed set command")
+		}
+
+		l.pos++
+		l.skipSpace()
+		value, err := l.token(false)
+		if err != nil {
+			return err
+		}
+		settings[name] = value
+	}
+}
+
+// skipCommand consumes the arguments of a command that is not parsed.
+func (l *muttrcLine) skipCommand() error {
+	for {
+		l.skipSpace()
+		if l.endOfCommand() {
+			return nil
+		}
+		if _, err := l.token(false); err != nil {
+			return err
+		}
+	}
+}
+
+// tokenChecks reports on an OAuth account's token file and its expiry.
+func tokenChecks(token TokenInfo) []component.DoctorCheck {
+	authorize := "run 'acorn mail neomutt tokens authorize " + token.Account + "'"
+	switch {
+	case token.TokenFile == "":
+		return []component.DoctorCheck{{Name: "token", Status: component.CheckFail,
+			Message: "no token file referenced by imap_oauth_refresh_command", Hint: "regenerate the account config"}}
+	case !token.Exists:
+		return []component.DoctorCheck{{Name: "token", Status: component.CheckFail, Message: "missing " + token.TokenFile, Hint: authorize}}
+	}
+
+	if token.Encrypted {
+		return []component.DoctorCheck{
+			{Name: "token", Status: component.CheckPass, Message: token.TokenFile + " (encrypted)"},
+			{Name: "token expiry", Status: component.CheckPass, Message: "not checked for encrypted tokens"},
+		}
+	}
+
+	checks := []component.DoctorCheck{{Name: "token", Status: component.CheckWarn, Message: token.TokenFile + " is not encrypted",
+		Hint: "configure a GPG identity in mutt_oauth2.py and re-authorize"}}
+	switch {
+	case token.Expires == nil:
+		checks = append(checks, component.DoctorCheck{Name: "token expiry", Status: component.CheckWarn, Message: "no expiration recorded"})
+	case !token.Expired:
+		checks = append(checks, component.DoctorCheck{Name: "token expiry", Status: component.CheckPass,
+			Message: "access token valid until " + token.Expires.Format(time.DateTime)})
+	case token.RefreshToken:
+		checks = append(checks, component.DoctorCheck{Name: "token expiry", Status: component.CheckPass,
+			Message: "access token expired, will be refreshed on next use"})
+	default:
+		checks = append(checks, component.DoctorCheck{Name: "token expiry", Status: component.CheckFail,
+			Message: "access token expired and no refresh token", Hint: authorize})
+	}
+	return checks
+}
+
+// checkIMAP connects to the server in an imap:// or imaps:// folder URL.
+func checkIMAP(folder string, timeout time.Duration) component.DoctorCheck {
+	check := component.DoctorCheck{Name: "imap"}
+	if folder == "" {
+		check.Status = component.CheckWarn
+		check.Message = "no IMAP folder configured"
+		return check
+	}
+
+	u, err := url.Parse(folder)
+	if err != nil || (u.Scheme != "imap" && u.Scheme != "imaps") || u.Hostname() == "" {
+		check.Status = component.CheckWarn
+		check.Message = "folder is not an IMAP URL: " + folder
+		return check
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "143"
+		if u.Scheme == "imaps" {
+			port = "993"
+		}
+	}
+	addr := net.JoinHostPort(u.Hostname(), port)
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if u.Scheme == "imaps" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: u.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		check.Status = component.CheckFail
+		check.Message = fmt.Sprintf("cannot connect to %s: %v", addr, err)
+		check.Hint = "check your network connection and the folder setting"
+		return check
+	}
+	conn.Close()
+
+	check.Status = component.CheckPass
+	check.Message = "connected to " + addr
+	return check
+}
+
+// cacheCheck verifies a cache directory exists and is not oversized.
+func cacheCheck(dir, typ string) component.DoctorCheck {
+	check := component.DoctorCheck{Name: typ + " cache"}
+	if _, err := os.Stat(dir); err != nil {
+		check.Status = component.CheckWarn
+		check.Message = "missing " + dir
+		check.Hint = "run 'acorn mail neomutt init'"
+		return check
+	}
+
+	size := fsutil.DirSize(dir)
+	if size > CacheSizeWarning {
+		check.Status = component.CheckWarn
+		check.Message = fmt.Sprintf("%s uses %s", dir, output.FormatBytes(size))
+		check.Hint = "run 'acorn mail neomutt cache clean'"
+		return check
+	}
+
+	check.Status = component.CheckPass
+	check.Message = fmt.Sprintf("%s (%s)", dir, output.FormatBytes(size))
+	return check
+}
@@ -0,0 +1,162 @@
+package neomutt
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/component"
+)
+
+func TestDoctor(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(root, "cache"))
+	t.Setenv("PATH", "")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	configDir := filepath.Join(root, "config", "neomutt")
+	tokenFile := filepath.Join(configDir, "gmail.me.tokens")
+	write := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(configDir, "accounts", "gmail-me.muttrc"),
+		"set from = \"me@example.com\"\n"+
+			"set imap_oauth_refresh_command = \"python3 mutt_oauth2.py "+tokenFile+"\"\n"+
+			"set folder = \"imap://"+ln.Addr().String()+"\"\n")
+	write(tokenFile, `{"access_token_expiration": "2000-01-01T00:00:00.000000"}`)
+	write(filepath.Join(configDir, "accounts", "broken.muttrc"), "set folder = \"imaps://x\n")
+	if err := os.MkdirAll(filepath.Join(root, "cache", "neomutt", CacheHeaders), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewHelper(false, false).Doctor(time.Second)
+
+	got := map[string]string{}
+	for _, c := range report.Checks {
+		got[c.Account+"/"+c.Name] = c.Status
+	}
+	want := map[string]string{
+		"/neomutt":                component.CheckFail,
+		"/config":                 component.CheckWarn,
+		"broken/account config":   component.CheckFail,
+		"gmail-me/account config": component.CheckPass,
+		"gmail-me/token":          component.CheckWarn,
+		"gmail-me/token expiry":   component.CheckFail,
+		"gmail-me/imap":           component.CheckPass,
+		"/oauth2 script":          component.CheckFail,
+		"/headers cache":          component.CheckPass,
+		"/bodies cache":           component.CheckWarn,
+	}
+	for key, status := range want {
+		if got[key] != status {
+			t.Errorf("%s = %q, want %q", key, got[key], status)
+		}
+	}
+	if report.Failed != 4 || report.Passed+report.Warnings+report.Failed != len(report.Checks) {
+		t.Errorf("counts = %d passed, %d warnings, %d failed", report.Passed, report.Warnings, report.Failed)
+	}
+}
+
+func TestCheckIMAPUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	if check := checkIMAP("imap://"+addr, time.Second); check.Status != component.CheckFail {
+		t.Errorf("closed port = %+v", check)
+	}
+	if check := checkIMAP("/var/mail/me", time.Second); check.Status != component.CheckWarn {
+		t.Errorf("local folder = %+v", check)
+	}
+}
+
+func TestParseAccountConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "spacing and quotes",
+			content: "set from = \"me@example.com\"\nset\trealname='Me Myself'\n  set   folder=imaps://imap.example.com/\n",
+			want:    map[string]string{"from": "me@example.com", "realname": "Me Myself", "folder": "imaps://imap.example.com/"},
+		},
+		{
+			name:    "comments",
+			content: "# set folder = \"imaps://old\"\nset folder = \"imaps://new\" # the new server\nset spoolfile = \"+INBOX\"#inline\n",
+			want:    map[string]string{"folder": "imaps://new", "spoolfile": "+INBOX"},
+		},
+		{
+			name:    "escapes and hashes in quotes",
+			content: `set status_format = "\"%f\" #%n"` + "\nset signature = 'a \\ b'\nset editor = vim\\ -c\\ startinsert\n",
+			want:    map[string]string{"status_format": `"%f" #%n`, "signature": `a \ b`, "editor": "vim -c startinsert"},
+		},
+		{
+			name: "several commands and assignments per line",
+			content: "set from = a@example.com realname = \"A\"; set folder = imaps://x\n" +
+				"mailboxes \"+INBOX\" \"+Sent Items\"; source ~/.muttrc.local\n",
+			want: map[string]string{"from": "a@example.com", "realname": "A", "folder": "imaps://x"},
+		},
+		{
+			name:    "booleans and queries",
+			content: "set sort_re\nset nomark_old ?folder &record\nunset record\n",
+			want:    map[string]string{},
+		},
+		{
+			name:    "continuation and backticks",
+			content: "set imap_pass = `pass show mail/me` \\\n  imap_user = me\r\nset folder = \\\n  \"imaps://x\"\n",
+			want:    map[string]string{"imap_pass": "`pass show mail/me`", "imap_user": "me", "folder": "imaps://x"},
+		},
+		{
+			name:    "unterminated quote",
+			content: "set from = \"me@example.com\"\nset folder = \"imaps://x\n",
+			wantErr: "line 2: unterminated quote",
+		},
+		{
+			name:    "missing name",
+			content: "set = imaps://x\n",
+			wantErr: "line 1: malformed set command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "account.muttrc")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseAccountConfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAccountConfig: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("settings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package neomutt
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/editor"
+)
+
+// AccountEditResult reports the outcome of editing an account config.
+// Problems lists directives that could be read before the edit but no
+// longer can.
+type AccountEditResult struct {
+	Account   string   `json:"account" yaml:"account"`
+	Path      string   `json:"path" yaml:"path"`
+	Changed   bool     `json:"changed" yaml:"changed"`
+	Email     string   `json:"email,omitempty" yaml:"email,omitempty"`
+	TokenFile string   `json:"token_file,omitempty" yaml:"token_file,omitempty"`
+	Problems  []string `json:"problems,omitempty" yaml:"problems,omitempty"`
+
+	session       *editor.Session
+	originalEmail string
+	originalToken string
+}
+
+// OK reports whether the edited config kept every directive it had.
+func (r *AccountEditResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// EditAccount opens the .muttrc of an account in the user's editor and,
+// once the editor exits, checks that its email and token file can still
+// be extracted. The account may be given by name or email address. Use
+// ReopenAccountEdit to fix reported problems.
+func (h *Helper) EditAccount(name string) (*AccountEditResult, error) {
+	account, err := h.findAccount(name)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := editor.Start(account.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account config: %w", err)
+	}
+
+	result := &AccountEditResult{
+		Account:       account.Name,
+		Path:          account.File,
+		session:       session,
+		originalEmail: extractEmailFromFile(account.File),
+		originalToken: extractTokenFileFromConfig(account.File),
+	}
+	if err := h.ReopenAccountEdit(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReopenAccountEdit opens the edited account config in the editor again
+// and re-checks it against the config before the first edit.
+func (h *Helper) ReopenAccountEdit(result *AccountEditResult) error {
+	after, err := result.session.Edit()
+	if err != nil {
+		return err
+	}
+	result.Changed = !bytes.Equal(result.session.Original(), after)
+	result.Email = extractEmailFromFile(result.Path)
+	result.TokenFile = extractTokenFileFromConfig(result.Path)
+
+	result.Problems = nil
+	if result.originalEmail != "" && result.Email == "" {
+		result.Problems = append(result.Problems,
+			"email address is missing (expected set from or set imap_user)")
+	}
+	if result.originalToken != "" && result.TokenFile == "" {
+		result.Problems = append(result.Problems,
+			"token file is missing (expected a .tokens path in imap_oauth_refresh_command)")
+	}
+	return nil
+}
+
+// findAccount looks up a configured account by name or email address.
+func (h *Helper) findAccount(name string) (*Account, error) {
+	accounts, err := h.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+	for i := range accounts {
+		if accounts[i].Name == name || accounts[i].Email == name {
+			return &accounts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("account not found: %s (see: acorn mail neomutt accounts)", name)
+}
@@ -0,0 +1,92 @@
+package neomutt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testAccountConfig = `set from = "me@example.com"
+set imap_oauth_refresh_command = "python3 ~/.config/neomutt/mutt_oauth2.py ~/.config/neomutt/me.tokens"
+`
+
+// setupEditAccount writes an account config and an $EDITOR stub that
+// replaces the edited file with content.
+func setupEditAccount(t *testing.T, content string) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(root, "cache"))
+
+	accounts := filepath.Join(root, "config", "neomutt", "accounts")
+	if err := os.MkdirAll(accounts, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(accounts, "gmail-me.muttrc")
+	if err := os.WriteFile(path, []byte(testAccountConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	replacement := filepath.Join(root, "replacement")
+	if err := os.WriteFile(replacement, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	editor := filepath.Join(root, "editor.sh")
+	script := "#!/bin/sh\ncp " + replacement + " \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", editor)
+	return path
+}
+
+func TestEditAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		changed  bool
+		problems int
+	}{
+		{"unchanged", testAccountConfig, false, 0},
+		{"still extractable", "set imap_user = 'me@example.com'\n" +
+			"set imap_oauth_refresh_command = \"oauth ~/.config/neomutt/me.tokens\"\n", true, 0},
+		{"email removed", "set imap_oauth_refresh_command = \"oauth ~/x/me.tokens\"\n", true, 1},
+		{"both removed", "set realname = \"Me\"\n", true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := setupEditAccount(t, tt.content)
+
+			result, err := NewHelper(false, false).EditAccount("gmail-me")
+			if err != nil {
+				t.Fatalf("EditAccount: %v", err)
+			}
+			if result.Path != path || result.Changed != tt.changed || len(result.Problems) != tt.problems {
+				t.Errorf("result = %+v", result)
+			}
+			if result.OK() != (tt.problems == 0) {
+				t.Errorf("OK() = %v with problems %v", result.OK(), result.Problems)
+			}
+		})
+	}
+}
+
+func TestEditAccountByEmail(t *testing.T) {
+	setupEditAccount(t, testAccountConfig)
+
+	result, err := NewHelper(false, false).EditAccount("me@example.com")
+	if err != nil {
+		t.Fatalf("EditAccount: %v", err)
+	}
+	if result.Account != "gmail-me" {
+		t.Errorf("Account = %q, want gmail-me", result.Account)
+	}
+}
+
+func TestEditAccountNotFound(t *testing.T) {
+	setupEditAccount(t, testAccountConfig)
+
+	if _, err := NewHelper(false, false).EditAccount("nobody"); err == nil {
+		t.Error("expected an error for an unknown account")
+	}
+}
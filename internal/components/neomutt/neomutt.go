@@ -2,11 +2,16 @@
 package neomutt
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/fsutil"
+	"github.com/mistergrinvalds/acorn/internal/utils/output"
 )
 
 // Status represents NeoMutt installation status.
@@ -28,12 +33,16 @@ type Account struct {
 	HasToken bool   `json:"has_token" yaml:"has_token"`
 }
 
-// TokenInfo represents OAuth2 token status.
+// TokenInfo represents OAuth2 token status. Expiry details are only known
+// for unencrypted token files.
 type TokenInfo struct {
-	Account   string `json:"account" yaml:"account"`
-	TokenFile string `json:"token_file" yaml:"token_file"`
-	Exists    bool   `json:"exists" yaml:"exists"`
-	Encrypted bool   `json:"encrypted" yaml:"encrypted"`
+	Account      string     `json:"account" yaml:"account"`
+	TokenFile    string     `json:"token_file" yaml:"token_file"`
+	Exists       bool       `json:"exists" yaml:"exists"`
+	Encrypted    bool       `json:"encrypted" yaml:"encrypted"`
+	Expires      *time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`
+	Expired      bool       `json:"expired" yaml:"expired"`
+	RefreshToken bool       `json:"refresh_token" yaml:"refresh_token"`
 }
 
 // CacheInfo represents cache directory information.
@@ -252,6 +261,8 @@ func (h *Helper) GetTokenStatus() ([]TokenInfo, error) {
 			data, _ := os.ReadFile(tokenFile)
 			if len(data) > 0 && !strings.HasPrefix(string(data), "{") {
 				info.Encrypted = true
+			} else {
+				readTokenExpiry(data, &info)
 			}
 		}
 
@@ -261,66 +272,41 @@ func (h *Helper) GetTokenStatus() ([]TokenInfo, error) {
 	return tokens, nil
 }
 
-// GetCacheInfo returns cache directory information.
-func (h *Helper) GetCacheInfo() *CacheInfo {
-	info := &CacheInfo{
-		HeaderCache:  filepath.Join(h.cacheDir, "headers"),
-		MessageCache: filepath.Join(h.cacheDir, "bodies"),
+// readTokenExpiry fills in expiry details from an unencrypted mutt_oauth2.py
+// token file, which stores the access token expiration as a local ISO 8601
+// timestamp.
+func readTokenExpiry(data []byte, info *TokenInfo) {
+	var token struct {
+		AccessTokenExpiration string `json:"access_token_expiration"`
+		RefreshToken          string `json:"refresh_token"`
 	}
-
-	info.HeaderSize = h.getDirSize(info.HeaderCache)
-	info.MessageSize = h.getDirSize(info.MessageCache)
-
-	return info
-}
-
-// getDirSize returns the size of a directory.
-func (h *Helper) getDirSize(dir string) string {
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return "0"
+	if err := json.Unmarshal(data, &token); err != nil {
+		return
 	}
 
-	cmd := exec.Command("du", "-sh", dir)
-	out, err := cmd.Output()
-	if err != nil {
-		return "unknown"
+	info.RefreshToken = token.RefreshToken != ""
+	if token.AccessTokenExpiration == "" {
+		return
 	}
-
-	parts := strings.Fields(string(out))
-	if len(parts) > 0 {
-		return parts[0]
+	expires, err := time.ParseInLocation("2006-01-02T15:04:05.999999", token.AccessTokenExpiration, time.Local)
+	if err != nil {
+		return
 	}
-	return "unknown"
+	info.Expires = &expires
+	info.Expired = time.Now().After(expires)
 }
 
-// CleanCache cleans the NeoMutt cache.
-func (h *Helper) CleanCache() error {
-	if h.dryRun {
-		fmt.Printf("[dry-run] would remove: %s\n", h.cacheDir)
-		return nil
-	}
-
-	// Remove header cache
-	headerCache := filepath.Join(h.cacheDir, "headers")
-	if err := os.RemoveAll(headerCache); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to clean header cache: %w", err)
-	}
-
-	// Remove message cache
-	messageCache := filepath.Join(h.cacheDir, "bodies")
-	if err := os.RemoveAll(messageCache); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to clean message cache: %w", err)
+// GetCacheInfo returns cache directory information.
+func (h *Helper) GetCacheInfo() *CacheInfo {
+	info := &CacheInfo{
+		HeaderCache:  filepath.Join(h.cacheDir, "headers"),
+		MessageCache: filepath.Join(h.cacheDir, "bodies"),
 	}
 
-	// Recreate directories
-	if err := os.MkdirAll(headerCache, 0755); err != nil {
-		return fmt.Errorf("failed to recreate header cache: %w", err)
-	}
-	if err := os.MkdirAll(messageCache, 0755); err != nil {
-		return fmt.Errorf("failed to recreate message cache: %w", err)
-	}
+	info.HeaderSize = output.FormatBytes(fsutil.DirSize(info.HeaderCache))
+	info.MessageSize = output.FormatBytes(fsutil.DirSize(info.MessageCache))
 
-	return nil
+	return info
 }
 
 // Launch starts NeoMutt.
@@ -0,0 +1,186 @@
+package node
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/fsutil"
+	"github.com/mistergrinvalds/acorn/internal/utils/output"
+)
+
+// CleanOptions selects which node_modules directories to remove.
+type CleanOptions struct {
+	Root      string
+	OlderThan time.Duration // only directories not modified within this window
+	Except    []string      // globs matched against the project path and name
+	Workers   int           // concurrent removals (default: 4)
+}
+
+// CleanEntry is a node_modules directory selected for removal.
+type CleanEntry struct {
+	Path    string    `json:"path" yaml:"path"`
+	Bytes   int64     `json:"bytes" yaml:"bytes"`
+	Size    string    `json:"size" yaml:"size"`
+	ModTime time.Time `json:"mod_time" yaml:"mod_time"`
+	Status  string    `json:"status,omitempty" yaml:"status,omitempty"` // removed, would_remove, failed
+	Error   string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// CleanResult contains the result of removing node_modules directories.
+type CleanResult struct {
+	Root           string       `json:"root" yaml:"root"`
+	DryRun         bool         `json:"dry_run" yaml:"dry_run"`
+	Entries        []CleanEntry `json:"entries" yaml:"entries"`
+	Excluded       []string     `json:"excluded,omitempty" yaml:"excluded,omitempty"`
+	Removed        int          `json:"removed" yaml:"removed"`
+	Failed         int          `json:"failed" yaml:"failed"`
+	ReclaimedBytes int64        `json:"reclaimed_bytes" yaml:"reclaimed_bytes"`
+	Reclaimed      string       `json:"reclaimed" yaml:"reclaimed"`
+}
+
+// ParseAge parses an age like "30d", "2w" or any time.ParseDuration value.
+func ParseAge(s string) (time.Duration, error) {
+	if n, ok := strings.CutSuffix(s, "d"); ok {
+		if days, err := strconv.Atoi(n); err == nil && days >= 0 {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	}
+	if n, ok := strings.CutSuffix(s, "w"); ok {
+		if weeks, err := strconv.Atoi(n); err == nil && weeks >= 0 {
+			return time.Duration(weeks) * 7 * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 30d, 2w or 12h)", s)
+	}
+	return d, nil
+}
+
+// FindCleanTargets finds node_modules directories under opts.Root that match
+// the age and exclusion filters, with their sizes. Excluded project paths are
+// returned separately.
+func (h *Helper) FindCleanTargets(opts CleanOptions) (*CleanResult, error) {
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+	for _, pattern := range opts.Except {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid --except pattern %q: %w", pattern, err)
+		}
+	}
+
+	result := &CleanResult{Root: opts.Root, DryRun: h.dryRun, Entries: []CleanEntry{}}
+	cutoff := time.Now().Add(-opts.OlderThan)
+
+	err := filepath.WalkDir(opts.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable directories
+		}
+		if !d.IsDir() || d.Name() != "node_modules" {
+			return nil
+		}
+
+		if matchesAny(filepath.Dir(path), opts.Except) {
+			result.Excluded = append(result.Excluded, path)
+			return filepath.SkipDir
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if opts.OlderThan > 0 && info.ModTime().After(cutoff) {
+			return filepath.SkipDir
+		}
+
+		result.Entries = append(result.Entries, CleanEntry{Path: path, ModTime: info.ModTime()})
+		return filepath.SkipDir // Don't recurse into node_modules
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	forEach(result.Entries, opts.Workers, func(e *CleanEntry) {
+		e.Bytes = fsutil.DirSize(e.Path)
+		e.Size = output.FormatBytes(e.Bytes)
+	})
+
+	return result, nil
+}
+
+// RemoveCleanTargets removes the directories found by FindCleanTargets using
+// a bounded pool of workers, recording a status for each entry.
+func (h *Helper) RemoveCleanTargets(result *CleanResult, workers int) {
+	forEach(result.Entries, workers, func(e *CleanEntry) {
+		if h.dryRun {
+			e.Status = "would_remove"
+			return
+		}
+		if h.verbose {
+			fmt.Printf("Removing %s\n", e.Path)
+		}
+		if err := os.RemoveAll(e.Path); err != nil {
+			e.Status = "failed"
+			e.Error = err.Error()
+			return
+		}
+		e.Status = "removed"
+	})
+
+	result.Removed, result.Failed, result.ReclaimedBytes = 0, 0, 0
+	for _, e := range result.Entries {
+		switch e.Status {
+		case "removed", "would_remove":
+			result.Removed++
+			result.ReclaimedBytes += e.Bytes
+		case "failed":
+			result.Failed++
+		}
+	}
+	result.Reclaimed = output.FormatBytes(result.ReclaimedBytes)
+}
+
+// forEach runs fn on every entry with at most workers goroutines.
+func forEach(entries []CleanEntry, workers int, fn func(*CleanEntry)) {
+	if workers <= 0 {
+		workers = 4
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(entries)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(&entries[i])
+			}
+		}()
+	}
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// matchesAny reports whether a project directory matches any glob, either by
+// its full path or by its base name.
+func matchesAny(projectDir string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, projectDir); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, filepath.Base(projectDir)); ok {
+			return true
+		}
+	}
+	return false
+}
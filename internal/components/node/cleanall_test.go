@@ -0,0 +1,96 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	}
+	for in, want := range tests {
+		got, err := ParseAge(in)
+		if err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "-1h", "xd"} {
+		if _, err := ParseAge(in); err == nil {
+			t.Errorf("ParseAge(%q) should fail", in)
+		}
+	}
+}
+
+func TestCleanTargets(t *testing.T) {
+	root := t.TempDir()
+	mk := func(project string, age time.Duration) string {
+		t.Helper()
+		dir := filepath.Join(root, project, "node_modules")
+		if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "pkg", "index.js"), make([]byte, 2048), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := time.Now().Add(-age)
+		if err := os.Chtimes(dir, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	old := mk("old", 60*24*time.Hour)
+	recent := mk("recent", time.Hour)
+	kept := mk("keep-me", 60*24*time.Hour)
+
+	opts := CleanOptions{Root: root, OlderThan: 30 * 24 * time.Hour, Except: []string{"keep-*"}}
+
+	dry := NewHelper(false, true)
+	result, err := dry.FindCleanTargets(opts)
+	if err != nil {
+		t.Fatalf("FindCleanTargets: %v", err)
+	}
+	if len(result.Entries) != 1 || result.Entries[0].Path != old {
+		t.Fatalf("entries = %+v, want only %s", result.Entries, old)
+	}
+	if result.Entries[0].Bytes != 2048 {
+		t.Errorf("bytes = %d, want 2048", result.Entries[0].Bytes)
+	}
+	if len(result.Excluded) != 1 || result.Excluded[0] != kept {
+		t.Errorf("excluded = %v, want %s", result.Excluded, kept)
+	}
+
+	dry.RemoveCleanTargets(result, 2)
+	if result.Entries[0].Status != "would_remove" || result.Removed != 1 {
+		t.Errorf("dry-run result = %+v", result)
+	}
+	if _, err := os.Stat(old); err != nil {
+		t.Fatal("dry-run should not remove directories")
+	}
+
+	h := NewHelper(false, false)
+	result, err = h.FindCleanTargets(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.RemoveCleanTargets(result, 2)
+	if result.Removed != 1 || result.ReclaimedBytes != 2048 {
+		t.Errorf("result = %+v", result)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("old node_modules should be removed")
+	}
+	for _, dir := range []string{recent, kept} {
+		if _, err := os.Stat(dir); err != nil {
+			t.Errorf("%s should be kept", dir)
+		}
+	}
+
+	if _, err := h.FindCleanTargets(CleanOptions{Root: root, Except: []string{"["}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
@@ -209,38 +209,6 @@ func (h *Helper) CleanNodeModules() error {
 	return cmd.Run()
 }
 
-// CleanAllNodeModules removes all node_modules in directory tree.
-func (h *Helper) CleanAllNodeModules(root string, force bool) (int, error) {
-	modules, err := h.FindNodeModules(root)
-	if err != nil {
-		return 0, err
-	}
-
-	if len(modules) == 0 {
-		return 0, nil
-	}
-
-	if !force {
-		return len(modules), fmt.Errorf("found %d node_modules directories, use --force to remove", len(modules))
-	}
-
-	if h.dryRun {
-		for _, m := range modules {
-			fmt.Printf("[dry-run] would remove %s\n", m.Path)
-		}
-		return len(modules), nil
-	}
-
-	count := 0
-	for _, m := range modules {
-		if err := os.RemoveAll(m.Path); err == nil {
-			count++
-		}
-	}
-
-	return count, nil
-}
-
 // GetNvmVersions returns installed NVM versions.
 func (h *Helper) GetNvmVersions() ([]string, error) {
 	nvmDir := h.GetNvmDir()
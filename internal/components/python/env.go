@@ -0,0 +1,88 @@
+package python
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ProjectInfo describes the Python project enclosing a directory.
+type ProjectInfo struct {
+	Dir       string `json:"dir" yaml:"dir"`
+	Pyproject bool   `json:"pyproject" yaml:"pyproject"`
+	UVLock    bool   `json:"uv_lock" yaml:"uv_lock"`
+	UVManaged bool   `json:"uv_managed" yaml:"uv_managed"`
+}
+
+// FindProject walks up from dir to the nearest directory containing a
+// pyproject.toml or uv.lock. A project is UV-managed when it has a uv.lock.
+// It returns nil when dir is not inside a project.
+func FindProject(dir string) *ProjectInfo {
+	for {
+		project := &ProjectInfo{Dir: dir}
+		if _, err := os.Stat(filepath.Join(dir, "pyproject.toml")); err == nil {
+			project.Pyproject = true
+		}
+		if _, err := os.Stat(filepath.Join(dir, "uv.lock")); err == nil {
+			project.UVLock = true
+		}
+		if project.Pyproject || project.UVLock {
+			project.UVManaged = project.UVLock
+			return project
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// countPackages returns the number of distributions installed for the
+// given interpreter, or 0 when it cannot be queried.
+func countPackages(python string) int {
+	out, err := exec.Command(python, "-c",
+		"import importlib.metadata as m; print(len(list(m.distributions())))").Output()
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// uvCacheDir returns UV's cache directory: UV_CACHE_DIR when set, otherwise
+// what uv reports, falling back to the XDG default when it exists.
+func uvCacheDir(hasUV bool) string {
+	if dir := os.Getenv("UV_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	if hasUV {
+		if out, err := exec.Command("uv", "cache", "dir").Output(); err == nil {
+			if dir := strings.TrimSpace(string(out)); dir != "" {
+				return dir
+			}
+		}
+	}
+
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		cacheHome = filepath.Join(os.Getenv("HOME"), ".cache")
+	}
+	dir := filepath.Join(cacheHome, "uv")
+	if _, err := os.Stat(dir); err != nil {
+		return ""
+	}
+	return dir
+}
+
+// isWithin reports whether path is inside dir.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,38 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProject(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindProject(nested); got != nil && got.Dir == root {
+		t.Fatalf("FindProject without markers = %+v", got)
+	}
+
+	os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte("[project]\n"), 0o644)
+	got := FindProject(nested)
+	if got == nil || got.Dir != root || !got.Pyproject || got.UVManaged {
+		t.Errorf("pyproject only = %+v", got)
+	}
+
+	os.WriteFile(filepath.Join(root, "uv.lock"), []byte("version = 1\n"), 0o644)
+	if got := FindProject(nested); got == nil || !got.UVLock || !got.UVManaged {
+		t.Errorf("with uv.lock = %+v", got)
+	}
+}
+
+func TestIsWithin(t *testing.T) {
+	if !isWithin("/home/u/.venv/bin/python3", "/home/u/.venv") {
+		t.Error("venv python should be within the venv")
+	}
+	if isWithin("/usr/bin/python3", "/home/u/.venv") || isWithin("/home/u/.venv2/bin/python3", "/home/u/.venv") {
+		t.Error("python outside the venv reported as within")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/fsutil"
 )
 
 // VenvInfo contains virtual environment information.
@@ -20,12 +22,19 @@ type VenvInfo struct {
 
 // EnvInfo contains Python environment information.
 type EnvInfo struct {
-	Python       string `json:"python" yaml:"python"`
-	Version      string `json:"version" yaml:"version"`
-	Pip          string `json:"pip,omitempty" yaml:"pip,omitempty"`
-	UV           string `json:"uv,omitempty" yaml:"uv,omitempty"`
-	VirtualEnv   string `json:"virtual_env,omitempty" yaml:"virtual_env,omitempty"`
-	EnvsLocation string `json:"envs_location,omitempty" yaml:"envs_location,omitempty"`
+	Python       string       `json:"python" yaml:"python"`
+	PythonPath   string       `json:"python_path,omitempty" yaml:"python_path,omitempty"`
+	Version      string       `json:"version" yaml:"version"`
+	Pip          string       `json:"pip,omitempty" yaml:"pip,omitempty"`
+	UV           string       `json:"uv,omitempty" yaml:"uv,omitempty"`
+	VirtualEnv   string       `json:"virtual_env,omitempty" yaml:"virtual_env,omitempty"`
+	VenvActive   bool         `json:"venv_active" yaml:"venv_active"`
+	EnvsLocation string       `json:"envs_location,omitempty" yaml:"envs_location,omitempty"`
+	Packages     int          `json:"packages" yaml:"packages"`
+	Project      *ProjectInfo `json:"project,omitempty" yaml:"project,omitempty"`
+	UVCacheDir   string       `json:"uv_cache_dir,omitempty" yaml:"uv_cache_dir,omitempty"`
+	UVCacheSize  int64        `json:"uv_cache_size" yaml:"uv_cache_size"`
+	Warnings     []string     `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 }
 
 // FastAPIDeps are the FastAPI development dependencies.
@@ -221,14 +230,33 @@ func (h *Helper) SetupDevTools() error {
 	return h.InstallPackages(DevToolsDeps)
 }
 
-// GetEnvInfo returns Python environment information.
+// GetEnvInfo returns Python environment information: the python3 found on
+// PATH, the active virtualenv, the enclosing project and the UV cache.
 func (h *Helper) GetEnvInfo() *EnvInfo {
 	info := &EnvInfo{}
 
-	// Python version
-	if out, err := exec.Command("python3", "--version").Output(); err == nil {
+	// Active virtual environment
+	info.VirtualEnv = os.Getenv("VIRTUAL_ENV")
+	info.VenvActive = info.VirtualEnv != ""
+
+	// Python that runs as python3, and its version
+	if path, err := exec.LookPath("python3"); err == nil {
 		info.Python = "python3"
-		info.Version = strings.TrimSpace(string(out))
+		info.PythonPath = path
+		if out, err := exec.Command(path, "--version").Output(); err == nil {
+			info.Version = strings.TrimSpace(string(out))
+		}
+		info.Packages = countPackages(path)
+	}
+	if info.VenvActive && info.PythonPath != "" && !isWithin(info.PythonPath, info.VirtualEnv) {
+		info.Warnings = append(info.Warnings,
+			fmt.Sprintf("python3 resolves to %s, outside the active virtualenv %s", info.PythonPath, info.VirtualEnv))
+	}
+	if info.VenvActive {
+		if _, err := os.Stat(info.VirtualEnv); err != nil {
+			info.Warnings = append(info.Warnings,
+				fmt.Sprintf("VIRTUAL_ENV points to a missing directory: %s", info.VirtualEnv))
+		}
 	}
 
 	// Pip version
@@ -239,13 +267,19 @@ func (h *Helper) GetEnvInfo() *EnvInfo {
 		}
 	}
 
-	// UV version
+	// UV version and cache
 	if out, err := exec.Command("uv", "--version").Output(); err == nil {
 		info.UV = strings.TrimSpace(string(out))
 	}
+	info.UVCacheDir = uvCacheDir(info.UV != "")
+	if info.UVCacheDir != "" {
+		info.UVCacheSize = fsutil.DirSize(info.UVCacheDir)
+	}
 
-	// Active virtual environment
-	info.VirtualEnv = os.Getenv("VIRTUAL_ENV")
+	// Enclosing project
+	if cwd, err := os.Getwd(); err == nil {
+		info.Project = FindProject(cwd)
+	}
 
 	// Envs location
 	info.EnvsLocation = os.Getenv("ENVS_LOCATION")
@@ -3,11 +3,14 @@ package secrets
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Status represents the status of the secrets file.
@@ -90,8 +93,7 @@ func (h *Helper) GetStatus() (*Status, error) {
 	keyCount := 0
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) > 0 && line[0] >= 'A' && line[0] <= 'Z' && strings.Contains(line, "=") {
+		if _, _, ok := parseAssignment(scanner.Text()); ok {
 			keyCount++
 		}
 	}
@@ -123,12 +125,8 @@ func (h *Helper) ListSecrets() ([]string, error) {
 	var keys []string
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if len(line) > 0 && line[0] >= 'A' && line[0] <= 'Z' {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) >= 1 {
-				keys = append(keys, parts[0])
-			}
+		if key, _, ok := parseAssignment(scanner.Text()); ok {
+			keys = append(keys, key)
 		}
 	}
 
@@ -153,22 +151,14 @@ func (h *Helper) LoadSecrets() (int, error) {
 	loaded := 0
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		// Skip empty lines and comments
-		if len(line) == 0 || line[0] == '#' {
+		key, value, ok := parseAssignment(scanner.Text())
+		if !ok {
 			continue
 		}
-		// Parse KEY=value
-		if idx := strings.Index(line, "="); idx > 0 {
-			key := line[:idx]
-			value := line[idx+1:]
-			// Remove quotes if present
-			value = strings.Trim(value, "\"'")
-			if err := os.Setenv(key, value); err == nil {
-				loaded++
-				if h.verbose {
-					fmt.Printf("Loaded: %s\n", key)
-				}
+		if err := os.Setenv(key, value); err == nil {
+			loaded++
+			if h.verbose {
+				fmt.Printf("Loaded: %s\n", key)
 			}
 		}
 	}
@@ -304,3 +294,336 @@ func (h *Helper) CreateSecretsFile() error {
 
 	return nil
 }
+
+// SecretMetadata tracks non-secret information about a key.
+type SecretMetadata struct {
+	SetAt time.Time `json:"set_at" yaml:"set_at"`
+}
+
+// SetResult contains the result of a set or unset operation.
+type SetResult struct {
+	Key      string `json:"key" yaml:"key"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Action   string `json:"action" yaml:"action"` // "added", "updated", "removed", "not_found"
+}
+
+var keyNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// safeValuePattern matches values that need no quoting in a dotenv file.
+var safeValuePattern = regexp.MustCompile(`^[A-Za-z0-9_./:@+,=%-]*$`)
+
+// ValidKeyName reports whether key is a valid environment variable name.
+func ValidKeyName(key string) bool {
+	return keyNamePattern.MatchString(key)
+}
+
+// GetMetadataFile returns the path to the sidecar metadata file.
+func (h *Helper) GetMetadataFile() string {
+	return filepath.Join(h.secretsDir, ".env.meta.json")
+}
+
+// LoadMetadata reads the sidecar metadata file. A missing file yields an empty map.
+func (h *Helper) LoadMetadata() (map[string]SecretMetadata, error) {
+	meta := make(map[string]SecretMetadata)
+
+	data, err := os.ReadFile(h.GetMetadataFile())
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read metadata file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("cannot parse metadata file: %w", err)
+	}
+	return meta, nil
+}
+
+// saveMetadata writes the sidecar metadata file with secure permissions.
+func (h *Helper) saveMetadata(meta map[string]SecretMetadata) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileSecure(h.GetMetadataFile(), append(data, '\n'))
+}
+
+// SetSecret sets a key in the secrets file, preserving comments and ordering.
+// Existing keys are updated in place, keeping any export keyword; new keys
+// are appended. The file is
+// written with 0600 permissions and the set time is recorded in the metadata.
+func (h *Helper) SetSecret(key, value string) (*SetResult, error) {
+	if !ValidKeyName(key) {
+		return nil, fmt.Errorf("invalid key name: %q (must match [A-Za-z_][A-Za-z0-9_]*)", key)
+	}
+	if strings.ContainsAny(value, "\n\r") {
+		return nil, fmt.Errorf("secret values cannot contain newlines")
+	}
+
+	if err := h.EnsureSecretsDir(); err != nil {
+		return nil, err
+	}
+
+	secretsFile := h.GetSecretsFile()
+	lines, err := readLines(secretsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SetResult{Key: key, FilePath: secretsFile, Action: "added"}
+	entry := key + "=" + quoteValue(value)
+
+	if idx := findKeyLine(lines, key); idx >= 0 {
+		lines[idx] = exportPrefix(lines[idx]) + entry
+		result.Action = "updated"
+	} else {
+		lines = append(lines, entry)
+	}
+
+	if err := writeFileSecure(secretsFile, []byte(strings.Join(lines, "\n")+"\n")); err != nil {
+		return nil, err
+	}
+
+	meta, err := h.LoadMetadata()
+	if err != nil {
+		return nil, err
+	}
+	meta[key] = SecretMetadata{SetAt: time.Now().UTC()}
+	if err := h.saveMetadata(meta); err != nil {
+		return nil, fmt.Errorf("secret saved but metadata update failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// DefaultRotateDays is the age after which rotate-check flags a secret.
+const DefaultRotateDays = 90
+
+// KeyAge reports how long ago a secret was last set.
+type KeyAge struct {
+	Key     string     `json:"key" yaml:"key"`
+	SetAt   *time.Time `json:"set_at,omitempty" yaml:"set_at,omitempty"`
+	AgeDays int        `json:"age_days" yaml:"age_days"`
+	Status  string     `json:"status" yaml:"status"` // "ok", "stale", "unknown"
+}
+
+// RotateReport contains the age of every key in the secrets file.
+type RotateReport struct {
+	MaxAgeDays int      `json:"max_age_days" yaml:"max_age_days"`
+	Keys       []KeyAge `json:"keys" yaml:"keys"`
+	Stale      int      `json:"stale" yaml:"stale"`
+	Unknown    int      `json:"unknown" yaml:"unknown"`
+}
+
+// RotateCheck reports the age of each secret from the set times recorded in
+// the metadata file. Keys older than maxAgeDays are stale; keys that were
+// never set through SetSecret have no recorded time and are unknown.
+func (h *Helper) RotateCheck(maxAgeDays int) (*RotateReport, error) {
+	keys, err := h.ListSecrets()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := h.LoadMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RotateReport{MaxAgeDays: maxAgeDays}
+	now := time.Now()
+	for _, key := range keys {
+		age := KeyAge{Key: key, Status: "unknown"}
+		if m, ok := meta[key]; ok && !m.SetAt.IsZero() {
+			setAt := m.SetAt
+			age.SetAt = &setAt
+			age.AgeDays = int(now.Sub(setAt).Hours() / 24)
+			age.Status = "ok"
+			if age.AgeDays > maxAgeDays {
+				age.Status = "stale"
+			}
+		}
+
+		switch age.Status {
+		case "stale":
+			report.Stale++
+		case "unknown":
+			report.Unknown++
+		}
+		report.Keys = append(report.Keys, age)
+	}
+
+	return report, nil
+}
+
+// UnsetSecret removes a key from the secrets file and its metadata.
+func (h *Helper) UnsetSecret(key string) (*SetResult, error) {
+	if !ValidKeyName(key) {
+		return nil, fmt.Errorf("invalid key name: %q (must match [A-Za-z_][A-Za-z0-9_]*)", key)
+	}
+
+	secretsFile := h.GetSecretsFile()
+	result := &SetResult{Key: key, FilePath: secretsFile, Action: "not_found"}
+
+	lines, err := readLines(secretsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := findKeyLine(lines, key)
+	if idx < 0 {
+		return result, nil
+	}
+
+	lines = append(lines[:idx], lines[idx+1:]...)
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	if err := writeFileSecure(secretsFile, []byte(content)); err != nil {
+		return nil, err
+	}
+	result.Action = "removed"
+
+	meta, err := h.LoadMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := meta[key]; ok {
+		delete(meta, key)
+		if err := h.saveMetadata(meta); err != nil {
+			return nil, fmt.Errorf("secret removed but metadata update failed: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// readLines reads a file into lines without the trailing newline.
+// A missing file yields no lines.
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read secrets file: %w", err)
+	}
+
+	content := strings.TrimSuffix(string(data), "\n")
+	if content == "" {
+		return nil, nil
+	}
+	return strings.Split(content, "\n"), nil
+}
+
+// findKeyLine returns the index of the line assigning key, or -1.
+// Matches both KEY=value and export KEY=value; commented lines are ignored.
+func findKeyLine(lines []string, key string) int {
+	for i, line := range lines {
+		if name, _, ok := parseAssignment(line); ok && name == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseAssignment splits a KEY=value or export KEY=value line. Comments,
+// blank lines and keys that are not valid names are rejected, so listing,
+// loading and setting all agree on which lines hold secrets.
+func parseAssignment(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "export ") {
+		line = strings.TrimLeft(line[len("export "):], " \t")
+	}
+	name, raw, found := strings.Cut(line, "=")
+	if !found || !ValidKeyName(name) {
+		return "", "", false
+	}
+	return name, unquoteValue(raw), true
+}
+
+// exportPrefix returns the "export " keyword, with its indentation, that
+// starts an assignment line, or "" when the line has none.
+func exportPrefix(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "export ") {
+		return ""
+	}
+	return line[:len(line)-len(trimmed)] + "export "
+}
+
+// valueEscaper escapes the characters that stay special inside double quotes.
+var valueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`")
+
+// quoteValue double-quotes a value unless it only contains safe characters.
+// Backslash escapes keep the result readable both by the shell and by
+// unquoteValue.
+func quoteValue(value string) string {
+	if safeValuePattern.MatchString(value) {
+		return value
+	}
+	return `"` + valueEscaper.Replace(value) + `"`
+}
+
+// unquoteValue parses the value side of a KEY=value line. Double-quoted
+// values honor backslash escapes, single-quoted values are literal, and
+// unquoted values end at a " #" comment.
+func unquoteValue(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	switch raw[0] {
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(raw); i++ {
+			c := raw[i]
+			if c == '"' {
+				break
+			}
+			if c == '\\' && i+1 < len(raw) && strings.IndexByte("\\\"$`", raw[i+1]) >= 0 {
+				i++
+				c = raw[i]
+			}
+			b.WriteByte(c)
+		}
+		return b.String()
+	case '\'':
+		value := raw[1:]
+		if end := strings.IndexByte(value, '\''); end >= 0 {
+			value = value[:end]
+		}
+		return value
+	}
+
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			return strings.TrimSpace(raw[:i])
+		}
+	}
+	return raw
+}
+
+// writeFileSecure atomically writes a file with 0600 permissions.
+func writeFileSecure(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot set permissions on %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,220 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetSecretPreservesLayout(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SECRETS_DIR", dir)
+	file := filepath.Join(dir, ".env")
+	if err := os.WriteFile(file, []byte("# comment\nFOO=1\n\nexport BAR=2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false)
+	res, err := h.SetSecret("BAR", "a b'c")
+	if err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+	if res.Action != "updated" {
+		t.Errorf("action = %q, want updated", res.Action)
+	}
+	if res, err = h.SetSecret("NEW", "hi"); err != nil || res.Action != "added" {
+		t.Fatalf("SetSecret NEW: %v %v", res, err)
+	}
+
+	data, _ := os.ReadFile(file)
+	want := "# comment\nFOO=1\n\nexport BAR=\"a b'c\"\nNEW=hi\n"
+	if string(data) != want {
+		t.Errorf("file =\n%s\nwant\n%s", data, want)
+	}
+
+	info, _ := os.Stat(file)
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	meta, err := h.LoadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta["BAR"].SetAt.IsZero() || meta["NEW"].SetAt.IsZero() {
+		t.Errorf("metadata not recorded: %+v", meta)
+	}
+}
+
+func TestSetLoadRoundTrip(t *testing.T) {
+	t.Setenv("SECRETS_DIR", t.TempDir())
+	h := NewHelper(false)
+
+	values := map[string]string{
+		"RT_SINGLE": "it's",
+		"RT_DOUBLE": `say "hi"`,
+		"RT_SPACES": "  padded value  ",
+		"RT_DOLLAR": "$HOME and ${USER}",
+		"RT_HASH":   "pass #word",
+		"RT_BACK":   "back\\slash `cmd`",
+		"RT_PLAIN":  "abc123",
+		"RT_EMPTY":  "",
+	}
+	for key, value := range values {
+		if _, err := h.SetSecret(key, value); err != nil {
+			t.Fatalf("SetSecret %s: %v", key, err)
+		}
+		t.Setenv(key, "")
+	}
+
+	if _, err := h.LoadSecrets(); err != nil {
+		t.Fatalf("LoadSecrets: %v", err)
+	}
+	for key, want := range values {
+		if got := os.Getenv(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestUnquoteValue(t *testing.T) {
+	tests := map[string]string{
+		`plain`:              "plain",
+		`plain # comment`:    "plain",
+		`pass#word`:          "pass#word",
+		`'single $x'`:        "single $x",
+		`"a \"b\" \$c"`:      `a "b" $c`,
+		`"keep \n"`:          `keep \n`,
+		`"quoted" # comment`: "quoted",
+		``:                   "",
+	}
+	for raw, want := range tests {
+		if got := unquoteValue(raw); got != want {
+			t.Errorf("unquoteValue(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestSetSecretKeepsExport(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SECRETS_DIR", dir)
+	file := filepath.Join(dir, ".env")
+	if err := os.WriteFile(file, []byte("export A=1\n  export B=2\nexport  C=3\nD=4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false)
+	for _, key := range []string{"A", "B", "C", "D"} {
+		if res, err := h.SetSecret(key, "x"); err != nil || res.Action != "updated" {
+			t.Fatalf("SetSecret %s: %v %v", key, res, err)
+		}
+	}
+
+	data, _ := os.ReadFile(file)
+	want := "export A=x\n  export B=x\nexport C=x\nD=x\n"
+	if string(data) != want {
+		t.Errorf("file =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestUnsetSecret(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SECRETS_DIR", dir)
+	h := NewHelper(false)
+
+	if _, err := h.SetSecret("FOO", "1"); err != nil {
+		t.Fatal(err)
+	}
+	res, err := h.UnsetSecret("FOO")
+	if err != nil || res.Action != "removed" {
+		t.Fatalf("UnsetSecret: %v %v", res, err)
+	}
+	res, err = h.UnsetSecret("FOO")
+	if err != nil || res.Action != "not_found" {
+		t.Fatalf("UnsetSecret again: %v %v", res, err)
+	}
+
+	meta, _ := h.LoadMetadata()
+	if _, ok := meta["FOO"]; ok {
+		t.Error("metadata still contains FOO")
+	}
+}
+
+func TestSetSecretInvalidKey(t *testing.T) {
+	t.Setenv("SECRETS_DIR", t.TempDir())
+	if _, err := NewHelper(false).SetSecret("1BAD", "x"); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
+
+func TestListSecretsMatchesSetGrammar(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SECRETS_DIR", dir)
+	content := "# COMMENTED=1\nexport EXPORTED=1\n_private=2\n1BAD=3\nnot a secret\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false)
+	if _, err := h.SetSecret("lower_key", "x"); err != nil {
+		t.Fatalf("SetSecret: %v", err)
+	}
+
+	keys, err := h.ListSecrets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"EXPORTED", "_private", "lower_key"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	status, err := h.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.KeyCount != len(want) {
+		t.Errorf("KeyCount = %d, want %d", status.KeyCount, len(want))
+	}
+}
+
+func TestRotateCheck(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SECRETS_DIR", dir)
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("FRESH=1\nOLD=2\nMANUAL=3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHelper(false)
+	meta := map[string]SecretMetadata{
+		"FRESH": {SetAt: time.Now().Add(-48 * time.Hour)},
+		"OLD":   {SetAt: time.Now().Add(-100 * 24 * time.Hour)},
+	}
+	if err := h.saveMetadata(meta); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := h.RotateCheck(90)
+	if err != nil {
+		t.Fatalf("RotateCheck: %v", err)
+	}
+	if report.Stale != 1 || report.Unknown != 1 {
+		t.Errorf("stale = %d, unknown = %d; want 1, 1", report.Stale, report.Unknown)
+	}
+
+	got := make(map[string]KeyAge)
+	for _, k := range report.Keys {
+		got[k.Key] = k
+	}
+	if k := got["FRESH"]; k.Status != "ok" || k.AgeDays != 2 {
+		t.Errorf("FRESH = %+v, want ok at 2 days", k)
+	}
+	if k := got["OLD"]; k.Status != "stale" || k.AgeDays != 100 {
+		t.Errorf("OLD = %+v, want stale at 100 days", k)
+	}
+	if k := got["MANUAL"]; k.Status != "unknown" || k.SetAt != nil {
+		t.Errorf("MANUAL = %+v, want unknown", k)
+	}
+}
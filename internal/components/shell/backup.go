@@ -0,0 +1,218 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultKeepBackups is how many generation backups are kept by default.
+const DefaultKeepBackups = 5
+
+// backupTimeFormat names backup directories so they sort chronologically.
+const backupTimeFormat = "20060102-150405"
+
+// BackupResult describes a snapshot of the generated shell scripts.
+type BackupResult struct {
+	Dir    string   `json:"dir,omitempty" yaml:"dir,omitempty"`
+	Files  []string `json:"files" yaml:"files"`
+	Pruned []string `json:"pruned,omitempty" yaml:"pruned,omitempty"`
+}
+
+// RestoreResult describes a restore of generated shell scripts from a backup.
+type RestoreResult struct {
+	Backup string   `json:"backup" yaml:"backup"`
+	Target string   `json:"target" yaml:"target"`
+	Files  []string `json:"files" yaml:"files"`
+	DryRun bool     `json:"dry_run" yaml:"dry_run"`
+}
+
+// BackupRoot returns the directory holding generation backups.
+func BackupRoot() string {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, _ := os.UserHomeDir()
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "acorn", "shell-backups")
+}
+
+// ListBackups returns the names of the available backups, oldest first.
+func ListBackups() ([]string, error) {
+	return listBackups(BackupRoot())
+}
+
+// BackupGenerated copies the current generated .sh scripts into a new
+// timestamped backup directory and prunes all but the newest keep backups.
+// A keep of zero or less keeps every backup. When there is nothing to back
+// up the result has no Dir.
+func (m *Manager) BackupGenerated(keep int) (*BackupResult, error) {
+	return backupScripts(m.getGeneratedShellDir(), BackupRoot(), keep, time.Now(), m.config.DryRun)
+}
+
+// RestoreBackup copies the scripts from the named backup ("latest" selects
+// the newest) back into the generated shell directory. Scripts generated
+// after the backup was taken are left in place.
+func (m *Manager) RestoreBackup(name string) (*RestoreResult, error) {
+	return restoreScripts(BackupRoot(), name, m.getGeneratedShellDir(), m.config.DryRun)
+}
+
+func backupScripts(srcDir, root string, keep int, now time.Time, dryRun bool) (*BackupResult, error) {
+	result := &BackupResult{Files: []string{}}
+
+	scripts, err := globScripts(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(scripts) == 0 {
+		return result, nil
+	}
+
+	dir := filepath.Join(root, now.Format(backupTimeFormat))
+	for i := 2; ; i++ {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			break
+		}
+		dir = filepath.Join(root, fmt.Sprintf("%s-%d", now.Format(backupTimeFormat), i))
+	}
+	result.Dir = dir
+
+	if !dryRun {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+	}
+	for _, src := range scripts {
+		name := filepath.Base(src)
+		if !dryRun {
+			if err := copyScript(src, filepath.Join(dir, name)); err != nil {
+				return nil, err
+			}
+		}
+		result.Files = append(result.Files, name)
+	}
+
+	if keep <= 0 {
+		return result, nil
+	}
+	existing, err := listBackups(root)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		existing = append(existing, filepath.Base(dir))
+	}
+	for len(existing) > keep {
+		old := filepath.Join(root, existing[0])
+		if !dryRun {
+			if err := os.RemoveAll(old); err != nil {
+				return nil, fmt.Errorf("failed to prune %s: %w", old, err)
+			}
+		}
+		result.Pruned = append(result.Pruned, old)
+		existing = existing[1:]
+	}
+
+	return result, nil
+}
+
+func restoreScripts(root, name, targetDir string, dryRun bool) (*RestoreResult, error) {
+	backups, err := listBackups(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("no backups found in %s", root)
+	}
+
+	if name == "latest" {
+		name = backups[len(backups)-1]
+	}
+	name = filepath.Base(name)
+	found := false
+	for _, b := range backups {
+		if b == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("backup not found: %s (available: %s)", name, strings.Join(backups, ", "))
+	}
+
+	dir := filepath.Join(root, name)
+	scripts, err := globScripts(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestoreResult{Backup: dir, Target: targetDir, Files: []string{}, DryRun: dryRun}
+	if !dryRun {
+		if err := os.MkdirAll(targetDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create generated directory: %w", err)
+		}
+	}
+	for _, src := range scripts {
+		base := filepath.Base(src)
+		if !dryRun {
+			if err := copyScript(src, filepath.Join(targetDir, base)); err != nil {
+				return nil, err
+			}
+		}
+		result.Files = append(result.Files, base)
+	}
+
+	return result, nil
+}
+
+// listBackups returns the backup directory names under root, oldest first.
+func listBackups(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// copyScript copies a generated script, keeping its permissions.
+func copyScript(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	if err := os.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	return nil
+}
+
+// globScripts returns the POSIX and fish scripts in dir.
+func globScripts(dir string) ([]string, error) {
+	var scripts []string
+	for _, pattern := range []string{"*.sh", "*.fish"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, matches...)
+	}
+	return scripts, nil
+}
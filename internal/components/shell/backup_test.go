@@ -0,0 +1,63 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBackupAndRestoreScripts(t *testing.T) {
+	gen := t.TempDir()
+	root := filepath.Join(t.TempDir(), "shell-backups")
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(gen, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	empty, err := backupScripts(gen, root, 2, time.Now(), false)
+	if err != nil || empty.Dir != "" {
+		t.Fatalf("empty backup = %+v, %v", empty, err)
+	}
+
+	write("go.sh", "v1")
+	write("shell.sh", "entry")
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if _, err := backupScripts(gen, root, 2, start.Add(time.Duration(i)*time.Minute), false); err != nil {
+			t.Fatalf("backup %d: %v", i, err)
+		}
+	}
+
+	backups, err := listBackups(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"20250101-120100", "20250101-120200"}; !reflect.DeepEqual(backups, want) {
+		t.Errorf("backups = %v, want %v (oldest pruned)", backups, want)
+	}
+
+	// Same timestamp gets a suffixed directory rather than overwriting
+	dup, err := backupScripts(gen, root, 0, start.Add(2*time.Minute), true)
+	if err != nil || filepath.Base(dup.Dir) != "20250101-120200-2" {
+		t.Errorf("duplicate backup dir = %+v, %v", dup, err)
+	}
+
+	write("go.sh", "broken")
+	result, err := restoreScripts(root, "latest", gen, false)
+	if err != nil {
+		t.Fatalf("restoreScripts: %v", err)
+	}
+	if !reflect.DeepEqual(result.Files, []string{"go.sh", "shell.sh"}) {
+		t.Errorf("restored = %v", result.Files)
+	}
+	if data, _ := os.ReadFile(filepath.Join(gen, "go.sh")); string(data) != "v1" {
+		t.Errorf("go.sh = %q, want v1", data)
+	}
+
+	if _, err := restoreScripts(root, "20240101-000000", gen, false); err == nil {
+		t.Error("unknown backup should fail")
+	}
+}
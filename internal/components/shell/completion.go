@@ -0,0 +1,104 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// completionTimeout bounds each step of a completion check, so a hanging
+// acorn or shell cannot stall generation.
+const completionTimeout = 10 * time.Second
+
+// CompletionCheck reports whether the completion script the entrypoint
+// evaluates can be generated and parsed by the shell.
+type CompletionCheck struct {
+	Shell  string `json:"shell" yaml:"shell"`
+	Binary string `json:"binary" yaml:"binary"`
+	Valid  bool   `json:"valid" yaml:"valid"`
+	// SyntaxChecked is false when the shell is not installed, so only
+	// generating the script was verified.
+	SyntaxChecked bool   `json:"syntax_checked" yaml:"syntax_checked"`
+	Error         string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// CompletionBinary returns the acorn the entrypoint will run: the one on
+// PATH, or else the running executable.
+func CompletionBinary() string {
+	if path, err := exec.LookPath("acorn"); err == nil {
+		return path
+	}
+	if exe, err := os.Executable(); err == nil {
+		return exe
+	}
+	return "acorn"
+}
+
+// CheckCompletions checks the completion script for the configured shell.
+func (m *Manager) CheckCompletions() *CompletionCheck {
+	return CheckCompletion(CompletionBinary(), m.config.Shell)
+}
+
+// CheckCompletion runs `binary completion <shell>` and parses its output
+// with `<shell> -n`, without executing it.
+func CheckCompletion(binary, shell string) *CompletionCheck {
+	check := &CompletionCheck{Shell: shell, Binary: binary}
+
+	script, err := runWithTimeout(nil, binary, "completion", shell)
+	if err != nil {
+		check.Error = fmt.Sprintf("acorn completion %s failed: %v", shell, err)
+		return check
+	}
+	if len(bytes.TrimSpace(script)) == 0 {
+		check.Error = fmt.Sprintf("acorn completion %s printed nothing", shell)
+		return check
+	}
+
+	shellPath, err := exec.LookPath(shell)
+	if err != nil {
+		check.Valid = true
+		return check
+	}
+	check.SyntaxChecked = true
+	if _, err := runWithTimeout(script, shellPath, "-n"); err != nil {
+		check.Error = fmt.Sprintf("%s cannot parse the completion script: %v", shell, err)
+		return check
+	}
+	check.Valid = true
+	return check
+}
+
+// runWithTimeout runs a command with optional stdin and returns its
+// stdout. Errors include the command's stderr.
+func runWithTimeout(stdin []byte, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out after %s", completionTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s", firstLine(msg))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
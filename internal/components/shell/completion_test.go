@@ -0,0 +1,105 @@
+package shell
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeCompletionStub writes an acorn stub that runs body for any command
+// and puts it first on PATH.
+func writeCompletionStub(t *testing.T, body string) string {
+	t.Helper()
+	dir := t.TempDir()
+	stub := filepath.Join(dir, "acorn")
+	if err := os.WriteFile(stub, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return stub
+}
+
+func TestCheckCompletion(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed")
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		valid   bool
+		wantErr string
+	}{
+		{"valid", `echo 'complete -F _acorn acorn'`, true, ""},
+		{"syntax error", `echo 'if then'`, false, "cannot parse"},
+		{"command fails", "echo 'unknown command' >&2; exit 1", false, "unknown command"},
+		{"empty output", "exit 0", false, "printed nothing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := writeCompletionStub(t, tt.body)
+			check := CheckCompletion(stub, "bash")
+			if check.Valid != tt.valid {
+				t.Fatalf("Valid = %v, want %v (error: %s)", check.Valid, tt.valid, check.Error)
+			}
+			if !strings.Contains(check.Error, tt.wantErr) {
+				t.Errorf("Error = %q, want it to contain %q", check.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckCompletionShellMissing(t *testing.T) {
+	stub := writeCompletionStub(t, `echo 'compdef _acorn acorn'`)
+	check := CheckCompletion(stub, "no-such-shell")
+	if !check.Valid || check.SyntaxChecked {
+		t.Errorf("missing shell: %+v", check)
+	}
+}
+
+func TestGenerateAllLintCompletions(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed")
+	}
+
+	tmp := t.TempDir()
+	saplingDir := filepath.Join(tmp, ".sapling")
+	t.Setenv("SAPLING_DIR", saplingDir)
+	if err := os.MkdirAll(filepath.Join(saplingDir, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{
+		AcornDir:        filepath.Join(tmp, "acorn"),
+		Shell:           "bash",
+		Platform:        "linux",
+		DryRun:          true,
+		LintCompletions: true,
+	}
+
+	writeCompletionStub(t, `echo 'if then'`)
+	result, err := NewManager(config).GenerateAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Completions == nil || result.Completions.Valid {
+		t.Fatalf("Completions = %+v, want a failed check", result.Completions)
+	}
+	if strings.Contains(result.Entrypoint.Content, "acorn completion") {
+		t.Error("entrypoint should not load completions that failed the check")
+	}
+
+	writeCompletionStub(t, `echo 'complete -F _acorn acorn'`)
+	result, err = NewManager(config).GenerateAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Completions == nil || !result.Completions.Valid {
+		t.Fatalf("Completions = %+v, want a passing check", result.Completions)
+	}
+	if !strings.Contains(result.Entrypoint.Content, "acorn completion bash 2>/dev/null") {
+		t.Error("entrypoint should load completions with errors suppressed")
+	}
+}
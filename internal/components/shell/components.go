@@ -33,6 +33,27 @@ func RegisterAllComponents(m *Manager) {
 	}
 }
 
+// RegisterComponents registers only the named components, in the same
+// order RegisterAllComponents would use. Names missing from the shell order
+// are registered after it.
+func RegisterComponents(m *Manager, names []string) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	for _, name := range GetComponentOrder() {
+		if wanted[name] {
+			registerComponentWithFiles(m, name)
+			delete(wanted, name)
+		}
+	}
+	for _, name := range names {
+		if wanted[name] {
+			registerComponentWithFiles(m, name)
+		}
+	}
+}
+
 // GetComponentOrder returns the ordered list of component names for shell generation.
 //
 // Resolution:
@@ -52,6 +73,20 @@ func GetComponentOrder() []string {
 	return result
 }
 
+// SetOrder sets the order the entrypoint sources components in, for
+// example GetComponentOrder sorted by dependencies.
+func (m *Manager) SetOrder(order []string) {
+	m.order = order
+}
+
+// componentOrder returns the entrypoint source order.
+func (m *Manager) componentOrder() []string {
+	if m.order != nil {
+		return m.order
+	}
+	return GetComponentOrder()
+}
+
 // registerComponentWithFiles loads and registers a component with its file specs.
 func registerComponentWithFiles(m *Manager, name string) {
 	result := loadComponentWithFiles(name)
@@ -0,0 +1,86 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/textdiff"
+)
+
+// ScriptDiff compares a freshly rendered script with the one installed in
+// the acorn dir.
+type ScriptDiff struct {
+	Component string `json:"component" yaml:"component"`
+	Installed string `json:"installed" yaml:"installed"`
+	InSync    bool   `json:"in_sync" yaml:"in_sync"`
+	Missing   bool   `json:"missing,omitempty" yaml:"missing,omitempty"` // nothing installed yet
+	DiffLines int    `json:"diff_lines" yaml:"diff_lines"`               // added plus removed lines
+	Diff      string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// DiffReport summarizes how the installed scripts differ from what
+// generation would produce now.
+type DiffReport struct {
+	Scripts []*ScriptDiff `json:"scripts" yaml:"scripts"`
+	Stale   int           `json:"stale" yaml:"stale"`
+}
+
+// Diff renders the scripts for the named components (every enabled one
+// plus the entrypoint when names is empty) in memory and compares each
+// with the file at its symlink path. Nothing is written.
+func (m *Manager) Diff(names ...string) (*DiffReport, error) {
+	entrypoint := len(names) == 0
+	if entrypoint {
+		names, _ = m.partitionEnabled()
+	}
+
+	report := &DiffReport{Scripts: []*ScriptDiff{}}
+	for _, name := range names {
+		script, err := m.RenderComponent(name)
+		if err != nil {
+			return nil, err
+		}
+		report.add(diffScript(name, script.SymlinkPath, script.Content))
+	}
+	if entrypoint {
+		path := filepath.Join(m.config.AcornDir, m.EntrypointName())
+		report.add(diffScript("shell", path, m.generateEntrypoint()))
+	}
+	return report, nil
+}
+
+// add appends a script diff and counts it if stale.
+func (r *DiffReport) add(d *ScriptDiff) {
+	r.Scripts = append(r.Scripts, d)
+	if !d.InSync {
+		r.Stale++
+	}
+}
+
+// diffScript compares content with the file installed at path, following
+// symlinks. A missing file is diffed as empty.
+func diffScript(component, path, content string) *ScriptDiff {
+	d := &ScriptDiff{Component: component, Installed: path}
+
+	installed, err := os.ReadFile(path)
+	if err != nil {
+		d.Missing = true
+	}
+	if err == nil && string(installed) == content {
+		d.InSync = true
+		return d
+	}
+
+	d.Diff = textdiff.Unified(path+" (installed)", string(installed), path+" (generated)", content)
+	d.DiffLines = textdiff.CountChanges(d.Diff)
+	return d
+}
+
+// Summary describes the report in one line.
+func (r *DiffReport) Summary() string {
+	if r.Stale == 0 {
+		return fmt.Sprintf("All %d scripts are in sync", len(r.Scripts))
+	}
+	return fmt.Sprintf("%d of %d scripts are stale", r.Stale, len(r.Scripts))
+}
@@ -0,0 +1,63 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	acornDir := t.TempDir()
+	manager := NewManager(&Config{AcornDir: acornDir, Shell: "bash"})
+	manager.RegisterComponent(&Component{Name: "go", Aliases: "alias gt='go test'\n"})
+	manager.RegisterComponent(&Component{Name: "git", Aliases: "alias gs='git status'\n"})
+	manager.SetOrder([]string{"git", "go"})
+
+	// go is current, git is stale and the entrypoint was never installed
+	current, err := manager.RenderComponent("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(acornDir, "go.sh"), []byte(current.Content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(acornDir, "git.sh"), []byte("alias gs='git status -sb'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := manager.Diff()
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(report.Scripts) != 3 || report.Stale != 2 {
+		t.Fatalf("report = %d scripts, %d stale", len(report.Scripts), report.Stale)
+	}
+
+	byName := make(map[string]*ScriptDiff)
+	for _, d := range report.Scripts {
+		byName[d.Component] = d
+	}
+	if d := byName["go"]; !d.InSync || d.Diff != "" || d.DiffLines != 0 {
+		t.Errorf("go = %+v, want in sync", d)
+	}
+	git := byName["git"]
+	if git.InSync || !strings.Contains(git.Diff, "-alias gs='git status -sb'") || !strings.Contains(git.Diff, "+alias gs='git status'") {
+		t.Errorf("git diff:\n%s", git.Diff)
+	}
+	if git.DiffLines < 2 {
+		t.Errorf("git DiffLines = %d", git.DiffLines)
+	}
+	if d := byName["shell"]; !d.Missing || d.InSync {
+		t.Errorf("entrypoint = %+v, want missing", d)
+	}
+
+	// Named components leave the entrypoint out
+	report, err = manager.Diff("go")
+	if err != nil || len(report.Scripts) != 1 || report.Stale != 0 {
+		t.Errorf("Diff(go) = %+v, %v", report, err)
+	}
+	if _, err := manager.Diff("bogus"); err == nil {
+		t.Error("unknown component should fail")
+	}
+}
@@ -0,0 +1,54 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/component"
+)
+
+// Doctor checks that the shell integration is generated and injected, and
+// that the completion script the entrypoint evaluates is valid.
+func (m *Manager) Doctor() *component.DoctorReport {
+	report := component.NewDoctorReport()
+
+	entrypoint := filepath.Join(m.config.AcornDir, m.EntrypointName())
+	if _, err := os.Stat(entrypoint); err == nil {
+		report.Add(component.DoctorCheck{Name: "entrypoint", Status: component.CheckPass, Message: entrypoint})
+	} else {
+		report.Add(component.DoctorCheck{Name: "entrypoint", Status: component.CheckFail,
+			Message: fmt.Sprintf("%s not found", entrypoint),
+			Hint:    "Run: acorn shell generate && acorn sync link"})
+	}
+
+	rcFile := m.GetRCFile()
+	if status, err := m.GetStatus(); err == nil && status.Injected {
+		report.Add(component.DoctorCheck{Name: "rc injection", Status: component.CheckPass, Message: rcFile})
+	} else {
+		report.Add(component.DoctorCheck{Name: "rc injection", Status: component.CheckWarn,
+			Message: fmt.Sprintf("%s does not source acorn", rcFile),
+			Hint:    "Run: acorn shell inject"})
+	}
+
+	report.Add(completionDoctorCheck(m.CheckCompletions()))
+	return report
+}
+
+// completionDoctorCheck turns a completion check into a doctor check.
+func completionDoctorCheck(c *CompletionCheck) component.DoctorCheck {
+	check := component.DoctorCheck{Name: "completions"}
+	switch {
+	case !c.Valid:
+		check.Status = component.CheckFail
+		check.Message = c.Error
+		check.Hint = "Regenerate with: acorn shell generate --lint-completions"
+	case !c.SyntaxChecked:
+		check.Status = component.CheckWarn
+		check.Message = fmt.Sprintf("%s completion script generated, but %s is not installed to parse it", c.Shell, c.Shell)
+	default:
+		check.Status = component.CheckPass
+		check.Message = fmt.Sprintf("%s completion script parses cleanly", c.Shell)
+	}
+	return check
+}
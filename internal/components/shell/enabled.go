@@ -0,0 +1,78 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnabledFile is the default enabled-components list, in the acorn config
+// directory.
+const EnabledFile = "enabled.txt"
+
+// LoadEnabledComponents reads an enabled-components list: one component
+// name per line, with blank lines and # comments ignored. A missing file
+// returns nil, meaning every component is enabled.
+func LoadEnabledComponents(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read enabled components: %w", err)
+	}
+	defer f.Close()
+
+	names := []string{}
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		name := strings.TrimSpace(line)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read enabled components: %w", err)
+	}
+	return names, nil
+}
+
+// SetEnabled restricts GenerateAll and the entrypoint to the named
+// components; everything else stays registered but is not generated.
+// It returns the names that are not registered components.
+func (m *Manager) SetEnabled(names []string) []string {
+	m.enabled = make(map[string]bool, len(names))
+	var unknown []string
+	for _, name := range names {
+		if _, ok := m.components[name]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		m.enabled[name] = true
+	}
+	return unknown
+}
+
+// isEnabled reports whether a component is generated by GenerateAll.
+func (m *Manager) isEnabled(name string) bool {
+	return m.enabled == nil || m.enabled[name]
+}
+
+// partitionEnabled splits the registered components into enabled and
+// disabled, both sorted.
+func (m *Manager) partitionEnabled() (enabled, disabled []string) {
+	enabled = []string{}
+	for _, name := range m.ListComponents() {
+		if m.isEnabled(name) {
+			enabled = append(enabled, name)
+		} else {
+			disabled = append(disabled, name)
+		}
+	}
+	return enabled, disabled
+}
@@ -0,0 +1,69 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadEnabledComponents(t *testing.T) {
+	dir := t.TempDir()
+
+	names, err := LoadEnabledComponents(filepath.Join(dir, "missing.txt"))
+	if err != nil || names != nil {
+		t.Errorf("missing file = %v, %v, want nil (all enabled)", names, err)
+	}
+
+	path := filepath.Join(dir, "enabled.txt")
+	content := "# core first\ncore\n\n  go  # languages\ngit\ngo\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	names, err = LoadEnabledComponents(path)
+	if err != nil {
+		t.Fatalf("LoadEnabledComponents failed: %v", err)
+	}
+	if want := []string{"core", "go", "git"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+
+	if err := os.WriteFile(path, []byte("# nothing enabled\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := LoadEnabledComponents(path); err != nil || names == nil || len(names) != 0 {
+		t.Errorf("empty list = %v, %v, want empty non-nil", names, err)
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	manager := NewManager(&Config{AcornDir: "/home/user/.config/acorn", Shell: "bash"})
+	for _, name := range []string{"core", "go", "python"} {
+		manager.RegisterComponent(&Component{Name: name})
+	}
+
+	enabled, disabled := manager.partitionEnabled()
+	if len(enabled) != 3 || disabled != nil {
+		t.Errorf("default = %v / %v, want all enabled", enabled, disabled)
+	}
+
+	unknown := manager.SetEnabled([]string{"core", "go", "bogus"})
+	if !reflect.DeepEqual(unknown, []string{"bogus"}) {
+		t.Errorf("unknown = %v, want [bogus]", unknown)
+	}
+
+	enabled, disabled = manager.partitionEnabled()
+	if !reflect.DeepEqual(enabled, []string{"core", "go"}) || !reflect.DeepEqual(disabled, []string{"python"}) {
+		t.Errorf("partition = %v / %v", enabled, disabled)
+	}
+
+	// Disabled components stay registered but are not sourced
+	if _, ok := manager.GetComponent("python"); !ok {
+		t.Error("disabled component should stay registered")
+	}
+	entrypoint := manager.generateEntrypoint()
+	if !strings.Contains(entrypoint, "go.sh") || strings.Contains(entrypoint, "python.sh") {
+		t.Errorf("entrypoint should source only enabled components:\n%s", entrypoint)
+	}
+}
@@ -0,0 +1,54 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterEnabled narrows the enabled set (see SetEnabled) to the components
+// named by only, if any, minus those named by exclude. Both lists must name
+// registered components. GenerateAll then reports the filtered-out
+// components as disabled and leaves them out of the entrypoint.
+func (m *Manager) FilterEnabled(only, exclude []string) error {
+	if err := m.checkComponentNames(append(append([]string{}, only...), exclude...)); err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(only))
+	for _, name := range only {
+		keep[name] = true
+	}
+	drop := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		drop[name] = true
+	}
+
+	enabled := make(map[string]bool)
+	for _, name := range m.ListComponents() {
+		if !m.isEnabled(name) || (len(only) > 0 && !keep[name]) || drop[name] {
+			continue
+		}
+		enabled[name] = true
+	}
+	if len(enabled) == 0 {
+		return fmt.Errorf("no enabled components left to generate after filtering")
+	}
+	m.enabled = enabled
+	return nil
+}
+
+// checkComponentNames returns an error listing every name that is not a
+// registered component, along with the available components.
+func (m *Manager) checkComponentNames(names []string) error {
+	var unknown []string
+	for _, name := range names {
+		if _, ok := m.components[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown component(s): %s (available: %s)",
+		strings.Join(unknown, ", "), strings.Join(m.ListComponents(), ", "))
+}
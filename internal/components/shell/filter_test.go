@@ -0,0 +1,80 @@
+package shell
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFilterEnabled(t *testing.T) {
+	newManager := func() *Manager {
+		manager := NewManager(&Config{AcornDir: "/home/user/.config/acorn", Shell: "bash"})
+		for _, name := range []string{"git", "go", "kubernetes", "python"} {
+			manager.RegisterComponent(&Component{Name: name})
+		}
+		return manager
+	}
+
+	tests := []struct {
+		name          string
+		enabled       []string
+		only, exclude []string
+		selected      []string
+		skipped       []string
+	}{
+		{"no filter", nil, nil, nil, []string{"git", "go", "kubernetes", "python"}, nil},
+		{"only", nil, []string{"python", "go"}, nil, []string{"go", "python"}, []string{"git", "kubernetes"}},
+		{"exclude", nil, nil, []string{"kubernetes"}, []string{"git", "go", "python"}, []string{"kubernetes"}},
+		{"exclude after only", nil, []string{"go", "python"}, []string{"python"}, []string{"go"}, []string{"git", "kubernetes", "python"}},
+		{"narrows enabled file", []string{"git", "go"}, nil, []string{"go"}, []string{"git"}, []string{"go", "kubernetes", "python"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newManager()
+			if tt.enabled != nil {
+				manager.SetEnabled(tt.enabled)
+			}
+			if err := manager.FilterEnabled(tt.only, tt.exclude); err != nil {
+				t.Fatalf("FilterEnabled failed: %v", err)
+			}
+			selected, skipped := manager.partitionEnabled()
+			if !reflect.DeepEqual(selected, tt.selected) || !reflect.DeepEqual(skipped, tt.skipped) {
+				t.Errorf("got %v / %v, want %v / %v", selected, skipped, tt.selected, tt.skipped)
+			}
+		})
+	}
+
+	manager := newManager()
+	err := manager.FilterEnabled([]string{"go", "rust"}, []string{"huggingface"})
+	if err == nil || !strings.Contains(err.Error(), "rust, huggingface") || !strings.Contains(err.Error(), "available: git, go, kubernetes, python") {
+		t.Errorf("unknown names: err = %v", err)
+	}
+	if err := newManager().FilterEnabled([]string{"go"}, []string{"go"}); err == nil {
+		t.Error("filtering out everything should fail")
+	}
+	manager = newManager()
+	manager.SetEnabled([]string{"git"})
+	if err := manager.FilterEnabled([]string{"go"}, nil); err == nil {
+		t.Error("--only naming a disabled component should leave nothing to generate")
+	}
+}
+
+func TestFilterEnabledEntrypoint(t *testing.T) {
+	manager := NewManager(&Config{AcornDir: "/home/user/.config/acorn", Shell: "bash"})
+	for _, name := range []string{"git", "go", "kubernetes"} {
+		manager.RegisterComponent(&Component{Name: name})
+	}
+	if err := manager.FilterEnabled(nil, []string{"kubernetes"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entrypoint := manager.generateEntrypoint()
+	for _, name := range []string{"git", "go"} {
+		if !strings.Contains(entrypoint, "$ACORN_CONFIG_DIR/"+name+".sh") {
+			t.Errorf("entrypoint should source %s.sh:\n%s", name, entrypoint)
+		}
+	}
+	if strings.Contains(entrypoint, "kubernetes.sh") {
+		t.Errorf("entrypoint still sources the excluded component:\n%s", entrypoint)
+	}
+}
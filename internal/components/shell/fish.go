@@ -0,0 +1,274 @@
+package shell
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/config"
+)
+
+// Fish cannot source the POSIX scripts, so components carry fish variants
+// of their environment, aliases and functions. Env, PATH, aliases and
+// wrappers are translated; raw shell functions need a hand-written
+// fish_functions entry and are left out otherwise.
+
+// bracedVar matches ${NAME}, which fish spells {$NAME}.
+var bracedVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// fishValue translates a POSIX double-quoted value for use in fish. It
+// reports false when the value uses a parameter expansion fish has no
+// spelling for, such as ${VAR:-default} or ${VAR#prefix}; fish refuses to
+// parse "${", so such values must be left out rather than passed through.
+func fishValue(v string) (string, bool) {
+	v = bracedVar.ReplaceAllString(v, "{$$$1}")
+	return v, !strings.Contains(v, "${")
+}
+
+// fishQuote single-quotes s for fish, which unlike POSIX allows \' and \\
+// escapes inside single quotes.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
+
+// generateFishEnvString generates the env section for fish.
+func (g *Generator) generateFishEnvString(cfg *config.BaseConfig) string {
+	var b strings.Builder
+
+	if len(cfg.Env) > 0 {
+		b.WriteString("# " + cfg.Name + " environment setup\n")
+		keys := make([]string, 0, len(cfg.Env))
+		for k := range cfg.Env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			v, ok := fishValue(cfg.Env[k])
+			if !ok {
+				b.WriteString(fmt.Sprintf("# %s skipped: unsupported parameter expansion in %q\n", k, cfg.Env[k]))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("set -gx %s \"%s\"\n", k, v))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(cfg.Paths) > 0 {
+		b.WriteString("# Add paths to PATH\n")
+		for _, p := range cfg.Paths {
+			if p.Condition != "" && p.Condition != g.platform {
+				continue
+			}
+
+			path, ok := fishValue(p.Path)
+			if !ok {
+				b.WriteString(fmt.Sprintf("# PATH entry skipped: unsupported parameter expansion in %q\n", p.Path))
+				continue
+			}
+			indent := ""
+			if p.Condition != "" {
+				b.WriteString(fmt.Sprintf("if test -d \"%s\"\n", path))
+				indent = "    "
+			}
+			b.WriteString(fmt.Sprintf("%sif not contains -- \"%s\" $PATH\n", indent, path))
+			b.WriteString(fmt.Sprintf("%s    set -gx PATH \"%s\" $PATH\n", indent, path))
+			b.WriteString(indent + "end\n")
+			if p.Condition != "" {
+				b.WriteString("end\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// generateFishAliasesString generates the aliases section for fish.
+func (g *Generator) generateFishAliasesString(aliases map[string]string) string {
+	if len(aliases) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	keys := make([]string, 0, len(aliases))
+	for k := range aliases {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		b.WriteString(fmt.Sprintf("alias %s %s\n", name, fishQuote(aliases[name])))
+	}
+	return b.String()
+}
+
+// generateFishFunctionsString generates wrappers and the fish_functions
+// variants as fish functions. Shell functions without a fish variant are
+// listed in a comment. As with POSIX scripts, functions prefixed with __
+// are called after definition.
+func (g *Generator) generateFishFunctionsString(cfg *config.BaseConfig) string {
+	var b strings.Builder
+	var initFunctions []string
+
+	for _, w := range cfg.Wrappers {
+		g.generateFishWrapper(&b, w)
+	}
+
+	keys := make([]string, 0, len(cfg.FishFunctions))
+	for k := range cfg.FishFunctions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		b.WriteString(fmt.Sprintf("# %s\n", name))
+		b.WriteString(fmt.Sprintf("function %s\n", name))
+		for _, line := range strings.Split(strings.TrimSpace(cfg.FishFunctions[name]), "\n") {
+			if line == "" {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(fmt.Sprintf("    %s\n", line))
+			}
+		}
+		b.WriteString("end\n\n")
+
+		if strings.HasPrefix(name, "__") {
+			initFunctions = append(initFunctions, name)
+		}
+	}
+
+	var posixOnly []string
+	for name := range cfg.GetShellFunctions() {
+		if _, ok := cfg.FishFunctions[name]; !ok {
+			posixOnly = append(posixOnly, name)
+		}
+	}
+	if len(posixOnly) > 0 {
+		sort.Strings(posixOnly)
+		b.WriteString(fmt.Sprintf("# Not available in fish (no fish_functions variant): %s\n\n", strings.Join(posixOnly, ", ")))
+	}
+
+	if len(initFunctions) > 0 {
+		b.WriteString("# Call init functions\n")
+		for _, name := range initFunctions {
+			b.WriteString(name + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// generateFishWrapper generates a single wrapper as a fish function.
+func (g *Generator) generateFishWrapper(b *strings.Builder, w config.Wrapper) {
+	b.WriteString(fmt.Sprintf("# %s\n", w.Name))
+	b.WriteString(fmt.Sprintf("function %s\n", w.Name))
+
+	if w.RequiresArg || w.Usage != "" {
+		usage := w.Usage
+		if usage == "" {
+			usage = w.Name + " <arg>"
+		}
+		b.WriteString("    if test (count $argv) -eq 0\n")
+		b.WriteString(fmt.Sprintf("        echo %s\n", fishQuote("Usage: "+usage)))
+		b.WriteString("        return 1\n")
+		b.WriteString("    end\n")
+	}
+
+	if w.DefaultArg != "" {
+		b.WriteString("    if test (count $argv) -eq 0\n")
+		b.WriteString(fmt.Sprintf("        set argv %s\n", fishQuote(w.DefaultArg)))
+		b.WriteString("    end\n")
+	}
+	b.WriteString(fmt.Sprintf("    %s $argv", w.Command))
+	if w.PostAction == "cd" {
+		b.WriteString("\n    and cd $argv[1]")
+	}
+	b.WriteString("\nend\n\n")
+}
+
+// generateFishComponentScript generates the fish script for a component,
+// including only the sections selected by the generation profile.
+func (m *Manager) generateFishComponentScript(c *Component) string {
+	var b strings.Builder
+	profile := m.profile()
+
+	b.WriteString("#!/usr/bin/env fish\n")
+	b.WriteString(fmt.Sprintf("# Acorn shell integration: %s\n", c.Name))
+	b.WriteString(fmt.Sprintf("# %s\n", c.Description))
+	b.WriteString(fmt.Sprintf("# Profile: %s\n", profile))
+	b.WriteString("# Generated by acorn - do not edit manually\n\n")
+
+	if c.FishEnv != "" && profile != ProfileAliasesOnly {
+		b.WriteString("# Environment\n")
+		b.WriteString(c.FishEnv)
+		b.WriteString("\n")
+	}
+
+	if c.FishAliases != "" && profile != ProfileMinimal {
+		b.WriteString("# Aliases\n")
+		b.WriteString(c.FishAliases)
+		b.WriteString("\n")
+	}
+
+	if c.FishFunctions != "" && profile != ProfileAliasesOnly {
+		b.WriteString("# Functions\n")
+		b.WriteString(c.FishFunctions)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// generateFishEntrypoint generates the shell.fish entrypoint, sourcing the
+// component scripts in the same order as the POSIX entrypoint.
+func (m *Manager) generateFishEntrypoint() string {
+	var b strings.Builder
+
+	b.WriteString("#!/usr/bin/env fish\n")
+	b.WriteString("# Acorn shell integration entrypoint\n")
+	b.WriteString(fmt.Sprintf("# Profile: %s\n", m.profile()))
+	b.WriteString("# Generated by acorn - do not edit manually\n")
+	b.WriteString("# Source this file from config.fish\n\n")
+
+	b.WriteString("# Acorn configuration directory\n")
+	b.WriteString(fmt.Sprintf("set -gx ACORN_CONFIG_DIR \"%s\"\n\n", m.config.AcornDir))
+
+	b.WriteString("# Source all component scripts in dependency order\n")
+	for _, name := range m.componentOrder() {
+		if _, ok := m.components[name]; ok && m.isEnabled(name) {
+			b.WriteString(fmt.Sprintf("test -f \"$ACORN_CONFIG_DIR/%s.fish\"; and source \"$ACORN_CONFIG_DIR/%s.fish\"\n", name, name))
+		}
+	}
+
+	if m.profile() != ProfileFull {
+		return b.String()
+	}
+	if m.noCompletions {
+		b.WriteString("\n# Acorn CLI completions left out: they failed validation when generated\n")
+		return b.String()
+	}
+
+	b.WriteString("\n# Acorn CLI completions\n")
+	b.WriteString("if command -q acorn\n")
+	b.WriteString("    acorn completion fish 2>/dev/null | source\n")
+	b.WriteString("end\n")
+
+	return b.String()
+}
+
+// fishInjectionBlock returns the marker-delimited config.fish block
+// sourcing the fish entrypoint.
+func (m *Manager) fishInjectionBlock() string {
+	return fmt.Sprintf("%s\nset -gx ACORN_CONFIG_DIR \"%s\"\ntest -f \"$ACORN_CONFIG_DIR/shell.fish\"; and source \"$ACORN_CONFIG_DIR/shell.fish\"\n%s",
+		InjectMarker, m.config.AcornDir, InjectMarkerEnd)
+}
+
+// fishRCFile returns config.fish under the XDG config directory.
+func (m *Manager) fishRCFile() string {
+	return filepath.Join(m.config.XDGConfigHome, "fish", "config.fish")
+}
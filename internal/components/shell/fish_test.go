@@ -0,0 +1,149 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/config"
+)
+
+func TestDetectShellFish(t *testing.T) {
+	t.Setenv("SHELL", "/usr/local/bin/fish")
+	if got := detectShell(); got != "fish" {
+		t.Errorf("detectShell() = %q, want fish", got)
+	}
+}
+
+func TestGenerateFishComponent(t *testing.T) {
+	cfg := &config.BaseConfig{
+		Name:    "go",
+		Env:     map[string]string{"GOPATH": "${XDG_DATA_HOME}/go"},
+		Paths:   []config.PathEntry{{Path: "$GOPATH/bin"}},
+		Aliases: map[string]string{"gt": "go test ./...", "say": `echo 'hi'`},
+		Wrappers: []config.Wrapper{
+			{Name: "gonew", Command: "acorn go new", Usage: "gonew <name>"},
+		},
+		ShellFunctions: map[string]string{"gocover": "go test -cover", "__go_init": "true"},
+		FishFunctions:  map[string]string{"__go_init": "true"},
+	}
+	c := NewGenerator().GenerateComponent(cfg)
+
+	for _, want := range []string{
+		`set -gx GOPATH "{$XDG_DATA_HOME}/go"`,
+		`if not contains -- "$GOPATH/bin" $PATH`,
+		`    set -gx PATH "$GOPATH/bin" $PATH`,
+	} {
+		if !strings.Contains(c.FishEnv, want) {
+			t.Errorf("FishEnv missing %q:\n%s", want, c.FishEnv)
+		}
+	}
+	if want := "alias gt 'go test ./...'\nalias say 'echo \\'hi\\''\n"; c.FishAliases != want {
+		t.Errorf("FishAliases = %q, want %q", c.FishAliases, want)
+	}
+	for _, want := range []string{
+		"function gonew\n    if test (count $argv) -eq 0\n        echo 'Usage: gonew <name>'\n",
+		"    acorn go new $argv\nend\n",
+		"function __go_init\n    true\nend\n",
+		"# Not available in fish (no fish_functions variant): gocover\n",
+		"# Call init functions\n__go_init\n",
+	} {
+		if !strings.Contains(c.FishFunctions, want) {
+			t.Errorf("FishFunctions missing %q:\n%s", want, c.FishFunctions)
+		}
+	}
+
+	manager := NewManager(&Config{AcornDir: "/home/user/.config/acorn", Shell: "fish"})
+	manager.RegisterComponent(c)
+	script := manager.generateComponentScript(c)
+	if !strings.HasPrefix(script, "#!/usr/bin/env fish\n") || strings.Contains(script, "export ") {
+		t.Errorf("fish script should not use POSIX syntax:\n%s", script)
+	}
+
+	entrypoint := manager.generateEntrypoint()
+	for _, want := range []string{
+		`set -gx ACORN_CONFIG_DIR "/home/user/.config/acorn"`,
+		`test -f "$ACORN_CONFIG_DIR/go.fish"; and source "$ACORN_CONFIG_DIR/go.fish"`,
+		"acorn completion fish 2>/dev/null | source",
+	} {
+		if !strings.Contains(entrypoint, want) {
+			t.Errorf("entrypoint missing %q:\n%s", want, entrypoint)
+		}
+	}
+	if manager.EntrypointName() != "shell.fish" {
+		t.Errorf("EntrypointName() = %q, want shell.fish", manager.EntrypointName())
+	}
+}
+
+func TestInjectEjectFish(t *testing.T) {
+	xdg := t.TempDir()
+	manager := NewManager(&Config{XDGConfigHome: xdg, AcornDir: filepath.Join(xdg, "acorn"), Shell: "fish"})
+
+	rcFile := filepath.Join(xdg, "fish", "config.fish")
+	if got := manager.GetRCFile(); got != rcFile {
+		t.Fatalf("GetRCFile() = %q, want %q", got, rcFile)
+	}
+
+	result, err := manager.Inject()
+	if err != nil || result.Action != "injected" {
+		t.Fatalf("Inject = %+v, %v", result, err)
+	}
+	content, err := os.ReadFile(rcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `test -f "$ACORN_CONFIG_DIR/shell.fish"; and source "$ACORN_CONFIG_DIR/shell.fish"`) {
+		t.Errorf("config.fish should source shell.fish:\n%s", content)
+	}
+
+	if result, err := manager.Eject(); err != nil || result.Action != "ejected" {
+		t.Fatalf("Eject = %+v, %v", result, err)
+	}
+	content, _ = os.ReadFile(rcFile)
+	if strings.Contains(string(content), "acorn") {
+		t.Errorf("config.fish still mentions acorn after eject:\n%s", content)
+	}
+}
+
+func TestFishValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"${XDG_DATA_HOME}/go", "{$XDG_DATA_HOME}/go", true},
+		{"$HOME/bin", "$HOME/bin", true},
+		{"${A}:${B}", "{$A}:{$B}", true},
+		{"${EDITOR:-vim}", "", false},
+		{"${PWD#/home}", "", false},
+		{"${HOME}/${SUB%/}", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := fishValue(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("fishValue(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	cfg := &config.BaseConfig{
+		Name:  "editor",
+		Env:   map[string]string{"EDITOR": "${EDITOR:-vim}", "PAGER": "less"},
+		Paths: []config.PathEntry{{Path: "${PWD#/tmp}/bin"}},
+	}
+	env := NewGenerator().GenerateComponent(cfg).FishEnv
+	for _, line := range strings.Split(env, "\n") {
+		if !strings.HasPrefix(line, "#") && strings.Contains(line, "${") {
+			t.Errorf("FishEnv passes a POSIX expansion through: %s", line)
+		}
+	}
+	for _, want := range []string{
+		`# EDITOR skipped: unsupported parameter expansion in "${EDITOR:-vim}"`,
+		`set -gx PAGER "less"`,
+		`# PATH entry skipped: unsupported parameter expansion in "${PWD#/tmp}/bin"`,
+	} {
+		if !strings.Contains(env, want) {
+			t.Errorf("FishEnv missing %q:\n%s", want, env)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package shell
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Kinds of ShellFunction.
+const (
+	KindFunction = "function"
+	KindAlias    = "alias"
+)
+
+// ShellFunction is a function or alias defined by a component's script.
+type ShellFunction struct {
+	Component   string `json:"component" yaml:"component"`
+	Name        string `json:"name" yaml:"name"`
+	Kind        string `json:"kind" yaml:"kind"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Matches reports whether pattern occurs, case-insensitively, in the name,
+// component or description.
+func (f ShellFunction) Matches(pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	for _, s := range []string{f.Name, f.Component, f.Description} {
+		if strings.Contains(strings.ToLower(s), pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// funcDefRe matches `name() {`, `name () {`, `function name {` and
+	// `function name() {` at the start of a line.
+	funcDefRe = regexp.MustCompile(`^(?:function\s+([A-Za-z_][\w:.-]*)\s*(?:\(\s*\))?|([A-Za-z_][\w:.-]*)\s*\(\s*\))\s*\{(.*)$`)
+	aliasRe   = regexp.MustCompile(`^alias\s+([^=\s]+)=(.*)$`)
+)
+
+// ParseFunctions extracts the public functions defined at the top level of
+// a shell script. The description is the comment block directly above the
+// definition, unless it only repeats the name (as generated headers do),
+// in which case the first comment or statement in the body is used.
+// Functions starting with an underscore are treated as private.
+func ParseFunctions(script string) []ShellFunction {
+	lines := strings.Split(script, "\n")
+	var fns []ShellFunction
+	var comments []string
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "#!") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") && line == trimmed {
+			comments = append(comments, strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+			continue
+		}
+
+		m := funcDefRe.FindStringSubmatch(line)
+		if m == nil {
+			comments = nil
+			continue
+		}
+
+		name := m[1] + m[2]
+		desc := describe(comments, name)
+		comments = nil
+
+		// One-line functions carry their body on the definition line;
+		// otherwise skip to the closing brace at column zero
+		body := []string{strings.TrimSuffix(strings.TrimSpace(m[3]), "}")}
+		if !strings.HasSuffix(strings.TrimSpace(m[3]), "}") {
+			for i++; i < len(lines) && !strings.HasPrefix(lines[i], "}"); i++ {
+				body = append(body, lines[i])
+			}
+		}
+
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+		if desc == "" {
+			desc = bodySummary(body)
+		}
+		fns = append(fns, ShellFunction{Name: name, Kind: KindFunction, Description: desc})
+	}
+	return fns
+}
+
+// ParseAliases extracts the aliases defined in a shell script, described by
+// the command they expand to.
+func ParseAliases(script string) []ShellFunction {
+	var aliases []ShellFunction
+	for line := range strings.SplitSeq(script, "\n") {
+		m := aliasRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		value := m[2]
+		if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		aliases = append(aliases, ShellFunction{Name: m[1], Kind: KindAlias, Description: value})
+	}
+	return aliases
+}
+
+// describe joins a comment block, ignoring a lone comment naming the function.
+func describe(comments []string, name string) string {
+	var kept []string
+	for _, c := range comments {
+		if c != "" && c != name {
+			kept = append(kept, c)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// bodySummary returns the first comment or statement in a function body,
+// skipping argument checks.
+func bodySummary(body []string) string {
+	for _, line := range body {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "if [ -z") || line == "fi" {
+			continue
+		}
+		if c, ok := strings.CutPrefix(line, "#"); ok {
+			return strings.TrimSpace(c)
+		}
+		if strings.HasPrefix(line, "echo \"Usage:") || line == "return 1" {
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
+// ListFunctions returns the functions, and optionally aliases, defined by
+// every registered component, ordered by component name.
+func (m *Manager) ListFunctions(includeAliases bool) []ShellFunction {
+	var all []ShellFunction
+	for _, name := range m.ListComponents() {
+		c := m.components[name]
+		fns := ParseFunctions(c.Functions)
+		if includeAliases {
+			fns = append(fns, ParseAliases(c.Aliases)...)
+		}
+		for i := range fns {
+			fns[i].Component = name
+		}
+		all = append(all, fns...)
+	}
+	return all
+}
@@ -0,0 +1,64 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFunctions(t *testing.T) {
+	script := `#!/bin/sh
+# Create and activate a virtualenv
+mkvenv() {
+    python3 -m venv "${1:-.venv}"
+}
+
+# gs
+gs() {
+    # Short git status
+    git status -sb
+}
+
+_private() {
+    echo hidden
+}
+
+function kctx {
+    if [ -z "$1" ]; then
+        echo "Usage: kctx <context>"
+        return 1
+    fi
+    kubectl config use-context "$1"
+}
+
+up() { cd ..; }
+`
+	want := []ShellFunction{
+		{Name: "mkvenv", Kind: KindFunction, Description: "Create and activate a virtualenv"},
+		{Name: "gs", Kind: KindFunction, Description: "Short git status"},
+		{Name: "kctx", Kind: KindFunction, Description: `kubectl config use-context "$1"`},
+		{Name: "up", Kind: KindFunction, Description: "cd ..;"},
+	}
+	if got := ParseFunctions(script); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFunctions() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseAliases(t *testing.T) {
+	script := "alias ll='ls -la'\nalias gco=\"git checkout\"\n# alias old=x\n"
+	want := []ShellFunction{
+		{Name: "ll", Kind: KindAlias, Description: "ls -la"},
+		{Name: "gco", Kind: KindAlias, Description: "git checkout"},
+	}
+	if got := ParseAliases(script); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAliases() = %+v, want %+v", got, want)
+	}
+}
+
+func TestShellFunctionMatches(t *testing.T) {
+	fn := ShellFunction{Component: "python", Name: "mkvenv", Description: "Create a virtualenv"}
+	for pattern, want := range map[string]bool{"VENV": true, "python": true, "create": true, "git": false} {
+		if got := fn.Matches(pattern); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
@@ -189,6 +189,10 @@ func (g *Generator) GenerateComponent(cfg *config.BaseConfig) *Component {
 		Env:         g.generateEnvString(cfg),
 		Aliases:     g.generateAliasesString(cfg.Aliases),
 		Functions:   g.generateFunctionsString(cfg),
+
+		FishEnv:       g.generateFishEnvString(cfg),
+		FishAliases:   g.generateFishAliasesString(cfg.Aliases),
+		FishFunctions: g.generateFishFunctionsString(cfg),
 	}
 }
 
@@ -0,0 +1,79 @@
+package shell
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// HashesFile is the sidecar in the generated shell directory recording the
+// content hash of each script as it was last written.
+const HashesFile = ".hashes.json"
+
+// contentHash returns the hex SHA-256 of a generated script.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadHashes reads the stored script hashes from dir. A missing or
+// unreadable sidecar yields an empty map, so every script counts as changed.
+func loadHashes(dir string) map[string]string {
+	hashes := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(dir, HashesFile))
+	if err != nil {
+		return hashes
+	}
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		return map[string]string{}
+	}
+	return hashes
+}
+
+// saveHashes writes the script hashes to the sidecar in dir.
+func saveHashes(dir string, hashes map[string]string) error {
+	data, err := json.MarshalIndent(hashes, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, HashesFile)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// scriptUnchanged reports whether a script with the given hash can be left
+// alone: the stored hash matches and the file on disk still has that
+// content, so hand edits or a restored backup are regenerated.
+func scriptUnchanged(path, hash string, hashes map[string]string) bool {
+	if hashes[filepath.Base(path)] != hash {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	return err == nil && contentHash(string(data)) == hash
+}
+
+// writeScript writes a generated script and records its hash. With
+// OnlyChanged set, a script whose content is unchanged is not rewritten,
+// leaving its mtime alone. Nothing is written in dry-run mode.
+func (m *Manager) writeScript(script *GeneratedScript, hashes map[string]string) error {
+	hash := contentHash(script.Content)
+	if m.config.OnlyChanged && scriptUnchanged(script.GeneratedPath, hash, hashes) {
+		script.Unchanged = true
+		return nil
+	}
+	if m.config.DryRun {
+		return nil
+	}
+
+	if err := os.WriteFile(script.GeneratedPath, []byte(script.Content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", script.GeneratedPath, err)
+	}
+	script.Written = true
+	hashes[filepath.Base(script.GeneratedPath)] = hash
+	return nil
+}
@@ -0,0 +1,55 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteScriptOnlyChanged(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManager(&Config{OnlyChanged: true})
+	script := func(content string) *GeneratedScript {
+		return &GeneratedScript{GeneratedPath: filepath.Join(dir, "go.sh"), Content: content}
+	}
+
+	hashes := loadHashes(dir)
+	first := script("v1")
+	if err := m.writeScript(first, hashes); err != nil || !first.Written || first.Unchanged {
+		t.Fatalf("first write = %+v, %v", first, err)
+	}
+	if err := saveHashes(dir, hashes); err != nil {
+		t.Fatal(err)
+	}
+
+	hashes = loadHashes(dir)
+	same := script("v1")
+	if err := m.writeScript(same, hashes); err != nil || same.Written || !same.Unchanged {
+		t.Errorf("unchanged write = %+v, %v", same, err)
+	}
+
+	changed := script("v2")
+	if err := m.writeScript(changed, hashes); err != nil || !changed.Written {
+		t.Errorf("changed write = %+v, %v", changed, err)
+	}
+
+	// A hand edit on disk is regenerated even though the stored hash matches
+	os.WriteFile(filepath.Join(dir, "go.sh"), []byte("edited"), 0o644)
+	again := script("v2")
+	if err := m.writeScript(again, hashes); err != nil || !again.Written {
+		t.Errorf("edited write = %+v, %v", again, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "go.sh")); string(data) != "v2" {
+		t.Errorf("go.sh = %q, want v2", data)
+	}
+}
+
+func TestScriptCounts(t *testing.T) {
+	result := &GenerateResult{
+		Scripts:    []*GeneratedScript{{Unchanged: true}, {Written: true}, {Unchanged: true}},
+		Entrypoint: &GeneratedScript{Written: true},
+	}
+	if regenerated, unchanged := result.ScriptCounts(); regenerated != 2 || unchanged != 2 {
+		t.Errorf("ScriptCounts() = %d, %d; want 2, 2", regenerated, unchanged)
+	}
+}
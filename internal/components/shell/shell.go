@@ -18,10 +18,17 @@ import (
 type Config struct {
 	XDGConfigHome string
 	AcornDir      string
-	Shell         string // bash or zsh
+	Shell         string // bash, zsh or fish; detected from $SHELL by NewConfig
 	Platform      string // darwin or linux
-	Verbose       bool
-	DryRun        bool
+	Profile       string // full, minimal, or aliases-only (empty means full)
+	Backup        bool   // snapshot existing scripts before overwriting them
+	KeepBackups   int    // backups to keep when Backup is set (<= 0 keeps all)
+	OnlyChanged   bool   // skip rewriting scripts whose content hash is unchanged
+	// LintCompletions checks acorn's completion script before the
+	// entrypoint relies on it, leaving completions out if it is broken.
+	LintCompletions bool
+	Verbose         bool
+	DryRun          bool
 }
 
 // NewConfig creates a new Config with defaults.
@@ -45,15 +52,70 @@ func NewConfig(verbose, dryRun bool) *Config {
 	}
 }
 
+// Generation profiles select which sections of each component script are emitted.
+const (
+	ProfileFull        = "full"         // environment, aliases, functions and completions
+	ProfileMinimal     = "minimal"      // environment and functions only
+	ProfileAliasesOnly = "aliases-only" // aliases only
+)
+
+// Profiles lists the supported generation profiles.
+var Profiles = []string{ProfileFull, ProfileMinimal, ProfileAliasesOnly}
+
+// ValidateProfile checks that profile is a supported generation profile.
+func ValidateProfile(profile string) error {
+	for _, p := range Profiles {
+		if profile == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown profile %q (use: %s)", profile, strings.Join(Profiles, ", "))
+}
+
+// Shells lists the shells integration can be generated for.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// ValidateShell checks that shell is a supported target shell.
+func ValidateShell(shell string) error {
+	for _, s := range Shells {
+		if shell == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown shell %q (use: %s)", shell, strings.Join(Shells, ", "))
+}
+
 // detectShell detects the current shell.
 func detectShell() string {
-	shell := os.Getenv("SHELL")
-	if strings.Contains(shell, "zsh") {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	switch {
+	case strings.Contains(shell, "zsh"):
 		return "zsh"
+	case strings.Contains(shell, "fish"):
+		return "fish"
 	}
 	return "bash"
 }
 
+// ScriptExt returns the extension of generated scripts: .fish when
+// targeting fish, .sh otherwise.
+func (m *Manager) ScriptExt() string {
+	if m.config.Shell == "fish" {
+		return ".fish"
+	}
+	return ".sh"
+}
+
+// EntrypointName returns the entrypoint file name, shell.sh or shell.fish.
+func (m *Manager) EntrypointName() string {
+	return "shell" + m.ScriptExt()
+}
+
+// isScriptFile reports whether name is a generated POSIX or fish script.
+func isScriptFile(name string) bool {
+	return strings.HasSuffix(name, ".sh") || strings.HasSuffix(name, ".fish")
+}
+
 // Component represents a shell component with its scripts.
 type Component struct {
 	Name        string
@@ -62,6 +124,11 @@ type Component struct {
 	Aliases     string // shell aliases
 	Functions   string // shell functions (wrappers that call acorn)
 	Completions string // shell completions
+
+	// Fish variants of the sections above, used when targeting fish
+	FishEnv       string
+	FishAliases   string
+	FishFunctions string
 }
 
 // GeneratedScript represents a generated shell script with metadata.
@@ -72,6 +139,7 @@ type GeneratedScript struct {
 	SymlinkPath  string `json:"symlink_path" yaml:"symlink_path"`       // Where symlink should point (XDG)
 	Content      string `json:"content" yaml:"content"`
 	Written      bool   `json:"written" yaml:"written"`
+	Unchanged    bool   `json:"unchanged,omitempty" yaml:"unchanged,omitempty"` // Skipped by OnlyChanged
 }
 
 // GenerateResult contains the result of a generate operation.
@@ -79,17 +147,39 @@ type GenerateResult struct {
 	AcornDir    string                    `json:"acorn_dir" yaml:"acorn_dir"`
 	Shell       string                    `json:"shell" yaml:"shell"`
 	Platform    string                    `json:"platform" yaml:"platform"`
+	Profile     string                    `json:"profile" yaml:"profile"`
 	DryRun      bool                      `json:"dry_run" yaml:"dry_run"`
 	Scripts     []*GeneratedScript        `json:"scripts" yaml:"scripts"`
 	Entrypoint  *GeneratedScript          `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`
 	ConfigFiles []*configfile.GeneratedFile `json:"config_files,omitempty" yaml:"config_files,omitempty"`
+	Backup      *BackupResult             `json:"backup,omitempty" yaml:"backup,omitempty"`
+	Disabled    []string                  `json:"disabled,omitempty" yaml:"disabled,omitempty"` // Registered but not enabled
+	Completions *CompletionCheck          `json:"completions,omitempty" yaml:"completions,omitempty"`
+}
+
+// ScriptCounts returns how many scripts, including the entrypoint, were
+// (or in dry-run mode would be) regenerated and how many were skipped as
+// unchanged.
+func (r *GenerateResult) ScriptCounts() (regenerated, unchanged int) {
+	scripts := r.Scripts
+	if r.Entrypoint != nil {
+		scripts = append(append([]*GeneratedScript{}, scripts...), r.Entrypoint)
+	}
+	for _, s := range scripts {
+		if s.Unchanged {
+			unchanged++
+		} else {
+			regenerated++
+		}
+	}
+	return regenerated, unchanged
 }
 
 // InjectResult contains the result of an inject/eject operation.
 type InjectResult struct {
 	RCFile         string `json:"rc_file" yaml:"rc_file"`
 	EntrypointPath string `json:"entrypoint_path" yaml:"entrypoint_path"`
-	Action         string `json:"action" yaml:"action"` // "injected", "ejected", "already_injected", "not_injected"
+	Action         string `json:"action" yaml:"action"` // "injected", "updated", "ejected", "already_injected", "not_injected", "print"
 	DryRun         bool   `json:"dry_run" yaml:"dry_run"`
 	InjectionBlock string `json:"injection_block,omitempty" yaml:"injection_block,omitempty"`
 }
@@ -99,6 +189,11 @@ type Manager struct {
 	config     *Config
 	components map[string]*Component
 	fileSpecs  map[string][]FileSpec // component name -> file specs for config file generation
+	enabled    map[string]bool       // components GenerateAll includes (nil means all)
+	order      []string              // entrypoint source order (nil means GetComponentOrder)
+	// noCompletions leaves completions out of the entrypoint after a
+	// failed completion check.
+	noCompletions bool
 }
 
 // FileSpec holds file generation specification.
@@ -154,6 +249,14 @@ func (m *Manager) GetComponent(name string) (*Component, bool) {
 	return c, ok
 }
 
+// profile returns the configured generation profile, defaulting to full.
+func (m *Manager) profile() string {
+	if m.config.Profile == "" {
+		return ProfileFull
+	}
+	return m.config.Profile
+}
+
 // getGeneratedShellDir returns the directory for generated shell scripts.
 // Scripts are written to .sapling/generated/shell/
 func (m *Manager) getGeneratedShellDir() string {
@@ -191,8 +294,8 @@ func (m *Manager) GenerateComponent(name string) (*GenerateResult, error) {
 	}
 
 	script := m.generateComponentScript(c)
-	generatedPath := filepath.Join(generatedDir, name+".sh")
-	symlinkPath := filepath.Join(m.config.AcornDir, name+".sh")
+	generatedPath := filepath.Join(generatedDir, name+m.ScriptExt())
+	symlinkPath := filepath.Join(m.config.AcornDir, name+m.ScriptExt())
 
 	genScript := &GeneratedScript{
 		Component:     name,
@@ -203,28 +306,64 @@ func (m *Manager) GenerateComponent(name string) (*GenerateResult, error) {
 		Written:       false,
 	}
 
-	// Write file if not dry-run
-	if !m.config.DryRun {
-		if err := os.WriteFile(generatedPath, []byte(script), 0o644); err != nil {
-			return nil, fmt.Errorf("failed to write %s: %w", generatedPath, err)
+	hashes := loadHashes(generatedDir)
+	if err := m.writeScript(genScript, hashes); err != nil {
+		return nil, err
+	}
+	if genScript.Written {
+		if err := saveHashes(generatedDir, hashes); err != nil {
+			return nil, err
 		}
-		genScript.Written = true
 	}
 
 	return &GenerateResult{
 		AcornDir: m.config.AcornDir,
 		Shell:    m.config.Shell,
 		Platform: m.config.Platform,
+		Profile:  m.profile(),
 		DryRun:   m.config.DryRun,
 		Scripts:  []*GeneratedScript{genScript},
 	}, nil
 }
 
+// RenderComponent renders the shell script for a single component in memory.
+// Nothing is written to disk and no sapling repository is required.
+func (m *Manager) RenderComponent(name string) (*GeneratedScript, error) {
+	c, ok := m.components[name]
+	if !ok {
+		return nil, fmt.Errorf("component not registered for shell generation: %s (available: %v)", name, m.ListComponents())
+	}
+
+	return &GeneratedScript{
+		Component:     name,
+		Description:   c.Description,
+		GeneratedPath: filepath.Join(m.getGeneratedShellDir(), name+m.ScriptExt()),
+		SymlinkPath:   filepath.Join(m.config.AcornDir, name+m.ScriptExt()),
+		Content:       m.generateComponentScript(c),
+	}, nil
+}
+
 // GenerateComponents generates shell scripts for specific components.
 // If names is empty, generates for all components.
 // Shell scripts are written to $DOTFILES_ROOT/.sapling/generated/shell/ and should be
 // symlinked to $XDG_CONFIG_HOME/acorn/ via `acorn sync link`.
 func (m *Manager) GenerateComponents(names ...string) (*GenerateResult, error) {
+	hashes := loadHashes(m.getGeneratedShellDir())
+	result, err := m.generateComponents(names, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if !m.config.DryRun {
+		if err := saveHashes(m.getGeneratedShellDir(), hashes); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// generateComponents writes the component scripts and config files,
+// recording the hashes of written scripts in hashes.
+func (m *Manager) generateComponents(names []string, hashes map[string]string) (*GenerateResult, error) {
 	// Check for valid sapling repo before generating
 	if !config.IsValidSaplingRepo() {
 		return nil, fmt.Errorf("no valid .sapling repository found. Run 'acorn setup' to configure one")
@@ -237,7 +376,7 @@ func (m *Manager) GenerateComponents(names ...string) (*GenerateResult, error) {
 	}
 
 	// If no names specified, use all components
-	if len(names) == 0 {
+	if names == nil {
 		names = m.ListComponents()
 	}
 
@@ -245,11 +384,21 @@ func (m *Manager) GenerateComponents(names ...string) (*GenerateResult, error) {
 		AcornDir:    m.config.AcornDir,
 		Shell:       m.config.Shell,
 		Platform:    m.config.Platform,
+		Profile:     m.profile(),
 		DryRun:      m.config.DryRun,
 		Scripts:     make([]*GeneratedScript, 0, len(names)),
 		ConfigFiles: make([]*configfile.GeneratedFile, 0),
 	}
 
+	// Snapshot the previous generation before anything is overwritten
+	if m.config.Backup {
+		backup, err := m.BackupGenerated(m.config.KeepBackups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up generated scripts: %w", err)
+		}
+		result.Backup = backup
+	}
+
 	// Create config file manager with generated directory
 	// Config files are written to $DOTFILES_ROOT/.sapling/generated/{component}/{filename}
 	generatedDir := filepath.Dir(generatedShellDir) // parent of shell/ is generated/
@@ -263,8 +412,8 @@ func (m *Manager) GenerateComponents(names ...string) (*GenerateResult, error) {
 		}
 
 		script := m.generateComponentScript(c)
-		generatedPath := filepath.Join(generatedShellDir, name+".sh")
-		symlinkPath := filepath.Join(m.config.AcornDir, name+".sh")
+		generatedPath := filepath.Join(generatedShellDir, name+m.ScriptExt())
+		symlinkPath := filepath.Join(m.config.AcornDir, name+m.ScriptExt())
 
 		genScript := &GeneratedScript{
 			Component:     name,
@@ -275,11 +424,8 @@ func (m *Manager) GenerateComponents(names ...string) (*GenerateResult, error) {
 			Written:       false,
 		}
 
-		if !m.config.DryRun {
-			if err := os.WriteFile(generatedPath, []byte(script), 0o644); err != nil {
-				return nil, fmt.Errorf("failed to write %s: %w", generatedPath, err)
-			}
-			genScript.Written = true
+		if err := m.writeScript(genScript, hashes); err != nil {
+			return nil, err
 		}
 
 		result.Scripts = append(result.Scripts, genScript)
@@ -331,22 +477,30 @@ func (m *Manager) shouldGenerateForPlatform(platforms []string) bool {
 	return false
 }
 
-// GenerateAll generates all component shell scripts and the entrypoint.
-// All scripts are written to $DOTFILES_ROOT/.sapling/generated/shell/ and should be
+// GenerateAll generates all enabled component shell scripts and the
+// entrypoint (see SetEnabled). All scripts are written to $DOTFILES_ROOT/.sapling/generated/shell/ and should be
 // symlinked to $XDG_CONFIG_HOME/acorn/ via `acorn sync link`.
 func (m *Manager) GenerateAll() (*GenerateResult, error) {
-	result, err := m.GenerateComponents() // all components
+	hashes := loadHashes(m.getGeneratedShellDir())
+	enabled, disabled := m.partitionEnabled()
+	result, err := m.generateComponents(enabled, hashes)
 	if err != nil {
 		return nil, err
 	}
+	result.Disabled = disabled
+
+	if m.config.LintCompletions && m.profile() == ProfileFull {
+		result.Completions = m.CheckCompletions()
+		m.noCompletions = !result.Completions.Valid
+	}
 
 	// Generate the main entrypoint
-	// Named "shell.sh" as the primary entrypoint sourced by rc files
+	// Named "shell.sh" (or "shell.fish") as the primary entrypoint sourced by rc files
 	// Written to generated/shell/shell.sh, symlinked to ~/.config/acorn/shell.sh
 	entrypoint := m.generateEntrypoint()
 	generatedShellDir := m.getGeneratedShellDir()
-	generatedPath := filepath.Join(generatedShellDir, "shell.sh")
-	symlinkPath := filepath.Join(m.config.AcornDir, "shell.sh")
+	generatedPath := filepath.Join(generatedShellDir, m.EntrypointName())
+	symlinkPath := filepath.Join(m.config.AcornDir, m.EntrypointName())
 
 	result.Entrypoint = &GeneratedScript{
 		Component:     "shell",
@@ -357,44 +511,54 @@ func (m *Manager) GenerateAll() (*GenerateResult, error) {
 		Written:       false,
 	}
 
+	if err := m.writeScript(result.Entrypoint, hashes); err != nil {
+		return nil, fmt.Errorf("failed to write entrypoint: %w", err)
+	}
+
 	if !m.config.DryRun {
-		if err := os.WriteFile(generatedPath, []byte(entrypoint), 0o644); err != nil {
-			return nil, fmt.Errorf("failed to write entrypoint: %w", err)
+		if err := saveHashes(generatedShellDir, hashes); err != nil {
+			return nil, err
 		}
-		result.Entrypoint.Written = true
 	}
 
 	return result, nil
 }
 
-// generateComponentScript generates a shell script for a component.
+// generateComponentScript generates a shell script for a component,
+// including only the sections selected by the generation profile.
 func (m *Manager) generateComponentScript(c *Component) string {
+	if m.config.Shell == "fish" {
+		return m.generateFishComponentScript(c)
+	}
+
 	var b strings.Builder
+	profile := m.profile()
 
 	b.WriteString(fmt.Sprintf("#!/bin/sh\n"))
 	b.WriteString(fmt.Sprintf("# Acorn shell integration: %s\n", c.Name))
 	b.WriteString(fmt.Sprintf("# %s\n", c.Description))
+	b.WriteString(fmt.Sprintf("# Profile: %s\n", profile))
 	b.WriteString("# Generated by acorn - do not edit manually\n\n")
 
-	if c.Env != "" {
+	if c.Env != "" && profile != ProfileAliasesOnly {
 		b.WriteString("# Environment\n")
 		b.WriteString(c.Env)
 		b.WriteString("\n")
 	}
 
-	if c.Aliases != "" {
+	if c.Aliases != "" && profile != ProfileMinimal {
 		b.WriteString("# Aliases\n")
 		b.WriteString(c.Aliases)
 		b.WriteString("\n")
 	}
 
-	if c.Functions != "" {
+	if c.Functions != "" && profile != ProfileAliasesOnly {
 		b.WriteString("# Functions\n")
 		b.WriteString(c.Functions)
 		b.WriteString("\n")
 	}
 
-	if c.Completions != "" {
+	if c.Completions != "" && profile == ProfileFull {
 		b.WriteString("# Completions\n")
 		b.WriteString(c.Completions)
 		b.WriteString("\n")
@@ -404,13 +568,19 @@ func (m *Manager) generateComponentScript(c *Component) string {
 }
 
 // generateEntrypoint generates the main shell.sh entrypoint.
-// Components are sourced in the order defined by GetComponentOrder() to ensure
-// dependencies are met (e.g., shell before theme, xdg before everything else).
+// Components are sourced in the order defined by GetComponentOrder() (or
+// SetOrder) to ensure dependencies are met (e.g., shell before theme, xdg
+// before everything else).
 func (m *Manager) generateEntrypoint() string {
+	if m.config.Shell == "fish" {
+		return m.generateFishEntrypoint()
+	}
+
 	var b strings.Builder
 
 	b.WriteString("#!/bin/sh\n")
 	b.WriteString("# Acorn shell integration entrypoint\n")
+	b.WriteString(fmt.Sprintf("# Profile: %s\n", m.profile()))
 	b.WriteString("# Generated by acorn - do not edit manually\n")
 	b.WriteString("# Source this file from your shell rc file\n\n")
 
@@ -419,21 +589,33 @@ func (m *Manager) generateEntrypoint() string {
 	b.WriteString("export ACORN_CONFIG_DIR\n\n")
 
 	b.WriteString("# Source all component scripts in dependency order\n")
-	// Use GetComponentOrder() to maintain correct loading order
-	for _, name := range GetComponentOrder() {
-		// Only include components that are registered
-		if _, ok := m.components[name]; ok {
+	// Use the component order to maintain correct loading order
+	for _, name := range m.componentOrder() {
+		// Only include components that are registered and enabled
+		if _, ok := m.components[name]; ok && m.isEnabled(name) {
 			b.WriteString(fmt.Sprintf("[ -f \"$ACORN_CONFIG_DIR/%s.sh\" ] && . \"$ACORN_CONFIG_DIR/%s.sh\"\n", name, name))
 		}
 	}
 
-	b.WriteString("\n# Acorn CLI completions\n")
-	b.WriteString("if command -v acorn >/dev/null 2>&1; then\n")
+	// Completions are only loaded by the full profile
+	if m.profile() != ProfileFull {
+		return b.String()
+	}
+	if m.noCompletions {
+		b.WriteString("\n# Acorn CLI completions left out: they failed validation when generated\n")
+		return b.String()
+	}
+
+	// A failing or unparseable completion script must not break the shell
+	completionShell := "bash"
 	if m.config.Shell == "zsh" {
-		b.WriteString("    eval \"$(acorn completion zsh)\"\n")
-	} else {
-		b.WriteString("    eval \"$(acorn completion bash)\"\n")
+		completionShell = "zsh"
 	}
+	b.WriteString("\n# Acorn CLI completions\n")
+	b.WriteString("if command -v acorn >/dev/null 2>&1; then\n")
+	b.WriteString(fmt.Sprintf("    _acorn_completion=\"$(acorn completion %s 2>/dev/null)\" &&\n", completionShell))
+	b.WriteString("        eval \"$_acorn_completion\" 2>/dev/null || true\n")
+	b.WriteString("    unset _acorn_completion\n")
 	b.WriteString("fi\n")
 
 	return b.String()
@@ -443,11 +625,15 @@ func (m *Manager) generateEntrypoint() string {
 // For bash on macOS, returns .bash_profile (login shell default).
 // For bash on Linux, returns .bashrc (interactive shell default).
 // For zsh, returns .zshrc (works for both).
+// For fish, returns $XDG_CONFIG_HOME/fish/config.fish.
 func (m *Manager) GetRCFile() string {
 	home, _ := os.UserHomeDir()
 
-	if m.config.Shell == "zsh" {
+	switch m.config.Shell {
+	case "zsh":
 		return filepath.Join(home, ".zshrc")
+	case "fish":
+		return m.fishRCFile()
 	}
 
 	// Bash: use .bash_profile on macOS (login shells), .bashrc on Linux
@@ -461,10 +647,13 @@ func (m *Manager) GetRCFile() string {
 const InjectMarker = "# >>> acorn shell integration >>>"
 const InjectMarkerEnd = "# <<< acorn shell integration <<<"
 
-// Inject adds the acorn source line to the shell rc file.
+// Inject adds the acorn source line to the shell rc file. An existing
+// injection block that no longer matches the current configuration (for
+// example, one pointing at an old ACORN_CONFIG_DIR) is rewritten in place,
+// leaving the rest of the rc file untouched.
 func (m *Manager) Inject() (*InjectResult, error) {
 	rcFile := m.GetRCFile()
-	entrypointPath := filepath.Join(m.config.AcornDir, "shell.sh")
+	entrypointPath := filepath.Join(m.config.AcornDir, m.EntrypointName())
 
 	result := &InjectResult{
 		RCFile:         rcFile,
@@ -478,15 +667,34 @@ func (m *Manager) Inject() (*InjectResult, error) {
 		return nil, fmt.Errorf("failed to read %s: %w", rcFile, err)
 	}
 
+	block := m.injectionBlock()
+
 	// Check if already injected
 	if strings.Contains(string(content), InjectMarker) {
-		result.Action = "already_injected"
+		// Without an end marker the block cannot be replaced safely
+		start, end, ok := findInjectionBlock(string(content))
+		if !ok || string(content)[start:end] == block {
+			result.Action = "already_injected"
+			return result, nil
+		}
+
+		result.InjectionBlock = block
+		if m.config.DryRun {
+			result.Action = "would_update"
+			return result, nil
+		}
+
+		newContent := string(content)[:start] + block + string(content)[end:]
+		if err := os.WriteFile(rcFile, []byte(newContent), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", rcFile, err)
+		}
+
+		result.Action = "updated"
 		return result, nil
 	}
 
 	// Create injection block with ACORN_CONFIG_DIR variable
-	injection := fmt.Sprintf("\n%s\nexport ACORN_CONFIG_DIR=\"%s\"\n[ -f \"$ACORN_CONFIG_DIR/shell.sh\" ] && . \"$ACORN_CONFIG_DIR/shell.sh\"\n%s\n",
-		InjectMarker, m.config.AcornDir, InjectMarkerEnd)
+	injection := "\n" + block + "\n"
 	result.InjectionBlock = injection
 
 	if m.config.DryRun {
@@ -494,7 +702,10 @@ func (m *Manager) Inject() (*InjectResult, error) {
 		return result, nil
 	}
 
-	// Append to rc file
+	// Append to rc file; config.fish may not exist yet
+	if err := os.MkdirAll(filepath.Dir(rcFile), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(rcFile), err)
+	}
 	f, err := os.OpenFile(rcFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open %s: %w", rcFile, err)
@@ -509,10 +720,47 @@ func (m *Manager) Inject() (*InjectResult, error) {
 	return result, nil
 }
 
+// PrintInjection returns the block Inject would add to the rc file, from
+// the start marker through the end marker, without touching any file.
+func (m *Manager) PrintInjection() *InjectResult {
+	return &InjectResult{
+		RCFile:         m.GetRCFile(),
+		EntrypointPath: filepath.Join(m.config.AcornDir, m.EntrypointName()),
+		Action:         "print",
+		DryRun:         m.config.DryRun,
+		InjectionBlock: m.injectionBlock(),
+	}
+}
+
+// injectionBlock returns the marker-delimited block sourcing the entrypoint,
+// without surrounding newlines.
+func (m *Manager) injectionBlock() string {
+	if m.config.Shell == "fish" {
+		return m.fishInjectionBlock()
+	}
+	return fmt.Sprintf("%s\nexport ACORN_CONFIG_DIR=\"%s\"\n[ -f \"$ACORN_CONFIG_DIR/shell.sh\" ] && . \"$ACORN_CONFIG_DIR/shell.sh\"\n%s",
+		InjectMarker, m.config.AcornDir, InjectMarkerEnd)
+}
+
+// findInjectionBlock locates the injection block in rc file content.
+// Returns the byte offsets of the block from the start marker through the
+// end marker, and false if either marker is missing.
+func findInjectionBlock(content string) (int, int, bool) {
+	start := strings.Index(content, InjectMarker)
+	if start < 0 {
+		return 0, 0, false
+	}
+	end := strings.Index(content[start:], InjectMarkerEnd)
+	if end < 0 {
+		return 0, 0, false
+	}
+	return start, start + end + len(InjectMarkerEnd), true
+}
+
 // Eject removes the acorn source line from the shell rc file.
 func (m *Manager) Eject() (*InjectResult, error) {
 	rcFile := m.GetRCFile()
-	entrypointPath := filepath.Join(m.config.AcornDir, "shell.sh")
+	entrypointPath := filepath.Join(m.config.AcornDir, m.EntrypointName())
 
 	result := &InjectResult{
 		RCFile:         rcFile,
@@ -568,6 +816,132 @@ func (m *Manager) Eject() (*InjectResult, error) {
 	return result, nil
 }
 
+// ComponentUsage describes how a component is wired into shell integration.
+type ComponentUsage struct {
+	Registered          bool   `json:"registered" yaml:"registered"`
+	GeneratedPath       string `json:"generated_path" yaml:"generated_path"`
+	GeneratedExists     bool   `json:"generated_exists" yaml:"generated_exists"`
+	SymlinkPath         string `json:"symlink_path" yaml:"symlink_path"`
+	Symlinked           bool   `json:"symlinked" yaml:"symlinked"`
+	SourcedByEntrypoint bool   `json:"sourced_by_entrypoint" yaml:"sourced_by_entrypoint"`
+}
+
+// GetComponentUsage reports whether a component is registered, has a
+// generated script, is symlinked into the acorn dir, and is sourced by the
+// installed shell.sh entrypoint.
+func (m *Manager) GetComponentUsage(name string) *ComponentUsage {
+	_, registered := m.components[name]
+	usage := &ComponentUsage{
+		Registered:    registered,
+		GeneratedPath: filepath.Join(m.getGeneratedShellDir(), name+m.ScriptExt()),
+		SymlinkPath:   filepath.Join(m.config.AcornDir, name+m.ScriptExt()),
+	}
+
+	if _, err := os.Stat(usage.GeneratedPath); err == nil {
+		usage.GeneratedExists = true
+	}
+
+	if li, err := os.Lstat(usage.SymlinkPath); err == nil && li.Mode()&os.ModeSymlink != 0 {
+		linked, lerr := os.Stat(usage.SymlinkPath)
+		generated, gerr := os.Stat(usage.GeneratedPath)
+		usage.Symlinked = lerr == nil && gerr == nil && os.SameFile(linked, generated)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(m.config.AcornDir, m.EntrypointName())); err == nil {
+		usage.SourcedByEntrypoint = strings.Contains(string(content), "/"+name+m.ScriptExt()+"\"")
+	}
+
+	return usage
+}
+
+// RemovedFile describes a file or symlink removed by Cleanup.
+type RemovedFile struct {
+	Path    string `json:"path" yaml:"path"`
+	Kind    string `json:"kind" yaml:"kind"` // "symlink" or "generated"
+	Removed bool   `json:"removed" yaml:"removed"`
+}
+
+// Cleanup removes the symlinks under the acorn dir that point into the
+// generated shell directory. When removeGenerated is true, the generated
+// .sh and .fish scripts and their hash sidecar are removed as well. In
+// dry-run mode nothing is deleted and each entry is reported with
+// Removed=false.
+func (m *Manager) Cleanup(removeGenerated bool) ([]RemovedFile, error) {
+	generatedDir := filepath.Clean(m.getGeneratedShellDir())
+	generatedDirs := []string{generatedDir}
+	if resolved, err := filepath.EvalSymlinks(generatedDir); err == nil && resolved != generatedDir {
+		generatedDirs = append(generatedDirs, resolved)
+	}
+
+	var removed []RemovedFile
+
+	entries, err := os.ReadDir(m.config.AcornDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", m.config.AcornDir, err)
+	}
+	for _, e := range entries {
+		if e.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+		linkPath := filepath.Join(m.config.AcornDir, e.Name())
+		target, err := os.Readlink(linkPath)
+		if err != nil {
+			continue
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(m.config.AcornDir, target)
+		}
+		if !isWithinAny(filepath.Clean(target), generatedDirs) {
+			continue
+		}
+
+		entry := RemovedFile{Path: linkPath, Kind: "symlink"}
+		if !m.config.DryRun {
+			if err := os.Remove(linkPath); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", linkPath, err)
+			}
+			entry.Removed = true
+		}
+		removed = append(removed, entry)
+	}
+
+	if !removeGenerated {
+		return removed, nil
+	}
+
+	entries, err = os.ReadDir(generatedDir)
+	if err != nil && !os.IsNotExist(err) {
+		return removed, fmt.Errorf("failed to read %s: %w", generatedDir, err)
+	}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || !(isScriptFile(e.Name()) || e.Name() == HashesFile) {
+			continue
+		}
+		path := filepath.Join(generatedDir, e.Name())
+
+		entry := RemovedFile{Path: path, Kind: "generated"}
+		if !m.config.DryRun {
+			if err := os.Remove(path); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			entry.Removed = true
+		}
+		removed = append(removed, entry)
+	}
+
+	return removed, nil
+}
+
+// isWithinAny reports whether path is inside one of dirs.
+func isWithinAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 // Status returns the current shell integration status.
 type Status struct {
 	Shell          string   `json:"shell" yaml:"shell"`
@@ -596,7 +970,7 @@ func (m *Manager) GetStatus() (*Status, error) {
 		// List generated files
 		entries, _ := os.ReadDir(m.config.AcornDir)
 		for _, e := range entries {
-			if strings.HasSuffix(e.Name(), ".sh") {
+			if isScriptFile(e.Name()) {
 				status.GeneratedFiles = append(status.GeneratedFiles, e.Name())
 			}
 		}
@@ -197,6 +197,114 @@ func TestGenerateComponentScript(t *testing.T) {
 	}
 }
 
+func TestGenerateComponentScriptProfiles(t *testing.T) {
+	component := &Component{
+		Name:        "test",
+		Description: "Test component",
+		Env:         "export TEST_VAR=value\n",
+		Aliases:     "alias t='test'\n",
+		Functions:   "test_func() { echo 'test'; }\n",
+		Completions: "complete -F _test test\n",
+	}
+
+	tests := []struct {
+		profile string
+		include []string
+		exclude []string
+	}{
+		{
+			profile: ProfileFull,
+			include: []string{"export TEST_VAR", "alias t=", "test_func()", "complete -F"},
+		},
+		{
+			profile: ProfileMinimal,
+			include: []string{"export TEST_VAR", "test_func()"},
+			exclude: []string{"alias t=", "complete -F"},
+		},
+		{
+			profile: ProfileAliasesOnly,
+			include: []string{"alias t="},
+			exclude: []string{"export TEST_VAR", "test_func()", "complete -F"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			config := NewConfig(false, true)
+			config.Profile = tt.profile
+			script := NewManager(config).generateComponentScript(component)
+
+			if !strings.Contains(script, "# Profile: "+tt.profile) {
+				t.Error("Missing profile comment")
+			}
+			for _, s := range tt.include {
+				if !strings.Contains(script, s) {
+					t.Errorf("Missing %q", s)
+				}
+			}
+			for _, s := range tt.exclude {
+				if strings.Contains(script, s) {
+					t.Errorf("Should not contain %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	for _, p := range Profiles {
+		if err := ValidateProfile(p); err != nil {
+			t.Errorf("ValidateProfile(%q) error = %v", p, err)
+		}
+	}
+	if err := ValidateProfile("tiny"); err == nil {
+		t.Error("ValidateProfile should reject unknown profiles")
+	}
+}
+
+func TestValidateShell(t *testing.T) {
+	for _, s := range Shells {
+		if err := ValidateShell(s); err != nil {
+			t.Errorf("ValidateShell(%q) error = %v", s, err)
+		}
+	}
+	for _, s := range []string{"ksh", "ZSH", ""} {
+		if err := ValidateShell(s); err == nil {
+			t.Errorf("ValidateShell(%q) should fail", s)
+		}
+	}
+}
+
+func TestGenerateShellOverride(t *testing.T) {
+	tmp := t.TempDir()
+	saplingDir := filepath.Join(tmp, ".sapling")
+	t.Setenv("SAPLING_DIR", saplingDir)
+	if err := os.MkdirAll(filepath.Join(saplingDir, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+	t.Setenv("SHELL", "/bin/bash")
+
+	config := NewConfig(false, true)
+	config.Shell = "zsh"
+	manager := NewManager(config)
+	manager.RegisterComponent(&Component{Name: "go"})
+
+	result, err := manager.GenerateAll()
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	if result.Shell != "zsh" {
+		t.Errorf("result.Shell = %q, want zsh", result.Shell)
+	}
+	if !strings.Contains(result.Entrypoint.Content, "acorn completion zsh") {
+		t.Error("entrypoint should load zsh completions")
+	}
+	if got := filepath.Base(manager.GetRCFile()); got != ".zshrc" {
+		t.Errorf("rc file = %s, want .zshrc", got)
+	}
+}
+
 func TestGenerateComponentDryRun(t *testing.T) {
 	config := NewConfig(false, true) // dry run = true
 	manager := NewManager(config)
@@ -387,6 +495,31 @@ func TestGenerateEntrypointBash(t *testing.T) {
 	}
 }
 
+func TestGenerateEntrypointProfile(t *testing.T) {
+	config := &Config{
+		AcornDir: "/home/user/.config/acorn",
+		Shell:    "bash",
+		Platform: "linux",
+		Profile:  ProfileAliasesOnly,
+	}
+	manager := NewManager(config)
+	manager.RegisterComponent(&Component{Name: "go"})
+
+	entrypoint := manager.generateEntrypoint()
+
+	if !strings.Contains(entrypoint, "# Profile: aliases-only") {
+		t.Error("Missing profile comment")
+	}
+
+	if !strings.Contains(entrypoint, "go.sh") {
+		t.Error("Missing go.sh sourcing")
+	}
+
+	if strings.Contains(entrypoint, "acorn completion") {
+		t.Error("Non-full profiles should not load completions")
+	}
+}
+
 func TestFileSpec(t *testing.T) {
 	spec := FileSpec{
 		Target: "/path/to/config",
@@ -478,3 +611,152 @@ func TestInjectMarkers(t *testing.T) {
 		t.Error("InjectMarkerEnd should contain 'acorn'")
 	}
 }
+
+func TestInjectUpdatesStaleBlock(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	rcFile := filepath.Join(home, ".bashrc")
+	stale := "export PATH=\"$HOME/bin:$PATH\"\n\n" +
+		InjectMarker + "\n" +
+		"export ACORN_CONFIG_DIR=\"/old/config/acorn\"\n" +
+		"[ -f \"$ACORN_CONFIG_DIR/shell.sh\" ] && . \"$ACORN_CONFIG_DIR/shell.sh\"\n" +
+		InjectMarkerEnd + "\n" +
+		"alias ll='ls -la'\n"
+	if err := os.WriteFile(rcFile, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	acornDir := filepath.Join(home, ".config", "acorn")
+	manager := NewManager(&Config{AcornDir: acornDir, Shell: "bash", Platform: "linux"})
+
+	// A dry run reports the update without writing
+	manager.config.DryRun = true
+	result, err := manager.Inject()
+	if err != nil {
+		t.Fatalf("Inject failed: %v", err)
+	}
+	if result.Action != "would_update" {
+		t.Errorf("dry-run Inject action = %q, want would_update", result.Action)
+	}
+	if content, _ := os.ReadFile(rcFile); string(content) != stale {
+		t.Error("dry run should not modify the rc file")
+	}
+	manager.config.DryRun = false
+
+	// Inject rewrites the stale block in place
+	result, err = manager.Inject()
+	if err != nil {
+		t.Fatalf("Inject failed: %v", err)
+	}
+	if result.Action != "updated" {
+		t.Errorf("Inject action = %q, want updated", result.Action)
+	}
+
+	content, err := os.ReadFile(rcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(content)
+	if strings.Contains(s, "/old/config/acorn") {
+		t.Error("stale ACORN_CONFIG_DIR should be replaced")
+	}
+	if !strings.Contains(s, "export ACORN_CONFIG_DIR=\""+acornDir+"\"") {
+		t.Error("current ACORN_CONFIG_DIR should be written")
+	}
+	if strings.Count(s, InjectMarker) != 1 {
+		t.Error("injection block should not be duplicated")
+	}
+	if want := "export PATH=\"$HOME/bin:$PATH\"\n\n" + manager.injectionBlock() + "\nalias ll='ls -la'\n"; s != want {
+		t.Errorf("content around the block should be preserved exactly:\n%s", s)
+	}
+
+	// A second inject is a no-op
+	result, err = manager.Inject()
+	if err != nil {
+		t.Fatalf("Inject failed: %v", err)
+	}
+	if result.Action != "already_injected" {
+		t.Errorf("second Inject action = %q, want already_injected", result.Action)
+	}
+}
+
+func TestPrintInjection(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	acornDir := filepath.Join(home, ".config", "acorn")
+	manager := NewManager(&Config{AcornDir: acornDir, Shell: "zsh", Platform: "linux"})
+
+	result := manager.PrintInjection()
+	if result.RCFile != filepath.Join(home, ".zshrc") {
+		t.Errorf("RCFile = %q", result.RCFile)
+	}
+	block := result.InjectionBlock
+	if !strings.HasPrefix(block, InjectMarker) || !strings.HasSuffix(block, InjectMarkerEnd) {
+		t.Errorf("block should be wrapped in markers: %q", block)
+	}
+	if !strings.Contains(block, "export ACORN_CONFIG_DIR=\""+acornDir+"\"") {
+		t.Errorf("block should export ACORN_CONFIG_DIR: %q", block)
+	}
+	if _, err := os.Stat(result.RCFile); !os.IsNotExist(err) {
+		t.Error("PrintInjection should not create the rc file")
+	}
+}
+
+func TestCleanupRemovesSymlinks(t *testing.T) {
+	tmp := t.TempDir()
+	saplingDir := filepath.Join(tmp, ".sapling")
+	t.Setenv("SAPLING_DIR", saplingDir)
+
+	genDir := filepath.Join(saplingDir, "generated", "shell")
+	acornDir := filepath.Join(tmp, "acorn")
+	for _, dir := range []string{genDir, acornDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	genFile := filepath.Join(genDir, "git.sh")
+	if err := os.WriteFile(genFile, []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(genFile, filepath.Join(acornDir, "git.sh")); err != nil {
+		t.Fatal(err)
+	}
+	// Unrelated user file must be kept
+	userFile := filepath.Join(acornDir, "local.sh")
+	if err := os.WriteFile(userFile, []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewManager(&Config{AcornDir: acornDir, Shell: "bash", Platform: "linux"})
+
+	removed, err := manager.Cleanup(false)
+	if err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	if len(removed) != 1 || removed[0].Kind != "symlink" || !removed[0].Removed {
+		t.Fatalf("Cleanup removed = %+v, want one symlink", removed)
+	}
+	if _, err := os.Lstat(filepath.Join(acornDir, "git.sh")); !os.IsNotExist(err) {
+		t.Error("symlink should be removed")
+	}
+	if _, err := os.Stat(genFile); err != nil {
+		t.Error("generated file should be kept without removeGenerated")
+	}
+	if _, err := os.Stat(userFile); err != nil {
+		t.Error("unrelated file should be kept")
+	}
+
+	removed, err = manager.Cleanup(true)
+	if err != nil {
+		t.Fatalf("Cleanup(true) failed: %v", err)
+	}
+	if len(removed) != 1 || removed[0].Kind != "generated" {
+		t.Fatalf("Cleanup(true) removed = %+v, want one generated file", removed)
+	}
+	if _, err := os.Stat(genFile); !os.IsNotExist(err) {
+		t.Error("generated file should be removed")
+	}
+}
@@ -0,0 +1,105 @@
+package tmux
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PluginResult is the outcome of installing a single TPM plugin.
+type PluginResult struct {
+	Plugin string `json:"plugin" yaml:"plugin"`
+	Status string `json:"status" yaml:"status"` // installed, already_installed, failed
+}
+
+// PluginInstallResult contains the result of installing declared plugins.
+type PluginInstallResult struct {
+	ConfigFile string         `json:"config_file" yaml:"config_file"`
+	Declared   []string       `json:"declared" yaml:"declared"`
+	Plugins    []PluginResult `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+}
+
+// pluginDeclRe matches `set -g @plugin 'owner/name'` style declarations.
+var pluginDeclRe = regexp.MustCompile(`^\s*set(?:-option)?\s+(?:-[a-zA-Z]+\s+)*@plugin\s+['"]?([^'"\s]+)['"]?`)
+
+// tpmOutputRe matches the per-plugin lines printed by TPM's install_plugins.
+var tpmOutputRe = regexp.MustCompile(`^\s*(?:Already installed "([^"]+)"|"([^"]+)" download (success|fail))`)
+
+// DeclaredPlugins returns the plugins declared with @plugin in a tmux config file.
+func DeclaredPlugins(configFile string) ([]string, error) {
+	f, err := os.Open(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tmux config: %w", err)
+	}
+	defer f.Close()
+
+	var plugins []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := pluginDeclRe.FindStringSubmatch(scanner.Text()); m != nil {
+			plugins = append(plugins, m[1])
+		}
+	}
+	return plugins, scanner.Err()
+}
+
+// ParsePluginOutput parses install_plugins output into per-plugin results.
+func ParsePluginOutput(out string) []PluginResult {
+	var results []PluginResult
+	for _, line := range strings.Split(out, "\n") {
+		m := tpmOutputRe.FindStringSubmatch(line)
+		switch {
+		case m == nil:
+			continue
+		case m[1] != "":
+			results = append(results, PluginResult{Plugin: m[1], Status: "already_installed"})
+		case m[3] == "success":
+			results = append(results, PluginResult{Plugin: m[2], Status: "installed"})
+		default:
+			results = append(results, PluginResult{Plugin: m[2], Status: "failed"})
+		}
+	}
+	return results
+}
+
+// InstallDeclaredPlugins runs TPM's non-interactive installer for the plugins
+// declared in tmux.conf. If no plugins are declared, nothing is run.
+func (h *Helper) InstallDeclaredPlugins() (*PluginInstallResult, error) {
+	configFile := GetConfigFile()
+	declared, err := DeclaredPlugins(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PluginInstallResult{ConfigFile: configFile, Declared: declared}
+	if len(declared) == 0 {
+		return result, nil
+	}
+
+	installScript := filepath.Join(GetTPMDir(), "bin", "install_plugins")
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: %s\n", installScript)
+		return result, nil
+	}
+	if !h.IsTPMInstalled() {
+		return nil, fmt.Errorf("TPM not installed. Run: acorn tmux tpm install")
+	}
+
+	if h.verbose {
+		fmt.Printf("Running: %s\n", installScript)
+	}
+
+	out, err := exec.Command(installScript).CombinedOutput()
+	result.Plugins = ParsePluginOutput(string(out))
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return result, fmt.Errorf("install_plugins failed: %s", msg)
+		}
+		return result, fmt.Errorf("install_plugins failed: %w", err)
+	}
+	return result, nil
+}
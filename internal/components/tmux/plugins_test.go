@@ -0,0 +1,58 @@
+package tmux
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeclaredPlugins(t *testing.T) {
+	conf := `# plugins
+set -g @plugin 'tmux-plugins/tpm'
+set -g @plugin "tmux-plugins/tmux-sensible"
+  set-option -g @plugin tmux-plugins/tmux-resurrect
+# set -g @plugin 'disabled/plugin'
+set -g @continuum-restore 'on'
+run '~/.tmux/plugins/tpm/tpm'
+`
+	path := filepath.Join(t.TempDir(), "tmux.conf")
+	if err := os.WriteFile(path, []byte(conf), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DeclaredPlugins(path)
+	if err != nil {
+		t.Fatalf("DeclaredPlugins: %v", err)
+	}
+	want := []string{"tmux-plugins/tpm", "tmux-plugins/tmux-sensible", "tmux-plugins/tmux-resurrect"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DeclaredPlugins = %v, want %v", got, want)
+	}
+
+	if _, err := DeclaredPlugins(filepath.Join(t.TempDir(), "missing.conf")); err == nil {
+		t.Error("missing config should fail")
+	}
+}
+
+func TestParsePluginOutput(t *testing.T) {
+	out := `Installing "tmux-sensible"
+  "tmux-sensible" download success
+Already installed "tpm"
+Installing "tmux-resurrect"
+  "tmux-resurrect" download fail
+
+TMUX environment reloaded.
+`
+	want := []PluginResult{
+		{Plugin: "tmux-sensible", Status: "installed"},
+		{Plugin: "tpm", Status: "already_installed"},
+		{Plugin: "tmux-resurrect", Status: "failed"},
+	}
+	if got := ParsePluginOutput(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePluginOutput = %v, want %v", got, want)
+	}
+	if got := ParsePluginOutput(""); len(got) != 0 {
+		t.Errorf("empty output = %v", got)
+	}
+}
@@ -0,0 +1,354 @@
+package tmux
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionSnapshot is a saved copy of a live tmux session.
+type SessionSnapshot struct {
+	Name    string           `json:"name" yaml:"name"`
+	SavedAt string           `json:"saved_at" yaml:"saved_at"`
+	Windows []WindowSnapshot `json:"windows" yaml:"windows"`
+}
+
+// WindowSnapshot is a saved tmux window.
+type WindowSnapshot struct {
+	Index  int            `json:"index" yaml:"index"`
+	Name   string         `json:"name" yaml:"name"`
+	Layout string         `json:"layout" yaml:"layout"`
+	Active bool           `json:"active" yaml:"active"`
+	Panes  []PaneSnapshot `json:"panes" yaml:"panes"`
+}
+
+// PaneSnapshot is a saved tmux pane.
+type PaneSnapshot struct {
+	Index   int    `json:"index" yaml:"index"`
+	Path    string `json:"path" yaml:"path"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	Active  bool   `json:"active" yaml:"active"`
+}
+
+// RestoreResult contains the result of restoring a session snapshot.
+type RestoreResult struct {
+	Session  string   `json:"session" yaml:"session"`
+	Windows  int      `json:"windows" yaml:"windows"`
+	Panes    int      `json:"panes" yaml:"panes"`
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// SessionKillResult reports which sessions a kill selected and removed.
+type SessionKillResult struct {
+	Matched []string `json:"matched" yaml:"matched"`
+	Killed  []string `json:"killed" yaml:"killed"`
+	Skipped []string `json:"skipped,omitempty" yaml:"skipped,omitempty"` // the current session, kept without --force
+	DryRun  bool     `json:"dry_run" yaml:"dry_run"`
+}
+
+// paneFormat is the list-panes format parsed by ParsePanes.
+const paneFormat = "#{window_index}\t#{window_name}\t#{window_layout}\t#{window_active}\t" +
+	"#{pane_index}\t#{pane_current_path}\t#{pane_current_command}\t#{pane_active}"
+
+// shellCommands are pane commands that are not replayed on restore.
+var shellCommands = map[string]bool{
+	"bash": true, "zsh": true, "fish": true, "sh": true, "dash": true, "ksh": true, "tmux": true,
+}
+
+// GetSessionSnapshotDir returns the default directory for saved sessions.
+func GetSessionSnapshotDir() string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(os.Getenv("HOME"), ".local", "share")
+	}
+	return filepath.Join(dataHome, "acorn", "tmux", "sessions")
+}
+
+// CurrentSession returns the name of the tmux session this process runs in.
+func (h *Helper) CurrentSession() (string, error) {
+	if os.Getenv("TMUX") == "" {
+		return "", fmt.Errorf("not inside tmux; specify a session name")
+	}
+	out, err := exec.Command("tmux", "display-message", "-p", "#S").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current session: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SelectSessionsToKill picks the sessions a kill applies to. With all every
+// session matches; otherwise a pattern containing *, ? or [ is a glob
+// matched against the whole name and any other pattern matches as a
+// substring. The current session is moved to Skipped unless force is set.
+func SelectSessionsToKill(sessions []SessionInfo, pattern string, all bool, current string, force bool) (*SessionKillResult, error) {
+	result := &SessionKillResult{Matched: []string{}, Killed: []string{}}
+	glob := strings.ContainsAny(pattern, "*?[")
+	if glob {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	for _, s := range sessions {
+		matched := all
+		if !all {
+			if glob {
+				matched, _ = path.Match(pattern, s.Name)
+			} else {
+				matched = strings.Contains(s.Name, pattern)
+			}
+		}
+		if !matched {
+			continue
+		}
+		if s.Name == current && !force {
+			result.Skipped = append(result.Skipped, s.Name)
+			continue
+		}
+		result.Matched = append(result.Matched, s.Name)
+	}
+
+	return result, nil
+}
+
+// KillSession kills a session by exact name.
+func (h *Helper) KillSession(name string) error {
+	_, err := h.tmuxOutput("", "kill-session", "-t", "="+name)
+	return err
+}
+
+// CaptureSession captures the windows, layouts, working directories and
+// running commands of a live session.
+func (h *Helper) CaptureSession(name string) (*SessionSnapshot, error) {
+	if !h.HasTmux() {
+		return nil, fmt.Errorf("tmux is not installed")
+	}
+	if exec.Command("tmux", "has-session", "-t", "="+name).Run() != nil {
+		return nil, fmt.Errorf("session not found: %s", name)
+	}
+
+	out, err := exec.Command("tmux", "list-panes", "-s", "-t", "="+name, "-F", paneFormat).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list panes: %w", err)
+	}
+
+	windows, err := ParsePanes(string(out))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionSnapshot{
+		Name:    name,
+		SavedAt: time.Now().Format(time.RFC3339),
+		Windows: windows,
+	}, nil
+}
+
+// ParsePanes parses list-panes output in paneFormat into windows.
+// Windows and panes keep the order tmux reports them in.
+func ParsePanes(out string) ([]WindowSnapshot, error) {
+	var windows []WindowSnapshot
+	byIndex := make(map[int]int)
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, "\t")
+		if len(parts) != 8 {
+			return nil, fmt.Errorf("unexpected list-panes output: %q", line)
+		}
+
+		winIndex, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid window index %q", parts[0])
+		}
+		paneIndex, err := strconv.Atoi(parts[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pane index %q", parts[4])
+		}
+
+		i, ok := byIndex[winIndex]
+		if !ok {
+			windows = append(windows, WindowSnapshot{
+				Index:  winIndex,
+				Name:   parts[1],
+				Layout: parts[2],
+				Active: parts[3] == "1",
+			})
+			i = len(windows) - 1
+			byIndex[winIndex] = i
+		}
+
+		pane := PaneSnapshot{
+			Index:  paneIndex,
+			Path:   parts[5],
+			Active: parts[7] == "1",
+		}
+		if !shellCommands[parts[6]] {
+			pane.Command = parts[6]
+		}
+		windows[i].Panes = append(windows[i].Panes, pane)
+	}
+
+	return windows, nil
+}
+
+// SaveSessionSnapshot writes a snapshot as JSON to path.
+func (h *Helper) SaveSessionSnapshot(snapshot *SessionSnapshot, path string) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would write session snapshot: %s\n", path)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadSessionSnapshot reads a snapshot from path.
+func LoadSessionSnapshot(path string) (*SessionSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot SessionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if len(snapshot.Windows) == 0 {
+		return nil, fmt.Errorf("snapshot %s has no windows", path)
+	}
+	return &snapshot, nil
+}
+
+// RestoreSession recreates a snapshot as a detached session. If name is
+// empty, the snapshot's session name is used. Panes whose working directory
+// no longer exists start in the home directory instead, as does the single
+// pane given to a window saved without any, so that every saved window is
+// recreated in order.
+func (h *Helper) RestoreSession(snapshot *SessionSnapshot, name string) (*RestoreResult, error) {
+	if name == "" {
+		name = snapshot.Name
+	}
+	if name == "" {
+		return nil, fmt.Errorf("session name is required")
+	}
+	if !h.HasTmux() {
+		return nil, fmt.Errorf("tmux is not installed")
+	}
+	if exec.Command("tmux", "has-session", "-t", "="+name).Run() == nil {
+		return nil, fmt.Errorf("session already exists: %s", name)
+	}
+
+	result := &RestoreResult{Session: name}
+	home, _ := os.UserHomeDir()
+
+	resolvePath := func(path string) string {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s no longer exists, using %s", path, home))
+		return home
+	}
+
+	var activeWindow string
+	for wi, w := range snapshot.Windows {
+		if len(w.Panes) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("window %d (%s) has no panes, starting a shell in %s", w.Index, w.Name, home))
+			w.Panes = []PaneSnapshot{{Path: home, Active: true}}
+		}
+
+		var args []string
+		if wi == 0 {
+			args = []string{"new-session", "-d", "-s", name}
+		} else {
+			args = []string{"new-window", "-t", "=" + name + ":"}
+		}
+		args = append(args, "-P", "-F", "#{window_id} #{pane_id}", "-c", resolvePath(w.Panes[0].Path))
+		if w.Name != "" {
+			args = append(args, "-n", w.Name)
+		}
+
+		out, err := h.tmuxOutput(fmt.Sprintf("@%d %%%d", wi, result.Panes), args...)
+		if err != nil {
+			return result, fmt.Errorf("failed to create window %s: %w", w.Name, err)
+		}
+		windowID, firstPane, _ := strings.Cut(out, " ")
+		paneIDs := []string{firstPane}
+		result.Windows++
+		result.Panes++
+
+		for _, p := range w.Panes[1:] {
+			paneID, err := h.tmuxOutput(fmt.Sprintf("%%%d", result.Panes),
+				"split-window", "-d", "-t", windowID, "-P", "-F", "#{pane_id}", "-c", resolvePath(p.Path))
+			if err != nil {
+				return result, fmt.Errorf("failed to split window %s: %w", w.Name, err)
+			}
+			paneIDs = append(paneIDs, paneID)
+			result.Panes++
+		}
+
+		if w.Layout != "" {
+			if _, err := h.tmuxOutput("", "select-layout", "-t", windowID, w.Layout); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("could not apply layout to window %s", w.Name))
+			}
+		}
+
+		for i, p := range w.Panes {
+			if p.Command != "" {
+				if _, err := h.tmuxOutput("", "send-keys", "-t", paneIDs[i], p.Command, "Enter"); err != nil {
+					return result, fmt.Errorf("failed to start %s: %w", p.Command, err)
+				}
+			}
+			if p.Active {
+				h.tmuxOutput("", "select-pane", "-t", paneIDs[i])
+			}
+		}
+
+		if w.Active {
+			activeWindow = windowID
+		}
+	}
+
+	if activeWindow != "" {
+		h.tmuxOutput("", "select-window", "-t", activeWindow)
+	}
+
+	return result, nil
+}
+
+// tmuxOutput runs tmux and returns its trimmed output. In dry-run mode the
+// command is printed and placeholder is returned instead.
+func (h *Helper) tmuxOutput(placeholder string, args ...string) (string, error) {
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run: tmux %s\n", strings.Join(args, " "))
+		return placeholder, nil
+	}
+
+	if h.verbose {
+		fmt.Printf("Running: tmux %s\n", strings.Join(args, " "))
+	}
+
+	out, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
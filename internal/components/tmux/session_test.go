@@ -0,0 +1,108 @@
+package tmux
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectSessionsToKill(t *testing.T) {
+	sessions := []SessionInfo{{Name: "test-a"}, {Name: "test-b"}, {Name: "work"}, {Name: "my-test"}}
+
+	tests := []struct {
+		name    string
+		pattern string
+		all     bool
+		current string
+		force   bool
+		matched []string
+		skipped []string
+	}{
+		{name: "glob", pattern: "test-*", matched: []string{"test-a", "test-b"}},
+		{name: "substring", pattern: "test", matched: []string{"test-a", "test-b", "my-test"}},
+		{name: "current skipped", pattern: "test-*", current: "test-a", matched: []string{"test-b"}, skipped: []string{"test-a"}},
+		{name: "current forced", pattern: "test-*", current: "test-a", force: true, matched: []string{"test-a", "test-b"}},
+		{name: "all", all: true, current: "work", matched: []string{"test-a", "test-b", "my-test"}, skipped: []string{"work"}},
+		{name: "none", pattern: "zzz", matched: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SelectSessionsToKill(sessions, tt.pattern, tt.all, tt.current, tt.force)
+			if err != nil {
+				t.Fatalf("SelectSessionsToKill: %v", err)
+			}
+			if !reflect.DeepEqual(result.Matched, tt.matched) || !reflect.DeepEqual(result.Skipped, tt.skipped) {
+				t.Errorf("matched %v skipped %v, want %v and %v", result.Matched, result.Skipped, tt.matched, tt.skipped)
+			}
+		})
+	}
+
+	if _, err := SelectSessionsToKill(sessions, "[", false, "", false); err == nil {
+		t.Error("malformed glob should fail")
+	}
+}
+
+func TestParsePanes(t *testing.T) {
+	out := "0\teditor\tb25d,80x24,0,0\t1\t0\t/src/app\tnvim\t1\n" +
+		"0\teditor\tb25d,80x24,0,0\t1\t1\t/src/app/web\tzsh\t0\n" +
+		"2\tlogs\tc3f1,80x24,0,0\t0\t0\t/var/log\ttail\t1\n"
+
+	got, err := ParsePanes(out)
+	if err != nil {
+		t.Fatalf("ParsePanes: %v", err)
+	}
+	want := []WindowSnapshot{
+		{Index: 0, Name: "editor", Layout: "b25d,80x24,0,0", Active: true, Panes: []PaneSnapshot{
+			{Index: 0, Path: "/src/app", Command: "nvim", Active: true},
+			{Index: 1, Path: "/src/app/web"},
+		}},
+		{Index: 2, Name: "logs", Layout: "c3f1,80x24,0,0", Panes: []PaneSnapshot{
+			{Index: 0, Path: "/var/log", Command: "tail", Active: true},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePanes =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got, err := ParsePanes("\n"); err != nil || len(got) != 0 {
+		t.Errorf("empty output: %v, %v", got, err)
+	}
+
+	for name, line := range map[string]string{
+		"too few fields":   "0\teditor\tlayout\t1\t0\t/src\tzsh",
+		"bad window index": "x\teditor\tlayout\t1\t0\t/src\tzsh\t1",
+		"bad pane index":   "0\teditor\tlayout\t1\tx\t/src\tzsh\t1",
+	} {
+		if _, err := ParsePanes(line); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRestoreSessionEmptyWindow(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	snapshot := &SessionSnapshot{
+		Name: "acorn-test-restore-empty-window",
+		Windows: []WindowSnapshot{
+			{Index: 0, Name: "empty"},
+			{Index: 1, Name: "work", Panes: []PaneSnapshot{{Path: home}, {Path: home}}},
+		},
+	}
+
+	result, err := NewHelper(false, true).RestoreSession(snapshot, "")
+	if err != nil {
+		t.Fatalf("RestoreSession: %v", err)
+	}
+	if result.Windows != 2 || result.Panes != 3 {
+		t.Errorf("restored %d windows and %d panes, want 2 and 3", result.Windows, result.Panes)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "no panes") {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+}
@@ -0,0 +1,277 @@
+package tmux
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/editor"
+	"gopkg.in/yaml.v3"
+)
+
+// SmugConfig is a smug session configuration file.
+type SmugConfig struct {
+	Session string       `yaml:"session"`
+	Root    string       `yaml:"root,omitempty"`
+	Attach  bool         `yaml:"attach,omitempty"`
+	Windows []SmugWindow `yaml:"windows"`
+}
+
+// SmugWindow is a window in a smug config. Its root and commands apply to
+// the window's first pane; Panes lists the additional splits.
+type SmugWindow struct {
+	Name     string     `yaml:"name"`
+	Root     string     `yaml:"root,omitempty"`
+	Layout   string     `yaml:"layout,omitempty"`
+	Commands []string   `yaml:"commands,omitempty"`
+	Panes    []SmugPane `yaml:"panes,omitempty"`
+}
+
+// SmugPane is an additional pane in a smug window.
+type SmugPane struct {
+	Type     string   `yaml:"type,omitempty"` // horizontal or vertical
+	Root     string   `yaml:"root,omitempty"`
+	Commands []string `yaml:"commands,omitempty"`
+}
+
+// SmugConfigFromSnapshot converts a captured session into a smug config
+// named name. The session root is the first pane's directory; window and
+// pane roots are only set where they differ from their parent. Duplicate
+// window names, which tmux allows but smug does not, get a numeric suffix.
+func SmugConfigFromSnapshot(snapshot *SessionSnapshot, name string) *SmugConfig {
+	cfg := &SmugConfig{Session: name, Attach: true}
+	if len(snapshot.Windows) > 0 && len(snapshot.Windows[0].Panes) > 0 {
+		cfg.Root = snapshot.Windows[0].Panes[0].Path
+	}
+
+	used := make(map[string]bool)
+	for _, w := range snapshot.Windows {
+		base := w.Name
+		if base == "" {
+			base = fmt.Sprintf("window-%d", w.Index)
+		}
+		winName := base
+		for n := 2; used[winName]; n++ {
+			winName = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[winName] = true
+
+		win := SmugWindow{Name: winName, Layout: w.Layout}
+		if len(w.Panes) > 0 {
+			first := w.Panes[0]
+			if first.Path != cfg.Root {
+				win.Root = first.Path
+			}
+			if first.Command != "" {
+				win.Commands = []string{first.Command}
+			}
+
+			windowRoot := first.Path
+			for _, p := range w.Panes[1:] {
+				pane := SmugPane{Type: "horizontal"}
+				if p.Path != windowRoot {
+					pane.Root = p.Path
+				}
+				if p.Command != "" {
+					pane.Commands = []string{p.Command}
+				}
+				win.Panes = append(win.Panes, pane)
+			}
+		}
+		cfg.Windows = append(cfg.Windows, win)
+	}
+
+	return cfg
+}
+
+// ValidateSmugConfig checks that a smug config can be started by smug.
+func ValidateSmugConfig(cfg *SmugConfig) error {
+	if strings.TrimSpace(cfg.Session) == "" {
+		return fmt.Errorf("smug config: session name is required")
+	}
+	if strings.ContainsAny(cfg.Session, ".:") {
+		return fmt.Errorf("smug config: session name %q must not contain '.' or ':'", cfg.Session)
+	}
+	if len(cfg.Windows) == 0 {
+		return fmt.Errorf("smug config: at least one window is required")
+	}
+
+	seen := make(map[string]bool)
+	for i, w := range cfg.Windows {
+		if strings.TrimSpace(w.Name) == "" {
+			return fmt.Errorf("smug config: windows[%d] has no name", i)
+		}
+		if seen[w.Name] {
+			return fmt.Errorf("smug config: duplicate window name %q", w.Name)
+		}
+		seen[w.Name] = true
+
+		for j, p := range w.Panes {
+			if p.Type != "" && p.Type != "horizontal" && p.Type != "vertical" {
+				return fmt.Errorf("smug config: windows[%d].panes[%d] type must be horizontal or vertical, got %q", i, j, p.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalSmugConfig renders a smug config as YAML with a usage header.
+func MarshalSmugConfig(cfg *SmugConfig, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# smug session: %s\n", cfg.Session)
+	fmt.Fprintf(&buf, "# Created: %s from tmux session %q\n", getCurrentDate(), source)
+	fmt.Fprintf(&buf, "# Usage: smug start %s\n\n", cfg.Session)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to encode smug config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CreateSmugSessionFromTmux captures a live tmux session and writes it as
+// the smug config name. The generated config is validated before writing.
+func (h *Helper) CreateSmugSessionFromTmux(name, session string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("session name required")
+	}
+
+	configFile := filepath.Join(GetSmugConfigDir(), name+".yml")
+	if _, err := os.Stat(configFile); err == nil {
+		return configFile, fmt.Errorf("config already exists: %s", configFile)
+	}
+
+	snapshot, err := h.CaptureSession(session)
+	if err != nil {
+		return "", err
+	}
+
+	cfg := SmugConfigFromSnapshot(snapshot, name)
+	if err := ValidateSmugConfig(cfg); err != nil {
+		return "", err
+	}
+
+	data, err := MarshalSmugConfig(cfg, session)
+	if err != nil {
+		return "", err
+	}
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would create: %s\n", configFile)
+		return configFile, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configFile), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create smug config directory: %w", err)
+	}
+	if err := os.WriteFile(configFile, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to create config: %w", err)
+	}
+
+	return configFile, nil
+}
+
+// SmugEditResult reports the outcome of editing a smug config.
+type SmugEditResult struct {
+	Path     string `json:"path" yaml:"path"`
+	Changed  bool   `json:"changed" yaml:"changed"`
+	Valid    bool   `json:"valid" yaml:"valid"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+	Reverted bool   `json:"reverted,omitempty" yaml:"reverted,omitempty"`
+
+	session *editor.Session
+}
+
+// GetSmugConfigPath returns the config file of the smug session name.
+func GetSmugConfigPath(name string) string {
+	return filepath.Join(GetSmugConfigDir(), strings.TrimSuffix(name, ".yml")+".yml")
+}
+
+// ParseSmugConfig parses and validates smug config YAML.
+func ParseSmugConfig(data []byte) (*SmugConfig, error) {
+	var cfg SmugConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("smug config: invalid YAML: %w", err)
+	}
+	if err := ValidateSmugConfig(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// EditSmugConfig opens the smug config name in the user's editor without
+// checking the result.
+func (h *Helper) EditSmugConfig(name string) error {
+	path := GetSmugConfigPath(name)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("smug config not found: %s", path)
+	}
+	return editor.Run(path)
+}
+
+// EditSmugConfigValidated snapshots the smug config name, opens it in the
+// user's editor and, once the editor exits, validates it. Use
+// ReopenSmugEdit to fix an invalid config and RevertSmugEdit to restore
+// the snapshot.
+func (h *Helper) EditSmugConfigValidated(name string) (*SmugEditResult, error) {
+	path := GetSmugConfigPath(name)
+	session, err := editor.Start(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("smug config not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read smug config: %w", err)
+	}
+
+	result := &SmugEditResult{Path: path, session: session}
+	if err := h.ReopenSmugEdit(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReopenSmugEdit opens the edited config in the editor again and
+// re-checks it against the snapshot taken before the first edit.
+func (h *Helper) ReopenSmugEdit(result *SmugEditResult) error {
+	after, err := result.session.Edit()
+	if err != nil {
+		return err
+	}
+	checkSmugEdit(result, after)
+	return nil
+}
+
+// checkSmugEdit updates result from the config content after editing.
+func checkSmugEdit(result *SmugEditResult, after []byte) {
+	result.Changed = !bytes.Equal(result.session.Original(), after)
+	result.Valid = true
+	result.Error = ""
+	if _, err := ParseSmugConfig(after); err != nil {
+		result.Valid = false
+		result.Error = err.Error()
+	}
+}
+
+// RevertSmugEdit restores the smug config to its content before the edit.
+func (h *Helper) RevertSmugEdit(result *SmugEditResult) error {
+	if result.session == nil {
+		return fmt.Errorf("no snapshot to revert to")
+	}
+	if h.dryRun {
+		fmt.Printf("[dry-run] would restore %s\n", result.Path)
+		return nil
+	}
+
+	if err := result.session.Revert(); err != nil {
+		return err
+	}
+	checkSmugEdit(result, result.session.Original())
+	result.Reverted = true
+	return nil
+}
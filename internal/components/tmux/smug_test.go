@@ -0,0 +1,133 @@
+package tmux
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/editor"
+)
+
+func TestParseSmugConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", "session: work\nwindows:\n  - name: code\n", ""},
+		{"invalid yaml", "session: [work\n", "invalid YAML"},
+		{"no windows", "session: work\n", "at least one window"},
+		{"bad pane type", "session: work\nwindows:\n  - name: code\n    panes:\n      - type: diagonal\n", "horizontal or vertical"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSmugConfig([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckSmugEdit(t *testing.T) {
+	original := []byte("session: work\nwindows:\n  - name: code\n")
+	result := &SmugEditResult{Path: "work.yml", session: editor.NewSession("work.yml", original)}
+
+	checkSmugEdit(result, original)
+	if result.Changed || !result.Valid {
+		t.Fatalf("unchanged edit: %+v", result)
+	}
+
+	checkSmugEdit(result, []byte("session: work\nwindows: []\n"))
+	if !result.Changed || result.Valid || result.Error == "" {
+		t.Fatalf("invalid edit: %+v", result)
+	}
+
+	checkSmugEdit(result, []byte("session: work\nwindows:\n  - name: logs\n"))
+	if !result.Changed || !result.Valid || result.Error != "" {
+		t.Fatalf("fixed edit: %+v", result)
+	}
+}
+
+func TestSmugConfigFromSnapshot(t *testing.T) {
+	snapshot := &SessionSnapshot{
+		Name: "live",
+		Windows: []WindowSnapshot{
+			{Index: 0, Name: "code", Layout: "tiled", Panes: []PaneSnapshot{
+				{Path: "/src/app", Command: "nvim"},
+				{Path: "/src/app"},
+				{Path: "/src/app/web", Command: "npm run dev"},
+			}},
+			{Index: 1, Name: "code", Panes: []PaneSnapshot{{Path: "/var/log", Command: "tail"}}},
+			{Index: 2, Panes: []PaneSnapshot{{Path: "/src/app"}}},
+		},
+	}
+
+	cfg := SmugConfigFromSnapshot(snapshot, "work")
+	want := &SmugConfig{
+		Session: "work",
+		Root:    "/src/app",
+		Attach:  true,
+		Windows: []SmugWindow{
+			{Name: "code", Layout: "tiled", Commands: []string{"nvim"}, Panes: []SmugPane{
+				{Type: "horizontal"},
+				{Type: "horizontal", Root: "/src/app/web", Commands: []string{"npm run dev"}},
+			}},
+			{Name: "code-2", Root: "/var/log", Commands: []string{"tail"}},
+			{Name: "window-2"},
+		},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("SmugConfigFromSnapshot =\n%+v\nwant\n%+v", cfg, want)
+	}
+	if err := ValidateSmugConfig(cfg); err != nil {
+		t.Errorf("generated config is invalid: %v", err)
+	}
+
+	data, err := MarshalSmugConfig(cfg, "live")
+	if err != nil {
+		t.Fatalf("MarshalSmugConfig: %v", err)
+	}
+	if !strings.Contains(string(data), `from tmux session "live"`) {
+		t.Errorf("missing source header:\n%s", data)
+	}
+	if _, err := ParseSmugConfig(data); err != nil {
+		t.Errorf("marshalled config does not parse: %v", err)
+	}
+}
+
+func TestValidateSmugConfig(t *testing.T) {
+	window := []SmugWindow{{Name: "code"}}
+	tests := []struct {
+		name    string
+		cfg     SmugConfig
+		wantErr string
+	}{
+		{"valid", SmugConfig{Session: "work", Windows: window}, ""},
+		{"no session", SmugConfig{Windows: window}, "session name is required"},
+		{"dotted session", SmugConfig{Session: "my.app", Windows: window}, "must not contain"},
+		{"no windows", SmugConfig{Session: "work"}, "at least one window"},
+		{"unnamed window", SmugConfig{Session: "work", Windows: []SmugWindow{{}}}, "has no name"},
+		{"duplicate window", SmugConfig{Session: "work", Windows: []SmugWindow{{Name: "a"}, {Name: "a"}}}, "duplicate window name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSmugConfig(&tt.cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@
 package tmux
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/gitrepo"
 )
 
 // SessionInfo contains tmux session information.
@@ -42,6 +45,15 @@ type SmugRepoStatus struct {
 	Sessions    []SmugSession `json:"sessions,omitempty" yaml:"sessions,omitempty"`
 }
 
+// SmugPushResult reports what SmugRepoPush did.
+type SmugPushResult struct {
+	Committed bool `json:"committed" yaml:"committed"`
+	Pulled    bool `json:"pulled" yaml:"pulled"`   // remote commits were pulled before pushing
+	Commits   int  `json:"commits" yaml:"commits"` // number of remote commits pulled
+	Clean     bool `json:"clean" yaml:"clean"`     // the pull rebased without conflicts
+	Pushed    bool `json:"pushed" yaml:"pushed"`
+}
+
 // Helper provides tmux helper operations.
 type Helper struct {
 	verbose bool
@@ -116,12 +128,92 @@ func GetSmugRepoDir() string {
 	return filepath.Join(dataHome, "smug-sessions")
 }
 
+// DefaultSmugRepo is the upstream smug sessions repository.
+const DefaultSmugRepo = "https://github.com/MisterGrinvalds/fmux.git"
+
+// GetSmugRepoFile returns the file storing the user's chosen smug repo URL.
+func GetSmugRepoFile() string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(os.Getenv("HOME"), ".local", "share")
+	}
+	return filepath.Join(dataHome, "acorn", "smug-repo")
+}
+
 // GetSmugRepo returns the smug git repo URL.
+// Precedence: SMUG_REPO env, the URL saved by repo-init --repo, then the default.
 func GetSmugRepo() string {
 	if repo := os.Getenv("SMUG_REPO"); repo != "" {
 		return repo
 	}
-	return "https://github.com/MisterGrinvalds/fmux.git"
+	if data, err := os.ReadFile(GetSmugRepoFile()); err == nil {
+		if repo := strings.TrimSpace(string(data)); repo != "" {
+			return repo
+		}
+	}
+	return DefaultSmugRepo
+}
+
+// SaveSmugRepo persists the smug repo URL for subsequent repo-init runs.
+func (h *Helper) SaveSmugRepo(repoURL string) error {
+	file := GetSmugRepoFile()
+	if h.dryRun {
+		fmt.Printf("[dry-run] would save smug repo %s to %s\n", repoURL, file)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return os.WriteFile(file, []byte(repoURL+"\n"), 0o644)
+}
+
+// ValidateRepoURL checks that a string looks like a git remote URL.
+// Accepts http(s)://, ssh://, git://, file:// URLs, scp-style
+// user@host:path remotes, and existing local directories.
+func ValidateRepoURL(repoURL string) error {
+	if repoURL == "" {
+		return fmt.Errorf("repository URL cannot be empty")
+	}
+	if strings.ContainsAny(repoURL, " \t\n") {
+		return fmt.Errorf("invalid repository URL: %q", repoURL)
+	}
+
+	for _, scheme := range []string{"https://", "http://", "ssh://", "git://", "file://"} {
+		if strings.HasPrefix(repoURL, scheme) {
+			if len(repoURL) == len(scheme) {
+				return fmt.Errorf("invalid repository URL: %q", repoURL)
+			}
+			return nil
+		}
+	}
+
+	// scp-style: git@github.com:user/repo.git
+	if at := strings.Index(repoURL, "@"); at > 0 {
+		if colon := strings.Index(repoURL[at:], ":"); colon > 1 && at+colon < len(repoURL)-1 {
+			return nil
+		}
+	}
+
+	if info, err := os.Stat(repoURL); err == nil && info.IsDir() {
+		return nil
+	}
+
+	return fmt.Errorf("invalid repository URL: %q (expected https://, ssh://, or user@host:path)", repoURL)
+}
+
+// GetSmugRepoRemote returns the origin URL of the local smug repo.
+func (h *Helper) GetSmugRepoRemote() (string, error) {
+	out, err := exec.Command("git", "-C", GetSmugRepoDir(), "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get smug repo remote: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SetSmugRepoRemote re-points the local smug repo's origin to a new URL.
+func (h *Helper) SetSmugRepoRemote(repoURL string) error {
+	return h.runInDir(GetSmugRepoDir(), "git", "remote", "set-url", "origin", repoURL)
 }
 
 // HasTmux checks if tmux is installed.
@@ -396,9 +488,12 @@ func (h *Helper) InstallSmug() error {
 }
 
 // SmugRepoInit initializes the smug sessions git repo.
-func (h *Helper) SmugRepoInit() error {
+// If repoURL is empty, the configured repo (see GetSmugRepo) is cloned.
+func (h *Helper) SmugRepoInit(repoURL string) error {
 	repoDir := GetSmugRepoDir()
-	repoURL := GetSmugRepo()
+	if repoURL == "" {
+		repoURL = GetSmugRepo()
+	}
 
 	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err == nil {
 		fmt.Printf("Smug repo already initialized at: %s\n", repoDir)
@@ -469,40 +564,85 @@ func (h *Helper) SmugRepoPull() error {
 	return h.runInDir(repoDir, "git", "pull", "--rebase")
 }
 
-// SmugRepoPush commits and pushes changes.
-func (h *Helper) SmugRepoPush(message string) error {
+// SmugRepoPush commits and pushes changes. With syncFirst, remote changes
+// are pulled (rebase with autostash) before pushing so the push is not
+// rejected when another machine pushed first. Local commits left unpushed by
+// an earlier rejected push are pushed even when there is nothing new to commit.
+func (h *Helper) SmugRepoPush(message string, syncFirst bool) (*SmugPushResult, error) {
 	repoDir := GetSmugRepoDir()
+	result := &SmugPushResult{Clean: true}
 
 	if _, err := os.Stat(filepath.Join(repoDir, ".git")); os.IsNotExist(err) {
-		return fmt.Errorf("smug repo not initialized. Run: acorn tmux smug repo-init")
+		return nil, fmt.Errorf("smug repo not initialized. Run: acorn tmux smug repo-init")
 	}
 
 	// Check for changes
 	out, err := exec.Command("git", "-C", repoDir, "status", "--porcelain").Output()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if len(strings.TrimSpace(string(out))) == 0 {
-		return fmt.Errorf("no changes to push")
+	hasChanges := len(strings.TrimSpace(string(out))) > 0
+	if !hasChanges && (gitrepo.Repo{Dir: repoDir}).RevCount("@{u}..HEAD") == 0 {
+		return nil, fmt.Errorf("no changes to push")
 	}
 
-	if message == "" {
-		message = "Update smug sessions"
-	}
+	if hasChanges {
+		if message == "" {
+			message = "Update smug sessions"
+		}
+
+		// Add yml files; each pattern separately since git rejects the
+		// whole add when one pattern matches nothing
+		if h.dryRun {
+			fmt.Printf("[dry-run] would run in %s: git add -- *.yml *.yaml\n", repoDir)
+		} else {
+			for _, pattern := range []string{"*.yml", "*.yaml"} {
+				exec.Command("git", "-C", repoDir, "add", "--", pattern).Run()
+			}
+		}
 
-	// Add yml files
-	if err := h.runInDir(repoDir, "git", "add", "*.yml", "*.yaml"); err != nil {
-		// Ignore if no yml files
+		// Commit
+		if err := h.runInDir(repoDir, "git", "commit", "-m", message); err != nil {
+			return nil, fmt.Errorf("commit failed: %w", err)
+		}
+		result.Committed = true
 	}
 
-	// Commit
-	if err := h.runInDir(repoDir, "git", "commit", "-m", message); err != nil {
-		return fmt.Errorf("commit failed: %w", err)
+	if syncFirst {
+		if err := h.pullBeforePush(repoDir, result); err != nil {
+			return result, err
+		}
 	}
 
 	// Push
 	fmt.Println("Pushing to remote...")
-	return h.runInDir(repoDir, "git", "push")
+	if err := h.runInDir(repoDir, "git", "push"); err != nil {
+		return result, fmt.Errorf("push failed: %w", err)
+	}
+	result.Pushed = true
+
+	return result, nil
+}
+
+// pullBeforePush fetches the upstream of repoDir and, when it has new
+// commits, rebases local commits onto it. A conflicting rebase is aborted so
+// the local commits are left as they were.
+func (h *Helper) pullBeforePush(repoDir string, result *SmugPushResult) error {
+	if h.dryRun {
+		fmt.Printf("[dry-run] would run in %s: git pull --rebase --autostash\n", repoDir)
+		return nil
+	}
+
+	behind, err := gitrepo.Repo{Dir: repoDir}.PullBeforePush(os.Stdout, os.Stderr)
+	if behind > 0 {
+		result.Pulled = true
+		result.Commits = behind
+	}
+	if errors.Is(err, gitrepo.ErrConflict) {
+		result.Clean = false
+		return fmt.Errorf("pull had conflicts and was aborted; your commit is kept locally: %w", err)
+	}
+	return err
 }
 
 // SmugRepoSync does a full sync (pull + push).
@@ -539,7 +679,7 @@ func (h *Helper) SmugRepoSync() error {
 		if err := h.runInDir(repoDir, "git", "stash", "pop"); err != nil {
 			return fmt.Errorf("stash pop failed: %w", err)
 		}
-		if err := h.SmugRepoPush("Sync local session changes"); err != nil {
+		if _, err := h.SmugRepoPush("Sync local session changes", false); err != nil {
 			return err
 		}
 	}
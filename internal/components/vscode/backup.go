@@ -0,0 +1,216 @@
+package vscode
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoLiveConfig is returned by CreateBackup when there is no live
+// configuration to back up.
+var ErrNoLiveConfig = errors.New("no VS Code configuration to back up")
+
+// backupTimeFormat names backup directories so they sort chronologically.
+const backupTimeFormat = "20060102_150405"
+
+// Backup is a snapshot of the live VS Code user configuration.
+type Backup struct {
+	Name      string   `json:"name" yaml:"name"`
+	Path      string   `json:"path" yaml:"path"`
+	Timestamp string   `json:"timestamp" yaml:"timestamp"`
+	Files     []string `json:"files" yaml:"files"`
+}
+
+// backupEntries are the parts of the user directory that are backed up:
+// settings, keybindings and the snippets directory.
+func backupEntries() []string {
+	return []string{"settings.json", "keybindings.json", "snippets"}
+}
+
+// BackupDir returns where backups are stored.
+func (h *Helper) BackupDir() string {
+	xdgData := os.Getenv("XDG_DATA_HOME")
+	if xdgData == "" {
+		home, _ := os.UserHomeDir()
+		xdgData = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(xdgData, "vscode", "backups")
+}
+
+// CreateBackup copies the live settings, keybindings and snippets into a
+// new timestamped directory under BackupDir. Symlinked files are backed up
+// by content. It returns ErrNoLiveConfig when none of them exist.
+func (h *Helper) CreateBackup() (*Backup, error) {
+	userDir := h.GetConfigPaths().UserDir
+
+	var present []string
+	for _, entry := range backupEntries() {
+		if _, err := os.Stat(filepath.Join(userDir, entry)); err == nil {
+			present = append(present, entry)
+		}
+	}
+	if len(present) == 0 {
+		return nil, ErrNoLiveConfig
+	}
+
+	timestamp := time.Now().Format(backupTimeFormat)
+	name := timestamp
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(h.BackupDir(), name)); os.IsNotExist(err) {
+			break
+		}
+		name = fmt.Sprintf("%s_%d", timestamp, i)
+	}
+	backup := &Backup{
+		Name:      name,
+		Path:      filepath.Join(h.BackupDir(), name),
+		Timestamp: timestamp,
+		Files:     present,
+	}
+
+	if h.dryRun {
+		fmt.Printf("[dry-run] would back up %s to: %s\n", strings.Join(present, ", "), backup.Path)
+		return backup, nil
+	}
+
+	if err := os.MkdirAll(backup.Path, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	for _, entry := range present {
+		if err := copyTree(filepath.Join(userDir, entry), filepath.Join(backup.Path, entry)); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", entry, err)
+		}
+	}
+
+	if h.verbose {
+		fmt.Printf("Backed up %s to: %s\n", strings.Join(present, ", "), backup.Path)
+	}
+	return backup, nil
+}
+
+// ListBackups returns the available backups, oldest first.
+func (h *Helper) ListBackups() ([]Backup, error) {
+	dir := h.BackupDir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		backups = append(backups, readBackup(filepath.Join(dir, entry.Name())))
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name < backups[j].Name })
+	return backups, nil
+}
+
+// readBackup describes the backup directory at path.
+func readBackup(path string) Backup {
+	name := filepath.Base(path)
+	backup := Backup{Name: name, Path: path, Timestamp: name}
+	if len(name) > len(backupTimeFormat) {
+		backup.Timestamp = name[:len(backupTimeFormat)]
+	}
+	for _, entry := range backupEntries() {
+		if _, err := os.Stat(filepath.Join(path, entry)); err == nil {
+			backup.Files = append(backup.Files, entry)
+		}
+	}
+	return backup
+}
+
+// RestoreBackup replaces the live configuration with the files in a
+// backup, given by name or path. The current configuration is backed up
+// first; that backup is returned, or nil when there was nothing to save.
+// Files missing from the backup are left untouched, while the snippets
+// directory is replaced as a whole.
+func (h *Helper) RestoreBackup(nameOrPath string) (*Backup, error) {
+	if nameOrPath == "" {
+		return nil, fmt.Errorf("backup name is required")
+	}
+
+	path := filepath.Join(h.BackupDir(), nameOrPath)
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		path = nameOrPath
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("backup not found: %s (see: acorn vscode config backups)", nameOrPath)
+		}
+	}
+	backup := readBackup(path)
+	if len(backup.Files) == 0 {
+		return nil, fmt.Errorf("backup %s contains no VS Code configuration", backup.Name)
+	}
+
+	previous, err := h.CreateBackup()
+	if err != nil && !errors.Is(err, ErrNoLiveConfig) {
+		return nil, fmt.Errorf("failed to back up current config: %w", err)
+	}
+
+	userDir := h.GetConfigPaths().UserDir
+	for _, entry := range backup.Files {
+		dst := filepath.Join(userDir, entry)
+		if h.dryRun {
+			fmt.Printf("[dry-run] would restore: %s -> %s\n", filepath.Join(path, entry), dst)
+			continue
+		}
+		// Remove what is there first so a symlinked file is replaced rather
+		// than written through to its target.
+		if err := os.RemoveAll(dst); err != nil {
+			return previous, fmt.Errorf("failed to replace %s: %w", dst, err)
+		}
+		if err := copyTree(filepath.Join(path, entry), dst); err != nil {
+			return previous, fmt.Errorf("failed to restore %s: %w", entry, err)
+		}
+	}
+
+	return previous, nil
+}
+
+// copyTree copies the file or directory src to dst, following symlinks.
+func copyTree(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyFile(src, dst)
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		return copyFile(path, target)
+	})
+}
+
+// copyFile copies the content of src to dst, creating its directory.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
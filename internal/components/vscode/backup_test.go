@@ -0,0 +1,130 @@
+package vscode
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// liveConfig points HOME and XDG_DATA_HOME at a temp dir and returns the
+// VS Code user directory inside it.
+func liveConfig(t *testing.T) (*Helper, string) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	h := NewHelper(false, false)
+	return h, h.GetConfigPaths().UserDir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	h, userDir := liveConfig(t)
+
+	if _, err := h.CreateBackup(); !errors.Is(err, ErrNoLiveConfig) {
+		t.Fatalf("CreateBackup with no config: err = %v, want ErrNoLiveConfig", err)
+	}
+
+	writeFile(t, filepath.Join(userDir, "settings.json"), `{"editor.fontSize": 14}`)
+	writeFile(t, filepath.Join(userDir, "snippets", "go.json"), `{"main": {}}`)
+
+	backup, err := h.CreateBackup()
+	if err != nil {
+		t.Fatalf("CreateBackup: %v", err)
+	}
+	if want := []string{"settings.json", "snippets"}; !reflect.DeepEqual(backup.Files, want) {
+		t.Errorf("backup files = %v, want %v", backup.Files, want)
+	}
+
+	// A second backup in the same second gets its own directory.
+	again, err := h.CreateBackup()
+	if err != nil {
+		t.Fatalf("CreateBackup: %v", err)
+	}
+	if again.Name == backup.Name {
+		t.Errorf("backups share the name %s", backup.Name)
+	}
+	backups, err := h.ListBackups()
+	if err != nil || len(backups) != 2 || backups[0].Name != backup.Name {
+		t.Fatalf("ListBackups = %+v, %v", backups, err)
+	}
+
+	// Simulate a sync clobbering the live config.
+	writeFile(t, filepath.Join(userDir, "settings.json"), `{"editor.fontSize": 20}`)
+	writeFile(t, filepath.Join(userDir, "snippets", "python.json"), `{}`)
+	writeFile(t, filepath.Join(userDir, "keybindings.json"), `[]`)
+
+	previous, err := h.RestoreBackup(backup.Name)
+	if err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	if got := readFile(t, filepath.Join(userDir, "settings.json")); got != `{"editor.fontSize": 14}` {
+		t.Errorf("restored settings = %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(userDir, "snippets", "python.json")); !os.IsNotExist(err) {
+		t.Errorf("snippets directory was not replaced: %v", err)
+	}
+	if got := readFile(t, filepath.Join(userDir, "keybindings.json")); got != `[]` {
+		t.Errorf("keybindings missing from the backup were changed: %s", got)
+	}
+
+	// The clobbered config was saved before restoring.
+	if previous == nil || len(previous.Files) != 3 {
+		t.Fatalf("previous backup = %+v", previous)
+	}
+	if got := readFile(t, filepath.Join(previous.Path, "settings.json")); got != `{"editor.fontSize": 20}` {
+		t.Errorf("pre-restore backup settings = %s", got)
+	}
+}
+
+func TestRestoreBackupReplacesSymlink(t *testing.T) {
+	h, userDir := liveConfig(t)
+
+	dotfiles := filepath.Join(t.TempDir(), "settings.json")
+	writeFile(t, dotfiles, `{"from": "dotfiles"}`)
+	if err := os.MkdirAll(userDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(dotfiles, filepath.Join(userDir, "settings.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := h.CreateBackup()
+	if err != nil {
+		t.Fatalf("CreateBackup: %v", err)
+	}
+	if got := readFile(t, filepath.Join(backup.Path, "settings.json")); got != `{"from": "dotfiles"}` {
+		t.Errorf("backup of symlinked settings = %s", got)
+	}
+
+	if _, err := h.RestoreBackup(backup.Path); err != nil {
+		t.Fatalf("RestoreBackup by path: %v", err)
+	}
+	if info, err := os.Lstat(filepath.Join(userDir, "settings.json")); err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("settings.json should be a regular file after restore: %v", err)
+	}
+
+	if _, err := h.RestoreBackup("missing"); err == nil {
+		t.Error("expected an error for a missing backup")
+	}
+}
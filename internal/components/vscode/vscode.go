@@ -78,6 +78,136 @@ var EssentialExtensions = []string{
 	"catppuccin.catppuccin-vsc-icons",
 }
 
+// BaseExtensions are installed with every language bundle.
+var BaseExtensions = []string{
+	"github.vscode-pull-request-github",
+	"eamodio.gitlens",
+	"editorconfig.editorconfig",
+	"catppuccin.catppuccin-vsc",
+	"catppuccin.catppuccin-vsc-icons",
+}
+
+// LanguageBundles are curated extensions per language, keyed by the
+// normalized language name.
+var LanguageBundles = map[string][]string{
+	"go": {
+		"golang.go", // installs gopls and delve on first use
+	},
+	"python": {
+		"ms-python.python",
+		"ms-python.vscode-pylance",
+		"ms-python.debugpy",
+		"charliermarsh.ruff",
+	},
+	"typescript": {
+		"dbaeumer.vscode-eslint",
+		"esbenp.prettier-vscode",
+	},
+	"rust": {
+		"rust-lang.rust-analyzer",
+		"vadimcn.vscode-lldb",
+	},
+	"terraform": {
+		"hashicorp.terraform",
+	},
+	"kubernetes": {
+		"ms-kubernetes-tools.vscode-kubernetes-tools",
+		"redhat.vscode-yaml",
+	},
+	"docker": {
+		"ms-azuretools.vscode-docker",
+	},
+}
+
+// ExtensionBundle is a named set of extensions.
+type ExtensionBundle struct {
+	Name       string   `json:"name" yaml:"name"`
+	Extensions []string `json:"extensions" yaml:"extensions"`
+}
+
+// NormalizeLanguage maps language aliases to their canonical name.
+func NormalizeLanguage(language string) string {
+	switch strings.ToLower(language) {
+	case "py":
+		return "python"
+	case "golang":
+		return "go"
+	case "ts", "node", "js", "javascript":
+		return "typescript"
+	case "rs":
+		return "rust"
+	case "tf":
+		return "terraform"
+	case "k8s":
+		return "kubernetes"
+	default:
+		return strings.ToLower(language)
+	}
+}
+
+// Languages returns the languages that have extension bundles, sorted.
+func Languages() []string {
+	langs := make([]string, 0, len(LanguageBundles))
+	for lang := range LanguageBundles {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+// EssentialBundles returns the base bundle followed by the bundles for the
+// given languages. With no languages, every language bundle is returned.
+func EssentialBundles(languages []string) ([]ExtensionBundle, error) {
+	bundles := []ExtensionBundle{{Name: "base", Extensions: BaseExtensions}}
+
+	if len(languages) == 0 {
+		languages = Languages()
+	}
+
+	seen := make(map[string]bool)
+	for _, lang := range languages {
+		lang = NormalizeLanguage(strings.TrimSpace(lang))
+		if lang == "" || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+
+		exts, ok := LanguageBundles[lang]
+		if !ok {
+			return nil, fmt.Errorf("no extension bundle for language %q (available: %s)",
+				lang, strings.Join(Languages(), ", "))
+		}
+		bundles = append(bundles, ExtensionBundle{Name: lang, Extensions: exts})
+	}
+
+	return bundles, nil
+}
+
+// ResolveEssentials returns the deduplicated extensions for the base set
+// plus the given language bundles.
+func ResolveEssentials(languages []string) ([]string, error) {
+	if len(languages) == 0 {
+		return nil, fmt.Errorf("at least one language is required")
+	}
+
+	bundles, err := EssentialBundles(languages)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, b := range bundles {
+		for _, id := range b.Extensions {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
 // Helper provides VS Code helper operations.
 type Helper struct {
 	verbose      bool
@@ -166,14 +296,8 @@ func (h *Helper) CreateProject(name, language string) error {
 	}
 
 	// Normalize language
-	switch language {
-	case "py":
-		language = "python"
-	case "golang":
-		language = "go"
-	case "ts", "node", "js":
-		language = "typescript"
-	case "":
+	language = NormalizeLanguage(language)
+	if language == "" {
 		language = "general"
 	}
 
@@ -269,7 +393,12 @@ func (h *Helper) InstallExtensionsFromFile(filePath string) error {
 
 // InstallEssentialExtensions installs the essential extensions.
 func (h *Helper) InstallEssentialExtensions() error {
-	for _, id := range EssentialExtensions {
+	return h.InstallExtensions(EssentialExtensions)
+}
+
+// InstallExtensions installs each extension, warning on failures.
+func (h *Helper) InstallExtensions(ids []string) error {
+	for _, id := range ids {
 		if err := h.InstallExtension(id); err != nil {
 			fmt.Printf("Warning: failed to install %s: %v\n", id, err)
 		}
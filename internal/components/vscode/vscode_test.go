@@ -0,0 +1,42 @@
+package vscode
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResolveEssentials(t *testing.T) {
+	ids, err := ResolveEssentials([]string{"go", "py", "golang"})
+	if err != nil {
+		t.Fatalf("ResolveEssentials() error = %v", err)
+	}
+
+	want := len(BaseExtensions) + len(LanguageBundles["go"]) + len(LanguageBundles["python"])
+	if len(ids) != want {
+		t.Errorf("got %d extensions, want %d: %v", len(ids), want, ids)
+	}
+	for _, id := range []string{"eamodio.gitlens", "golang.go", "charliermarsh.ruff"} {
+		if !slices.Contains(ids, id) {
+			t.Errorf("missing %s in %v", id, ids)
+		}
+	}
+}
+
+func TestResolveEssentialsUnknownLanguage(t *testing.T) {
+	if _, err := ResolveEssentials([]string{"cobol"}); err == nil {
+		t.Error("expected error for unknown language")
+	}
+}
+
+func TestEssentialBundlesAll(t *testing.T) {
+	bundles, err := EssentialBundles(nil)
+	if err != nil {
+		t.Fatalf("EssentialBundles() error = %v", err)
+	}
+	if len(bundles) != len(LanguageBundles)+1 {
+		t.Errorf("got %d bundles, want %d", len(bundles), len(LanguageBundles)+1)
+	}
+	if bundles[0].Name != "base" {
+		t.Errorf("first bundle = %s, want base", bundles[0].Name)
+	}
+}
@@ -0,0 +1,41 @@
+package component
+
+import "sort"
+
+// NameCollision is an alias, function or variable name defined by more
+// than one component. Whichever component is sourced last silently
+// shadows the others.
+type NameCollision struct {
+	Name       string   `json:"name" yaml:"name"`
+	Components []string `json:"components" yaml:"components"`
+}
+
+// NameOwners maps each name in byComponent (component -> name -> value) to
+// the components that define it, sorted.
+func NameOwners(byComponent map[string]map[string]string) map[string][]string {
+	owners := map[string][]string{}
+	for comp, values := range byComponent {
+		for name := range values {
+			owners[name] = append(owners[name], comp)
+		}
+	}
+	for _, comps := range owners {
+		sort.Strings(comps)
+	}
+	return owners
+}
+
+// FindNameCollisions returns the names in byComponent (component -> name ->
+// value) that more than one component defines, sorted by name.
+func FindNameCollisions(byComponent map[string]map[string]string) []NameCollision {
+	collisions := []NameCollision{}
+	for name, comps := range NameOwners(byComponent) {
+		if len(comps) > 1 {
+			collisions = append(collisions, NameCollision{Name: name, Components: comps})
+		}
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		return collisions[i].Name < collisions[j].Name
+	})
+	return collisions
+}
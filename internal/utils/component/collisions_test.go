@@ -0,0 +1,38 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindNameCollisions(t *testing.T) {
+	byComponent := map[string]map[string]string{
+		"git":    {"g": "git", "gs": "git status"},
+		"go":     {"g": "go", "gt": "go test"},
+		"gitlab": {"gs": "glab status", "g": "glab"},
+		"docker": {"d": "docker"},
+	}
+
+	want := []NameCollision{
+		{Name: "g", Components: []string{"git", "gitlab", "go"}},
+		{Name: "gs", Components: []string{"git", "gitlab"}},
+	}
+	if got := FindNameCollisions(byComponent); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindNameCollisions() = %v, want %v", got, want)
+	}
+
+	if got := FindNameCollisions(map[string]map[string]string{"docker": {"d": "docker"}}); len(got) != 0 {
+		t.Errorf("FindNameCollisions() = %v, want none", got)
+	}
+}
+
+func TestNameOwners(t *testing.T) {
+	owners := NameOwners(map[string]map[string]string{
+		"b": {"x": "1", "y": "2"},
+		"a": {"x": "3"},
+	})
+	want := map[string][]string{"x": {"a", "b"}, "y": {"b"}}
+	if !reflect.DeepEqual(owners, want) {
+		t.Errorf("NameOwners() = %v, want %v", owners, want)
+	}
+}
@@ -143,3 +143,17 @@ func (c *Component) SupportsCurrentShell(shell string) bool {
 	}
 	return false
 }
+
+// Dependents returns the names of components that list name in requires.components.
+func Dependents(components []*Component, name string) []string {
+	var names []string
+	for _, c := range components {
+		for _, dep := range c.Requires.Components {
+			if dep == name {
+				names = append(names, c.Name)
+				break
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,44 @@
+package component
+
+// Doctor check outcomes.
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// DoctorCheck is the outcome of a single health check run by a
+// component's doctor command.
+type DoctorCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Account string `json:"account,omitempty" yaml:"account,omitempty"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+	Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// DoctorReport aggregates the checks of a doctor run.
+type DoctorReport struct {
+	Checks   []DoctorCheck `json:"checks" yaml:"checks"`
+	Passed   int           `json:"passed" yaml:"passed"`
+	Warnings int           `json:"warnings" yaml:"warnings"`
+	Failed   int           `json:"failed" yaml:"failed"`
+}
+
+// NewDoctorReport returns an empty report.
+func NewDoctorReport() *DoctorReport {
+	return &DoctorReport{Checks: []DoctorCheck{}}
+}
+
+// Add records a check and counts it by status.
+func (r *DoctorReport) Add(check DoctorCheck) {
+	r.Checks = append(r.Checks, check)
+	switch check.Status {
+	case CheckPass:
+		r.Passed++
+	case CheckWarn:
+		r.Warnings++
+	case CheckFail:
+		r.Failed++
+	}
+}
@@ -0,0 +1,16 @@
+package component
+
+import "testing"
+
+func TestDoctorReportAdd(t *testing.T) {
+	report := NewDoctorReport()
+	for _, status := range []string{CheckPass, CheckPass, CheckWarn, CheckFail} {
+		report.Add(DoctorCheck{Name: "check", Status: status})
+	}
+	if len(report.Checks) != 4 || report.Passed != 2 || report.Warnings != 1 || report.Failed != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if NewDoctorReport().Checks == nil {
+		t.Error("new report should have an empty, non-nil check list")
+	}
+}
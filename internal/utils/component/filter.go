@@ -0,0 +1,97 @@
+package component
+
+import "os/exec"
+
+// Filter selects components by metadata. Empty fields match everything;
+// set fields are combined with AND.
+type Filter struct {
+	Category     string // exact category match
+	Platform     string // component supports this OS (empty platforms match all)
+	RequiresTool string // component lists this tool in requires.tools
+	MissingTools bool   // component has at least one required tool not installed
+	Installed    bool   // component's shell integration is generated and linked
+	NotInstalled bool   // component's shell integration is not active
+
+	// ToolExists reports whether a tool is installed. Defaults to a PATH lookup.
+	ToolExists func(tool string) bool
+
+	// IsInstalled reports whether a component's shell integration is active.
+	// Required when Installed or NotInstalled is set.
+	IsInstalled func(name string) bool
+}
+
+// IsEmpty returns true if the filter matches every component.
+func (f Filter) IsEmpty() bool {
+	return f.Category == "" && f.Platform == "" && f.RequiresTool == "" && !f.MissingTools &&
+		!f.Installed && !f.NotInstalled
+}
+
+// Matches reports whether a component satisfies the filter.
+func (f Filter) Matches(c *Component) bool {
+	if f.Category != "" && c.Category != f.Category {
+		return false
+	}
+
+	if f.Platform != "" && !c.SupportsCurrentPlatform(f.Platform) {
+		return false
+	}
+
+	if f.RequiresTool != "" && !c.RequiresTool(f.RequiresTool) {
+		return false
+	}
+
+	if f.MissingTools && len(c.MissingTools(f.toolExists())) == 0 {
+		return false
+	}
+
+	if (f.Installed || f.NotInstalled) && f.IsInstalled != nil {
+		if installed := f.IsInstalled(c.Name); installed != f.Installed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply returns the components that satisfy the filter, preserving order.
+func (f Filter) Apply(components []*Component) []*Component {
+	filtered := make([]*Component, 0, len(components))
+	for _, c := range components {
+		if f.Matches(c) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// toolExists returns the configured tool lookup or the PATH default.
+func (f Filter) toolExists() func(string) bool {
+	if f.ToolExists != nil {
+		return f.ToolExists
+	}
+	return func(tool string) bool {
+		_, err := exec.LookPath(tool)
+		return err == nil
+	}
+}
+
+// RequiresTool checks if the component lists a tool in requires.tools.
+func (c *Component) RequiresTool(tool string) bool {
+	for _, t := range c.Requires.Tools {
+		if t == tool {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingTools returns the required tools for which exists returns false.
+func (c *Component) MissingTools(exists func(string) bool) []string {
+	var missing []string
+	for _, t := range c.Requires.Tools {
+		if !exists(t) {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,55 @@
+package component
+
+import "testing"
+
+func TestFilter_Apply(t *testing.T) {
+	components := []*Component{
+		{Name: "git", Category: "vcs", Requires: Requires{Tools: []string{"git"}}},
+		{Name: "brew", Category: "system", Platforms: []string{"darwin"}, Requires: Requires{Tools: []string{"brew"}}},
+		{Name: "python", Category: "lang", Requires: Requires{Tools: []string{"python3", "uv"}}},
+		{Name: "shell", Category: "system"},
+	}
+
+	installed := map[string]bool{"git": true, "python3": true}
+	exists := func(tool string) bool { return installed[tool] }
+	active := func(name string) bool { return name == "git" || name == "shell" }
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"empty", Filter{}, []string{"git", "brew", "python", "shell"}},
+		{"category", Filter{Category: "system"}, []string{"brew", "shell"}},
+		{"platform", Filter{Platform: "linux"}, []string{"git", "python", "shell"}},
+		{"requires tool", Filter{RequiresTool: "uv"}, []string{"python"}},
+		{"missing tools", Filter{MissingTools: true, ToolExists: exists}, []string{"brew", "python"}},
+		{"composed", Filter{Category: "system", MissingTools: true, ToolExists: exists}, []string{"brew"}},
+		{"installed", Filter{Installed: true, IsInstalled: active}, []string{"git", "shell"}},
+		{"not installed", Filter{NotInstalled: true, IsInstalled: active}, []string{"brew", "python"}},
+		{"no match", Filter{Category: "system", Platform: "linux", RequiresTool: "brew"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.Apply(components)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d components, want %d", len(got), len(tt.want))
+			}
+			for i, c := range got {
+				if c.Name != tt.want[i] {
+					t.Errorf("got[%d] = %s, want %s", i, c.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFilter_IsEmpty(t *testing.T) {
+	if !(Filter{}).IsEmpty() {
+		t.Error("zero Filter should be empty")
+	}
+	if (Filter{MissingTools: true}).IsEmpty() {
+		t.Error("Filter with MissingTools should not be empty")
+	}
+}
@@ -0,0 +1,188 @@
+package component
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/textdiff"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigMethod is the method used for config files that omit one.
+const DefaultConfigMethod = "symlink"
+
+// FormatResult is the outcome of normalizing a component.yaml.
+type FormatResult struct {
+	Component string `json:"component" yaml:"component"`
+	Path      string `json:"path" yaml:"path"`
+	Changed   bool   `json:"changed" yaml:"changed"`
+	Written   bool   `json:"written" yaml:"written"`
+	Diff      string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// fieldOrder lists the canonical key order for each mapping in component.yaml,
+// keyed by its path. Sequence items use the path of their parent plus "[]".
+var fieldOrder = map[string][]string{
+	"":                     {"name", "description", "version", "category", "platforms", "shells", "requires", "provides", "xdg", "config"},
+	"requires":             {"tools", "components"},
+	"provides":             {"aliases", "functions", "completions"},
+	"xdg":                  {"config", "data", "cache", "state"},
+	"config":               {"files", "directories"},
+	"config.files[]":       {"source", "target", "method", "platform", "permissions"},
+	"config.directories[]": {"target", "permissions"},
+}
+
+// FormatYAML rewrites component.yaml content in canonical form: keys in the
+// canonical order (unknown keys keep their relative order at the end),
+// two-space indentation, and an explicit method on every config file.
+// Comments attached to keys move with them.
+func FormatYAML(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse component.yaml: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("component.yaml must be a mapping")
+	}
+	normalizeNode(root, "")
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode component.yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FormatFile normalizes a component's YAML file. With dryRun the file is
+// left untouched and the result carries a diff of the proposed changes.
+func FormatFile(comp *Component, dryRun bool) (*FormatResult, error) {
+	result := &FormatResult{Component: comp.Name, Path: comp.YAMLPath}
+
+	data, err := os.ReadFile(comp.YAMLPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", comp.YAMLPath, err)
+	}
+
+	formatted, err := FormatYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", comp.YAMLPath, err)
+	}
+
+	if bytes.Equal(data, formatted) {
+		return result, nil
+	}
+	result.Changed = true
+
+	if dryRun {
+		result.Diff = textdiff.Unified(comp.YAMLPath, string(data), comp.YAMLPath+" (formatted)", string(formatted))
+		return result, nil
+	}
+
+	info, err := os.Stat(comp.YAMLPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(comp.YAMLPath, formatted, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", comp.YAMLPath, err)
+	}
+	result.Written = true
+
+	return result, nil
+}
+
+// normalizeNode reorders the keys of a mapping at path and recurses into
+// known child mappings and sequences.
+func normalizeNode(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if path == "config.files[]" {
+			setDefault(node, "method", DefaultConfigMethod)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			normalizeNode(node.Content[i+1], joinPath(path, node.Content[i].Value))
+		}
+		if order, ok := fieldOrder[path]; ok && len(node.Content) > 0 {
+			// A comment above the first key is the file header; keep it on top
+			header := node.Content[0].HeadComment
+			if path == "" {
+				node.Content[0].HeadComment = ""
+			}
+			reorderKeys(node, order)
+			if path == "" && header != "" {
+				first := node.Content[0]
+				first.HeadComment = strings.TrimSpace(header + "\n" + first.HeadComment)
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			normalizeNode(item, path+"[]")
+		}
+	}
+}
+
+// reorderKeys sorts a mapping's key/value pairs into order, keeping keys
+// not listed in order after the known ones.
+func reorderKeys(node *yaml.Node, order []string) {
+	rank := make(map[string]int, len(order))
+	for i, key := range order {
+		rank[key] = i
+	}
+
+	type pair struct{ key, value *yaml.Node }
+	known := make([]*pair, len(order))
+	var unknown []*pair
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		p := &pair{node.Content[i], node.Content[i+1]}
+		if r, ok := rank[p.key.Value]; ok && known[r] == nil {
+			known[r] = p
+		} else {
+			unknown = append(unknown, p)
+		}
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, p := range append(known, unknown...) {
+		if p != nil {
+			content = append(content, p.key, p.value)
+		}
+	}
+	node.Content = content
+}
+
+// setDefault adds key: value to a mapping when key is missing or empty.
+func setDefault(node *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			if v := node.Content[i+1]; v.Kind == yaml.ScalarNode && v.Value == "" {
+				v.Value = value
+				v.Tag = "!!str"
+				v.Style = 0
+			}
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
+
+// joinPath appends key to a dotted mapping path.
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -0,0 +1,92 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatYAML(t *testing.T) {
+	in := `# Header
+version: "1.0.0"
+name: demo
+category: dev # inline
+description: Demo
+config:
+    files:
+        - target: ~/.democonfig
+          source: democonfig
+        - source: other
+          target: ~/.other
+          method: copy
+custom: keep
+`
+	want := `# Header
+name: demo
+description: Demo
+version: "1.0.0"
+category: dev # inline
+config:
+  files:
+    - source: democonfig
+      target: ~/.democonfig
+      method: symlink
+    - source: other
+      target: ~/.other
+      method: copy
+custom: keep
+`
+	got, err := FormatYAML([]byte(in))
+	if err != nil {
+		t.Fatalf("FormatYAML: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("FormatYAML() =\n%s\nwant\n%s", got, want)
+	}
+
+	again, err := FormatYAML(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(got) {
+		t.Errorf("FormatYAML is not idempotent:\n%s", again)
+	}
+}
+
+func TestFormatFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "component.yaml")
+	orig := "description: d\nname: demo\n"
+	if err := os.WriteFile(path, []byte(orig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	comp := &Component{Name: "demo", Path: dir, YAMLPath: path}
+
+	result, err := FormatFile(comp, true)
+	if err != nil {
+		t.Fatalf("FormatFile dry-run: %v", err)
+	}
+	if !result.Changed || result.Written || !strings.Contains(result.Diff, "-description: d\n name: demo\n+description: d") {
+		t.Errorf("dry-run result = %+v", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != orig {
+		t.Error("dry-run should not modify the file")
+	}
+
+	result, err = FormatFile(comp, false)
+	if err != nil {
+		t.Fatalf("FormatFile: %v", err)
+	}
+	if !result.Written {
+		t.Errorf("result = %+v, want written", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "name: demo\ndescription: d\n" {
+		t.Errorf("file = %q", data)
+	}
+
+	result, err = FormatFile(comp, false)
+	if err != nil || result.Changed {
+		t.Errorf("canonical file should be unchanged: %+v, %v", result, err)
+	}
+}
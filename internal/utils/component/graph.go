@@ -0,0 +1,374 @@
+package component
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GraphNode is one component in a dependency graph.
+type GraphNode struct {
+	Name       string   `json:"name" yaml:"name"`
+	Requires   []string `json:"requires" yaml:"requires"`
+	RequiredBy []string `json:"required_by" yaml:"required_by"`
+	Missing    []string `json:"missing,omitempty" yaml:"missing,omitempty"`
+	Orphan     bool     `json:"orphan" yaml:"orphan"`
+	InCycle    bool     `json:"in_cycle" yaml:"in_cycle"`
+}
+
+// Graph is the dependency graph formed by requires.components across a set
+// of components. Edges point from a component to the ones it requires.
+type Graph struct {
+	Nodes   []GraphNode `json:"nodes" yaml:"nodes"`
+	Cycles  [][]string  `json:"cycles" yaml:"cycles"`
+	Orphans []string    `json:"orphans" yaml:"orphans"`
+	Missing []string    `json:"missing" yaml:"missing"`
+
+	index map[string]int
+}
+
+// BuildGraph resolves every component's requires.components into edges.
+// Requirements naming components outside comps are recorded as missing
+// rather than as edges. Orphans are components with no dependencies that
+// nothing depends on.
+func BuildGraph(comps []*Component) *Graph {
+	g := &Graph{
+		Cycles:  [][]string{},
+		Orphans: []string{},
+		Missing: []string{},
+		index:   make(map[string]int),
+	}
+
+	sorted := make([]*Component, len(comps))
+	copy(sorted, comps)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, c := range sorted {
+		if _, ok := g.index[c.Name]; ok {
+			continue
+		}
+		g.index[c.Name] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, GraphNode{Name: c.Name, Requires: []string{}, RequiredBy: []string{}})
+	}
+
+	missing := make(map[string]bool)
+	for _, c := range sorted {
+		node := &g.Nodes[g.index[c.Name]]
+		seen := make(map[string]bool)
+		for _, dep := range c.Requires.Components {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if _, ok := g.index[dep]; !ok {
+				node.Missing = append(node.Missing, dep)
+				missing[dep] = true
+				continue
+			}
+			node.Requires = append(node.Requires, dep)
+		}
+		sort.Strings(node.Requires)
+	}
+
+	for _, node := range g.Nodes {
+		for _, dep := range node.Requires {
+			target := &g.Nodes[g.index[dep]]
+			target.RequiredBy = append(target.RequiredBy, node.Name)
+		}
+	}
+
+	for i := range g.Nodes {
+		node := &g.Nodes[i]
+		if len(node.Requires) == 0 && len(node.Missing) == 0 && len(node.RequiredBy) == 0 {
+			node.Orphan = true
+			g.Orphans = append(g.Orphans, node.Name)
+		}
+	}
+	for name := range missing {
+		g.Missing = append(g.Missing, name)
+	}
+	sort.Strings(g.Missing)
+
+	g.Cycles = g.findCycles()
+	for _, cycle := range g.Cycles {
+		for _, name := range cycle {
+			g.Nodes[g.index[name]].InCycle = true
+		}
+	}
+
+	return g
+}
+
+// Node returns the named node, or nil if it is not in the graph.
+func (g *Graph) Node(name string) *GraphNode {
+	i, ok := g.index[name]
+	if !ok {
+		return nil
+	}
+	return &g.Nodes[i]
+}
+
+// findCycles returns the strongly connected components that form cycles
+// (more than one member, or a component requiring itself), each sorted by
+// name, using Tarjan's algorithm.
+func (g *Graph) findCycles() [][]string {
+	var (
+		counter int
+		stack   []int
+		onStack = make([]bool, len(g.Nodes))
+		order   = make([]int, len(g.Nodes))
+		low     = make([]int, len(g.Nodes))
+		visited = make([]bool, len(g.Nodes))
+		cycles  = [][]string{}
+	)
+
+	var visit func(v int)
+	visit = func(v int) {
+		visited[v] = true
+		order[v], low[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, dep := range g.Nodes[v].Requires {
+			w := g.index[dep]
+			if !visited[w] {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], order[w])
+			}
+		}
+
+		if low[v] != order[v] {
+			return
+		}
+		var members []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			members = append(members, g.Nodes[w].Name)
+			if w == v {
+				break
+			}
+		}
+		selfLoop := false
+		for _, dep := range g.Nodes[v].Requires {
+			if dep == g.Nodes[v].Name {
+				selfLoop = true
+			}
+		}
+		if len(members) > 1 || selfLoop {
+			sort.Strings(members)
+			cycles = append(cycles, members)
+		}
+	}
+
+	for v := range g.Nodes {
+		if !visited[v] {
+			visit(v)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// WriteDOT renders the graph as Graphviz DOT. Edges inside a cycle are
+// drawn red, orphans grey and missing components as dashed boxes.
+func (g *Graph) WriteDOT(w io.Writer) {
+	fmt.Fprintln(w, "digraph components {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+
+	for _, node := range g.Nodes {
+		var attrs []string
+		switch {
+		case node.InCycle:
+			attrs = append(attrs, "color=red")
+		case node.Orphan:
+			attrs = append(attrs, "color=grey", "fontcolor=grey")
+		}
+		writeDOTNode(w, node.Name, attrs)
+	}
+	for _, name := range g.Missing {
+		writeDOTNode(w, name, []string{"style=dashed", "color=red", `label="` + name + ` (missing)"`})
+	}
+
+	for _, node := range g.Nodes {
+		for _, dep := range node.Requires {
+			attr := ""
+			if g.sameCycle(node.Name, dep) {
+				attr = " [color=red]"
+			}
+			fmt.Fprintf(w, "  %q -> %q%s;\n", node.Name, dep, attr)
+		}
+		for _, dep := range node.Missing {
+			fmt.Fprintf(w, "  %q -> %q [style=dashed];\n", node.Name, dep)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeDOTNode writes a node statement with optional attributes.
+func writeDOTNode(w io.Writer, name string, attrs []string) {
+	if len(attrs) == 0 {
+		fmt.Fprintf(w, "  %q;\n", name)
+		return
+	}
+	fmt.Fprintf(w, "  %q [%s];\n", name, strings.Join(attrs, ", "))
+}
+
+// sameCycle reports whether a and b belong to the same cycle.
+func (g *Graph) sameCycle(a, b string) bool {
+	for _, cycle := range g.Cycles {
+		var hasA, hasB bool
+		for _, name := range cycle {
+			hasA = hasA || name == a
+			hasB = hasB || name == b
+		}
+		if hasA && hasB {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteTree renders the graph as an indented text tree rooted at the
+// components nothing depends on, each followed by what it requires.
+// Orphans are left out of the tree; a dependency already on the current
+// path is marked as a cycle instead of being expanded again.
+func (g *Graph) WriteTree(w io.Writer) {
+	printed := make(map[string]bool)
+
+	var walk func(name, prefix string, path map[string]bool)
+	walk = func(name, prefix string, path map[string]bool) {
+		printed[name] = true
+		node := g.Node(name)
+		children := append(append([]string{}, node.Requires...), node.Missing...)
+		for i, dep := range children {
+			branch, next := "├── ", "│   "
+			if i == len(children)-1 {
+				branch, next = "└── ", "    "
+			}
+			switch {
+			case g.Node(dep) == nil:
+				fmt.Fprintf(w, "%s%s%s (missing)\n", prefix, branch, dep)
+			case path[dep]:
+				fmt.Fprintf(w, "%s%s%s (cycle)\n", prefix, branch, dep)
+			default:
+				fmt.Fprintf(w, "%s%s%s\n", prefix, branch, dep)
+				path[dep] = true
+				walk(dep, prefix+next, path)
+				delete(path, dep)
+			}
+		}
+	}
+
+	root := func(name string) {
+		fmt.Fprintln(w, name)
+		walk(name, "", map[string]bool{name: true})
+	}
+
+	for _, node := range g.Nodes {
+		if !node.Orphan && len(node.RequiredBy) == 0 {
+			root(node.Name)
+		}
+	}
+	// Components reachable only through a cycle have no root above them
+	for _, node := range g.Nodes {
+		if !node.Orphan && !printed[node.Name] {
+			root(node.Name)
+		}
+	}
+}
+
+// CycleError reports components whose requires.components form a cycle.
+// Path starts and ends at the same component.
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// CyclePath returns a path around cycle (one of g.Cycles) that starts and
+// ends at start, following requires edges between the cycle's members.
+func (g *Graph) CyclePath(cycle []string, start string) []string {
+	members := make(map[string]bool, len(cycle))
+	for _, name := range cycle {
+		members[name] = true
+	}
+
+	// Breadth-first search for the shortest way back to start
+	prev := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, dep := range g.Node(name).Requires {
+			if !members[dep] {
+				continue
+			}
+			if dep == start {
+				path := []string{start}
+				for n := name; n != start; n = prev[n] {
+					path = append(path, n)
+				}
+				path = append(path, start)
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := prev[dep]; !seen {
+				prev[dep] = name
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return []string{start, start}
+}
+
+// SortByDependencies orders comps so that each comes after the components
+// it requires, otherwise keeping their given order. Requirements naming
+// components outside comps are ignored. If requirements form a cycle, a
+// *CycleError is returned.
+func SortByDependencies(comps []*Component) ([]string, error) {
+	g := BuildGraph(comps)
+	if len(g.Cycles) > 0 {
+		cycle := g.Cycles[0]
+		return nil, &CycleError{Path: g.CyclePath(cycle, cycle[0])}
+	}
+
+	var pending []string
+	seen := make(map[string]bool)
+	for _, c := range comps {
+		if !seen[c.Name] {
+			seen[c.Name] = true
+			pending = append(pending, c.Name)
+		}
+	}
+
+	order := make([]string, 0, len(pending))
+	placed := make(map[string]bool, len(pending))
+	for len(pending) > 0 {
+		for i, name := range pending {
+			ready := true
+			for _, dep := range g.Node(name).Requires {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				order = append(order, name)
+				placed[name] = true
+				pending = append(pending[:i], pending[i+1:]...)
+				break
+			}
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,91 @@
+package component
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildGraph(t *testing.T) {
+	comp := func(name string, deps ...string) *Component {
+		c := &Component{Name: name}
+		c.Requires.Components = deps
+		return c
+	}
+	g := BuildGraph([]*Component{
+		comp("git", "core"),
+		comp("core"),
+		comp("fzf", "tmux", "ghost"),
+		comp("tmux", "fzf"),
+		comp("wget"),
+	})
+
+	if want := [][]string{{"fzf", "tmux"}}; !reflect.DeepEqual(g.Cycles, want) {
+		t.Errorf("cycles = %v, want %v", g.Cycles, want)
+	}
+	if want := []string{"wget"}; !reflect.DeepEqual(g.Orphans, want) {
+		t.Errorf("orphans = %v, want %v", g.Orphans, want)
+	}
+	if want := []string{"ghost"}; !reflect.DeepEqual(g.Missing, want) {
+		t.Errorf("missing = %v, want %v", g.Missing, want)
+	}
+	if core := g.Node("core"); !reflect.DeepEqual(core.RequiredBy, []string{"git"}) || core.Orphan {
+		t.Errorf("core = %+v", core)
+	}
+
+	var tree bytes.Buffer
+	g.WriteTree(&tree)
+	wantTree := "git\n└── core\nfzf\n├── tmux\n│   └── fzf (cycle)\n└── ghost (missing)\n"
+	if tree.String() != wantTree {
+		t.Errorf("tree =\n%s\nwant\n%s", tree.String(), wantTree)
+	}
+
+	var dot bytes.Buffer
+	g.WriteDOT(&dot)
+	for _, want := range []string{`"fzf" -> "tmux" [color=red];`, `"git" -> "core";`, `"wget" [color=grey`, `"fzf" -> "ghost" [style=dashed];`} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot.String())
+		}
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	comp := func(name string, deps ...string) *Component {
+		c := &Component{Name: name}
+		c.Requires.Components = deps
+		return c
+	}
+
+	order, err := SortByDependencies([]*Component{
+		comp("core"),
+		comp("python", "node", "core"),
+		comp("git"),
+		comp("node", "missing"),
+	})
+	if err != nil {
+		t.Fatalf("SortByDependencies failed: %v", err)
+	}
+	if want := []string{"core", "git", "node", "python"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+
+	_, err = SortByDependencies([]*Component{
+		comp("core"),
+		comp("python", "node"),
+		comp("node", "python"),
+	})
+	cycle, ok := err.(*CycleError)
+	if !ok || !reflect.DeepEqual(cycle.Path, []string{"node", "python", "node"}) {
+		t.Fatalf("err = %v, want node -> python -> node cycle", err)
+	}
+	if cycle.Error() != "dependency cycle: node -> python -> node" {
+		t.Errorf("Error() = %q", cycle.Error())
+	}
+
+	vr := &ValidationResult{Component: comp("python", "node"), Valid: true}
+	CheckDependencyCycles(vr, []*Component{vr.Component, comp("node", "python")})
+	if vr.Valid || len(vr.Errors) != 1 || !strings.Contains(vr.Errors[0], "python -> node -> python") {
+		t.Errorf("validation = %+v", vr)
+	}
+}
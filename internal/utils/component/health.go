@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"sync"
 )
 
 // HealthStatus represents the health state of a component.
@@ -74,6 +75,37 @@ func CheckHealth(comp *Component) *HealthCheck {
 	return hc
 }
 
+// CheckHealthAll checks comps with at most workers concurrent checks. The
+// results are in the same order as comps, whatever order the checks
+// finish in; workers <= 1 checks them one at a time.
+func CheckHealthAll(comps []*Component, workers int) []*HealthCheck {
+	results := make([]*HealthCheck, len(comps))
+	if workers <= 1 {
+		for i, comp := range comps {
+			results[i] = CheckHealth(comp)
+		}
+		return results
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(comps)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = CheckHealth(comps[i])
+			}
+		}()
+	}
+	for i := range comps {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
 // addError adds an error issue and sets status to error.
 func (hc *HealthCheck) addError(issue string) {
 	hc.Issues = append(hc.Issues, issue)
@@ -0,0 +1,72 @@
+package component
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// healthComponents creates n components, each with a shell file to syntax
+// check. Every third one has a syntax error.
+func healthComponents(t testing.TB, n int) []*Component {
+	t.Helper()
+	root := t.TempDir()
+
+	comps := make([]*Component, n)
+	for i := range comps {
+		dir := filepath.Join(root, fmt.Sprintf("comp%02d", i))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		script := "export FOO=1\n"
+		if i%3 == 0 {
+			script = "if true; then\n"
+		}
+		if err := os.WriteFile(filepath.Join(dir, "env.sh"), []byte(script), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		comps[i] = &Component{
+			Name:        fmt.Sprintf("comp%02d", i),
+			Version:     "1.0.0",
+			Description: "test component",
+			Path:        dir,
+		}
+	}
+	return comps
+}
+
+func TestCheckHealthAll(t *testing.T) {
+	comps := healthComponents(t, 12)
+	serial := CheckHealthAll(comps, 1)
+
+	for _, workers := range []int{2, 4, 32} {
+		parallel := CheckHealthAll(comps, workers)
+		if len(parallel) != len(comps) {
+			t.Fatalf("workers=%d: got %d results, want %d", workers, len(parallel), len(comps))
+		}
+		for i, hc := range parallel {
+			if hc.Component != comps[i] {
+				t.Errorf("workers=%d: result %d is %s, want %s", workers, i, hc.Component.Name, comps[i].Name)
+			}
+			if hc.Status != serial[i].Status {
+				t.Errorf("workers=%d: %s status = %s, want %s", workers, hc.Component.Name, hc.Status, serial[i].Status)
+			}
+		}
+	}
+
+	if serial[0].Status != StatusError || serial[1].Status != StatusHealthy {
+		t.Errorf("statuses = %s, %s; want error, healthy", serial[0].Status, serial[1].Status)
+	}
+}
+
+func BenchmarkCheckHealthAll(b *testing.B) {
+	comps := healthComponents(b, 24)
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				CheckHealthAll(comps, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package component
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// JUnitSuites is the root of a JUnit XML report.
+type JUnitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []JUnitSuite `xml:"testsuite"`
+}
+
+// JUnitSuite is a group of test cases.
+type JUnitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []JUnitCase `xml:"testcase"`
+}
+
+// JUnitCase is a single test case; one per component.
+type JUnitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitFailure marks a failed test case. Message is the first problem and
+// Text lists them all.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// HealthJUnit reports health checks as JUnit test cases. Components with
+// an error status fail with their issues; warnings are attached as output.
+func HealthJUnit(results []*HealthCheck) *JUnitSuites {
+	suite := JUnitSuite{Name: "component status", Timestamp: junitTimestamp()}
+	for _, hc := range results {
+		tc := JUnitCase{Name: hc.Component.Name, ClassName: "component.status"}
+		if hc.Status == StatusError {
+			tc.Failure = junitFailure("health", hc.Issues)
+		}
+		if len(hc.Warnings) > 0 {
+			tc.SystemOut = "warning: " + strings.Join(hc.Warnings, "\nwarning: ")
+		}
+		suite.add(tc)
+	}
+	return newJUnitSuites(suite)
+}
+
+// ValidationJUnit reports validation results as JUnit test cases, failing
+// invalid components with their validation errors.
+func ValidationJUnit(results []*ValidationResult) *JUnitSuites {
+	suite := JUnitSuite{Name: "component validate", Timestamp: junitTimestamp()}
+	for _, vr := range results {
+		tc := JUnitCase{Name: vr.Component.Name, ClassName: "component.validate"}
+		if !vr.Valid {
+			tc.Failure = junitFailure("validation", vr.Errors)
+		}
+		suite.add(tc)
+	}
+	return newJUnitSuites(suite)
+}
+
+// WriteJUnit writes a JUnit XML report to path.
+func WriteJUnit(path string, report *JUnitSuites) error {
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode junit report: %w", err)
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write junit report: %w", err)
+	}
+	return nil
+}
+
+// add appends a test case and updates the suite's counts.
+func (s *JUnitSuite) add(tc JUnitCase) {
+	s.Cases = append(s.Cases, tc)
+	s.Tests++
+	if tc.Failure != nil {
+		s.Failures++
+	}
+}
+
+func newJUnitSuites(suite JUnitSuite) *JUnitSuites {
+	return &JUnitSuites{
+		Name:     "acorn",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []JUnitSuite{suite},
+	}
+}
+
+func junitFailure(kind string, problems []string) *JUnitFailure {
+	f := &JUnitFailure{Type: kind, Message: kind + " failed"}
+	if len(problems) > 0 {
+		f.Message = problems[0]
+		f.Text = strings.Join(problems, "\n")
+	}
+	return f
+}
+
+// junitTimestamp is the report time in the ISO 8601 form JUnit expects.
+func junitTimestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05")
+}
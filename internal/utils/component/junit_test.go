@@ -0,0 +1,62 @@
+package component
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHealthJUnit(t *testing.T) {
+	results := []*HealthCheck{
+		{Component: &Component{Name: "go"}, Status: StatusHealthy},
+		{Component: &Component{Name: "tmux"}, Status: StatusWarning, Warnings: []string{"tmux.conf not linked"}},
+		{Component: &Component{Name: "python"}, Status: StatusError, Issues: []string{"required tool missing: uv", "bad <syntax> in env.sh"}},
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := WriteJUnit(path, HealthJUnit(results)); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Errorf("missing XML header:\n%s", data)
+	}
+
+	var report JUnitSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("report does not parse: %v\n%s", err, data)
+	}
+	if report.Tests != 3 || report.Failures != 1 || len(report.Suites) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	cases := report.Suites[0].Cases
+	if cases[0].Failure != nil || cases[1].Failure != nil {
+		t.Error("healthy and warning components should pass")
+	}
+	if !strings.Contains(cases[1].SystemOut, "tmux.conf not linked") {
+		t.Errorf("warning not reported: %q", cases[1].SystemOut)
+	}
+	f := cases[2].Failure
+	if f == nil || f.Message != "required tool missing: uv" || !strings.Contains(f.Text, "bad <syntax> in env.sh") {
+		t.Errorf("python failure = %+v", f)
+	}
+}
+
+func TestValidationJUnit(t *testing.T) {
+	report := ValidationJUnit([]*ValidationResult{
+		{Component: &Component{Name: "go"}, Valid: true},
+		{Component: &Component{Name: "broken"}, Valid: false, Errors: []string{"missing version"}},
+	})
+	if report.Tests != 2 || report.Failures != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if f := report.Suites[0].Cases[1].Failure; f == nil || f.Type != "validation" || f.Message != "missing version" {
+		t.Errorf("failure = %+v", f)
+	}
+}
@@ -0,0 +1,129 @@
+package component
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/output"
+)
+
+// WriteMarkdown renders a component's metadata as a Markdown section whose
+// title is a heading of the given level (1-6).
+func WriteMarkdown(w io.Writer, comp *Component, level int) {
+	level = max(1, min(level, 6))
+	heading := strings.Repeat("#", level)
+	sub := strings.Repeat("#", min(level+1, 6))
+
+	fmt.Fprintf(w, "%s %s\n\n", heading, comp.Name)
+	if comp.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", comp.Description)
+	}
+
+	platforms := "all"
+	if len(comp.Platforms) > 0 {
+		platforms = strings.Join(comp.Platforms, ", ")
+	}
+	if comp.Version != "" {
+		fmt.Fprintf(w, "- **Version:** %s\n", comp.Version)
+	}
+	if comp.Category != "" {
+		fmt.Fprintf(w, "- **Category:** %s\n", comp.Category)
+	}
+	fmt.Fprintf(w, "- **Platforms:** %s\n", platforms)
+	if len(comp.Shells) > 0 {
+		fmt.Fprintf(w, "- **Shells:** %s\n", strings.Join(comp.Shells, ", "))
+	}
+	fmt.Fprintln(w)
+
+	if len(comp.Requires.Tools) > 0 || len(comp.Requires.Components) > 0 {
+		fmt.Fprintf(w, "%s Requires\n\n", sub)
+		writeCodeList(w, "Tools", comp.Requires.Tools)
+		writeLinkList(w, "Components", comp.Requires.Components)
+		fmt.Fprintln(w)
+	}
+
+	p := comp.Provides
+	if len(p.Aliases) > 0 || len(p.Functions) > 0 || len(p.Completions) > 0 {
+		fmt.Fprintf(w, "%s Provides\n\n", sub)
+		writeCodeList(w, "Aliases", p.Aliases)
+		writeCodeList(w, "Functions", p.Functions)
+		writeCodeList(w, "Completions", p.Completions)
+		fmt.Fprintln(w)
+	}
+
+	if len(comp.Config.Files) > 0 {
+		fmt.Fprintf(w, "%s Configuration Files\n\n", sub)
+		table := output.NewTable("Source", "Target", "Method", "Platform")
+		for _, f := range comp.Config.Files {
+			method := f.Method
+			if method == "" {
+				method = DefaultConfigMethod
+			}
+			platform := f.Platform
+			if platform == "" {
+				platform = "all"
+			}
+			table.AddRow("`"+f.Source+"`", "`"+f.Target+"`", method, platform)
+		}
+		table.RenderMarkdown(w)
+		fmt.Fprintln(w)
+	}
+}
+
+// WriteCatalogMarkdown renders an index of components followed by a
+// section for each one.
+func WriteCatalogMarkdown(w io.Writer, comps []*Component) {
+	fmt.Fprintln(w, "# Component Catalog")
+	fmt.Fprintln(w)
+
+	table := output.NewTable("Component", "Category", "Description")
+	for _, comp := range comps {
+		table.AddRow(fmt.Sprintf("[%s](#%s)", comp.Name, markdownAnchor(comp.Name)), comp.Category, comp.Description)
+	}
+	table.RenderMarkdown(w)
+	fmt.Fprintln(w)
+
+	for _, comp := range comps {
+		WriteMarkdown(w, comp, 2)
+	}
+}
+
+// writeCodeList writes a labelled list item with each value in code spans.
+func writeCodeList(w io.Writer, label string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "`" + v + "`"
+	}
+	fmt.Fprintf(w, "- **%s:** %s\n", label, strings.Join(quoted, ", "))
+}
+
+// writeLinkList writes a labelled list item linking to other components'
+// sections.
+func writeLinkList(w io.Writer, label string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	links := make([]string, len(names))
+	for i, name := range names {
+		links[i] = fmt.Sprintf("[%s](#%s)", name, markdownAnchor(name))
+	}
+	fmt.Fprintf(w, "- **%s:** %s\n", label, strings.Join(links, ", "))
+}
+
+// markdownAnchor returns the GitHub-style heading anchor for a name.
+func markdownAnchor(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	comp := &Component{
+		Name:        "tmux",
+		Description: "Terminal multiplexer",
+		Version:     "1.2.0",
+		Category:    "terminal",
+		Platforms:   []string{"darwin", "linux"},
+		Requires:    Requires{Tools: []string{"tmux"}, Components: []string{"core"}},
+		Provides:    Provides{Aliases: []string{"t", "ta"}},
+		Config: Config{Files: []ConfigFile{
+			{Source: "config/tmux.conf", Target: "${XDG_CONFIG_HOME}/tmux/tmux.conf"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	WriteMarkdown(&buf, comp, 3)
+	got := buf.String()
+
+	for _, want := range []string{
+		"### tmux\n\nTerminal multiplexer\n",
+		"- **Platforms:** darwin, linux\n",
+		"#### Requires\n",
+		"- **Tools:** `tmux`\n",
+		"- **Components:** [core](#core)\n",
+		"- **Aliases:** `t`, `ta`\n",
+		"| `config/tmux.conf` | `${XDG_CONFIG_HOME}/tmux/tmux.conf` | symlink | all |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Provides Functions") || strings.Contains(got, "**Functions:**") {
+		t.Errorf("empty sections should be omitted:\n%s", got)
+	}
+}
+
+func TestWriteCatalogMarkdown(t *testing.T) {
+	comps := []*Component{
+		{Name: "git", Category: "vcs", Description: "Git helpers"},
+		{Name: "tmux", Category: "terminal", Description: "Terminal | multiplexer"},
+	}
+
+	var buf bytes.Buffer
+	WriteCatalogMarkdown(&buf, comps)
+	got := buf.String()
+
+	for _, want := range []string{
+		"# Component Catalog\n",
+		"| [git](#git) | vcs | Git helpers |\n",
+		"| [tmux](#tmux) | terminal | Terminal \\| multiplexer |\n",
+		"\n## git\n",
+		"\n## tmux\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("catalog missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	ioutils "github.com/mistergrinvalds/acorn/internal/utils/io"
+	"gopkg.in/yaml.v3"
+)
+
+// snapshotSchemaVersion is bumped when the snapshot file format changes.
+const snapshotSchemaVersion = 1
+
+// HealthSnapshot is a point-in-time record of component health.
+type HealthSnapshot struct {
+	SchemaVersion int                 `json:"schema_version" yaml:"schema_version"`
+	CreatedAt     time.Time           `json:"created_at" yaml:"created_at"`
+	Platform      string              `json:"platform" yaml:"platform"`
+	Components    []ComponentSnapshot `json:"components" yaml:"components"`
+}
+
+// ComponentSnapshot records the health of one component.
+type ComponentSnapshot struct {
+	Name             string          `json:"name" yaml:"name"`
+	Version          string          `json:"version,omitempty" yaml:"version,omitempty"`
+	Status           HealthStatus    `json:"status" yaml:"status"`
+	Tools            map[string]bool `json:"tools,omitempty" yaml:"tools,omitempty"` // required tool -> installed
+	Issues           []string        `json:"issues,omitempty" yaml:"issues,omitempty"`
+	Warnings         []string        `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	ValidationErrors []string        `json:"validation_errors,omitempty" yaml:"validation_errors,omitempty"`
+}
+
+// HealthDiff describes what changed between two health snapshots.
+type HealthDiff struct {
+	Since   time.Time         `json:"since" yaml:"since"`
+	Now     time.Time         `json:"now" yaml:"now"`
+	Added   []string          `json:"added" yaml:"added"`
+	Removed []string          `json:"removed" yaml:"removed"`
+	Changed []ComponentChange `json:"changed" yaml:"changed"`
+}
+
+// ComponentChange describes how one component's health changed.
+type ComponentChange struct {
+	Name             string       `json:"name" yaml:"name"`
+	OldStatus        HealthStatus `json:"old_status" yaml:"old_status"`
+	NewStatus        HealthStatus `json:"new_status" yaml:"new_status"`
+	ToolsAppeared    []string     `json:"tools_appeared,omitempty" yaml:"tools_appeared,omitempty"`
+	ToolsDisappeared []string     `json:"tools_disappeared,omitempty" yaml:"tools_disappeared,omitempty"`
+	NewIssues        []string     `json:"new_issues,omitempty" yaml:"new_issues,omitempty"`
+	ResolvedIssues   []string     `json:"resolved_issues,omitempty" yaml:"resolved_issues,omitempty"`
+}
+
+// HasChanges reports whether the diff contains any change.
+func (d *HealthDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// NewSnapshot records the health checks, required tool availability and
+// validation errors of the checked components.
+func NewSnapshot(checks []*HealthCheck) *HealthSnapshot {
+	snap := &HealthSnapshot{
+		SchemaVersion: snapshotSchemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		Platform:      runtime.GOOS,
+		Components:    make([]ComponentSnapshot, 0, len(checks)),
+	}
+
+	for _, hc := range checks {
+		cs := ComponentSnapshot{
+			Name:     hc.Component.Name,
+			Version:  hc.Component.Version,
+			Status:   hc.Status,
+			Issues:   hc.Issues,
+			Warnings: hc.Warnings,
+		}
+		if len(hc.Component.Requires.Tools) > 0 {
+			cs.Tools = make(map[string]bool, len(hc.Component.Requires.Tools))
+			for _, tool := range hc.Component.Requires.Tools {
+				cs.Tools[tool] = commandExists(tool)
+			}
+		}
+		cs.ValidationErrors = Validate(hc.Component).Errors
+		snap.Components = append(snap.Components, cs)
+	}
+
+	return snap
+}
+
+// WriteSnapshot writes a snapshot to path, as YAML for .yaml/.yml files and
+// JSON otherwise.
+func WriteSnapshot(path string, snap *HealthSnapshot) error {
+	if err := ioutils.WriteFile(path, snap); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads a snapshot written by WriteSnapshot.
+func LoadSnapshot(path string) (*HealthSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap HealthSnapshot
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &snap)
+	default:
+		err = json.Unmarshal(data, &snap)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if snap.SchemaVersion > snapshotSchemaVersion {
+		return nil, fmt.Errorf("snapshot %s has schema version %d, newer than supported %d",
+			path, snap.SchemaVersion, snapshotSchemaVersion)
+	}
+	return &snap, nil
+}
+
+// DiffSnapshots reports components added or removed between two snapshots,
+// and for components in both, changes in status, tool availability and
+// issues.
+func DiffSnapshots(old, cur *HealthSnapshot) *HealthDiff {
+	diff := &HealthDiff{
+		Since:   old.CreatedAt,
+		Now:     cur.CreatedAt,
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []ComponentChange{},
+	}
+
+	oldByName := make(map[string]ComponentSnapshot, len(old.Components))
+	for _, c := range old.Components {
+		oldByName[c.Name] = c
+	}
+	curByName := make(map[string]ComponentSnapshot, len(cur.Components))
+	for _, c := range cur.Components {
+		curByName[c.Name] = c
+	}
+
+	for _, c := range old.Components {
+		if _, ok := curByName[c.Name]; !ok {
+			diff.Removed = append(diff.Removed, c.Name)
+		}
+	}
+
+	for _, c := range cur.Components {
+		prev, ok := oldByName[c.Name]
+		if !ok {
+			diff.Added = append(diff.Added, c.Name)
+			continue
+		}
+
+		change := ComponentChange{
+			Name:      c.Name,
+			OldStatus: prev.Status,
+			NewStatus: c.Status,
+		}
+		for _, tool := range sortedKeys(c.Tools) {
+			if c.Tools[tool] && !prev.Tools[tool] {
+				change.ToolsAppeared = append(change.ToolsAppeared, tool)
+			}
+		}
+		for _, tool := range sortedKeys(prev.Tools) {
+			if prev.Tools[tool] && !c.Tools[tool] {
+				change.ToolsDisappeared = append(change.ToolsDisappeared, tool)
+			}
+		}
+
+		prevIssues := prev.allIssues()
+		curIssues := c.allIssues()
+		change.NewIssues = missingFrom(curIssues, prevIssues)
+		change.ResolvedIssues = missingFrom(prevIssues, curIssues)
+
+		if change.OldStatus != change.NewStatus || len(change.ToolsAppeared) > 0 ||
+			len(change.ToolsDisappeared) > 0 || len(change.NewIssues) > 0 || len(change.ResolvedIssues) > 0 {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
+}
+
+// allIssues returns health issues, warnings and validation errors together.
+func (c ComponentSnapshot) allIssues() []string {
+	all := make([]string, 0, len(c.Issues)+len(c.Warnings)+len(c.ValidationErrors))
+	all = append(all, c.Issues...)
+	all = append(all, c.Warnings...)
+	all = append(all, c.ValidationErrors...)
+	return all
+}
+
+// missingFrom returns the items of a that are not in b, without duplicates.
+func missingFrom(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !inB[s] {
+			out = append(out, s)
+			inB[s] = true
+		}
+	}
+	return out
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
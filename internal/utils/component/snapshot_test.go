@@ -0,0 +1,88 @@
+package component
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	old := &HealthSnapshot{
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Components: []ComponentSnapshot{
+			{Name: "git", Status: StatusHealthy, Tools: map[string]bool{"git": true}},
+			{Name: "python", Status: StatusWarning, Tools: map[string]bool{"python3": true, "uv": false},
+				Warnings: []string{"required tool not installed: uv"}},
+			{Name: "legacy", Status: StatusHealthy},
+			{Name: "shell", Status: StatusHealthy},
+		},
+	}
+	cur := &HealthSnapshot{
+		CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Components: []ComponentSnapshot{
+			{Name: "git", Status: StatusWarning, Tools: map[string]bool{"git": false},
+				Warnings: []string{"required tool not installed: git"}},
+			{Name: "python", Status: StatusHealthy, Tools: map[string]bool{"python3": true, "uv": true}},
+			{Name: "shell", Status: StatusHealthy},
+			{Name: "node", Status: StatusHealthy},
+		},
+	}
+
+	diff := DiffSnapshots(old, cur)
+
+	if !slices.Equal(diff.Added, []string{"node"}) {
+		t.Errorf("Added = %v, want [node]", diff.Added)
+	}
+	if !slices.Equal(diff.Removed, []string{"legacy"}) {
+		t.Errorf("Removed = %v, want [legacy]", diff.Removed)
+	}
+	if len(diff.Changed) != 2 {
+		t.Fatalf("Changed = %+v, want git and python", diff.Changed)
+	}
+
+	git := diff.Changed[0]
+	if git.Name != "git" || git.OldStatus != StatusHealthy || git.NewStatus != StatusWarning {
+		t.Errorf("git change = %+v", git)
+	}
+	if !slices.Equal(git.ToolsDisappeared, []string{"git"}) || len(git.NewIssues) != 1 {
+		t.Errorf("git tools/issues = %+v", git)
+	}
+
+	python := diff.Changed[1]
+	if !slices.Equal(python.ToolsAppeared, []string{"uv"}) || len(python.ResolvedIssues) != 1 {
+		t.Errorf("python change = %+v", python)
+	}
+
+	if !diff.HasChanges() {
+		t.Error("HasChanges() = false, want true")
+	}
+	if DiffSnapshots(cur, cur).HasChanges() {
+		t.Error("diff of identical snapshots should have no changes")
+	}
+}
+
+func TestWriteLoadSnapshot(t *testing.T) {
+	snap := &HealthSnapshot{
+		SchemaVersion: snapshotSchemaVersion,
+		CreatedAt:     time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		Platform:      "linux",
+		Components: []ComponentSnapshot{
+			{Name: "git", Status: StatusHealthy, Tools: map[string]bool{"git": true}},
+		},
+	}
+
+	for _, name := range []string{"health.json", "health.yaml"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := WriteSnapshot(path, snap); err != nil {
+			t.Fatalf("WriteSnapshot(%s): %v", name, err)
+		}
+		got, err := LoadSnapshot(path)
+		if err != nil {
+			t.Fatalf("LoadSnapshot(%s): %v", name, err)
+		}
+		if !got.CreatedAt.Equal(snap.CreatedAt) || len(got.Components) != 1 || !got.Components[0].Tools["git"] {
+			t.Errorf("%s round trip = %+v", name, got)
+		}
+	}
+}
@@ -3,6 +3,10 @@ package component
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/configfile"
 )
 
 // ValidationResult represents the result of component validation.
@@ -36,10 +40,18 @@ func Validate(comp *Component) *ValidationResult {
 
 	// Validate config files
 	for i, cfg := range comp.Config.Files {
-		// Check source exists
-		sourcePath := comp.Path + "/" + cfg.Source
-		if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+		// Check source is a file inside the component directory
+		sourcePath := filepath.Join(comp.Path, cfg.Source)
+		rel, relErr := filepath.Rel(comp.Path, sourcePath)
+		switch info, err := os.Stat(sourcePath); {
+		case cfg.Source == "":
+			vr.addError(fmt.Sprintf("config.files[%d].source is empty", i))
+		case relErr != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)):
+			vr.addError(fmt.Sprintf("config.files[%d].source is outside the component directory: %s", i, cfg.Source))
+		case os.IsNotExist(err):
 			vr.addError(fmt.Sprintf("config.files[%d].source does not exist: %s", i, cfg.Source))
+		case err == nil && info.IsDir():
+			vr.addError(fmt.Sprintf("config.files[%d].source is a directory: %s", i, cfg.Source))
 		}
 
 		// Check target is specified
@@ -79,6 +91,82 @@ func Validate(comp *Component) *ValidationResult {
 	return vr
 }
 
+// ValidateAll validates each component and checks across them for config
+// files that claim the same target and for dependency cycles.
+func ValidateAll(comps []*Component) []*ValidationResult {
+	g := BuildGraph(comps)
+	results := make([]*ValidationResult, len(comps))
+	for i, comp := range comps {
+		results[i] = Validate(comp)
+		CheckTargetCollisions(results[i], comps)
+		checkCycles(results[i], g)
+	}
+	return results
+}
+
+// CheckDependencyCycles adds an error to vr when its component's
+// requires.components lead back to it through the components in all.
+func CheckDependencyCycles(vr *ValidationResult, all []*Component) {
+	checkCycles(vr, BuildGraph(all))
+}
+
+// checkCycles adds an error to vr for the cycle in g its component is in,
+// with the path starting at that component.
+func checkCycles(vr *ValidationResult, g *Graph) {
+	for _, cycle := range g.Cycles {
+		for _, name := range cycle {
+			if name == vr.Component.Name {
+				err := &CycleError{Path: g.CyclePath(cycle, name)}
+				vr.addError(fmt.Sprintf("requires.components: %v", err))
+				return
+			}
+		}
+	}
+}
+
+// CheckTargetCollisions adds an error to vr for each of its component's
+// config files whose target is also claimed by another config file in all,
+// either in another component or earlier in the same one. Targets are
+// compared after expanding ~ and environment variables; files limited to
+// different platforms never collide.
+func CheckTargetCollisions(vr *ValidationResult, all []*Component) {
+	comp := vr.Component
+	for i, cfg := range comp.Config.Files {
+		if cfg.Target == "" {
+			continue
+		}
+		target := normalizeTarget(cfg.Target)
+
+		for _, other := range all {
+			same := other.Path == comp.Path
+			for j, ocfg := range other.Config.Files {
+				if same && j >= i {
+					continue
+				}
+				if ocfg.Target == "" || !platformsOverlap(cfg.Platform, ocfg.Platform) || normalizeTarget(ocfg.Target) != target {
+					continue
+				}
+				if same {
+					vr.addError(fmt.Sprintf("config.files[%d].target %s is also the target of config.files[%d]", i, cfg.Target, j))
+				} else {
+					vr.addError(fmt.Sprintf("config.files[%d].target %s collides with component %s (config.files[%d])", i, cfg.Target, other.Name, j))
+				}
+			}
+		}
+	}
+}
+
+// normalizeTarget expands and cleans a config target for comparison.
+func normalizeTarget(target string) string {
+	return filepath.Clean(configfile.ExpandPath(target))
+}
+
+// platformsOverlap reports whether files for two platforms (empty means
+// every platform) can be installed on the same machine.
+func platformsOverlap(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
 // addError adds a validation error.
 func (vr *ValidationResult) addError(err string) {
 	vr.Errors = append(vr.Errors, err)
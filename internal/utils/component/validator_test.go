@@ -0,0 +1,116 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestComponent creates a component directory holding the given files.
+func newTestComponent(t *testing.T, root, name string, files []ConfigFile, sources ...string) *Component {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, src := range sources {
+		if err := os.WriteFile(filepath.Join(dir, src), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &Component{
+		Name:        name,
+		Version:     "1.0.0",
+		Description: name,
+		Category:    "test",
+		Path:        dir,
+		Config:      Config{Files: files},
+	}
+}
+
+func errorsContaining(vr *ValidationResult, substr string) int {
+	n := 0
+	for _, err := range vr.Errors {
+		if strings.Contains(err, substr) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestValidateConfigSources(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "subdir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	comp := newTestComponent(t, root, "git", []ConfigFile{
+		{Source: "gitconfig", Target: "~/.gitconfig"},
+		{Source: "missing", Target: "~/.missing"},
+		{Source: "../subdir", Target: "~/.escape"},
+		{Source: "", Target: "~/.empty"},
+	}, "gitconfig")
+	if err := os.Mkdir(filepath.Join(comp.Path, "conf.d"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	comp.Config.Files = append(comp.Config.Files, ConfigFile{Source: "conf.d", Target: "~/.conf.d"})
+
+	vr := Validate(comp)
+	if vr.Valid {
+		t.Fatal("expected validation to fail")
+	}
+	for _, want := range []string{
+		"config.files[1].source does not exist",
+		"config.files[2].source is outside the component directory",
+		"config.files[3].source is empty",
+		"config.files[4].source is a directory",
+	} {
+		if errorsContaining(vr, want) != 1 {
+			t.Errorf("missing error %q in %v", want, vr.Errors)
+		}
+	}
+	if errorsContaining(vr, "config.files[0]") != 0 {
+		t.Errorf("valid source reported: %v", vr.Errors)
+	}
+}
+
+func TestValidateAllTargetCollisions(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+	root := t.TempDir()
+
+	git := newTestComponent(t, root, "git", []ConfigFile{
+		{Source: "a", Target: "~/.config/shared"},
+		{Source: "b", Target: "~/.config/mac-only", Platform: "darwin"},
+	}, "a", "b")
+	tools := newTestComponent(t, root, "tools", []ConfigFile{
+		{Source: "a", Target: "/home/test/.config/./shared"},
+		{Source: "b", Target: "~/.config/mac-only", Platform: "linux"},
+		{Source: "c", Target: "~/.config/twice"},
+		{Source: "d", Target: "~/.config/twice"},
+	}, "a", "b", "c", "d")
+
+	results := ValidateAll([]*Component{git, tools})
+
+	if n := errorsContaining(results[0], "collides with component tools (config.files[0])"); n != 1 {
+		t.Errorf("git errors = %v", results[0].Errors)
+	}
+	if n := errorsContaining(results[1], "collides with component git (config.files[0])"); n != 1 {
+		t.Errorf("tools errors = %v", results[1].Errors)
+	}
+	if n := errorsContaining(results[1], "config.files[3].target ~/.config/twice is also the target of config.files[2]"); n != 1 {
+		t.Errorf("tools errors = %v", results[1].Errors)
+	}
+	for _, vr := range results {
+		if errorsContaining(vr, "mac-only") != 0 {
+			t.Errorf("files for different platforms should not collide: %v", vr.Errors)
+		}
+	}
+
+	// A separately loaded copy of a component is not a collision with itself
+	single := Validate(git)
+	copyOfGit := *git
+	CheckTargetCollisions(single, []*Component{&copyOfGit, tools})
+	if len(single.Errors) != 1 {
+		t.Errorf("single component errors = %v", single.Errors)
+	}
+}
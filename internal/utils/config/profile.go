@@ -0,0 +1,106 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetupProfile declares how `acorn setup` provisions a machine, so laptops
+// and servers can be set up from a profile checked into the sapling repo.
+type SetupProfile struct {
+	// Name identifies the profile (e.g., "laptop").
+	Name string `yaml:"name,omitempty"`
+
+	// Description is a human-readable description of the machine.
+	Description string `yaml:"description,omitempty"`
+
+	// Sapling is where the .sapling repository comes from.
+	Sapling ProfileSapling `yaml:"sapling,omitempty"`
+
+	// Build controls the go build step. Omitted means build.
+	Build *bool `yaml:"build,omitempty"`
+
+	// Components lists the components whose shell scripts are generated
+	// and whose configurations are synced. Empty means all of them.
+	Components []string `yaml:"components"`
+
+	// Tools lists registry tools to install when missing.
+	Tools []string `yaml:"tools"`
+}
+
+// ProfileSapling is the source of the .sapling repository. At most one of
+// Repo and Path may be set.
+type ProfileSapling struct {
+	// Repo is a git URL to clone .sapling from.
+	Repo string `yaml:"repo,omitempty"`
+
+	// Path is an existing .sapling directory to link.
+	Path string `yaml:"path,omitempty"`
+}
+
+// ShouldBuild reports whether the profile wants the acorn binary built.
+func (p *SetupProfile) ShouldBuild() bool {
+	return p.Build == nil || *p.Build
+}
+
+// Validate checks the profile for conflicting or duplicate entries.
+func (p *SetupProfile) Validate() error {
+	if p.Sapling.Repo != "" && p.Sapling.Path != "" {
+		return fmt.Errorf("sapling.repo and sapling.path are mutually exclusive")
+	}
+	if err := checkDuplicates("components", p.Components); err != nil {
+		return err
+	}
+	return checkDuplicates("tools", p.Tools)
+}
+
+// checkDuplicates rejects empty or repeated names in a profile list.
+func checkDuplicates(field string, names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%s: empty name", field)
+		}
+		if seen[name] {
+			return fmt.Errorf("%s: %q listed more than once", field, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// LoadSetupProfile reads and validates a setup profile. Unknown keys are
+// rejected so that typos do not silently fall back to defaults.
+func LoadSetupProfile(path string) (*SetupProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile %s: %w", path, err)
+	}
+
+	profile, err := ParseSetupProfile(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// ParseSetupProfile parses and validates setup profile YAML.
+func ParseSetupProfile(data []byte) (*SetupProfile, error) {
+	profile := &SetupProfile{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(profile); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
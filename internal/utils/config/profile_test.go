@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSetupProfile(t *testing.T) {
+	profile, err := ParseSetupProfile([]byte(`name: server
+sapling:
+  repo: git@github.com:me/sapling.git
+build: false
+components: [core, git, tmux]
+tools: [jq]
+`))
+	if err != nil {
+		t.Fatalf("ParseSetupProfile failed: %v", err)
+	}
+	if profile.Name != "server" || profile.Sapling.Repo != "git@github.com:me/sapling.git" {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+	if profile.ShouldBuild() {
+		t.Error("ShouldBuild() = true, want false")
+	}
+	if !reflect.DeepEqual(profile.Components, []string{"core", "git", "tmux"}) {
+		t.Errorf("Components = %v", profile.Components)
+	}
+
+	empty, err := ParseSetupProfile(nil)
+	if err != nil || !empty.ShouldBuild() {
+		t.Errorf("empty profile = %+v, %v", empty, err)
+	}
+}
+
+func TestParseSetupProfileInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":   "components: [git]\nbiuld: false\n",
+		"both sources":  "sapling:\n  repo: x\n  path: y\n",
+		"duplicate":     "components: [git, git]\n",
+		"empty tool":    "tools: ['']\n",
+		"wrong type":    "components: git\n",
+		"invalid build": "build: maybe\n",
+	}
+	for name, data := range tests {
+		if _, err := ParseSetupProfile([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadSetupProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "laptop.yaml")
+	if err := os.WriteFile(path, []byte("tools: [jq, jq]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadSetupProfile(path)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadSetupProfile error = %v, want it to name the file", err)
+	}
+	if _, err := LoadSetupProfile(path + ".missing"); err == nil {
+		t.Error("expected error for missing profile")
+	}
+}
@@ -23,6 +23,10 @@ type BaseConfig struct {
 	// Raw shell functions (for interactive code that can't be generated)
 	ShellFunctions map[string]string `yaml:"shell_functions,omitempty"`
 
+	// Fish variants of shell functions (name -> fish function body); shell
+	// functions without one are left out of fish scripts
+	FishFunctions map[string]string `yaml:"fish_functions,omitempty"`
+
 	// Config files to generate for this component
 	Files []FileConfig `yaml:"files,omitempty"`
 
@@ -0,0 +1,76 @@
+// Package editor opens files in the user's editor and supports validated
+// edits: snapshot a file, edit it, check the result, and reopen or revert.
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Run opens path in $EDITOR, falling back to vim.
+func Run(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vim"
+	}
+
+	cmd := exec.Command(editor, path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// Session is an edit of a file, holding a snapshot of its content from
+// before the first edit so that it can be compared and reverted.
+type Session struct {
+	Path     string
+	original []byte
+}
+
+// Start snapshots the file at path. The error from reading it is returned
+// as is, so callers can check os.IsNotExist.
+func Start(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSession(path, data), nil
+}
+
+// NewSession returns a session for path with original as the snapshot.
+func NewSession(path string, original []byte) *Session {
+	return &Session{Path: path, original: original}
+}
+
+// Original returns the file content from before the first edit.
+func (s *Session) Original() []byte {
+	return s.original
+}
+
+// Edit opens the file in the editor and returns its content once the
+// editor exits. It can be called again to fix up a rejected edit.
+func (s *Session) Edit() ([]byte, error) {
+	if err := Run(s.Path); err != nil {
+		return nil, err
+	}
+	after, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-read %s: %w", s.Path, err)
+	}
+	return after, nil
+}
+
+// Revert restores the snapshot, keeping the file's current permissions.
+func (s *Session) Revert() error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(s.Path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(s.Path, s.original, mode); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", s.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+// Package fsutil provides small filesystem helpers shared by components.
+package fsutil
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// DirSize returns the total size of regular files under path. Entries that
+// cannot be read are skipped, and a missing path has size 0.
+func DirSize(path string) int64 {
+	var total int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
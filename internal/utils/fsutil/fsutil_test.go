@@ -0,0 +1,36 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirSize(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel string, size int) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a", 100)
+	write("sub/b", 20)
+	write("sub/deeper/c", 3)
+	if err := os.Symlink(filepath.Join(root, "a"), filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := DirSize(root); got != 123 {
+		t.Errorf("DirSize = %d, want 123", got)
+	}
+	if got := DirSize(filepath.Join(root, "sub", "b")); got != 20 {
+		t.Errorf("DirSize(file) = %d, want 20", got)
+	}
+	if got := DirSize(filepath.Join(root, "missing")); got != 0 {
+		t.Errorf("DirSize(missing) = %d, want 0", got)
+	}
+}
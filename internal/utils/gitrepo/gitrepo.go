@@ -0,0 +1,171 @@
+// Package gitrepo pulls and inspects git working trees for the commands
+// that sync dotfiles and session repositories.
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Pull strategies.
+const (
+	StrategyRebase = "rebase"
+	StrategyMerge  = "merge"
+	StrategyFFOnly = "ff-only"
+)
+
+// ErrDiverged is returned by Pull with StrategyFFOnly when local and
+// remote have both gained commits, so the pull cannot fast-forward.
+var ErrDiverged = errors.New("cannot fast-forward; local and remote have diverged")
+
+// ErrConflict is returned by PullBeforePush when rebasing local commits
+// onto the remote conflicted and the rebase was aborted.
+var ErrConflict = errors.New("remote changes conflict with local commits")
+
+// nonFastForwardMarkers are the messages git prints when pull --ff-only
+// refuses a diverged branch.
+var nonFastForwardMarkers = []string{
+	"Not possible to fast-forward",
+	"Diverging branches can't be fast-forwarded",
+}
+
+// Repo is a git working tree.
+type Repo struct {
+	Dir string
+}
+
+// Command returns a git command run in the working tree.
+func (r Repo) Command(args ...string) *exec.Cmd {
+	return exec.Command("git", append([]string{"-C", r.Dir}, args...)...)
+}
+
+// RevCount returns the number of commits in a revision range, or 0 when it
+// cannot be computed (e.g. no upstream is configured).
+func (r Repo) RevCount(revRange string) int {
+	out, err := r.Command("rev-list", "--count", revRange).Output()
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return n
+}
+
+// Head returns the commit HEAD points at, or "" when it cannot be read.
+func (r Repo) Head() string {
+	out, err := r.Command("rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// InterruptedOp returns "rebase" or "merge" when the repository is in the
+// middle of one, or "" when it is not. The state files are located with
+// git rev-parse --git-path, so worktrees and submodules, whose .git is a
+// file, are handled.
+func (r Repo) InterruptedOp() string {
+	for _, op := range []struct{ name, path string }{
+		{"rebase", "rebase-merge"},
+		{"rebase", "rebase-apply"},
+		{"merge", "MERGE_HEAD"},
+	} {
+		if _, err := os.Stat(r.gitPath(op.path)); err == nil {
+			return op.name
+		}
+	}
+	return ""
+}
+
+// gitPath resolves a path inside the repository's git directory.
+func (r Repo) gitPath(name string) string {
+	out, err := r.Command("rev-parse", "--git-path", name).Output()
+	if err != nil {
+		return filepath.Join(r.Dir, ".git", name)
+	}
+	path := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.Dir, path)
+	}
+	return path
+}
+
+// PullArgs returns the git pull arguments for a strategy.
+func PullArgs(strategy string) ([]string, error) {
+	switch strategy {
+	case StrategyRebase:
+		return []string{"pull", "--rebase"}, nil
+	case StrategyMerge:
+		return []string{"pull", "--no-rebase"}, nil
+	case StrategyFFOnly:
+		return []string{"pull", "--ff-only"}, nil
+	}
+	return nil, fmt.Errorf("invalid strategy %q (must be rebase, merge or ff-only)", strategy)
+}
+
+// Pull runs git pull with strategy, copying git's output to stdout and
+// stderr. A refused fast-forward is reported as ErrDiverged; any other
+// failure wraps git's error.
+func (r Repo) Pull(strategy string, stdout, stderr io.Writer) error {
+	args, err := PullArgs(strategy)
+	if err != nil {
+		return err
+	}
+
+	var captured bytes.Buffer
+	cmd := r.Command(args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = io.MultiWriter(stderr, &captured)
+	if err := cmd.Run(); err != nil {
+		if strategy == StrategyFFOnly && isNonFastForward(captured.String()) {
+			return fmt.Errorf("git pull failed: %w (try --strategy rebase or merge): %w", ErrDiverged, err)
+		}
+		return fmt.Errorf("git pull failed: %w", err)
+	}
+	return nil
+}
+
+// isNonFastForward reports whether git's pull output says the branch
+// cannot be fast-forwarded.
+func isNonFastForward(output string) bool {
+	for _, marker := range nonFastForwardMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// PullBeforePush fetches the upstream and, when it has new commits,
+// rebases local commits onto it with --autostash so that a following push
+// is not rejected. It returns the number of commits pulled. A conflicting
+// rebase is aborted, leaving the local commits as they were, and
+// ErrConflict is returned.
+func (r Repo) PullBeforePush(stdout, stderr io.Writer) (int, error) {
+	if err := r.Command("fetch", "-q").Run(); err != nil {
+		return 0, fmt.Errorf("git fetch failed: %w", err)
+	}
+
+	behind := r.RevCount("HEAD..@{u}")
+	if behind == 0 {
+		return 0, nil
+	}
+
+	cmd := r.Command("pull", "--rebase", "--autostash")
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if r.InterruptedOp() == "rebase" {
+			r.Command("rebase", "--abort").Run()
+			return behind, fmt.Errorf("%w; resolve with: git -C %s pull --rebase", ErrConflict, r.Dir)
+		}
+		return behind, fmt.Errorf("git pull failed: %w", err)
+	}
+	return behind, nil
+}
@@ -0,0 +1,161 @@
+package gitrepo
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newClones returns two clones of a fresh bare repository holding one
+// commit, with "a" already pushed.
+func newClones(t *testing.T) (a, b Repo) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	run(t, dir, "init", "-q", "--bare", remote)
+	run(t, dir, "clone", "-q", remote, "a")
+	a = Repo{Dir: filepath.Join(dir, "a")}
+	commitFile(t, a, "file.txt", "base\n")
+	run(t, a.Dir, "push", "-q", "origin", "HEAD")
+	run(t, dir, "clone", "-q", remote, "b")
+	return a, Repo{Dir: filepath.Join(dir, "b")}
+}
+
+func run(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func commitFile(t *testing.T, r Repo, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(r.Dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run(t, r.Dir, "add", name)
+	run(t, r.Dir, "commit", "-q", "-m", "update "+name)
+}
+
+func TestPullArgs(t *testing.T) {
+	for strategy, want := range map[string]string{
+		StrategyRebase: "--rebase",
+		StrategyMerge:  "--no-rebase",
+		StrategyFFOnly: "--ff-only",
+	} {
+		args, err := PullArgs(strategy)
+		if err != nil || len(args) != 2 || args[1] != want {
+			t.Errorf("PullArgs(%s) = %v, %v", strategy, args, err)
+		}
+	}
+	if _, err := PullArgs("squash"); err == nil {
+		t.Error("unknown strategy should fail")
+	}
+}
+
+func TestPullFFOnlyDiverged(t *testing.T) {
+	a, b := newClones(t)
+	commitFile(t, a, "a.txt", "a\n")
+	run(t, a.Dir, "push", "-q")
+	commitFile(t, b, "b.txt", "b\n")
+
+	err := b.Pull(StrategyFFOnly, io.Discard, io.Discard)
+	if !errors.Is(err, ErrDiverged) {
+		t.Fatalf("Pull = %v, want ErrDiverged", err)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Errorf("Pull error should wrap git's error: %v", err)
+	}
+
+	if err := b.Pull(StrategyRebase, io.Discard, io.Discard); err != nil {
+		t.Fatalf("rebase pull: %v", err)
+	}
+	if n := b.RevCount("@{u}..HEAD"); n != 1 {
+		t.Errorf("after rebase, %d local commit(s) ahead, want 1", n)
+	}
+}
+
+func TestPullFFOnlyOtherFailure(t *testing.T) {
+	_, b := newClones(t)
+	run(t, b.Dir, "remote", "set-url", "origin", filepath.Join(t.TempDir(), "missing.git"))
+
+	err := b.Pull(StrategyFFOnly, io.Discard, io.Discard)
+	if err == nil || errors.Is(err, ErrDiverged) {
+		t.Fatalf("Pull = %v, want a failure that is not ErrDiverged", err)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Errorf("Pull error should wrap git's error: %v", err)
+	}
+}
+
+func TestInterruptedOpWorktree(t *testing.T) {
+	a, _ := newClones(t)
+	wt := Repo{Dir: filepath.Join(t.TempDir(), "wt")}
+	run(t, a.Dir, "worktree", "add", "-q", wt.Dir)
+
+	// A worktree's .git is a file pointing at the main repository
+	if info, err := os.Stat(filepath.Join(wt.Dir, ".git")); err != nil || info.IsDir() {
+		t.Fatalf("expected .git to be a file in the worktree: %v", err)
+	}
+	if op := wt.InterruptedOp(); op != "" {
+		t.Fatalf("InterruptedOp() = %q on a clean worktree", op)
+	}
+
+	if err := os.MkdirAll(wt.gitPath("rebase-merge"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if op := wt.InterruptedOp(); op != "rebase" {
+		t.Errorf("InterruptedOp() = %q, want rebase", op)
+	}
+	if op := a.InterruptedOp(); op != "" {
+		t.Errorf("main worktree InterruptedOp() = %q, want none", op)
+	}
+}
+
+func TestPullBeforePush(t *testing.T) {
+	a, b := newClones(t)
+
+	if n, err := b.PullBeforePush(io.Discard, io.Discard); err != nil || n != 0 {
+		t.Fatalf("nothing to pull: got %d, %v", n, err)
+	}
+
+	commitFile(t, a, "a.txt", "a\n")
+	run(t, a.Dir, "push", "-q")
+	commitFile(t, b, "b.txt", "b\n")
+	if n, err := b.PullBeforePush(io.Discard, io.Discard); err != nil || n != 1 {
+		t.Fatalf("clean rebase: got %d, %v", n, err)
+	}
+
+	// Conflicting edits to the same file abort the rebase
+	commitFile(t, a, "file.txt", "from a\n")
+	run(t, a.Dir, "push", "-q")
+	commitFile(t, b, "file.txt", "from b\n")
+	head := b.Head()
+
+	n, err := b.PullBeforePush(io.Discard, io.Discard)
+	if !errors.Is(err, ErrConflict) || n != 1 {
+		t.Fatalf("conflict: got %d, %v", n, err)
+	}
+	if op := b.InterruptedOp(); op != "" {
+		t.Errorf("rebase left in progress: %s", op)
+	}
+	if got := b.Head(); got != head {
+		t.Errorf("HEAD moved from %s to %s", head, got)
+	}
+}
@@ -0,0 +1,250 @@
+package installer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheExecutor is implemented by install methods whose package manager
+// can download a package into its local cache without installing it.
+type CacheExecutor interface {
+	MethodExecutor
+
+	// Cache downloads the tool's package into the package manager's cache.
+	Cache(ctx context.Context, tool PlannedTool, stdout, stderr io.Writer) (*CachedPackage, error)
+}
+
+// CachedPackage describes a package downloaded into a local cache.
+type CachedPackage struct {
+	Path string // Cached artifact (or cache directory when unknown)
+	Size int64  // Bytes the artifact occupies
+}
+
+// CacheResult represents the result of pre-downloading a component's tools.
+type CacheResult struct {
+	Component string            `json:"component" yaml:"component"`
+	Tools     []CacheToolResult `json:"tools" yaml:"tools"`
+	Duration  time.Duration     `json:"duration" yaml:"duration"`
+	DryRun    bool              `json:"dry_run" yaml:"dry_run"`
+}
+
+// CacheToolResult represents the result of caching a single tool.
+type CacheToolResult struct {
+	Name       string `json:"name" yaml:"name"`
+	Method     string `json:"method,omitempty" yaml:"method,omitempty"`
+	Cached     bool   `json:"cached" yaml:"cached"`
+	Skipped    bool   `json:"skipped" yaml:"skipped"`
+	SkipReason string `json:"skip_reason,omitempty" yaml:"skip_reason,omitempty"`
+	Path       string `json:"path,omitempty" yaml:"path,omitempty"`
+	Size       int64  `json:"size" yaml:"size"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Summary returns how many tools were cached, skipped and failed, and the
+// total size cached.
+func (r *CacheResult) Summary() (cached, skipped, failed int, size int64) {
+	for _, t := range r.Tools {
+		switch {
+		case t.Skipped:
+			skipped++
+		case t.Cached:
+			cached++
+			size += t.Size
+		default:
+			failed++
+		}
+	}
+	return
+}
+
+// Cache downloads the packages for a component's tools into the package
+// managers' local caches without installing them, so a later Install is
+// fast or works offline. Tools that are already installed, or whose method
+// has no cache-only mode (apt, curl), are skipped.
+func (i *Installer) Cache(ctx context.Context, component string) (*CacheResult, error) {
+	start := time.Now()
+
+	plan, err := i.Plan(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CacheResult{
+		Component: component,
+		Tools:     []CacheToolResult{},
+		DryRun:    i.dryRun,
+	}
+	for _, tool := range append(append([]PlannedTool{}, plan.Prerequisites...), plan.Tools...) {
+		result.Tools = append(result.Tools, i.cacheTool(ctx, tool))
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// cacheTool downloads a single tool's package.
+func (i *Installer) cacheTool(ctx context.Context, tool PlannedTool) CacheToolResult {
+	result := CacheToolResult{
+		Name:   tool.Name,
+		Method: tool.Method.Type,
+	}
+
+	if tool.AlreadyInstalled {
+		result.Skipped = true
+		result.SkipReason = "already installed"
+		return result
+	}
+
+	executor, err := GetExecutor(tool.Method.Type)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	cacher, ok := executor.(CacheExecutor)
+	if !ok {
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("%s does not support cache-only downloads", tool.Method.Type)
+		return result
+	}
+
+	if i.dryRun {
+		result.Skipped = true
+		result.SkipReason = "dry run"
+		return result
+	}
+
+	if !cacher.Available() {
+		result.Error = fmt.Sprintf("%s not available on this system", tool.Method.Type)
+		return result
+	}
+
+	if i.verbose {
+		fmt.Fprintf(i.stdout, "Caching %s via %s...\n", tool.Name, tool.Method.Type)
+	}
+
+	pkg, err := cacher.Cache(ctx, tool, i.stdout, i.stderr)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Cached = true
+	result.Path = pkg.Path
+	result.Size = pkg.Size
+	return result
+}
+
+// Cache runs `brew fetch` and reports the downloaded bottle from
+// `brew --cache`.
+func (e *BrewExecutor) Cache(ctx context.Context, tool PlannedTool, stdout, stderr io.Writer) (*CachedPackage, error) {
+	pkg := tool.Method.Package
+	if pkg == "" {
+		pkg = tool.Name
+	}
+
+	if err := runCommand(ctx, "brew", []string{"fetch", pkg}, stdout, stderr); err != nil {
+		return nil, err
+	}
+
+	out, err := exec.CommandContext(ctx, "brew", "--cache", pkg).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate cached %s: %w", pkg, err)
+	}
+	path := strings.TrimSpace(string(out))
+	return &CachedPackage{Path: path, Size: pathSize(path)}, nil
+}
+
+// Cache runs `npm cache add`. npm does not report where the tarball
+// landed, so the size is the growth of the cache directory.
+func (e *NpmExecutor) Cache(ctx context.Context, tool PlannedTool, stdout, stderr io.Writer) (*CachedPackage, error) {
+	pkg := tool.Method.Package
+	if pkg == "" {
+		pkg = tool.Name
+	}
+
+	dir := ""
+	if out, err := exec.CommandContext(ctx, "npm", "config", "get", "cache").Output(); err == nil {
+		dir = strings.TrimSpace(string(out))
+	}
+	before := pathSize(dir)
+
+	if err := runCommand(ctx, "npm", []string{"cache", "add", pkg}, stdout, stderr); err != nil {
+		return nil, err
+	}
+
+	return &CachedPackage{Path: dir, Size: max(pathSize(dir)-before, 0)}, nil
+}
+
+// Cache runs `go mod download` for the module providing the package. The
+// module path is found by trimming path elements from the package path.
+func (e *GoExecutor) Cache(ctx context.Context, tool PlannedTool, stdout, stderr io.Writer) (*CachedPackage, error) {
+	if tool.Method.Package == "" {
+		return nil, fmt.Errorf("go install requires package path")
+	}
+
+	pkg, version, ok := strings.Cut(tool.Method.Package, "@")
+	if !ok {
+		version = "latest"
+	}
+
+	lastErr := fmt.Errorf("no module path in %s", pkg)
+	for _, mod := range moduleCandidates(pkg) {
+		cmd := exec.CommandContext(ctx, "go", "mod", "download", "-json", mod+"@"+version)
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = stderr
+		runErr := cmd.Run()
+
+		var info struct {
+			Zip   string
+			Error string
+		}
+		if err := json.Unmarshal(out.Bytes(), &info); err != nil {
+			lastErr = fmt.Errorf("go mod download %s failed: %w", mod, err)
+			continue
+		}
+		if runErr != nil || info.Error != "" {
+			lastErr = fmt.Errorf("go mod download %s failed: %s", mod, info.Error)
+			continue
+		}
+		return &CachedPackage{Path: info.Zip, Size: pathSize(info.Zip)}, nil
+	}
+	return nil, lastErr
+}
+
+// moduleCandidates returns the possible module paths for a package path,
+// longest first, down to a host plus one path element.
+func moduleCandidates(pkg string) []string {
+	var candidates []string
+	for p := strings.Trim(pkg, "/"); strings.Contains(p, "/"); p = p[:strings.LastIndex(p, "/")] {
+		candidates = append(candidates, p)
+	}
+	return candidates
+}
+
+// pathSize returns the size of a file, or the total size of the regular
+// files under a directory. Missing paths have size zero.
+func pathSize(path string) int64 {
+	if path == "" {
+		return 0
+	}
+	var total int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
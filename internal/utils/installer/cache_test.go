@@ -0,0 +1,69 @@
+package installer
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/config"
+)
+
+func TestModuleCandidates(t *testing.T) {
+	got := moduleCandidates("github.com/owner/repo/cmd/tool")
+	want := []string{"github.com/owner/repo/cmd/tool", "github.com/owner/repo/cmd", "github.com/owner/repo", "github.com/owner"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("moduleCandidates = %v, want %v", got, want)
+	}
+	if got := moduleCandidates("gopls"); len(got) != 0 {
+		t.Errorf("moduleCandidates(gopls) = %v, want none", got)
+	}
+}
+
+func TestCacheTool(t *testing.T) {
+	i := NewInstaller(WithDryRun(true), WithOutput(&bytes.Buffer{}, &bytes.Buffer{}))
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		tool       PlannedTool
+		skipReason string
+		wantErr    bool
+	}{
+		{
+			name:       "installed",
+			tool:       PlannedTool{Name: "jq", AlreadyInstalled: true, Method: config.InstallMethod{Type: InstallTypeBrew}},
+			skipReason: "already installed",
+		},
+		{
+			name:       "unsupported",
+			tool:       PlannedTool{Name: "rustup", Method: config.InstallMethod{Type: InstallTypeCurl}},
+			skipReason: "curl does not support cache-only downloads",
+		},
+		{
+			name:       "dry run",
+			tool:       PlannedTool{Name: "bat", Method: config.InstallMethod{Type: InstallTypeBrew}},
+			skipReason: "dry run",
+		},
+		{
+			name:    "unknown method",
+			tool:    PlannedTool{Name: "x", Method: config.InstallMethod{Type: "unsupported"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := i.cacheTool(ctx, tt.tool)
+			if tt.wantErr {
+				if got.Error == "" {
+					t.Errorf("expected error, got %+v", got)
+				}
+				return
+			}
+			if !got.Skipped || got.SkipReason != tt.skipReason {
+				t.Errorf("got %+v, want skipped with %q", got, tt.skipReason)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/mistergrinvalds/acorn/internal/utils/config"
@@ -18,6 +19,7 @@ type Installer struct {
 	verbose  bool
 	stdout   io.Writer
 	stderr   io.Writer
+	versions map[string]string // tool name -> pinned version
 }
 
 // Option configures the Installer.
@@ -57,6 +59,17 @@ func WithOutput(stdout, stderr io.Writer) Option {
 	}
 }
 
+// WithToolVersion pins a tool to a specific version. A tool installed at
+// a different version is planned for reinstallation.
+func WithToolVersion(tool, version string) Option {
+	return func(i *Installer) {
+		if i.versions == nil {
+			i.versions = make(map[string]string)
+		}
+		i.versions[tool] = strings.TrimPrefix(version, "v")
+	}
+}
+
 // Plan creates an installation plan for a component.
 func (i *Installer) Plan(ctx context.Context, component string) (*InstallPlan, error) {
 	cfg, err := i.loadInstallConfig(component)
@@ -74,10 +87,43 @@ func (i *Installer) Plan(ctx context.Context, component string) (*InstallPlan, e
 		return nil, err
 	}
 
+	if err := i.applyVersions(plan); err != nil {
+		return nil, err
+	}
+
 	plan.DryRun = i.dryRun
 	return plan, nil
 }
 
+// applyVersions sets the pinned versions on the planned tools.
+func (i *Installer) applyVersions(plan *InstallPlan) error {
+	found := make(map[string]bool)
+	for _, tools := range [][]PlannedTool{plan.Prerequisites, plan.Tools} {
+		for j := range tools {
+			t := &tools[j]
+			version, ok := i.versions[t.Name]
+			if !ok {
+				continue
+			}
+			found[t.Name] = true
+			if !supportsVersion(t.Method.Type) {
+				return fmt.Errorf("cannot pin %s: %q installs do not support versions", t.Name, t.Method.Type)
+			}
+			t.TargetVersion = version
+			if t.AlreadyInstalled && ExtractVersion(t.Version) != version {
+				t.AlreadyInstalled = false
+			}
+		}
+	}
+
+	for tool := range i.versions {
+		if !found[tool] {
+			return fmt.Errorf("tool %s not found in %s install config", tool, plan.Component)
+		}
+	}
+	return nil
+}
+
 // Install executes the installation for a component.
 func (i *Installer) Install(ctx context.Context, component string) (*InstallResult, error) {
 	start := time.Now()
@@ -168,6 +214,11 @@ func (i *Installer) installTool(ctx context.Context, tool PlannedTool) ToolResul
 
 	result.Success = true
 	result.Duration = time.Since(start)
+	if tool.Check != "" {
+		if ok, version := NewResolver(i.platform).checkInstalled(tool.Check); ok {
+			result.Version = version
+		}
+	}
 
 	// Show post-install message
 	if tool.PostInstall.Message != "" && !i.dryRun {
@@ -98,6 +98,10 @@ func (e *NpmExecutor) Execute(ctx context.Context, tool PlannedTool, stdout, std
 		pkg = tool.Name
 	}
 
+	if tool.TargetVersion != "" {
+		pkg += "@" + tool.TargetVersion
+	}
+
 	args := []string{"install"}
 	if tool.Method.Global {
 		args = append(args, "-g")
@@ -86,6 +86,7 @@ func (r *Resolver) resolveTool(tool config.ToolInstall, reason string) error {
 		Method:           method,
 		AlreadyInstalled: installed,
 		Version:          version,
+		Check:            tool.Check,
 		Reason:           reason,
 		PostInstall:      tool.PostInstall,
 	})
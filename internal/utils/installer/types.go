@@ -49,6 +49,8 @@ type PlannedTool struct {
 	Method           config.InstallMethod
 	AlreadyInstalled bool
 	Version          string // Current version if installed
+	TargetVersion    string // Requested version; empty means latest
+	Check            string // Command that verifies the installation
 	Reason           string // "direct" or "prerequisite"
 	PostInstall      config.PostInstallConfig
 }
@@ -0,0 +1,134 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// semverRe matches a full semantic version such as 1.2.3 or 1.2.3-beta.1.
+	semverRe = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+	// versionInTextRe finds a version inside check output like
+	// "2.0.14 (Claude Code)" or "wrangler 3.99.0".
+	versionInTextRe = regexp.MustCompile(`\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?`)
+)
+
+// ValidateVersion checks that v is a semantic version (MAJOR.MINOR.PATCH
+// with optional pre-release and build suffixes), with an optional v prefix.
+func ValidateVersion(v string) error {
+	if !semverRe.MatchString(strings.TrimPrefix(v, "v")) {
+		return fmt.Errorf("invalid version %q (expected MAJOR.MINOR.PATCH, e.g. 1.0.30)", v)
+	}
+	return nil
+}
+
+// ExtractVersion returns the first semantic version in s, or "" if none.
+func ExtractVersion(s string) string {
+	return versionInTextRe.FindString(s)
+}
+
+// CompareVersions compares two semantic versions, returning -1, 0 or 1.
+// A pre-release sorts before its release; pre-releases compare as strings.
+func CompareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	bCore, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+	for i := 0; i < 3; i++ {
+		var an, bn int
+		if i < len(aParts) {
+			an, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bn, _ = strconv.Atoi(bParts[i])
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	case aPre < bPre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// supportsVersion reports whether an install method can pin a version.
+func supportsVersion(methodType string) bool {
+	return methodType == InstallTypeNpm
+}
+
+// UpdateCheck compares a tool's installed version with the latest published.
+type UpdateCheck struct {
+	Tool            string `json:"tool" yaml:"tool"`
+	Package         string `json:"package" yaml:"package"`
+	Installed       string `json:"installed,omitempty" yaml:"installed,omitempty"`
+	Latest          string `json:"latest" yaml:"latest"`
+	UpdateAvailable bool   `json:"update_available" yaml:"update_available"`
+}
+
+// CheckUpdate looks up the latest published version of a component's tool
+// and compares it with the installed one. Only npm-installed tools are
+// supported.
+func (i *Installer) CheckUpdate(ctx context.Context, component, tool string) (*UpdateCheck, error) {
+	plan, err := i.Plan(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range append(append([]PlannedTool{}, plan.Prerequisites...), plan.Tools...) {
+		if t.Name != tool {
+			continue
+		}
+		if t.Method.Type != InstallTypeNpm {
+			return nil, fmt.Errorf("update check is only supported for npm installs (%s uses %q)", tool, t.Method.Type)
+		}
+
+		pkg := t.Method.Package
+		if pkg == "" {
+			pkg = t.Name
+		}
+		latest, err := npmLatestVersion(ctx, pkg)
+		if err != nil {
+			return nil, err
+		}
+
+		check := &UpdateCheck{Tool: tool, Package: pkg, Latest: latest}
+		if t.AlreadyInstalled {
+			check.Installed = ExtractVersion(t.Version)
+			check.UpdateAvailable = check.Installed != "" && CompareVersions(check.Installed, latest) < 0
+		}
+		return check, nil
+	}
+	return nil, fmt.Errorf("tool %s not found in %s install config", tool, component)
+}
+
+// npmLatestVersion asks the npm registry for a package's latest version.
+func npmLatestVersion(ctx context.Context, pkg string) (string, error) {
+	out, err := exec.CommandContext(ctx, "npm", "view", pkg, "version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query npm for %s: %w", pkg, err)
+	}
+	latest := strings.TrimSpace(string(out))
+	if latest == "" {
+		return "", fmt.Errorf("npm returned no version for %s", pkg)
+	}
+	return latest, nil
+}
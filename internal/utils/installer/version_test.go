@@ -0,0 +1,88 @@
+package installer
+
+import (
+	"testing"
+
+	"github.com/mistergrinvalds/acorn/internal/utils/config"
+)
+
+func TestValidateVersion(t *testing.T) {
+	for _, v := range []string{"1.0.30", "v2.1.0", "1.0.0-beta.1", "1.2.3+build.5"} {
+		if err := ValidateVersion(v); err != nil {
+			t.Errorf("ValidateVersion(%q) = %v", v, err)
+		}
+	}
+	for _, v := range []string{"", "1.0", "1.x.0", "latest", "1.0.0 ", "^1.0.0"} {
+		if err := ValidateVersion(v); err == nil {
+			t.Errorf("ValidateVersion(%q) should fail", v)
+		}
+	}
+}
+
+func TestExtractVersion(t *testing.T) {
+	tests := map[string]string{
+		"2.0.14 (Claude Code)": "2.0.14",
+		"wrangler 3.99.0":      "3.99.0",
+		"v1.2.3-rc.1":          "1.2.3-rc.1",
+		"unknown":              "",
+	}
+	for in, want := range tests {
+		if got := ExtractVersion(in); got != want {
+			t.Errorf("ExtractVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.30", "1.0.30", 0},
+		{"1.0.9", "1.0.30", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.0.0-beta", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestApplyVersions(t *testing.T) {
+	npm := config.InstallMethod{Type: InstallTypeNpm}
+	plan := &InstallPlan{
+		Component: "claude",
+		Tools: []PlannedTool{
+			{Name: "claude", Method: npm, AlreadyInstalled: true, Version: "1.0.30 (Claude Code)"},
+		},
+	}
+
+	i := NewInstaller(WithToolVersion("claude", "v1.0.30"))
+	if err := i.applyVersions(plan); err != nil {
+		t.Fatalf("applyVersions failed: %v", err)
+	}
+	if tool := plan.Tools[0]; tool.TargetVersion != "1.0.30" || !tool.AlreadyInstalled {
+		t.Errorf("matching version = %+v, want pinned and still installed", tool)
+	}
+
+	i = NewInstaller(WithToolVersion("claude", "1.0.35"))
+	if err := i.applyVersions(plan); err != nil {
+		t.Fatalf("applyVersions failed: %v", err)
+	}
+	if plan.Tools[0].AlreadyInstalled {
+		t.Error("different installed version should be planned for reinstall")
+	}
+
+	if err := NewInstaller(WithToolVersion("other", "1.0.0")).applyVersions(plan); err == nil {
+		t.Error("expected error pinning a tool not in the plan")
+	}
+
+	plan.Tools[0].Method = config.InstallMethod{Type: InstallTypeBrew}
+	if err := NewInstaller(WithToolVersion("claude", "1.0.0")).applyVersions(plan); err == nil {
+		t.Error("expected error pinning a brew install")
+	}
+}
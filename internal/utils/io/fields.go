@@ -0,0 +1,325 @@
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Field projection works on the JSON form of command output, so field
+// names are json tags and match whatever -o json prints. Names match
+// case-insensitively, and dotted paths (metadata.name) select nested fields.
+//
+// Output is treated as rows: a slice is one row per element, an object
+// wrapping a single slice (e.g. {"pods": [...]}) is one row per element of
+// that slice, and anything else is a single row.
+
+// rowSet is command output split into rows.
+type rowSet struct {
+	rows    []any
+	keys    []string // top-level row keys in encoding order
+	wrapKey string   // key of the wrapping object, if any
+	single  bool     // output was a single object rather than a list
+}
+
+// toRows normalizes data through JSON and splits it into rows.
+func toRows(data any) (*rowSet, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	v, err := decodeGeneric(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &rowSet{}
+	var rawRows []json.RawMessage
+	switch val := v.(type) {
+	case []any:
+		set.rows = val
+		_ = json.Unmarshal(raw, &rawRows)
+	case map[string]any:
+		if key, list, ok := wrappedList(val); ok {
+			set.rows = list
+			set.wrapKey = key
+			var wrapper map[string]json.RawMessage
+			_ = json.Unmarshal(raw, &wrapper)
+			_ = json.Unmarshal(wrapper[key], &rawRows)
+		} else {
+			set.rows = []any{val}
+			set.single = true
+			rawRows = []json.RawMessage{raw}
+		}
+	default:
+		set.rows = []any{val}
+		set.single = true
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range rawRows {
+		for _, key := range objectKeys(r) {
+			if !seen[key] {
+				seen[key] = true
+				set.keys = append(set.keys, key)
+			}
+		}
+	}
+	return set, nil
+}
+
+// decodeGeneric decodes JSON keeping integers exact, so large values such
+// as byte sizes or timestamps do not turn into floats.
+func decodeGeneric(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed to decode output: %w", err)
+	}
+	return convertNumbers(v), nil
+}
+
+// convertNumbers replaces json.Number with int64 or float64, which YAML
+// and templates treat as numbers rather than strings.
+func convertNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n
+		}
+		f, _ := val.Float64()
+		return f
+	case []any:
+		for i := range val {
+			val[i] = convertNumbers(val[i])
+		}
+	case map[string]any:
+		for k := range val {
+			val[k] = convertNumbers(val[k])
+		}
+	}
+	return v
+}
+
+// wrappedList reports whether obj is a single-key object holding a list.
+func wrappedList(obj map[string]any) (string, []any, bool) {
+	if len(obj) != 1 {
+		return "", nil, false
+	}
+	for key, val := range obj {
+		if list, ok := val.([]any); ok {
+			return key, list, true
+		}
+	}
+	return "", nil, false
+}
+
+// objectKeys returns the keys of a JSON object in the order they appear.
+func objectKeys(raw []byte) []string {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return keys
+		}
+		key, _ := tok.(string)
+		keys = append(keys, key)
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return keys
+		}
+	}
+	return keys
+}
+
+// lookupField resolves a dotted path in row, returning the value and the
+// path spelled as the keys in the data.
+func lookupField(row any, path string) (any, []string, bool) {
+	var keys []string
+	cur := row
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, nil, false
+		}
+		key, ok := matchKey(obj, part)
+		if !ok {
+			return nil, nil, false
+		}
+		keys = append(keys, key)
+		cur = obj[key]
+	}
+	return cur, keys, true
+}
+
+// matchKey finds name in obj, exactly or else case-insensitively.
+func matchKey(obj map[string]any, name string) (string, bool) {
+	if _, ok := obj[name]; ok {
+		return name, true
+	}
+	for key := range obj {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// validateFields checks every field resolves in at least one row. Empty
+// output has nothing to check against and passes.
+func validateFields(rows []any, fields []string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	for _, field := range fields {
+		if field == "" {
+			return fmt.Errorf("empty field name in --fields")
+		}
+		found := false
+		for _, row := range rows {
+			if _, _, ok := lookupField(row, field); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown field %q (available: %s)", field, strings.Join(availableFields(rows), ", "))
+		}
+	}
+	return nil
+}
+
+// availableFields lists the field paths present in rows, nested objects
+// included as dotted paths.
+func availableFields(rows []any) []string {
+	seen := make(map[string]bool)
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, val := range obj {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			seen[path] = true
+			walk(path, val)
+		}
+	}
+	for _, row := range rows {
+		walk("", row)
+	}
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ProjectFields reduces data to the given fields, keeping its shape: each
+// row keeps only the selected fields, nested paths stay nested, and a
+// wrapping object or single object is preserved. Unknown fields are an
+// error listing the available ones.
+func ProjectFields(data any, fields []string) (any, error) {
+	set, err := toRows(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateFields(set.rows, fields); err != nil {
+		return nil, err
+	}
+
+	projected := make([]any, len(set.rows))
+	for i, row := range set.rows {
+		out := map[string]any{}
+		for _, field := range fields {
+			val, keys, ok := lookupField(row, field)
+			if !ok {
+				continue
+			}
+			setPath(out, keys, val)
+		}
+		projected[i] = out
+	}
+
+	switch {
+	case set.single:
+		return projected[0], nil
+	case set.wrapKey != "":
+		return map[string]any{set.wrapKey: projected}, nil
+	default:
+		return projected, nil
+	}
+}
+
+// setPath stores val in obj under the nested keys.
+func setPath(obj map[string]any, keys []string, val any) {
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := obj[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			obj[key] = next
+		}
+		obj = next
+	}
+	obj[keys[len(keys)-1]] = val
+}
+
+// tabulate flattens data into a header and string cells. With fields the
+// columns are those fields; otherwise they are the rows' top-level keys.
+func tabulate(data any, fields []string) ([]string, [][]string, error) {
+	set, err := toRows(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := fields
+	if len(columns) == 0 {
+		columns = set.keys
+		if len(columns) == 0 {
+			return nil, nil, fmt.Errorf("output is not tabular; use -o json or --fields")
+		}
+	} else if err := validateFields(set.rows, fields); err != nil {
+		return nil, nil, err
+	}
+
+	cells := make([][]string, len(set.rows))
+	for i, row := range set.rows {
+		cells[i] = make([]string, len(columns))
+		for j, col := range columns {
+			if val, _, ok := lookupField(row, col); ok {
+				cells[i][j] = formatCell(val)
+			}
+		}
+	}
+	return columns, cells, nil
+}
+
+// formatCell renders a value for a table or CSV cell.
+func formatCell(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool, int64, float64:
+		return fmt.Sprint(val)
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
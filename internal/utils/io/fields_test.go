@@ -0,0 +1,102 @@
+package io
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testPod struct {
+	Name     string            `json:"name"`
+	Restarts int64             `json:"restarts"`
+	Status   testStatus        `json:"status"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+type testStatus struct {
+	Phase string `json:"phase"`
+	Ready bool   `json:"ready"`
+}
+
+var testPods = []testPod{
+	{Name: "api", Restarts: 3, Status: testStatus{Phase: "Running", Ready: true}, Labels: map[string]string{"app": "api"}},
+	{Name: "worker", Status: testStatus{Phase: "Pending"}},
+}
+
+func TestProjectFields(t *testing.T) {
+	got, err := ProjectFields(testPods, []string{"Name", "status.phase"})
+	if err != nil {
+		t.Fatalf("ProjectFields failed: %v", err)
+	}
+	want := []any{
+		map[string]any{"name": "api", "status": map[string]any{"phase": "Running"}},
+		map[string]any{"name": "worker", "status": map[string]any{"phase": "Pending"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectFields = %#v, want %#v", got, want)
+	}
+
+	wrapped, err := ProjectFields(map[string][]testPod{"pods": testPods}, []string{"restarts"})
+	if err != nil {
+		t.Fatalf("ProjectFields (wrapped) failed: %v", err)
+	}
+	wantWrapped := map[string]any{"pods": []any{
+		map[string]any{"restarts": int64(3)},
+		map[string]any{"restarts": int64(0)},
+	}}
+	if !reflect.DeepEqual(wrapped, wantWrapped) {
+		t.Errorf("ProjectFields (wrapped) = %#v, want %#v", wrapped, wantWrapped)
+	}
+
+	single, err := ProjectFields(testPods[0], []string{"labels.app"})
+	if err != nil {
+		t.Fatalf("ProjectFields (single) failed: %v", err)
+	}
+	if want := map[string]any{"labels": map[string]any{"app": "api"}}; !reflect.DeepEqual(single, want) {
+		t.Errorf("ProjectFields (single) = %#v, want %#v", single, want)
+	}
+}
+
+func TestProjectFieldsUnknown(t *testing.T) {
+	_, err := ProjectFields(testPods, []string{"name", "node"})
+	if err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+	for _, s := range []string{`"node"`, "name", "status.phase", "labels.app"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q should mention %s", err, s)
+		}
+	}
+
+	if _, err := ProjectFields([]testPod{}, []string{"node"}); err != nil {
+		t.Errorf("empty output should not be validated: %v", err)
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	headers, rows, err := tabulate(testPods, nil)
+	if err != nil {
+		t.Fatalf("tabulate failed: %v", err)
+	}
+	if want := []string{"name", "restarts", "status", "labels"}; !reflect.DeepEqual(headers, want) {
+		t.Errorf("headers = %v, want %v", headers, want)
+	}
+	if want := []string{"api", "3", `{"phase":"Running","ready":true}`, `{"app":"api"}`}; !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("row 0 = %v, want %v", rows[0], want)
+	}
+
+	headers, rows, err = tabulate(testPods, []string{"name", "status.ready", "labels.app"})
+	if err != nil {
+		t.Fatalf("tabulate with fields failed: %v", err)
+	}
+	if want := [][]string{{"api", "true", "api"}, {"worker", "false", ""}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+	if len(headers) != 3 {
+		t.Errorf("headers = %v", headers)
+	}
+
+	if _, _, err := tabulate([]string{"a", "b"}, nil); err == nil {
+		t.Error("expected error for non-tabular output")
+	}
+}
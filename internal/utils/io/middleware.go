@@ -2,6 +2,7 @@ package io
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -20,9 +21,15 @@ func AddOutputFlag(cmd *cobra.Command) {
 func BindFlags(cmd *cobra.Command, cfg *IOConfig) {
 	// Output flags
 	cmd.PersistentFlags().StringVarP((*string)(&cfg.OutputFormat), "output", "o", "table",
-		"Output format (table|json|yaml|ndjson|raw)")
+		"Output format (table|json|yaml|ndjson|csv|raw|template)")
 	cmd.PersistentFlags().StringVar(&cfg.OutputFile, "output-file", "",
 		"Write output to file instead of stdout")
+	cmd.PersistentFlags().StringVar(&cfg.Template, "template", "",
+		"Render output with a Go template (implies -o template)")
+	cmd.PersistentFlags().StringVar(&cfg.TemplateFile, "template-file", "",
+		"Render output with a Go template read from file (implies -o template)")
+	cmd.PersistentFlags().StringSliceVar(&cfg.Fields, "fields", nil,
+		"Only output these fields (json names, dotted for nested, e.g. name,status.phase)")
 
 	// Input flags
 	cmd.PersistentFlags().StringVarP((*string)(&cfg.InputFormat), "input-format", "I", "auto",
@@ -45,6 +52,10 @@ func Middleware(cfg *IOConfig) (preRun, postRun func(*cobra.Command, []string) e
 	var ioCtx *IOContext
 
 	preRun = func(cmd *cobra.Command, args []string) error {
+		if err := resolveTemplate(cfg); err != nil {
+			return err
+		}
+
 		// Auto-detect NoColor for non-TTY
 		if !cfg.NoColor && cfg.OutputFile == "" {
 			if !term.IsTerminal(int(os.Stdout.Fd())) {
@@ -88,6 +99,30 @@ func Middleware(cfg *IOConfig) (preRun, postRun func(*cobra.Command, []string) e
 	return preRun, postRun
 }
 
+// resolveTemplate switches to template output when a template is given and
+// loads --template-file into cfg.Template.
+func resolveTemplate(cfg *IOConfig) error {
+	if cfg.Template != "" && cfg.TemplateFile != "" {
+		return fmt.Errorf("--template and --template-file are mutually exclusive")
+	}
+
+	if cfg.TemplateFile != "" {
+		data, err := os.ReadFile(cfg.TemplateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %w", err)
+		}
+		cfg.Template = string(data)
+	}
+
+	if cfg.Template != "" {
+		cfg.OutputFormat = FormatTemplate
+	} else if ParseFormat(string(cfg.OutputFormat)) == FormatTemplate {
+		return fmt.Errorf("-o template requires --template or --template-file")
+	}
+
+	return nil
+}
+
 // NewIOContext creates a fully initialized IOContext.
 func NewIOContext(ctx context.Context, cfg *IOConfig) (*IOContext, error) {
 	if ctx == nil {
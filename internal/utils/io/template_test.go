@@ -0,0 +1,84 @@
+package io
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveTemplate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "pods.tmpl")
+	if err := os.WriteFile(file, []byte("{{.Name}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		cfg        IOConfig
+		wantFormat Format
+		wantTmpl   string
+		wantErr    string
+	}{
+		{"no template", IOConfig{OutputFormat: FormatJSON}, FormatJSON, "", ""},
+		{"inline switches format", IOConfig{OutputFormat: FormatTable, Template: "{{.Name}}"}, FormatTemplate, "{{.Name}}", ""},
+		{"file", IOConfig{OutputFormat: FormatTable, TemplateFile: file}, FormatTemplate, "{{.Name}}\n", ""},
+		{"both", IOConfig{Template: "x", TemplateFile: file}, "", "", "mutually exclusive"},
+		{"missing file", IOConfig{TemplateFile: filepath.Join(t.TempDir(), "none.tmpl")}, "", "", "failed to read template file"},
+		{"-o template alone", IOConfig{OutputFormat: FormatTemplate}, "", "", "requires --template or --template-file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			err := resolveTemplate(&cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveTemplate: %v", err)
+			}
+			if cfg.OutputFormat != tt.wantFormat || cfg.Template != tt.wantTmpl {
+				t.Errorf("format = %q, template = %q; want %q, %q", cfg.OutputFormat, cfg.Template, tt.wantFormat, tt.wantTmpl)
+			}
+		})
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	newWriter := func(tmpl string) (*Writer, *bytes.Buffer, error) {
+		var buf bytes.Buffer
+		w, err := NewWriter(&IOConfig{OutputFormat: FormatTemplate, Template: tmpl, OutputWriter: &buf})
+		return w, &buf, err
+	}
+
+	w, buf, err := newWriter(`{{range .}}{{.Name | upper}} {{.Restarts}} {{json .Status}}{{"\n"}}{{end}}`)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err := w.Write(testPods); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := "API 3 {\"phase\":\"Running\",\"ready\":true}\nWORKER 0 {\"phase\":\"Pending\",\"ready\":false}\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	// A malformed template is rejected before the command runs.
+	if _, _, err := newWriter("{{.Name"); err == nil || !strings.Contains(err.Error(), "template parse error") {
+		t.Errorf("invalid template: err = %v", err)
+	}
+
+	// Referencing a missing field fails at execution.
+	w, _, err = newWriter("{{.Nope}}")
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err := w.Write(testPods[0]); err == nil || !strings.Contains(err.Error(), "template execution error") {
+		t.Errorf("missing field: err = %v", err)
+	}
+}
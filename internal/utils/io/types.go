@@ -21,6 +21,10 @@ const (
 	FormatTable Format = "table"
 	// FormatRaw outputs data as-is without marshaling.
 	FormatRaw Format = "raw"
+	// FormatTemplate renders data with a user-supplied Go text/template.
+	FormatTemplate Format = "template"
+	// FormatCSV outputs rows as comma-separated values with a header.
+	FormatCSV Format = "csv"
 	// FormatAuto auto-detects format from content.
 	FormatAuto Format = "auto"
 )
@@ -38,6 +42,10 @@ func ParseFormat(s string) Format {
 		return FormatTable
 	case "raw":
 		return FormatRaw
+	case "template", "go-template":
+		return FormatTemplate
+	case "csv":
+		return FormatCSV
 	case "auto":
 		return FormatAuto
 	default:
@@ -51,9 +59,10 @@ func (f Format) String() string {
 }
 
 // IsStructured returns true if the format is a structured data format (JSON/YAML/NDJSON).
+// Template and CSV output also count, since they render the structured value.
 func (f Format) IsStructured() bool {
 	switch f {
-	case FormatJSON, FormatYAML, FormatNDJSON:
+	case FormatJSON, FormatYAML, FormatNDJSON, FormatTemplate, FormatCSV:
 		return true
 	default:
 		return false
@@ -71,6 +80,9 @@ type IOConfig struct {
 	OutputFormat Format    // Format for output data (table, json, yaml, ndjson, raw)
 	OutputFile   string    // File to write to (empty = stdout)
 	OutputWriter io.Writer // Underlying writer (set by middleware)
+	Template     string    // Go text/template for template output
+	TemplateFile string    // File containing the template
+	Fields       []string  // Fields (json names, dotted for nested) to project output to
 
 	// Behavior flags
 	Pretty    bool // Pretty-print JSON/YAML output
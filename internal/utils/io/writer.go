@@ -2,12 +2,17 @@ package io
 
 import (
 	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"text/template"
 
+	"github.com/mistergrinvalds/acorn/internal/utils/output"
 	"gopkg.in/yaml.v3"
 )
 
@@ -23,12 +28,24 @@ type Writer struct {
 	// For JSON array streaming
 	arrayStarted bool
 	itemCount    int
+
+	// For template output
+	template *template.Template
 }
 
 // NewWriter creates a Writer from IOConfig.
 func NewWriter(cfg *IOConfig) (*Writer, error) {
 	var writer io.Writer
 
+	// Parse the template up front so errors surface before the command runs
+	var tmpl *template.Template
+	if cfg.OutputFormat == FormatTemplate {
+		var err error
+		if tmpl, err = output.ParseTemplate(cfg.Template); err != nil {
+			return nil, err
+		}
+	}
+
 	if cfg.OutputFile != "" {
 		f, err := os.Create(cfg.OutputFile)
 		if err != nil {
@@ -40,6 +57,7 @@ func NewWriter(cfg *IOConfig) (*Writer, error) {
 			writer:   f,
 			buffered: bufio.NewWriterSize(f, 64*1024),
 			file:     f,
+			template: tmpl,
 		}, nil
 	}
 
@@ -53,6 +71,7 @@ func NewWriter(cfg *IOConfig) (*Writer, error) {
 		config:   cfg,
 		writer:   writer,
 		buffered: bufio.NewWriterSize(writer, 64*1024),
+		template: tmpl,
 	}, nil
 }
 
@@ -65,6 +84,26 @@ func (w *Writer) Write(data interface{}) error {
 		return fmt.Errorf("writer is closed")
 	}
 
+	switch w.config.OutputFormat {
+	case FormatCSV:
+		return w.writeCSV(data)
+	case FormatTable:
+		if len(w.config.Fields) > 0 {
+			return w.writeTable(data)
+		}
+		return fmt.Errorf("table format must be handled by command")
+	case FormatRaw:
+		return w.writeRaw(data)
+	}
+
+	if len(w.config.Fields) > 0 {
+		projected, err := ProjectFields(data, w.config.Fields)
+		if err != nil {
+			return err
+		}
+		data = projected
+	}
+
 	switch w.config.OutputFormat {
 	case FormatJSON:
 		return w.writeJSON(data)
@@ -72,10 +111,8 @@ func (w *Writer) Write(data interface{}) error {
 		return w.writeYAML(data)
 	case FormatNDJSON:
 		return w.writeNDJSON(data)
-	case FormatRaw:
-		return w.writeRaw(data)
-	case FormatTable:
-		return fmt.Errorf("table format must be handled by command")
+	case FormatTemplate:
+		return w.writeTemplate(data)
 	default:
 		return w.writeJSON(data) // Default to JSON
 	}
@@ -92,6 +129,14 @@ func (w *Writer) WriteStream(item interface{}) error {
 		return fmt.Errorf("writer is closed")
 	}
 
+	if len(w.config.Fields) > 0 {
+		projected, err := ProjectFields(item, w.config.Fields)
+		if err != nil {
+			return err
+		}
+		item = projected
+	}
+
 	switch w.config.OutputFormat {
 	case FormatNDJSON:
 		// Write as single line JSON
@@ -217,6 +262,91 @@ func (w *Writer) writeNDJSON(data interface{}) error {
 	}
 }
 
+// writeTemplate renders data with the configured template.
+func (w *Writer) writeTemplate(data interface{}) error {
+	if w.template == nil {
+		return fmt.Errorf("template format requires --template or --template-file")
+	}
+	if err := output.ExecuteTemplate(w.buffered, w.template, data); err != nil {
+		return err
+	}
+	return w.buffered.Flush()
+}
+
+// writeTable renders the --fields columns as a table.
+func (w *Writer) writeTable(data interface{}) error {
+	headers, rows, err := tabulate(data, w.config.Fields)
+	if err != nil {
+		return err
+	}
+
+	upper := make([]string, len(headers))
+	for i, h := range headers {
+		upper[i] = strings.ToUpper(h)
+	}
+	table := output.NewTable(upper...)
+	for _, row := range rows {
+		table.AddRow(row...)
+	}
+	table.Render(w.buffered)
+	return w.buffered.Flush()
+}
+
+// writeCSV writes rows as CSV, with the --fields columns or every
+// top-level field.
+func (w *Writer) writeCSV(data interface{}) error {
+	if err := WriteCSV(w.buffered, data, w.config.Fields); err != nil {
+		return err
+	}
+	return w.buffered.Flush()
+}
+
+// WriteCSV writes data as CSV to out: a header of the given fields, or of
+// every top-level field when fields is empty, then one line per row.
+func WriteCSV(out io.Writer, data any, fields []string) error {
+	headers, rows, err := tabulate(data, fields)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(out)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	return cw.WriteAll(rows)
+}
+
+// WriteFile writes data to path through a Writer, as YAML for .yaml/.yml
+// files and indented JSON otherwise.
+func WriteFile(path string, data any) error {
+	format := FormatJSON
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = FormatYAML
+	}
+
+	w, err := NewWriter(&IOConfig{OutputFormat: format, OutputFile: path, Pretty: true})
+	if err != nil {
+		return err
+	}
+	if err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// CheckWritableDir reports whether files can be created in dir by
+// creating and removing a hidden probe file.
+func CheckWritableDir(dir string) error {
+	probe, err := os.CreateTemp(dir, ".acorn-write-check-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
 // writeRaw writes data as-is (for []byte or string).
 func (w *Writer) writeRaw(data interface{}) error {
 	switch v := data.(type) {
@@ -273,7 +403,11 @@ func (w *Writer) Close() error {
 }
 
 // IsStructured returns true if output format is structured (JSON/YAML/NDJSON).
+// Table output with --fields counts too, since the writer renders it.
 func (w *Writer) IsStructured() bool {
+	if w.config.OutputFormat == FormatTable && len(w.config.Fields) > 0 {
+		return true
+	}
 	return w.config.OutputFormat.IsStructured()
 }
 
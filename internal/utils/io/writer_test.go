@@ -0,0 +1,37 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	pod := testPods[1]
+
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"pod.json", "{\n  \"name\": \"worker\",\n  \"restarts\": 0,\n  \"status\": {\n    \"phase\": \"Pending\",\n    \"ready\": false\n  }\n}\n"},
+		{"pod.YAML", "name: worker\nrestarts: 0\nstatus:\n  phase: Pending\n  ready: false\nlabels: {}\n"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.file)
+		if err := WriteFile(path, pod); err != nil {
+			t.Fatalf("WriteFile(%s): %v", tt.file, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s =\n%s\nwant\n%s", tt.file, data, tt.want)
+		}
+	}
+
+	if err := WriteFile(filepath.Join(dir, "missing", "pod.json"), pod); err == nil {
+		t.Error("writing into a missing directory should fail")
+	}
+}
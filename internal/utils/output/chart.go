@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// sparkBlocks are the block heights used by Sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// barBlocks are the partial-width blocks used for the tip of a bar, in
+// eighths of a cell.
+var barBlocks = []rune(" ▏▎▍▌▋▊▉")
+
+// Sparkline renders values as a single line of block characters scaled
+// between zero and the largest value.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 && v > 0 {
+			i = (v*(len(sparkBlocks)-1) + peak/2) / peak
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+// BarChart writes one horizontal bar per label, scaled so the largest value
+// fills the space left in width after the labels and values, with
+// eighth-cell precision. Values are shown compactly after each bar.
+func BarChart(w io.Writer, labels []string, values []int, width int) {
+	labelWidth, valueWidth, peak := 0, 0, 0
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = CompactNumber(v)
+		labelWidth = max(labelWidth, len(labels[i]))
+		valueWidth = max(valueWidth, len(formatted[i]))
+		peak = max(peak, v)
+	}
+
+	barWidth := max(width-labelWidth-valueWidth-2, 10)
+	for i, v := range values {
+		eighths := 0
+		if peak > 0 {
+			eighths = v * barWidth * 8 / peak
+		}
+		bar := strings.Repeat("█", eighths/8)
+		if eighths%8 > 0 {
+			bar += string(barBlocks[eighths%8])
+		}
+		fmt.Fprintf(w, "%-*s %-*s %*s\n", labelWidth, labels[i], barWidth, bar, valueWidth, formatted[i])
+	}
+}
+
+// CompactNumber formats n with a k/M/B suffix, e.g. 1.2M.
+func CompactNumber(n int) string {
+	switch {
+	case n >= 1_000_000_000:
+		return strconv.FormatFloat(float64(n)/1e9, 'f', 1, 64) + "B"
+	case n >= 1_000_000:
+		return strconv.FormatFloat(float64(n)/1e6, 'f', 1, 64) + "M"
+	case n >= 1_000:
+		return strconv.FormatFloat(float64(n)/1e3, 'f', 1, 64) + "k"
+	}
+	return strconv.Itoa(n)
+}
+
+// TerminalWidth returns the width of the terminal on stdout, falling back
+// to $COLUMNS and then 80.
+func TerminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
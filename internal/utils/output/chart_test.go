@@ -0,0 +1,44 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	if got := Sparkline([]int{0, 50, 100, 25}); got != "▁▅█▃" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	if got := Sparkline([]int{0, 0}); got != "▁▁" {
+		t.Errorf("Sparkline of zeros = %q", got)
+	}
+}
+
+func TestBarChart(t *testing.T) {
+	var buf bytes.Buffer
+	BarChart(&buf, []string{"mon", "tue"}, []int{1500, 3000}, 20)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	want := []string{
+		"mon █████▌      1.5k",
+		"tue ███████████ 3.0k",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestCompactNumber(t *testing.T) {
+	tests := map[int]string{999: "999", 1500: "1.5k", 2_300_000: "2.3M", 4_000_000_000: "4.0B"}
+	for n, want := range tests {
+		if got := CompactNumber(n); got != want {
+			t.Errorf("CompactNumber(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
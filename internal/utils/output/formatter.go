@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"text/template"
 
 	"gopkg.in/yaml.v3"
 )
@@ -47,6 +48,27 @@ func NewPrinter(w io.Writer, format Format) *Printer {
 	}
 }
 
+// ParseTemplate parses a user-supplied output template. Besides the
+// text/template builtins it provides json, upper, lower and join.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template parse error: %w", err)
+	}
+	return tmpl, nil
+}
+
+// templateFuncs are the helper functions available to output templates.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
 // Print outputs data in the configured format.
 func (p *Printer) Print(data interface{}) error {
 	switch p.format {
@@ -75,6 +97,14 @@ func (p *Printer) printYAML(data interface{}) error {
 	return encoder.Encode(data)
 }
 
+// ExecuteTemplate renders data with tmpl, wrapping failures as execution errors.
+func ExecuteTemplate(w io.Writer, tmpl *template.Template, data interface{}) error {
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("template execution error: %w", err)
+	}
+	return nil
+}
+
 // Table provides utilities for table output.
 type Table struct {
 	headers []string
@@ -130,6 +160,35 @@ func (t *Table) Render(w io.Writer) {
 	}
 }
 
+// RenderMarkdown renders the table as a GitHub-flavored Markdown table.
+// Pipes and newlines in cells are escaped so each row stays on one line.
+func (t *Table) RenderMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(markdownCells(t.headers), " | "))
+
+	seps := make([]string, len(t.headers))
+	for i := range seps {
+		seps[i] = "---"
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(seps, " | "))
+
+	for _, row := range t.rows {
+		cells := make([]string, len(t.headers))
+		copy(cells, row)
+		fmt.Fprintf(w, "| %s |\n", strings.Join(markdownCells(cells), " | "))
+	}
+}
+
+// markdownCells escapes cells for use in a Markdown table row.
+func markdownCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(c, "|", "\\|")
+		c = strings.ReplaceAll(c, "\r\n", " ")
+		escaped[i] = strings.ReplaceAll(c, "\n", " ")
+	}
+	return escaped
+}
+
 // ColorCode represents an ANSI color code.
 type ColorCode string
 
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		rows    [][]string
+		want    string
+	}{
+		{
+			name:    "header only",
+			headers: []string{"Tool", "Status"},
+			want:    "| Tool | Status |\n| --- | --- |\n",
+		},
+		{
+			name:    "rows",
+			headers: []string{"Tool", "Status", "Version"},
+			rows:    [][]string{{"go", "installed", "1.23.1"}, {"kubectl", "missing", ""}},
+			want: "| Tool | Status | Version |\n| --- | --- | --- |\n" +
+				"| go | installed | 1.23.1 |\n" +
+				"| kubectl | missing |  |\n",
+		},
+		{
+			name:    "escapes pipes and newlines",
+			headers: []string{"Tool", "Notes"},
+			rows:    [][]string{{"jq", "a|b"}, {"fzf", "line one\nline two\r\nline three"}},
+			want: "| Tool | Notes |\n| --- | --- |\n" +
+				"| jq | a\\|b |\n" +
+				"| fzf | line one line two line three |\n",
+		},
+		{
+			name:    "short and long rows fit the header",
+			headers: []string{"A", "B"},
+			rows:    [][]string{{"1"}, {"1", "2", "3"}},
+			want:    "| A | B |\n| --- | --- |\n| 1 |  |\n| 1 | 2 |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(tt.headers...)
+			for _, row := range tt.rows {
+				table.AddRow(row...)
+			}
+			var buf bytes.Buffer
+			table.RenderMarkdown(&buf)
+			if buf.String() != tt.want {
+				t.Errorf("RenderMarkdown =\n%q\nwant\n%q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package textdiff produces line diffs without depending on diff(1).
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around each change.
+const context = 3
+
+// op is a single line of an edit script: ' ' kept, '-' removed, '+' added.
+type op struct {
+	kind byte
+	line string
+	// Line numbers (1-based) in a and b before this op is applied.
+	ai, bi int
+}
+
+// Unified returns a unified diff of a and b, labelled aLabel and bLabel,
+// with three lines of context around each change. It returns an empty
+// string when a and b are equal.
+func Unified(aLabel, a, bLabel, b string) string {
+	if a == b {
+		return ""
+	}
+	ops := edits(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", aLabel, bLabel)
+	for start := 0; start < len(ops); {
+		// Find the next change
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context lines
+		lo := max(first-context, start)
+		hi, kept := first, 0
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				hi, kept = i, 0
+				continue
+			}
+			kept++
+			if kept > 2*context {
+				break
+			}
+		}
+		hi = min(hi+context, len(ops)-1)
+
+		writeHunk(&sb, ops[lo:hi+1])
+		start = hi + 1
+	}
+	return sb.String()
+}
+
+// writeHunk writes one @@ hunk covering ops.
+func writeHunk(sb *strings.Builder, ops []op) {
+	var aLen, bLen int
+	for _, o := range ops {
+		if o.kind != '+' {
+			aLen++
+		}
+		if o.kind != '-' {
+			bLen++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(ops[0].ai, aLen), hunkRange(ops[0].bi, bLen))
+	for _, o := range ops {
+		sb.WriteByte(o.kind)
+		sb.WriteString(o.line)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange formats a hunk range the way diff -u does: an empty range
+// starts at the line before it.
+func hunkRange(start, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
+
+// CountChanges returns the number of added and removed lines in a unified
+// diff, ignoring the --- and +++ header lines.
+func CountChanges(diff string) int {
+	n := 0
+	for _, line := range strings.Split(diff, "\n") {
+		if (strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++")) ||
+			(strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---")) {
+			n++
+		}
+	}
+	return n
+}
+
+// splitLines splits s into lines, dropping the final newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// edits returns the edit script turning x into y, from a longest common
+// subsequence of their lines.
+func edits(x, y []string) []op {
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			ops = append(ops, op{' ', x[i], i + 1, j + 1})
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{'-', x[i], i + 1, j + 1})
+			i++
+		default:
+			ops = append(ops, op{'+', y[j], i + 1, j + 1})
+			j++
+		}
+	}
+	return ops
+}
@@ -114,6 +114,45 @@ func (c *Checker) CheckTools(names []string) []ToolStatus {
 	return results
 }
 
+// CheckPrereqs checks the tools a component requires.
+func (c *Checker) CheckPrereqs(component string, names []string) []PrereqStatus {
+	results := make([]PrereqStatus, len(names))
+	for i, status := range c.CheckTools(names) {
+		results[i] = PrereqStatus{
+			Component: component,
+			Tool:      status.Name,
+			Installed: status.Installed,
+			Version:   status.Version,
+			Path:      status.Path,
+		}
+	}
+	return results
+}
+
+// MissingRequired returns the names that are not installed, in the order
+// given. Tools already in result are not checked again; others, including
+// tools outside the registry, are looked up.
+func (c *Checker) MissingRequired(result *StatusResult, names []string) []string {
+	known := make(map[string]bool)
+	for _, cat := range result.Categories {
+		for _, tool := range cat.Tools {
+			known[tool.Name] = tool.Installed
+		}
+	}
+
+	missing := []string{}
+	for _, name := range names {
+		installed, ok := known[name]
+		if !ok {
+			installed = c.CheckTool(name).Installed
+		}
+		if !installed {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
 // GetMissing returns tools that are not installed.
 func (c *Checker) GetMissing() []ToolStatus {
 	var missing []ToolStatus
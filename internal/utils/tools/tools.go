@@ -10,6 +10,15 @@ type ToolStatus struct {
 	Category  string `json:"category" yaml:"category"`
 }
 
+// PrereqStatus reports whether a tool required by a component is installed.
+type PrereqStatus struct {
+	Component string `json:"component" yaml:"component"`
+	Tool      string `json:"tool" yaml:"tool"`
+	Installed bool   `json:"installed" yaml:"installed"`
+	Version   string `json:"version,omitempty" yaml:"version,omitempty"`
+	Path      string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
 // ToolCategory groups tools by purpose.
 type ToolCategory struct {
 	Name  string       `json:"name" yaml:"name"`
@@ -22,6 +31,51 @@ type StatusResult struct {
 	Summary    StatusSummary  `json:"summary" yaml:"summary"`
 }
 
+// Group-by modes for presenting a StatusResult.
+const (
+	GroupByCategory = "category"
+	GroupByNone     = "none"
+)
+
+// GroupByModes lists the accepted --group-by values.
+var GroupByModes = []string{GroupByCategory, GroupByNone}
+
+// GroupedStatus is a StatusResult keyed by category name.
+type GroupedStatus struct {
+	Categories map[string][]ToolStatus `json:"categories" yaml:"categories"`
+	Summary    StatusSummary           `json:"summary" yaml:"summary"`
+}
+
+// FlatStatus is a StatusResult as a single list of tools.
+type FlatStatus struct {
+	Tools   []ToolStatus  `json:"tools" yaml:"tools"`
+	Summary StatusSummary `json:"summary" yaml:"summary"`
+}
+
+// Grouped returns the result as a map from category to its tools.
+// Categories without tools are omitted.
+func (r *StatusResult) Grouped() *GroupedStatus {
+	grouped := &GroupedStatus{
+		Categories: make(map[string][]ToolStatus),
+		Summary:    r.Summary,
+	}
+	for _, cat := range r.Categories {
+		if len(cat.Tools) > 0 {
+			grouped.Categories[cat.Name] = cat.Tools
+		}
+	}
+	return grouped
+}
+
+// Flat returns the result as one list of tools in category display order.
+func (r *StatusResult) Flat() *FlatStatus {
+	flat := &FlatStatus{Tools: []ToolStatus{}, Summary: r.Summary}
+	for _, cat := range r.Categories {
+		flat.Tools = append(flat.Tools, cat.Tools...)
+	}
+	return flat
+}
+
 // StatusSummary provides totals.
 type StatusSummary struct {
 	Total     int `json:"total" yaml:"total"`
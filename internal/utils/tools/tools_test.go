@@ -0,0 +1,70 @@
+package tools
+
+import "testing"
+
+func TestStatusResultGrouping(t *testing.T) {
+	result := &StatusResult{
+		Categories: []ToolCategory{
+			{Name: CategorySystem, Tools: []ToolStatus{
+				{Name: "git", Installed: true, Category: CategorySystem},
+				{Name: "curl", Category: CategorySystem},
+			}},
+			{Name: CategoryLanguages},
+			{Name: CategoryCloud, Tools: []ToolStatus{
+				{Name: "kubectl", Installed: true, Category: CategoryCloud},
+			}},
+		},
+		Summary: StatusSummary{Total: 3, Installed: 2, Missing: 1},
+	}
+
+	grouped := result.Grouped()
+	if len(grouped.Categories) != 2 {
+		t.Errorf("grouped categories = %v", grouped.Categories)
+	}
+	if tools := grouped.Categories[CategorySystem]; len(tools) != 2 || tools[0].Name != "git" {
+		t.Errorf("System = %+v", tools)
+	}
+	if _, ok := grouped.Categories[CategoryLanguages]; ok {
+		t.Error("empty category should be omitted")
+	}
+
+	flat := result.Flat()
+	var names []string
+	for _, tool := range flat.Tools {
+		names = append(names, tool.Name)
+	}
+	if len(names) != 3 || names[0] != "git" || names[2] != "kubectl" {
+		t.Errorf("flat = %v", names)
+	}
+	if flat.Summary != result.Summary {
+		t.Errorf("summary = %+v", flat.Summary)
+	}
+}
+
+func TestCheckPrereqs(t *testing.T) {
+	results := NewChecker().CheckPrereqs("demo", []string{"sh", "acorn-no-such-tool"})
+	if len(results) != 2 {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[0].Component != "demo" || results[0].Tool != "sh" || !results[0].Installed {
+		t.Errorf("sh = %+v", results[0])
+	}
+	if results[1].Tool != "acorn-no-such-tool" || results[1].Installed {
+		t.Errorf("missing = %+v", results[1])
+	}
+}
+
+func TestMissingRequired(t *testing.T) {
+	result := &StatusResult{Categories: []ToolCategory{{
+		Name: "System",
+		Tools: []ToolStatus{
+			{Name: "git", Installed: true},
+			{Name: "docker", Installed: false},
+		},
+	}}}
+
+	missing := NewChecker().MissingRequired(result, []string{"docker", "git", "sh", "acorn-no-such-tool"})
+	if len(missing) != 2 || missing[0] != "docker" || missing[1] != "acorn-no-such-tool" {
+		t.Errorf("missing = %v, want [docker acorn-no-such-tool]", missing)
+	}
+}